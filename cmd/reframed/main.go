@@ -2,16 +2,23 @@
 package main
 
 import (
+	"context"
 	"log/slog"
+	"net/http"
 
 	"github.com/rshelekhov/reframed/internal/app/httpserver"
+	"github.com/rshelekhov/reframed/internal/app/scheduler"
+	"github.com/rshelekhov/reframed/internal/lib/broker"
 	"github.com/rshelekhov/reframed/internal/lib/middleware/jwtoken"
+	"github.com/rshelekhov/reframed/internal/lib/safehttp"
 
 	"github.com/golang-jwt/jwt/v5"
 
 	"github.com/rshelekhov/reframed/config"
 	v1 "github.com/rshelekhov/reframed/internal/controller/http/v1"
 	"github.com/rshelekhov/reframed/internal/lib/logger"
+	"github.com/rshelekhov/reframed/internal/port"
+	"github.com/rshelekhov/reframed/internal/storage/blob"
 	"github.com/rshelekhov/reframed/internal/storage/postgres"
 	"github.com/rshelekhov/reframed/internal/usecase"
 )
@@ -54,13 +61,101 @@ func main() {
 	authStorage := postgres.NewAuthStorage(pg)
 	taskStorage := postgres.NewTaskStorage(pg)
 	tagStorage := postgres.NewTagStorage(pg)
+	idempotencyStorage := postgres.NewIdempotencyStorage(pg)
+	webhookStorage := postgres.NewWebhookStorage(pg)
+	ruleStorage := postgres.NewRuleStorage(pg)
+	templateStorage := postgres.NewTemplateStorage(pg)
+	areaStorage := postgres.NewAreaStorage(pg)
+	goalStorage := postgres.NewGoalStorage(pg)
+	memberStorage := postgres.NewListMemberStorage(pg)
+	commentStorage := postgres.NewCommentStorage(pg)
+	auditStorage := postgres.NewAuditStorage(pg)
+	undoStorage := postgres.NewUndoStorage(pg)
+	reviewStorage := postgres.NewReviewStorage(pg)
+	statsStorage := postgres.NewStatsStorage(pg)
+	habitStorage := postgres.NewHabitStorage(pg)
+	attachmentStorage := postgres.NewAttachmentStorage(pg)
+
+	// The files attached to the tasks are kept apart from the database
+	var blobStore port.BlobStore
+	if cfg.Attachment.Backend == "s3" {
+		blobStore, err = blob.NewS3Store(&http.Client{}, cfg.Attachment.S3)
+	} else {
+		blobStore, err = blob.NewLocalStore(
+			cfg.Attachment.Local.Dir, cfg.Attachment.Local.BaseURL, cfg.Attachment.Local.SigningKey,
+		)
+	}
+	if err != nil {
+		log.Error("failed to init blob store", logger.Err(err))
+	}
+
+	log.Debug("blob store initiated", slog.String("backend", cfg.Attachment.Backend))
+
+	webhookUsecase := usecase.NewWebhookUsecase(webhookStorage, safehttp.NewClient(cfg.Webhook.Timeout), cfg.Webhook)
+	goalUsecase := usecase.NewGoalUsecase(goalStorage)
+
+	// Change events are delivered in-process, so only the sessions
+	// connected to this instance receive them. Every event is also
+	// queued for the webhooks subscribed to it, and the task events
+	// update the progress history of the goals
+	eventBroker := broker.NewFanoutBroker(broker.NewMemoryBroker(), webhookUsecase, goalUsecase)
 
 	// Usecases
-	headingUsecase := usecase.NewHeadingUsecase(headingStorage)
-	listUsecase := usecase.NewListUsecase(listStorage, headingUsecase)
-	authUsecase := usecase.NewAuthUsecase(authStorage, listUsecase, headingUsecase)
-	tagUsecase := usecase.NewTagUsecase(tagStorage)
-	taskUsecase := usecase.NewTaskUsecase(taskStorage, headingUsecase, tagUsecase, listUsecase)
+	auditUsecase := usecase.NewAuditUsecase(auditStorage)
+	undoUsecase := usecase.NewUndoUsecase(undoStorage, memberStorage, cfg.Undo.TokenTTL, eventBroker)
+	tagUsecase := usecase.NewTagUsecase(tagStorage, auditUsecase)
+	headingUsecase := usecase.NewHeadingUsecase(
+		headingStorage, taskStorage, memberStorage, tagUsecase, auditUsecase, undoUsecase, eventBroker,
+	)
+	listUsecase := usecase.NewListUsecase(
+		listStorage, headingStorage, areaStorage, taskStorage, memberStorage, headingUsecase, tagUsecase, auditUsecase,
+		undoUsecase, eventBroker,
+	)
+	authUsecase := usecase.NewAuthUsecase(authStorage, listUsecase, headingUsecase, auditUsecase)
+	ruleUsecase := usecase.NewRuleUsecase(
+		ruleStorage, taskStorage, memberStorage, headingUsecase, tagUsecase, listUsecase, auditUsecase, eventBroker,
+	)
+	commentUsecase := usecase.NewCommentUsecase(commentStorage, taskStorage, memberStorage, authStorage, eventBroker, log)
+	habitUsecase := usecase.NewHabitUsecase(habitStorage, authStorage)
+	taskUsecase := usecase.NewTaskUsecase(
+		taskStorage, memberStorage, headingUsecase, tagUsecase, listUsecase, ruleUsecase, commentUsecase, habitUsecase,
+		auditUsecase, undoUsecase, eventBroker,
+	)
+	templateUsecase := usecase.NewTemplateUsecase(templateStorage, listUsecase, headingUsecase, taskUsecase)
+	areaUsecase := usecase.NewAreaUsecase(areaStorage, listUsecase, eventBroker)
+	listMemberUsecase := usecase.NewListMemberUsecase(memberStorage, listStorage, authStorage, eventBroker)
+	idempotencyUsecase := usecase.NewIdempotencyUsecase(idempotencyStorage, cfg.Idempotency.KeyTTL)
+	reviewUsecase := usecase.NewReviewUsecase(reviewStorage, taskUsecase, listUsecase)
+	statsUsecase := usecase.NewStatsUsecase(statsStorage, cfg.Stats.CacheTTL)
+	attachmentUsecase := usecase.NewAttachmentUsecase(attachmentStorage, memberStorage, blobStore, cfg.Attachment)
+
+	// Background jobs
+	ctx := context.Background()
+
+	scheduler.Every(ctx, log, "delete expired idempotency keys", cfg.Idempotency.CleanupInterval,
+		func(ctx context.Context) error {
+			_, err := idempotencyUsecase.DeleteExpiredKeys(ctx)
+			return err
+		},
+	)
+
+	scheduler.Every(ctx, log, "delete expired undo operations", cfg.Undo.CleanupInterval,
+		func(ctx context.Context) error {
+			_, err := undoUsecase.DeleteExpiredUndoOperations(ctx)
+			return err
+		},
+	)
+
+	scheduler.Every(ctx, log, "purge attachments", cfg.Attachment.CleanupInterval,
+		func(ctx context.Context) error {
+			_, err := attachmentUsecase.PurgeAttachments(ctx)
+			return err
+		},
+	)
+
+	scheduler.Every(ctx, log, "deliver webhooks", cfg.Webhook.DeliveryInterval, webhookUsecase.DeliverPendingWebhooks)
+
+	scheduler.Every(ctx, log, "run deadline rules", cfg.Rules.DeadlineCheckInterval, ruleUsecase.RunDeadlineRules)
 
 	// HTTP Server
 	log.Info("starting httpserver", slog.String("address", cfg.HTTPServer.Address))
@@ -73,6 +168,22 @@ func main() {
 		headingUsecase,
 		taskUsecase,
 		tagUsecase,
+		idempotencyUsecase,
+		eventBroker,
+		cfg.HTTPServer.AllowedOrigins,
+		webhookUsecase,
+		ruleUsecase,
+		templateUsecase,
+		areaUsecase,
+		goalUsecase,
+		listMemberUsecase,
+		commentUsecase,
+		auditUsecase,
+		undoUsecase,
+		reviewUsecase,
+		statsUsecase,
+		habitUsecase,
+		attachmentUsecase,
 	)
 
 	srv := httpserver.NewServer(cfg, log, tokenAuth, router)
@@ -6,16 +6,25 @@ import (
 
 type (
 	ServerSettings struct {
-		AppEnv     string           `mapstructure:"APP_ENV"`
-		HTTPServer HTTPServerConfig `mapstructure:",squash"`
-		Postgres   PostgresConfig   `mapstructure:",squash"`
-		JWTAuth    JWTConfig        `mapstructure:",squash"`
+		AppEnv      string            `mapstructure:"APP_ENV"`
+		HTTPServer  HTTPServerConfig  `mapstructure:",squash"`
+		Postgres    PostgresConfig    `mapstructure:",squash"`
+		JWTAuth     JWTConfig         `mapstructure:",squash"`
+		Idempotency IdempotencyConfig `mapstructure:",squash"`
+		Webhook     WebhookConfig     `mapstructure:",squash"`
+		Rules       RulesConfig       `mapstructure:",squash"`
+		Undo        UndoConfig        `mapstructure:",squash"`
+		Stats       StatsConfig       `mapstructure:",squash"`
+		Attachment  AttachmentConfig  `mapstructure:",squash"`
 	}
 
 	HTTPServerConfig struct {
 		Address     string        `mapstructure:"HTTP_SERVER_ADDRESS"`
 		Timeout     time.Duration `mapstructure:"HTTP_SERVER_TIMEOUT" envDefault:"10s"`
 		IdleTimeout time.Duration `mapstructure:"HTTP_SERVER_IDLE_TIMEOUT" envDefault:"60s"`
+		// AllowedOrigins are the comma separated origins of the web clients allowed to open WebSocket connections,
+		// the connections from the origin of the server itself are always allowed
+		AllowedOrigins []string `mapstructure:"HTTP_SERVER_ALLOWED_ORIGINS"`
 	}
 
 	PostgresConfig struct {
@@ -49,4 +58,70 @@ type (
 		Cost int    `mapstructure:"PASSWORD_HASH_BCRYPT_COST"`
 		Salt string `mapstructure:"PASSWORD_HASH_BCRYPT_SALT"`
 	}
+
+	IdempotencyConfig struct {
+		KeyTTL          time.Duration `mapstructure:"IDEMPOTENCY_KEY_TTL" envDefault:"24h"`
+		CleanupInterval time.Duration `mapstructure:"IDEMPOTENCY_CLEANUP_INTERVAL" envDefault:"1h"`
+	}
+
+	WebhookConfig struct {
+		DeliveryInterval time.Duration `mapstructure:"WEBHOOK_DELIVERY_INTERVAL" envDefault:"10s"`
+		Timeout          time.Duration `mapstructure:"WEBHOOK_TIMEOUT" envDefault:"10s"`
+		MaxAttempts      int           `mapstructure:"WEBHOOK_MAX_ATTEMPTS" envDefault:"8"`
+		RetryBaseDelay   time.Duration `mapstructure:"WEBHOOK_RETRY_BASE_DELAY" envDefault:"30s"`
+		MaxRetryDelay    time.Duration `mapstructure:"WEBHOOK_MAX_RETRY_DELAY" envDefault:"6h"`
+		// MaxFailures is the number of failed deliveries in a row after which the webhook is disabled
+		MaxFailures int `mapstructure:"WEBHOOK_MAX_FAILURES" envDefault:"20"`
+	}
+
+	RulesConfig struct {
+		// DeadlineCheckInterval is how often the tasks are checked for passed deadlines to run the deadline rules
+		DeadlineCheckInterval time.Duration `mapstructure:"RULES_DEADLINE_CHECK_INTERVAL" envDefault:"1m"`
+	}
+
+	UndoConfig struct {
+		// TokenTTL is how long an operation can be undone after it was made
+		TokenTTL        time.Duration `mapstructure:"UNDO_TOKEN_TTL" envDefault:"10m"`
+		CleanupInterval time.Duration `mapstructure:"UNDO_CLEANUP_INTERVAL" envDefault:"1h"`
+	}
+
+	StatsConfig struct {
+		// CacheTTL is how long the computed statistics are served from memory
+		CacheTTL time.Duration `mapstructure:"STATS_CACHE_TTL" envDefault:"5m"`
+	}
+
+	AttachmentConfig struct {
+		// Backend is the blob store the files are kept in: local or s3
+		Backend string `mapstructure:"ATTACHMENT_BACKEND" envDefault:"local"`
+		// MaxSize is the largest file that can be uploaded, in bytes
+		MaxSize int64 `mapstructure:"ATTACHMENT_MAX_SIZE" envDefault:"26214400"`
+		// UserQuota is the space the files uploaded by a user can take, in bytes
+		UserQuota int64 `mapstructure:"ATTACHMENT_USER_QUOTA" envDefault:"1073741824"`
+		// AllowedTypes is the comma-separated list of the sniffed MIME types which can be uploaded,
+		// any type is allowed if it's empty
+		AllowedTypes string        `mapstructure:"ATTACHMENT_ALLOWED_TYPES"`
+		URLTTL       time.Duration `mapstructure:"ATTACHMENT_URL_TTL" envDefault:"15m"`
+		// PurgeAfter is how long the attachments of the deleted tasks are kept, so the deletion can still be undone
+		PurgeAfter      time.Duration   `mapstructure:"ATTACHMENT_PURGE_AFTER" envDefault:"720h"`
+		CleanupInterval time.Duration   `mapstructure:"ATTACHMENT_CLEANUP_INTERVAL" envDefault:"1h"`
+		Local           LocalBlobConfig `mapstructure:",squash"`
+		S3              S3BlobConfig    `mapstructure:",squash"`
+	}
+
+	LocalBlobConfig struct {
+		Dir string `mapstructure:"ATTACHMENT_LOCAL_DIR" envDefault:"./data/attachments"`
+		// BaseURL is the public address of the /blobs route serving the download links
+		BaseURL    string `mapstructure:"ATTACHMENT_LOCAL_BASE_URL" envDefault:"http://localhost:8080/blobs"`
+		SigningKey string `mapstructure:"ATTACHMENT_LOCAL_SIGNING_KEY"`
+	}
+
+	// S3BlobConfig works with any S3-compatible storage, like MinIO run locally with UsePathStyle
+	S3BlobConfig struct {
+		Endpoint        string `mapstructure:"S3_ENDPOINT" envDefault:"https://s3.amazonaws.com"`
+		Region          string `mapstructure:"S3_REGION" envDefault:"us-east-1"`
+		Bucket          string `mapstructure:"S3_BUCKET"`
+		AccessKeyID     string `mapstructure:"S3_ACCESS_KEY_ID"`
+		SecretAccessKey string `mapstructure:"S3_SECRET_ACCESS_KEY"`
+		UsePathStyle    bool   `mapstructure:"S3_USE_PATH_STYLE" envDefault:"false"`
+	}
 )
@@ -0,0 +1,39 @@
+// Package scheduler runs periodic background jobs.
+package scheduler
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/rshelekhov/reframed/internal/lib/logger"
+)
+
+type Job func(ctx context.Context) error
+
+// Every runs the job in the background with the given interval until the context is canceled.
+// The job is disabled if the interval is not set
+func Every(ctx context.Context, log logger.Interface, name string, interval time.Duration, job Job) {
+	if interval <= 0 {
+		log.Warn("background job disabled, interval is not set", slog.String("job", name))
+		return
+	}
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if err := job(ctx); err != nil {
+					log.Error("background job failed", slog.String("job", name), logger.Err(err))
+				}
+			}
+		}
+	}()
+
+	log.Info("background job scheduled", slog.String("job", name), slog.String("interval", interval.String()))
+}
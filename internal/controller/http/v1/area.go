@@ -0,0 +1,270 @@
+package v1
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/rshelekhov/reframed/internal/lib/constants/key"
+	"github.com/rshelekhov/reframed/internal/lib/constants/le"
+	"github.com/rshelekhov/reframed/internal/lib/logger"
+	"github.com/rshelekhov/reframed/internal/lib/middleware/jwtoken"
+	"github.com/rshelekhov/reframed/internal/model"
+	"github.com/rshelekhov/reframed/internal/port"
+)
+
+type areaController struct {
+	logger  logger.Interface
+	jwt     *jwtoken.TokenService
+	usecase port.AreaUsecase
+}
+
+func NewAreaRoutes(
+	r *chi.Mux,
+	log logger.Interface,
+	jwt *jwtoken.TokenService,
+	usecase port.AreaUsecase,
+) {
+	c := &areaController{
+		logger:  log,
+		jwt:     jwt,
+		usecase: usecase,
+	}
+
+	// Protected routes
+	r.Group(func(r chi.Router) {
+		r.Use(jwtoken.Verifier(jwt))
+		r.Use(jwtoken.Authenticator())
+
+		r.Route("/user/areas", func(r chi.Router) {
+			r.Get("/", c.GetAreasByUserID())
+			r.Post("/", c.CreateArea())
+			r.Put("/order", c.ReorderAreas())
+
+			r.Route("/{area_id}", func(r chi.Router) {
+				r.Get("/", c.GetAreaByID())
+				r.Put("/", c.UpdateArea())
+				r.Delete("/", c.DeleteArea())
+			})
+		})
+	})
+}
+
+func (c *areaController) CreateArea() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "area.controller.CreateArea"
+
+		ctx := r.Context()
+		log := logger.LogWithRequest(c.logger, op, r)
+
+		userID, err := jwtoken.GetUserID(ctx)
+		if err != nil {
+			handleInternalServerError(w, r, log, le.ErrFailedToGetUserIDFromToken, err)
+			return
+		}
+
+		areaInput := &model.AreaRequestData{}
+		if err = decodeAndValidateJSON(w, r, log, areaInput); err != nil {
+			return
+		}
+
+		areaInput.UserID = userID
+
+		areaResp, err := c.usecase.CreateArea(ctx, areaInput)
+		if err != nil {
+			handleInternalServerError(w, r, log, le.ErrFailedToCreateArea, err)
+			return
+		}
+
+		handleResponseCreated(w, r, log, "area created", areaResp, slog.String(key.AreaID, areaResp.ID))
+	}
+}
+
+func (c *areaController) GetAreaByID() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "area.controller.GetAreaByID"
+
+		ctx := r.Context()
+		log := logger.LogWithRequest(c.logger, op, r)
+
+		userID, err := jwtoken.GetUserID(ctx)
+		if err != nil {
+			handleInternalServerError(w, r, log, le.ErrFailedToGetUserIDFromToken, err)
+			return
+		}
+
+		areaID := chi.URLParam(r, key.AreaID)
+		if areaID == "" {
+			handleResponseError(w, r, log, http.StatusBadRequest, le.ErrEmptyQueryAreaID)
+			return
+		}
+
+		areaInput := model.AreaRequestData{
+			ID:     areaID,
+			UserID: userID,
+		}
+
+		areaResp, err := c.usecase.GetAreaByID(ctx, areaInput)
+
+		switch {
+		case errors.Is(err, le.ErrAreaNotFound):
+			handleResponseError(w, r, log, http.StatusNotFound, le.ErrAreaNotFound)
+			return
+		case err != nil:
+			handleInternalServerError(w, r, log, le.ErrFailedToGetData, err)
+			return
+		default:
+			handleResponseSuccess(w, r, log, "area received", areaResp, slog.String(key.AreaID, areaID))
+		}
+	}
+}
+
+func (c *areaController) GetAreasByUserID() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "area.controller.GetAreasByUserID"
+
+		ctx := r.Context()
+		log := logger.LogWithRequest(c.logger, op, r)
+
+		userID, err := jwtoken.GetUserID(ctx)
+		if err != nil {
+			handleInternalServerError(w, r, log, le.ErrFailedToGetUserIDFromToken, err)
+			return
+		}
+
+		areasResp, err := c.usecase.GetAreasByUserID(ctx, userID)
+
+		switch {
+		case errors.Is(err, le.ErrNoAreasFound):
+			handleResponseError(w, r, log, http.StatusNotFound, le.ErrNoAreasFound)
+			return
+		case err != nil:
+			handleInternalServerError(w, r, log, le.ErrFailedToGetAreas, err)
+			return
+		default:
+			handleResponseSuccess(w, r, log, "areas found", areasResp,
+				slog.Int(key.Count, len(areasResp)),
+			)
+		}
+	}
+}
+
+func (c *areaController) UpdateArea() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "area.controller.UpdateArea"
+
+		ctx := r.Context()
+		log := logger.LogWithRequest(c.logger, op, r)
+
+		userID, err := jwtoken.GetUserID(ctx)
+		if err != nil {
+			handleInternalServerError(w, r, log, le.ErrFailedToGetUserIDFromToken, err)
+			return
+		}
+
+		areaID := chi.URLParam(r, key.AreaID)
+		if areaID == "" {
+			handleResponseError(w, r, log, http.StatusBadRequest, le.ErrEmptyQueryAreaID)
+			return
+		}
+
+		areaInput := &model.AreaRequestData{}
+		if err = decodeAndValidateJSON(w, r, log, areaInput); err != nil {
+			return
+		}
+
+		areaInput.ID = areaID
+		areaInput.UserID = userID
+
+		areaResp, err := c.usecase.UpdateArea(ctx, areaInput)
+
+		switch {
+		case errors.Is(err, le.ErrAreaNotFound):
+			handleResponseError(w, r, log, http.StatusNotFound, le.ErrAreaNotFound)
+			return
+		case err != nil:
+			handleInternalServerError(w, r, log, le.ErrFailedToUpdateArea, err)
+			return
+		default:
+			handleResponseSuccess(w, r, log, "area updated", areaResp, slog.String(key.AreaID, areaID))
+		}
+	}
+}
+
+func (c *areaController) ReorderAreas() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "area.controller.ReorderAreas"
+
+		ctx := r.Context()
+		log := logger.LogWithRequest(c.logger, op, r)
+
+		userID, err := jwtoken.GetUserID(ctx)
+		if err != nil {
+			handleInternalServerError(w, r, log, le.ErrFailedToGetUserIDFromToken, err)
+			return
+		}
+
+		reorderInput := &model.ReorderRequestData{}
+		if err = decodeAndValidateJSON(w, r, log, reorderInput); err != nil {
+			return
+		}
+
+		reorderInput.UserID = userID
+
+		err = c.usecase.ReorderAreas(ctx, reorderInput)
+
+		switch {
+		case errors.Is(err, le.ErrAreaNotFound):
+			handleResponseError(w, r, log, http.StatusNotFound, le.ErrAreaNotFound)
+			return
+		case err != nil:
+			handleInternalServerError(w, r, log, le.ErrFailedToReorderAreas, err)
+			return
+		default:
+			handleResponseSuccess(w, r, log, "areas reordered", reorderInput.IDs,
+				slog.Int(key.Count, len(reorderInput.IDs)),
+			)
+		}
+	}
+}
+
+func (c *areaController) DeleteArea() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "area.controller.DeleteArea"
+
+		ctx := r.Context()
+		log := logger.LogWithRequest(c.logger, op, r)
+
+		userID, err := jwtoken.GetUserID(ctx)
+		if err != nil {
+			handleInternalServerError(w, r, log, le.ErrFailedToGetUserIDFromToken, err)
+			return
+		}
+
+		areaID := chi.URLParam(r, key.AreaID)
+		if areaID == "" {
+			handleResponseError(w, r, log, http.StatusBadRequest, le.ErrEmptyQueryAreaID)
+			return
+		}
+
+		areaInput := model.AreaRequestData{
+			ID:     areaID,
+			UserID: userID,
+		}
+
+		err = c.usecase.DeleteArea(ctx, areaInput)
+
+		switch {
+		case errors.Is(err, le.ErrAreaNotFound):
+			handleResponseError(w, r, log, http.StatusNotFound, le.ErrAreaNotFound)
+			return
+		case err != nil:
+			handleInternalServerError(w, r, log, le.ErrFailedToDeleteArea, err)
+			return
+		default:
+			handleResponseSuccess(w, r, log, "area deleted", areaID, slog.String(key.AreaID, areaID))
+		}
+	}
+}
@@ -0,0 +1,374 @@
+package v1
+
+import (
+	"errors"
+	"io"
+	"log/slog"
+	"mime"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/rshelekhov/reframed/internal/lib/constants/key"
+	"github.com/rshelekhov/reframed/internal/lib/constants/le"
+	"github.com/rshelekhov/reframed/internal/lib/logger"
+	"github.com/rshelekhov/reframed/internal/lib/middleware/jwtoken"
+	"github.com/rshelekhov/reframed/internal/model"
+	"github.com/rshelekhov/reframed/internal/port"
+)
+
+const (
+	// multipartOverhead is the room left in the limit of the upload request for the other parts of the form
+	multipartOverhead = 1 << 20
+	// multipartMemory is how much of the uploaded file is kept in memory, the rest is spilled to a temporary file
+	multipartMemory = 1 << 20
+)
+
+type attachmentController struct {
+	logger  logger.Interface
+	jwt     *jwtoken.TokenService
+	usecase port.AttachmentUsecase
+}
+
+func NewAttachmentRoutes(
+	r *chi.Mux,
+	log logger.Interface,
+	jwt *jwtoken.TokenService,
+	usecase port.AttachmentUsecase,
+) {
+	c := &attachmentController{
+		logger:  log,
+		jwt:     jwt,
+		usecase: usecase,
+	}
+
+	// Public routes, the signature of the download link is the authorization
+	r.Get("/blobs/*", c.DownloadBlob()) // ?expires=&file_name=&content_type=&signature=
+
+	// Protected routes
+	r.Group(func(r chi.Router) {
+		r.Use(jwtoken.Verifier(jwt))
+		r.Use(jwtoken.Authenticator())
+
+		r.Get("/user/attachments/usage", c.GetAttachmentUsage())
+
+		r.Route("/user/tasks/{task_id}/attachments", func(r chi.Router) {
+			r.Get("/", c.GetAttachmentsByTaskID())
+			r.Post("/", c.UploadAttachment()) // multipart/form-data with the file field
+
+			r.Get("/{attachment_id}", c.GetAttachmentByID())
+			r.Delete("/{attachment_id}", c.DeleteAttachment())
+		})
+	})
+}
+
+func (c *attachmentController) UploadAttachment() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "attachment.controller.UploadAttachment"
+
+		ctx := r.Context()
+		log := logger.LogWithRequest(c.logger, op, r)
+
+		userID, err := jwtoken.GetUserID(ctx)
+		if err != nil {
+			handleInternalServerError(w, r, log, le.ErrFailedToGetUserIDFromToken, err)
+			return
+		}
+
+		taskID := chi.URLParam(r, key.TaskID)
+		if taskID == "" {
+			handleResponseError(w, r, log, http.StatusBadRequest, le.ErrEmptyQueryTaskID)
+			return
+		}
+
+		r.Body = http.MaxBytesReader(w, r.Body, c.usecase.MaxUploadSize()+multipartOverhead)
+
+		if err = r.ParseMultipartForm(multipartMemory); err != nil {
+			var maxBytesErr *http.MaxBytesError
+			if errors.As(err, &maxBytesErr) {
+				handleResponseError(w, r, log, http.StatusRequestEntityTooLarge, le.ErrAttachmentTooLarge)
+				return
+			}
+			handleResponseError(w, r, log, http.StatusBadRequest, le.ErrAttachmentFileRequired, logger.Err(err))
+			return
+		}
+		defer r.MultipartForm.RemoveAll()
+
+		file, header, err := r.FormFile(key.File)
+		if err != nil {
+			handleResponseError(w, r, log, http.StatusBadRequest, le.ErrAttachmentFileRequired, logger.Err(err))
+			return
+		}
+		defer file.Close()
+
+		attachmentResp, err := c.usecase.UploadAttachment(ctx, model.AttachmentUploadData{
+			TaskID:   taskID,
+			FileName: header.Filename,
+			Size:     header.Size,
+			Content:  file,
+			UserID:   userID,
+		})
+
+		switch {
+		case errors.Is(err, le.ErrTaskNotFound):
+			handleResponseError(w, r, log, http.StatusNotFound, le.ErrTaskNotFound)
+			return
+		case errors.Is(err, le.ErrNoPermissionToEditList):
+			handleResponseError(w, r, log, http.StatusForbidden, le.ErrNoPermissionToEditList)
+			return
+		case errors.Is(err, le.ErrAttachmentTooLarge):
+			handleResponseError(w, r, log, http.StatusRequestEntityTooLarge, le.ErrAttachmentTooLarge)
+			return
+		case errors.Is(err, le.ErrAttachmentQuotaExceeded):
+			handleResponseError(w, r, log, http.StatusRequestEntityTooLarge, le.ErrAttachmentQuotaExceeded)
+			return
+		case errors.Is(err, le.ErrAttachmentTypeNotAllowed):
+			handleResponseError(w, r, log, http.StatusUnsupportedMediaType, le.ErrAttachmentTypeNotAllowed)
+			return
+		case err != nil:
+			handleInternalServerError(w, r, log, le.ErrFailedToUploadAttachment, err)
+			return
+		default:
+			handleResponseCreated(w, r, log, "attachment uploaded", attachmentResp,
+				slog.String(key.TaskID, taskID),
+				slog.String(key.AttachmentID, attachmentResp.ID),
+				slog.String(key.ContentType, attachmentResp.ContentType),
+				slog.Int64(key.Size, attachmentResp.Size),
+			)
+		}
+	}
+}
+
+func (c *attachmentController) GetAttachmentByID() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "attachment.controller.GetAttachmentByID"
+
+		ctx := r.Context()
+		log := logger.LogWithRequest(c.logger, op, r)
+
+		userID, err := jwtoken.GetUserID(ctx)
+		if err != nil {
+			handleInternalServerError(w, r, log, le.ErrFailedToGetUserIDFromToken, err)
+			return
+		}
+
+		taskID := chi.URLParam(r, key.TaskID)
+		if taskID == "" {
+			handleResponseError(w, r, log, http.StatusBadRequest, le.ErrEmptyQueryTaskID)
+			return
+		}
+
+		attachmentID := chi.URLParam(r, key.AttachmentID)
+		if attachmentID == "" {
+			handleResponseError(w, r, log, http.StatusBadRequest, le.ErrEmptyQueryAttachmentID)
+			return
+		}
+
+		attachmentResp, err := c.usecase.GetAttachmentByID(ctx, model.AttachmentRequestData{
+			ID:     attachmentID,
+			TaskID: taskID,
+			UserID: userID,
+		})
+
+		switch {
+		case errors.Is(err, le.ErrTaskNotFound):
+			handleResponseError(w, r, log, http.StatusNotFound, le.ErrTaskNotFound)
+			return
+		case errors.Is(err, le.ErrAttachmentNotFound):
+			handleResponseError(w, r, log, http.StatusNotFound, le.ErrAttachmentNotFound)
+			return
+		case err != nil:
+			handleInternalServerError(w, r, log, le.ErrFailedToGetAttachments, err)
+			return
+		default:
+			handleResponseSuccess(w, r, log, "attachment received", attachmentResp,
+				slog.String(key.TaskID, taskID),
+				slog.String(key.AttachmentID, attachmentID),
+			)
+		}
+	}
+}
+
+func (c *attachmentController) GetAttachmentsByTaskID() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "attachment.controller.GetAttachmentsByTaskID"
+
+		ctx := r.Context()
+		log := logger.LogWithRequest(c.logger, op, r)
+
+		userID, err := jwtoken.GetUserID(ctx)
+		if err != nil {
+			handleInternalServerError(w, r, log, le.ErrFailedToGetUserIDFromToken, err)
+			return
+		}
+
+		taskID := chi.URLParam(r, key.TaskID)
+		if taskID == "" {
+			handleResponseError(w, r, log, http.StatusBadRequest, le.ErrEmptyQueryTaskID)
+			return
+		}
+
+		attachmentsResp, err := c.usecase.GetAttachmentsByTaskID(ctx, model.AttachmentRequestData{
+			TaskID: taskID,
+			UserID: userID,
+		})
+
+		switch {
+		case errors.Is(err, le.ErrTaskNotFound):
+			handleResponseError(w, r, log, http.StatusNotFound, le.ErrTaskNotFound)
+			return
+		case errors.Is(err, le.ErrNoAttachmentsFound):
+			handleResponseError(w, r, log, http.StatusNotFound, le.ErrNoAttachmentsFound)
+			return
+		case err != nil:
+			handleInternalServerError(w, r, log, le.ErrFailedToGetAttachments, err)
+			return
+		default:
+			handleResponseSuccess(w, r, log, "attachments found", attachmentsResp,
+				slog.String(key.TaskID, taskID),
+				slog.Int(key.Count, len(attachmentsResp)),
+			)
+		}
+	}
+}
+
+func (c *attachmentController) DeleteAttachment() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "attachment.controller.DeleteAttachment"
+
+		ctx := r.Context()
+		log := logger.LogWithRequest(c.logger, op, r)
+
+		userID, err := jwtoken.GetUserID(ctx)
+		if err != nil {
+			handleInternalServerError(w, r, log, le.ErrFailedToGetUserIDFromToken, err)
+			return
+		}
+
+		taskID := chi.URLParam(r, key.TaskID)
+		if taskID == "" {
+			handleResponseError(w, r, log, http.StatusBadRequest, le.ErrEmptyQueryTaskID)
+			return
+		}
+
+		attachmentID := chi.URLParam(r, key.AttachmentID)
+		if attachmentID == "" {
+			handleResponseError(w, r, log, http.StatusBadRequest, le.ErrEmptyQueryAttachmentID)
+			return
+		}
+
+		err = c.usecase.DeleteAttachment(ctx, model.AttachmentRequestData{
+			ID:     attachmentID,
+			TaskID: taskID,
+			UserID: userID,
+		})
+
+		switch {
+		case errors.Is(err, le.ErrTaskNotFound):
+			handleResponseError(w, r, log, http.StatusNotFound, le.ErrTaskNotFound)
+			return
+		case errors.Is(err, le.ErrAttachmentNotFound):
+			handleResponseError(w, r, log, http.StatusNotFound, le.ErrAttachmentNotFound)
+			return
+		case errors.Is(err, le.ErrNoPermissionToEditList):
+			handleResponseError(w, r, log, http.StatusForbidden, le.ErrNoPermissionToEditList)
+			return
+		case err != nil:
+			handleInternalServerError(w, r, log, le.ErrFailedToDeleteAttachment, err)
+			return
+		default:
+			handleResponseSuccess(w, r, log, "attachment deleted", attachmentID,
+				slog.String(key.TaskID, taskID),
+				slog.String(key.AttachmentID, attachmentID),
+			)
+		}
+	}
+}
+
+func (c *attachmentController) GetAttachmentUsage() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "attachment.controller.GetAttachmentUsage"
+
+		ctx := r.Context()
+		log := logger.LogWithRequest(c.logger, op, r)
+
+		userID, err := jwtoken.GetUserID(ctx)
+		if err != nil {
+			handleInternalServerError(w, r, log, le.ErrFailedToGetUserIDFromToken, err)
+			return
+		}
+
+		usageResp, err := c.usecase.GetAttachmentUsage(ctx, userID)
+		if err != nil {
+			handleInternalServerError(w, r, log, le.ErrFailedToGetAttachmentUsage, err)
+			return
+		}
+
+		handleResponseSuccess(w, r, log, "attachment usage received", usageResp, slog.String(key.UserID, userID))
+	}
+}
+
+// DownloadBlob serves the download links of the blob stores which don't serve them on their own.
+// The file is always sent as an attachment with the signed content type, so it's never rendered by the browser
+func (c *attachmentController) DownloadBlob() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "attachment.controller.DownloadBlob"
+
+		ctx := r.Context()
+		log := logger.LogWithRequest(c.logger, op, r)
+
+		query := r.URL.Query()
+
+		expires, err := strconv.ParseInt(query.Get(key.Expires), 10, 64)
+		if err != nil {
+			handleResponseError(w, r, log, http.StatusForbidden, le.ErrInvalidBlobSignature)
+			return
+		}
+
+		blobInput := model.SignedBlobRequestData{
+			// The key of the blob is the rest of the path, it can contain slashes
+			Key:         chi.URLParam(r, "*"),
+			FileName:    query.Get(key.FileName),
+			ContentType: query.Get(key.ContentType),
+			Expires:     expires,
+			Signature:   query.Get(key.Signature),
+		}
+
+		blob, err := c.usecase.OpenSignedBlob(ctx, blobInput)
+
+		switch {
+		case errors.Is(err, le.ErrInvalidBlobSignature):
+			handleResponseError(w, r, log, http.StatusForbidden, le.ErrInvalidBlobSignature)
+			return
+		case errors.Is(err, le.ErrBlobURLExpired):
+			handleResponseError(w, r, log, http.StatusForbidden, le.ErrBlobURLExpired)
+			return
+		case errors.Is(err, le.ErrBlobNotFound):
+			handleResponseError(w, r, log, http.StatusNotFound, le.ErrBlobNotFound)
+			return
+		case err != nil:
+			handleInternalServerError(w, r, log, le.ErrFailedToDownloadBlob, err)
+			return
+		}
+		defer blob.Close()
+
+		w.Header().Set("Content-Type", blobInput.ContentType)
+		w.Header().Set("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": blobInput.FileName}))
+		w.Header().Set("X-Content-Type-Options", "nosniff")
+		w.Header().Set("Cache-Control", "private, no-store")
+
+		log.Info("blob downloaded", slog.String(key.FileName, blobInput.FileName))
+
+		// The files of the local store can be seeked, so the downloads can be resumed with the range requests
+		if content, ok := blob.(io.ReadSeeker); ok {
+			http.ServeContent(w, r, "", time.Time{}, content)
+			return
+		}
+
+		if _, err = io.Copy(w, blob); err != nil {
+			log.Error("failed to write blob", logger.Err(err))
+		}
+	}
+}
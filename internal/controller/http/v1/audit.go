@@ -0,0 +1,129 @@
+package v1
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/rshelekhov/reframed/internal/lib/constants/key"
+	"github.com/rshelekhov/reframed/internal/lib/constants/le"
+	"github.com/rshelekhov/reframed/internal/lib/logger"
+	"github.com/rshelekhov/reframed/internal/lib/middleware/jwtoken"
+	"github.com/rshelekhov/reframed/internal/model"
+	"github.com/rshelekhov/reframed/internal/port"
+)
+
+type auditController struct {
+	logger  logger.Interface
+	jwt     *jwtoken.TokenService
+	usecase port.AuditUsecase
+}
+
+func NewAuditRoutes(
+	r *chi.Mux,
+	log logger.Interface,
+	jwt *jwtoken.TokenService,
+	usecase port.AuditUsecase,
+) {
+	c := &auditController{
+		logger:  log,
+		jwt:     jwt,
+		usecase: usecase,
+	}
+
+	// Protected routes
+	r.Group(func(r chi.Router) {
+		r.Use(jwtoken.Verifier(jwt))
+		r.Use(jwtoken.Authenticator())
+
+		r.Get("/user/audit", c.GetAuditEntriesByUserID())
+		r.Get("/user/audit/{entity_type}/{entity_id}", c.GetAuditEntriesByEntity())
+	})
+}
+
+func (c *auditController) GetAuditEntriesByUserID() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "audit.controller.GetAuditEntriesByUserID"
+
+		ctx := r.Context()
+		log := logger.LogWithRequest(c.logger, op, r)
+
+		userID, err := jwtoken.GetUserID(ctx)
+		if err != nil {
+			handleInternalServerError(w, r, log, le.ErrFailedToGetUserIDFromToken, err)
+			return
+		}
+
+		pagination := ParseLimitAndAfterID(r)
+
+		entriesResp, err := c.usecase.GetAuditEntriesByUserID(ctx, userID, pagination)
+
+		switch {
+		case errors.Is(err, le.ErrNoAuditEntriesFound):
+			handleResponseError(w, r, log, http.StatusNotFound, le.ErrNoAuditEntriesFound)
+			return
+		case err != nil:
+			handleInternalServerError(w, r, log, le.ErrFailedToGetAuditEntries, err)
+			return
+		default:
+			handleResponseSuccess(w, r, log, "audit entries found", entriesResp,
+				slog.Int(key.Count, len(entriesResp)),
+			)
+		}
+	}
+}
+
+func (c *auditController) GetAuditEntriesByEntity() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "audit.controller.GetAuditEntriesByEntity"
+
+		ctx := r.Context()
+		log := logger.LogWithRequest(c.logger, op, r)
+
+		userID, err := jwtoken.GetUserID(ctx)
+		if err != nil {
+			handleInternalServerError(w, r, log, le.ErrFailedToGetUserIDFromToken, err)
+			return
+		}
+
+		entityType := chi.URLParam(r, key.EntityType)
+		if entityType == "" {
+			handleResponseError(w, r, log, http.StatusBadRequest, le.ErrEmptyQueryEntityType)
+			return
+		}
+
+		entityID := chi.URLParam(r, key.EntityID)
+		if entityID == "" {
+			handleResponseError(w, r, log, http.StatusBadRequest, le.ErrEmptyQueryEntityID)
+			return
+		}
+
+		pagination := ParseLimitAndAfterID(r)
+
+		entriesResp, err := c.usecase.GetAuditEntriesByEntity(ctx, model.AuditRequestData{
+			EntityType: model.AuditEntityType(entityType),
+			EntityID:   entityID,
+			UserID:     userID,
+		}, pagination)
+
+		switch {
+		case errors.Is(err, le.ErrInvalidAuditEntityType):
+			handleResponseError(w, r, log, http.StatusBadRequest, le.ErrInvalidAuditEntityType)
+			return
+		case errors.Is(err, le.ErrNoAuditEntriesFound):
+			handleResponseError(w, r, log, http.StatusNotFound, le.ErrNoAuditEntriesFound)
+			return
+		case err != nil:
+			handleInternalServerError(w, r, log, le.ErrFailedToGetAuditEntries, err)
+			return
+		default:
+			handleResponseSuccess(w, r, log, "audit entries found", entriesResp,
+				slog.String(key.EntityType, entityType),
+				slog.String(key.EntityID, entityID),
+				slog.Int(key.Count, len(entriesResp)),
+			)
+		}
+	}
+}
@@ -55,6 +55,7 @@ func NewAuthRoutes(
 			r.Get("/", c.GetUserProfile())
 			r.Put("/", c.UpdateUser())
 			r.Delete("/", c.DeleteUser())
+			r.Put("/time-zone", c.UpdateUserTimeZone())
 		})
 	})
 }
@@ -341,6 +342,43 @@ func (c *authController) UpdateUser() http.HandlerFunc {
 	}
 }
 
+// UpdateUserTimeZone sets the time zone of the user
+func (c *authController) UpdateUserTimeZone() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "user.controller.UpdateUserTimeZone"
+
+		ctx := r.Context()
+		log := logger.LogWithRequest(c.logger, op, r)
+
+		userID, err := jwtoken.GetUserID(ctx)
+		if err != nil {
+			handleInternalServerError(w, r, log, le.ErrFailedToGetUserIDFromToken, err)
+			return
+		}
+
+		timeZoneInput := &model.UserTimeZoneRequestData{}
+		if err = decodeAndValidateJSON(w, r, log, timeZoneInput); err != nil {
+			return
+		}
+
+		userResp, err := c.usecase.UpdateUserTimeZone(ctx, *timeZoneInput, userID)
+
+		switch {
+		case errors.Is(err, le.ErrUserNotFound):
+			handleResponseError(w, r, log, http.StatusNotFound, le.ErrUserNotFound, slog.String(key.UserID, userID))
+			return
+		case err != nil:
+			handleInternalServerError(w, r, log, le.ErrFailedToUpdateUser, err)
+			return
+		default:
+			handleResponseSuccess(w, r, log, "user time zone updated", userResp,
+				slog.String(key.UserID, userID),
+				slog.String(key.TimeZone, userResp.TimeZone),
+			)
+		}
+	}
+}
+
 // DeleteUser deletes a user by ID
 func (c *authController) DeleteUser() http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
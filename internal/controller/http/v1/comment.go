@@ -0,0 +1,249 @@
+package v1
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/rshelekhov/reframed/internal/lib/constants/key"
+	"github.com/rshelekhov/reframed/internal/lib/constants/le"
+	"github.com/rshelekhov/reframed/internal/lib/logger"
+	"github.com/rshelekhov/reframed/internal/lib/middleware/jwtoken"
+	"github.com/rshelekhov/reframed/internal/model"
+	"github.com/rshelekhov/reframed/internal/port"
+)
+
+type commentController struct {
+	logger  logger.Interface
+	jwt     *jwtoken.TokenService
+	usecase port.CommentUsecase
+}
+
+func NewCommentRoutes(
+	r *chi.Mux,
+	log logger.Interface,
+	jwt *jwtoken.TokenService,
+	usecase port.CommentUsecase,
+) {
+	c := &commentController{
+		logger:  log,
+		jwt:     jwt,
+		usecase: usecase,
+	}
+
+	// Protected routes
+	r.Group(func(r chi.Router) {
+		r.Use(jwtoken.Verifier(jwt))
+		r.Use(jwtoken.Authenticator())
+
+		r.Get("/user/tasks/{task_id}/comments", c.GetCommentsByTaskID())
+		r.Post("/user/tasks/{task_id}/comments", c.CreateComment())
+		r.Put("/user/tasks/{task_id}/comments/{comment_id}", c.UpdateComment())
+		r.Delete("/user/tasks/{task_id}/comments/{comment_id}", c.DeleteComment())
+	})
+}
+
+func (c *commentController) CreateComment() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "comment.controller.CreateComment"
+
+		ctx := r.Context()
+		log := logger.LogWithRequest(c.logger, op, r)
+
+		userID, err := jwtoken.GetUserID(ctx)
+		if err != nil {
+			handleInternalServerError(w, r, log, le.ErrFailedToGetUserIDFromToken, err)
+			return
+		}
+
+		taskID := chi.URLParam(r, key.TaskID)
+		if taskID == "" {
+			handleResponseError(w, r, log, http.StatusBadRequest, le.ErrEmptyQueryTaskID)
+			return
+		}
+
+		commentInput := &model.CommentRequestData{}
+		if err = decodeAndValidateJSON(w, r, log, commentInput); err != nil {
+			return
+		}
+
+		commentInput.TaskID = taskID
+		commentInput.UserID = userID
+
+		commentResp, err := c.usecase.CreateComment(ctx, commentInput)
+
+		switch {
+		case errors.Is(err, le.ErrTaskNotFound):
+			handleResponseError(w, r, log, http.StatusNotFound, le.ErrTaskNotFound)
+			return
+		case err != nil:
+			handleInternalServerError(w, r, log, le.ErrFailedToCreateComment, err)
+			return
+		default:
+			handleResponseCreated(w, r, log, "comment created", commentResp,
+				slog.String(key.TaskID, taskID),
+				slog.String(key.CommentID, commentResp.ID),
+			)
+		}
+	}
+}
+
+func (c *commentController) GetCommentsByTaskID() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "comment.controller.GetCommentsByTaskID"
+
+		ctx := r.Context()
+		log := logger.LogWithRequest(c.logger, op, r)
+
+		userID, err := jwtoken.GetUserID(ctx)
+		if err != nil {
+			handleInternalServerError(w, r, log, le.ErrFailedToGetUserIDFromToken, err)
+			return
+		}
+
+		taskID := chi.URLParam(r, key.TaskID)
+		if taskID == "" {
+			handleResponseError(w, r, log, http.StatusBadRequest, le.ErrEmptyQueryTaskID)
+			return
+		}
+
+		pagination := ParseLimitAndAfterID(r)
+
+		commentsResp, err := c.usecase.GetCommentsByTaskID(ctx, model.CommentRequestData{
+			TaskID: taskID,
+			UserID: userID,
+		}, pagination)
+
+		switch {
+		case errors.Is(err, le.ErrTaskNotFound):
+			handleResponseError(w, r, log, http.StatusNotFound, le.ErrTaskNotFound)
+			return
+		case errors.Is(err, le.ErrNoCommentsFound):
+			handleResponseError(w, r, log, http.StatusNotFound, le.ErrNoCommentsFound)
+			return
+		case err != nil:
+			handleInternalServerError(w, r, log, le.ErrFailedToGetComments, err)
+			return
+		default:
+			handleResponseSuccess(w, r, log, "comments found", commentsResp,
+				slog.String(key.TaskID, taskID),
+				slog.Int(key.Count, len(commentsResp)),
+			)
+		}
+	}
+}
+
+func (c *commentController) UpdateComment() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "comment.controller.UpdateComment"
+
+		ctx := r.Context()
+		log := logger.LogWithRequest(c.logger, op, r)
+
+		userID, err := jwtoken.GetUserID(ctx)
+		if err != nil {
+			handleInternalServerError(w, r, log, le.ErrFailedToGetUserIDFromToken, err)
+			return
+		}
+
+		taskID := chi.URLParam(r, key.TaskID)
+		if taskID == "" {
+			handleResponseError(w, r, log, http.StatusBadRequest, le.ErrEmptyQueryTaskID)
+			return
+		}
+
+		commentID := chi.URLParam(r, key.CommentID)
+		if commentID == "" {
+			handleResponseError(w, r, log, http.StatusBadRequest, le.ErrEmptyQueryCommentID)
+			return
+		}
+
+		commentInput := &model.CommentRequestData{}
+		if err = decodeAndValidateJSON(w, r, log, commentInput); err != nil {
+			return
+		}
+
+		commentInput.ID = commentID
+		commentInput.TaskID = taskID
+		commentInput.UserID = userID
+
+		commentResp, err := c.usecase.UpdateComment(ctx, commentInput)
+
+		switch {
+		case errors.Is(err, le.ErrTaskNotFound):
+			handleResponseError(w, r, log, http.StatusNotFound, le.ErrTaskNotFound)
+			return
+		case errors.Is(err, le.ErrCommentNotFound):
+			handleResponseError(w, r, log, http.StatusNotFound, le.ErrCommentNotFound)
+			return
+		case errors.Is(err, le.ErrNoPermissionToEditComment):
+			handleResponseError(w, r, log, http.StatusForbidden, le.ErrNoPermissionToEditComment)
+			return
+		case err != nil:
+			handleInternalServerError(w, r, log, le.ErrFailedToUpdateComment, err)
+			return
+		default:
+			handleResponseSuccess(w, r, log, "comment updated", commentResp,
+				slog.String(key.TaskID, taskID),
+				slog.String(key.CommentID, commentID),
+			)
+		}
+	}
+}
+
+func (c *commentController) DeleteComment() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "comment.controller.DeleteComment"
+
+		ctx := r.Context()
+		log := logger.LogWithRequest(c.logger, op, r)
+
+		userID, err := jwtoken.GetUserID(ctx)
+		if err != nil {
+			handleInternalServerError(w, r, log, le.ErrFailedToGetUserIDFromToken, err)
+			return
+		}
+
+		taskID := chi.URLParam(r, key.TaskID)
+		if taskID == "" {
+			handleResponseError(w, r, log, http.StatusBadRequest, le.ErrEmptyQueryTaskID)
+			return
+		}
+
+		commentID := chi.URLParam(r, key.CommentID)
+		if commentID == "" {
+			handleResponseError(w, r, log, http.StatusBadRequest, le.ErrEmptyQueryCommentID)
+			return
+		}
+
+		commentInput := model.CommentRequestData{
+			ID:     commentID,
+			TaskID: taskID,
+			UserID: userID,
+		}
+
+		err = c.usecase.DeleteComment(ctx, commentInput)
+
+		switch {
+		case errors.Is(err, le.ErrTaskNotFound):
+			handleResponseError(w, r, log, http.StatusNotFound, le.ErrTaskNotFound)
+			return
+		case errors.Is(err, le.ErrCommentNotFound):
+			handleResponseError(w, r, log, http.StatusNotFound, le.ErrCommentNotFound)
+			return
+		case errors.Is(err, le.ErrNoPermissionToEditComment):
+			handleResponseError(w, r, log, http.StatusForbidden, le.ErrNoPermissionToEditComment)
+			return
+		case err != nil:
+			handleInternalServerError(w, r, log, le.ErrFailedToDeleteComment, err)
+			return
+		default:
+			handleResponseSuccess(w, r, log, "comment deleted", commentInput,
+				slog.String(key.TaskID, taskID),
+				slog.String(key.CommentID, commentID),
+			)
+		}
+	}
+}
@@ -0,0 +1,235 @@
+package v1
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"golang.org/x/net/websocket"
+
+	"github.com/rshelekhov/reframed/internal/lib/constants/key"
+	"github.com/rshelekhov/reframed/internal/lib/constants/le"
+	"github.com/rshelekhov/reframed/internal/lib/logger"
+	"github.com/rshelekhov/reframed/internal/lib/middleware/jwtoken"
+	"github.com/rshelekhov/reframed/internal/model"
+	"github.com/rshelekhov/reframed/internal/port"
+)
+
+// keepAliveInterval is the interval of comments sent to the SSE stream,
+// so proxies don't close an idle connection
+const keepAliveInterval = 15 * time.Second
+
+type eventController struct {
+	logger         logger.Interface
+	jwt            *jwtoken.TokenService
+	broker         port.EventBroker
+	allowedOrigins []string
+}
+
+func NewEventRoutes(
+	r *chi.Mux,
+	log logger.Interface,
+	jwt *jwtoken.TokenService,
+	broker port.EventBroker,
+	allowedOrigins []string,
+) {
+	c := &eventController{
+		logger:         log,
+		jwt:            jwt,
+		broker:         broker,
+		allowedOrigins: allowedOrigins,
+	}
+
+	r.Group(func(r chi.Router) {
+		r.Use(jwtoken.Verifier(jwt))
+		r.Use(jwtoken.Authenticator())
+
+		r.Route("/user/events", func(r chi.Router) {
+			r.Get("/", c.StreamEvents())     // Server-Sent Events
+			r.Get("/ws", c.StreamEventsWS()) // WebSocket
+		})
+	})
+}
+
+func (c *eventController) StreamEvents() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "event.controller.StreamEvents"
+
+		ctx := r.Context()
+		log := logger.LogWithRequest(c.logger, op, r)
+
+		userID, err := jwtoken.GetUserID(ctx)
+		if err != nil {
+			handleInternalServerError(w, r, log, le.ErrFailedToGetUserIDFromToken, err)
+			return
+		}
+
+		flusher, ok := w.(http.Flusher)
+		if !ok {
+			handleResponseError(w, r, log, http.StatusInternalServerError, le.ErrStreamingNotSupported)
+			return
+		}
+
+		events, err := c.broker.Subscribe(ctx, userID)
+		if err != nil {
+			handleInternalServerError(w, r, log, le.ErrFailedToSubscribeToEvents, err)
+			return
+		}
+
+		// The stream lives longer than the write timeout of the server
+		if err = http.NewResponseController(w).SetWriteDeadline(time.Time{}); err != nil {
+			log.Warn("failed to reset write deadline", logger.Err(err))
+		}
+
+		w.Header().Set("Content-Type", "text/event-stream")
+		w.Header().Set("Cache-Control", "no-cache")
+		w.Header().Set("Connection", "keep-alive")
+		w.Header().Set("X-Accel-Buffering", "no")
+		w.WriteHeader(http.StatusOK)
+		flusher.Flush()
+
+		log.Info("event stream opened", slog.String(key.UserID, userID))
+
+		keepAlive := time.NewTicker(keepAliveInterval)
+		defer keepAlive.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				log.Info("event stream closed", slog.String(key.UserID, userID))
+				return
+			case <-keepAlive.C:
+				if _, err = fmt.Fprint(w, ": keep-alive\n\n"); err != nil {
+					return
+				}
+			case event, ok := <-events:
+				if !ok {
+					return
+				}
+				if err = writeServerSentEvent(w, event); err != nil {
+					log.Error("failed to write event", logger.Err(err))
+					return
+				}
+			}
+
+			flusher.Flush()
+		}
+	}
+}
+
+func writeServerSentEvent(w http.ResponseWriter, event model.Event) error {
+	data, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	_, err = fmt.Fprintf(w, "id: %s\nevent: %s\ndata: %s\n\n", event.ID, event.Type, data)
+
+	return err
+}
+
+// StreamEventsWS pushes the same events as StreamEvents over WebSocket.
+// Browsers can't set headers for WebSocket, so the access token can be passed in the query
+func (c *eventController) StreamEventsWS() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "event.controller.StreamEventsWS"
+
+		log := logger.LogWithRequest(c.logger, op, r)
+
+		userID, err := jwtoken.GetUserID(r.Context())
+		if err != nil {
+			handleInternalServerError(w, r, log, le.ErrFailedToGetUserIDFromToken, err)
+			return
+		}
+
+		server := websocket.Server{
+			// The access token can come from the query, so a page of any site could open the stream
+			// with the token it got hold of, only the known origins are allowed
+			Handshake: func(config *websocket.Config, r *http.Request) error {
+				if err := c.checkOrigin(config, r); err != nil {
+					log.Warn("websocket origin rejected", logger.Err(err))
+					return err
+				}
+				return nil
+			},
+		}
+
+		server.Handler = func(ws *websocket.Conn) {
+			defer ws.Close()
+
+			// The connection is hijacked, so the deadlines of the server don't apply anymore
+			if err := ws.SetDeadline(time.Time{}); err != nil {
+				log.Warn("failed to reset connection deadline", logger.Err(err))
+			}
+
+			ctx, cancel := context.WithCancel(r.Context())
+			defer cancel()
+
+			events, err := c.broker.Subscribe(ctx, userID)
+			if err != nil {
+				log.Error("failed to subscribe to events", logger.Err(err))
+				return
+			}
+
+			log.Info("websocket event stream opened", slog.String(key.UserID, userID))
+
+			// The client doesn't send anything, reading only detects the closed connection
+			go func() {
+				defer cancel()
+
+				var msg []byte
+				for {
+					if err := websocket.Message.Receive(ws, &msg); err != nil {
+						return
+					}
+				}
+			}()
+
+			for {
+				select {
+				case <-ctx.Done():
+					log.Info("websocket event stream closed", slog.String(key.UserID, userID))
+					return
+				case event, ok := <-events:
+					if !ok {
+						return
+					}
+					if err := websocket.JSON.Send(ws, event); err != nil {
+						log.Error("failed to send event", logger.Err(err))
+						return
+					}
+				}
+			}
+		}
+
+		server.ServeHTTP(w, r)
+	}
+}
+
+// checkOrigin allows the WebSocket connection from the origin of the server itself and from the configured origins
+func (c *eventController) checkOrigin(config *websocket.Config, r *http.Request) error {
+	origin, err := websocket.Origin(config, r)
+	if err != nil {
+		return err
+	}
+	if origin == nil {
+		return le.ErrWebSocketOriginNotAllowed
+	}
+
+	if strings.EqualFold(origin.Host, r.Host) {
+		return nil
+	}
+
+	for _, allowed := range c.allowedOrigins {
+		if strings.EqualFold(strings.TrimSuffix(strings.TrimSpace(allowed), "/"), origin.Scheme+"://"+origin.Host) {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("%w: %s", le.ErrWebSocketOriginNotAllowed, origin)
+}
@@ -0,0 +1,510 @@
+package v1
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/rshelekhov/reframed/internal/lib/constants/key"
+	"github.com/rshelekhov/reframed/internal/lib/constants/le"
+	"github.com/rshelekhov/reframed/internal/lib/logger"
+	"github.com/rshelekhov/reframed/internal/lib/middleware/jwtoken"
+	"github.com/rshelekhov/reframed/internal/model"
+	"github.com/rshelekhov/reframed/internal/port"
+)
+
+type goalController struct {
+	logger  logger.Interface
+	jwt     *jwtoken.TokenService
+	usecase port.GoalUsecase
+}
+
+func NewGoalRoutes(
+	r *chi.Mux,
+	log logger.Interface,
+	jwt *jwtoken.TokenService,
+	usecase port.GoalUsecase,
+) {
+	c := &goalController{
+		logger:  log,
+		jwt:     jwt,
+		usecase: usecase,
+	}
+
+	// Protected routes
+	r.Group(func(r chi.Router) {
+		r.Use(jwtoken.Verifier(jwt))
+		r.Use(jwtoken.Authenticator())
+
+		r.Route("/user/goals", func(r chi.Router) {
+			r.Get("/", c.GetGoalsByUserID())
+			r.Post("/", c.CreateGoal())
+
+			r.Route("/{goal_id}", func(r chi.Router) {
+				r.Get("/", c.GetGoalByID())
+				r.Put("/", c.UpdateGoal())
+				r.Delete("/", c.DeleteGoal())
+				r.Get("/progress", c.GetGoalProgressHistory())
+
+				r.Post("/key-results", c.CreateKeyResult())
+				r.Put("/key-results/{key_result_id}", c.UpdateKeyResult())
+				r.Delete("/key-results/{key_result_id}", c.DeleteKeyResult())
+
+				r.Put("/lists/{list_id}", c.LinkListToGoal())
+				r.Delete("/lists/{list_id}", c.UnlinkListFromGoal())
+				r.Put("/tasks/{task_id}", c.LinkTaskToGoal())
+				r.Delete("/tasks/{task_id}", c.UnlinkTaskFromGoal())
+			})
+		})
+	})
+}
+
+func (c *goalController) CreateGoal() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "goal.controller.CreateGoal"
+
+		ctx := r.Context()
+		log := logger.LogWithRequest(c.logger, op, r)
+
+		userID, err := jwtoken.GetUserID(ctx)
+		if err != nil {
+			handleInternalServerError(w, r, log, le.ErrFailedToGetUserIDFromToken, err)
+			return
+		}
+
+		goalInput := &model.GoalRequestData{}
+		if err = decodeAndValidateJSON(w, r, log, goalInput); err != nil {
+			return
+		}
+
+		goalInput.UserID = userID
+
+		goalResp, err := c.usecase.CreateGoal(ctx, goalInput)
+		if err != nil {
+			handleInternalServerError(w, r, log, le.ErrFailedToCreateGoal, err)
+			return
+		}
+
+		handleResponseCreated(w, r, log, "goal created", goalResp, slog.String(key.GoalID, goalResp.ID))
+	}
+}
+
+func (c *goalController) GetGoalByID() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "goal.controller.GetGoalByID"
+
+		ctx := r.Context()
+		log := logger.LogWithRequest(c.logger, op, r)
+
+		userID, err := jwtoken.GetUserID(ctx)
+		if err != nil {
+			handleInternalServerError(w, r, log, le.ErrFailedToGetUserIDFromToken, err)
+			return
+		}
+
+		goalID := chi.URLParam(r, key.GoalID)
+		if goalID == "" {
+			handleResponseError(w, r, log, http.StatusBadRequest, le.ErrEmptyQueryGoalID)
+			return
+		}
+
+		goalInput := model.GoalRequestData{
+			ID:     goalID,
+			UserID: userID,
+		}
+
+		goalResp, err := c.usecase.GetGoalByID(ctx, goalInput)
+
+		switch {
+		case errors.Is(err, le.ErrGoalNotFound):
+			handleResponseError(w, r, log, http.StatusNotFound, le.ErrGoalNotFound)
+			return
+		case err != nil:
+			handleInternalServerError(w, r, log, le.ErrFailedToGetData, err)
+			return
+		default:
+			handleResponseSuccess(w, r, log, "goal received", goalResp, slog.String(key.GoalID, goalID))
+		}
+	}
+}
+
+func (c *goalController) GetGoalsByUserID() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "goal.controller.GetGoalsByUserID"
+
+		ctx := r.Context()
+		log := logger.LogWithRequest(c.logger, op, r)
+
+		userID, err := jwtoken.GetUserID(ctx)
+		if err != nil {
+			handleInternalServerError(w, r, log, le.ErrFailedToGetUserIDFromToken, err)
+			return
+		}
+
+		goalsResp, err := c.usecase.GetGoalsByUserID(ctx, userID)
+
+		switch {
+		case errors.Is(err, le.ErrNoGoalsFound):
+			handleResponseError(w, r, log, http.StatusNotFound, le.ErrNoGoalsFound)
+			return
+		case err != nil:
+			handleInternalServerError(w, r, log, le.ErrFailedToGetGoals, err)
+			return
+		default:
+			handleResponseSuccess(w, r, log, "goals found", goalsResp,
+				slog.Int(key.Count, len(goalsResp)),
+			)
+		}
+	}
+}
+
+func (c *goalController) GetGoalProgressHistory() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "goal.controller.GetGoalProgressHistory"
+
+		ctx := r.Context()
+		log := logger.LogWithRequest(c.logger, op, r)
+
+		userID, err := jwtoken.GetUserID(ctx)
+		if err != nil {
+			handleInternalServerError(w, r, log, le.ErrFailedToGetUserIDFromToken, err)
+			return
+		}
+
+		goalID := chi.URLParam(r, key.GoalID)
+		if goalID == "" {
+			handleResponseError(w, r, log, http.StatusBadRequest, le.ErrEmptyQueryGoalID)
+			return
+		}
+
+		goalInput := model.GoalRequestData{
+			ID:     goalID,
+			UserID: userID,
+		}
+
+		historyResp, err := c.usecase.GetGoalProgressHistory(ctx, goalInput)
+
+		switch {
+		case errors.Is(err, le.ErrGoalNotFound):
+			handleResponseError(w, r, log, http.StatusNotFound, le.ErrGoalNotFound)
+			return
+		case err != nil:
+			handleInternalServerError(w, r, log, le.ErrFailedToGetData, err)
+			return
+		default:
+			handleResponseSuccess(w, r, log, "goal progress history received", historyResp,
+				slog.String(key.GoalID, goalID),
+				slog.Int(key.Count, len(historyResp)),
+			)
+		}
+	}
+}
+
+func (c *goalController) UpdateGoal() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "goal.controller.UpdateGoal"
+
+		ctx := r.Context()
+		log := logger.LogWithRequest(c.logger, op, r)
+
+		userID, err := jwtoken.GetUserID(ctx)
+		if err != nil {
+			handleInternalServerError(w, r, log, le.ErrFailedToGetUserIDFromToken, err)
+			return
+		}
+
+		goalID := chi.URLParam(r, key.GoalID)
+		if goalID == "" {
+			handleResponseError(w, r, log, http.StatusBadRequest, le.ErrEmptyQueryGoalID)
+			return
+		}
+
+		goalInput := &model.GoalRequestData{}
+		if err = decodeAndValidateJSON(w, r, log, goalInput); err != nil {
+			return
+		}
+
+		goalInput.ID = goalID
+		goalInput.UserID = userID
+
+		goalResp, err := c.usecase.UpdateGoal(ctx, goalInput)
+
+		switch {
+		case errors.Is(err, le.ErrGoalNotFound):
+			handleResponseError(w, r, log, http.StatusNotFound, le.ErrGoalNotFound)
+			return
+		case err != nil:
+			handleInternalServerError(w, r, log, le.ErrFailedToUpdateGoal, err)
+			return
+		default:
+			handleResponseSuccess(w, r, log, "goal updated", goalResp, slog.String(key.GoalID, goalID))
+		}
+	}
+}
+
+func (c *goalController) DeleteGoal() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "goal.controller.DeleteGoal"
+
+		ctx := r.Context()
+		log := logger.LogWithRequest(c.logger, op, r)
+
+		userID, err := jwtoken.GetUserID(ctx)
+		if err != nil {
+			handleInternalServerError(w, r, log, le.ErrFailedToGetUserIDFromToken, err)
+			return
+		}
+
+		goalID := chi.URLParam(r, key.GoalID)
+		if goalID == "" {
+			handleResponseError(w, r, log, http.StatusBadRequest, le.ErrEmptyQueryGoalID)
+			return
+		}
+
+		goalInput := model.GoalRequestData{
+			ID:     goalID,
+			UserID: userID,
+		}
+
+		err = c.usecase.DeleteGoal(ctx, goalInput)
+
+		switch {
+		case errors.Is(err, le.ErrGoalNotFound):
+			handleResponseError(w, r, log, http.StatusNotFound, le.ErrGoalNotFound)
+			return
+		case err != nil:
+			handleInternalServerError(w, r, log, le.ErrFailedToDeleteGoal, err)
+			return
+		default:
+			handleResponseSuccess(w, r, log, "goal deleted", goalID, slog.String(key.GoalID, goalID))
+		}
+	}
+}
+
+func (c *goalController) CreateKeyResult() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "goal.controller.CreateKeyResult"
+
+		ctx := r.Context()
+		log := logger.LogWithRequest(c.logger, op, r)
+
+		userID, err := jwtoken.GetUserID(ctx)
+		if err != nil {
+			handleInternalServerError(w, r, log, le.ErrFailedToGetUserIDFromToken, err)
+			return
+		}
+
+		goalID := chi.URLParam(r, key.GoalID)
+		if goalID == "" {
+			handleResponseError(w, r, log, http.StatusBadRequest, le.ErrEmptyQueryGoalID)
+			return
+		}
+
+		keyResultInput := &model.KeyResultRequestData{}
+		if err = decodeAndValidateJSON(w, r, log, keyResultInput); err != nil {
+			return
+		}
+
+		keyResultInput.GoalID = goalID
+		keyResultInput.UserID = userID
+
+		goalResp, err := c.usecase.CreateKeyResult(ctx, keyResultInput)
+
+		switch {
+		case errors.Is(err, le.ErrInvalidKeyResult):
+			handleResponseError(w, r, log, http.StatusBadRequest, le.ErrInvalidKeyResult)
+			return
+		case errors.Is(err, le.ErrGoalNotFound):
+			handleResponseError(w, r, log, http.StatusNotFound, le.ErrGoalNotFound)
+			return
+		case err != nil:
+			handleInternalServerError(w, r, log, le.ErrFailedToCreateKeyResult, err)
+			return
+		default:
+			handleResponseCreated(w, r, log, "key result created", goalResp, slog.String(key.GoalID, goalID))
+		}
+	}
+}
+
+func (c *goalController) UpdateKeyResult() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "goal.controller.UpdateKeyResult"
+
+		ctx := r.Context()
+		log := logger.LogWithRequest(c.logger, op, r)
+
+		userID, err := jwtoken.GetUserID(ctx)
+		if err != nil {
+			handleInternalServerError(w, r, log, le.ErrFailedToGetUserIDFromToken, err)
+			return
+		}
+
+		goalID := chi.URLParam(r, key.GoalID)
+		if goalID == "" {
+			handleResponseError(w, r, log, http.StatusBadRequest, le.ErrEmptyQueryGoalID)
+			return
+		}
+
+		keyResultID := chi.URLParam(r, key.KeyResultID)
+		if keyResultID == "" {
+			handleResponseError(w, r, log, http.StatusBadRequest, le.ErrEmptyQueryKeyResultID)
+			return
+		}
+
+		keyResultInput := &model.KeyResultRequestData{}
+		if err = decodeAndValidateJSON(w, r, log, keyResultInput); err != nil {
+			return
+		}
+
+		keyResultInput.ID = keyResultID
+		keyResultInput.GoalID = goalID
+		keyResultInput.UserID = userID
+
+		goalResp, err := c.usecase.UpdateKeyResult(ctx, keyResultInput)
+
+		switch {
+		case errors.Is(err, le.ErrInvalidKeyResult):
+			handleResponseError(w, r, log, http.StatusBadRequest, le.ErrInvalidKeyResult)
+			return
+		case errors.Is(err, le.ErrGoalNotFound):
+			handleResponseError(w, r, log, http.StatusNotFound, le.ErrGoalNotFound)
+			return
+		case errors.Is(err, le.ErrKeyResultNotFound):
+			handleResponseError(w, r, log, http.StatusNotFound, le.ErrKeyResultNotFound)
+			return
+		case err != nil:
+			handleInternalServerError(w, r, log, le.ErrFailedToUpdateKeyResult, err)
+			return
+		default:
+			handleResponseSuccess(w, r, log, "key result updated", goalResp,
+				slog.String(key.GoalID, goalID),
+				slog.String(key.KeyResultID, keyResultID),
+			)
+		}
+	}
+}
+
+func (c *goalController) DeleteKeyResult() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "goal.controller.DeleteKeyResult"
+
+		ctx := r.Context()
+		log := logger.LogWithRequest(c.logger, op, r)
+
+		userID, err := jwtoken.GetUserID(ctx)
+		if err != nil {
+			handleInternalServerError(w, r, log, le.ErrFailedToGetUserIDFromToken, err)
+			return
+		}
+
+		goalID := chi.URLParam(r, key.GoalID)
+		if goalID == "" {
+			handleResponseError(w, r, log, http.StatusBadRequest, le.ErrEmptyQueryGoalID)
+			return
+		}
+
+		keyResultID := chi.URLParam(r, key.KeyResultID)
+		if keyResultID == "" {
+			handleResponseError(w, r, log, http.StatusBadRequest, le.ErrEmptyQueryKeyResultID)
+			return
+		}
+
+		keyResultInput := model.KeyResultRequestData{
+			ID:     keyResultID,
+			GoalID: goalID,
+			UserID: userID,
+		}
+
+		goalResp, err := c.usecase.DeleteKeyResult(ctx, keyResultInput)
+
+		switch {
+		case errors.Is(err, le.ErrGoalNotFound):
+			handleResponseError(w, r, log, http.StatusNotFound, le.ErrGoalNotFound)
+			return
+		case errors.Is(err, le.ErrKeyResultNotFound):
+			handleResponseError(w, r, log, http.StatusNotFound, le.ErrKeyResultNotFound)
+			return
+		case err != nil:
+			handleInternalServerError(w, r, log, le.ErrFailedToDeleteKeyResult, err)
+			return
+		default:
+			handleResponseSuccess(w, r, log, "key result deleted", goalResp,
+				slog.String(key.GoalID, goalID),
+				slog.String(key.KeyResultID, keyResultID),
+			)
+		}
+	}
+}
+
+func (c *goalController) LinkListToGoal() http.HandlerFunc {
+	return c.changeGoalLink("goal.controller.LinkListToGoal", "list linked to goal", le.ErrFailedToLinkGoal, c.usecase.LinkListToGoal)
+}
+
+func (c *goalController) UnlinkListFromGoal() http.HandlerFunc {
+	return c.changeGoalLink("goal.controller.UnlinkListFromGoal", "list unlinked from goal", le.ErrFailedToUnlinkGoal, c.usecase.UnlinkListFromGoal)
+}
+
+func (c *goalController) LinkTaskToGoal() http.HandlerFunc {
+	return c.changeGoalLink("goal.controller.LinkTaskToGoal", "task linked to goal", le.ErrFailedToLinkGoal, c.usecase.LinkTaskToGoal)
+}
+
+func (c *goalController) UnlinkTaskFromGoal() http.HandlerFunc {
+	return c.changeGoalLink("goal.controller.UnlinkTaskFromGoal", "task unlinked from goal", le.ErrFailedToUnlinkGoal, c.usecase.UnlinkTaskFromGoal)
+}
+
+// changeGoalLink returns the handler linking or unlinking the list or the task from the URL to the goal
+func (c *goalController) changeGoalLink(
+	op, msg string,
+	failure le.LocalError,
+	change func(ctx context.Context, data model.GoalLinkRequestData) (model.GoalResponseData, error),
+) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		log := logger.LogWithRequest(c.logger, op, r)
+
+		userID, err := jwtoken.GetUserID(ctx)
+		if err != nil {
+			handleInternalServerError(w, r, log, le.ErrFailedToGetUserIDFromToken, err)
+			return
+		}
+
+		goalID := chi.URLParam(r, key.GoalID)
+		if goalID == "" {
+			handleResponseError(w, r, log, http.StatusBadRequest, le.ErrEmptyQueryGoalID)
+			return
+		}
+
+		linkInput := model.GoalLinkRequestData{
+			GoalID: goalID,
+			ListID: chi.URLParam(r, key.ListID),
+			TaskID: chi.URLParam(r, key.TaskID),
+			UserID: userID,
+		}
+
+		goalResp, err := change(ctx, linkInput)
+
+		switch {
+		case errors.Is(err, le.ErrGoalNotFound):
+			handleResponseError(w, r, log, http.StatusNotFound, le.ErrGoalNotFound)
+			return
+		case errors.Is(err, le.ErrListNotFound):
+			handleResponseError(w, r, log, http.StatusNotFound, le.ErrListNotFound)
+			return
+		case errors.Is(err, le.ErrTaskNotFound):
+			handleResponseError(w, r, log, http.StatusNotFound, le.ErrTaskNotFound)
+			return
+		case err != nil:
+			handleInternalServerError(w, r, log, failure, err)
+			return
+		default:
+			handleResponseSuccess(w, r, log, msg, goalResp,
+				slog.String(key.GoalID, goalID),
+				slog.String(key.ListID, linkInput.ListID),
+				slog.String(key.TaskID, linkInput.TaskID),
+			)
+		}
+	}
+}
@@ -0,0 +1,342 @@
+package v1
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/rshelekhov/reframed/internal/lib/constants/key"
+	"github.com/rshelekhov/reframed/internal/lib/constants/le"
+	"github.com/rshelekhov/reframed/internal/lib/logger"
+	"github.com/rshelekhov/reframed/internal/lib/middleware/jwtoken"
+	"github.com/rshelekhov/reframed/internal/model"
+	"github.com/rshelekhov/reframed/internal/port"
+)
+
+type habitController struct {
+	logger  logger.Interface
+	jwt     *jwtoken.TokenService
+	usecase port.HabitUsecase
+}
+
+func NewHabitRoutes(
+	r *chi.Mux,
+	log logger.Interface,
+	jwt *jwtoken.TokenService,
+	usecase port.HabitUsecase,
+) {
+	c := &habitController{
+		logger:  log,
+		jwt:     jwt,
+		usecase: usecase,
+	}
+
+	// Protected routes
+	r.Group(func(r chi.Router) {
+		r.Use(jwtoken.Verifier(jwt))
+		r.Use(jwtoken.Authenticator())
+
+		r.Route("/user/habits", func(r chi.Router) {
+			r.Get("/", c.GetHabitsByUserID())
+			r.Post("/", c.CreateHabit())
+
+			r.Route("/{habit_id}", func(r chi.Router) {
+				r.Get("/", c.GetHabitByID())
+				r.Put("/", c.UpdateHabit())
+				r.Delete("/", c.DeleteHabit())
+
+				r.Post("/check-ins", c.CheckInHabit())       // ?date=YYYY-MM-DD, today of the user by default
+				r.Delete("/check-ins", c.UndoHabitCheckIn()) // ?date=YYYY-MM-DD, today of the user by default
+				r.Get("/heatmap", c.GetHabitHeatmap())       // ?from=YYYY-MM-DD&to=YYYY-MM-DD, the last year by default
+			})
+		})
+	})
+}
+
+func (c *habitController) CreateHabit() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "habit.controller.CreateHabit"
+
+		ctx := r.Context()
+		log := logger.LogWithRequest(c.logger, op, r)
+
+		userID, err := jwtoken.GetUserID(ctx)
+		if err != nil {
+			handleInternalServerError(w, r, log, le.ErrFailedToGetUserIDFromToken, err)
+			return
+		}
+
+		habitInput := &model.HabitRequestData{}
+		if err = decodeAndValidateJSON(w, r, log, habitInput); err != nil {
+			return
+		}
+
+		habitInput.UserID = userID
+
+		habitResp, err := c.usecase.CreateHabit(ctx, habitInput)
+		if err != nil {
+			handleInternalServerError(w, r, log, le.ErrFailedToCreateHabit, err)
+			return
+		}
+
+		handleResponseCreated(w, r, log, "habit created", habitResp, slog.String(key.HabitID, habitResp.ID))
+	}
+}
+
+func (c *habitController) GetHabitByID() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "habit.controller.GetHabitByID"
+
+		ctx := r.Context()
+		log := logger.LogWithRequest(c.logger, op, r)
+
+		userID, err := jwtoken.GetUserID(ctx)
+		if err != nil {
+			handleInternalServerError(w, r, log, le.ErrFailedToGetUserIDFromToken, err)
+			return
+		}
+
+		habitID := chi.URLParam(r, key.HabitID)
+		if habitID == "" {
+			handleResponseError(w, r, log, http.StatusBadRequest, le.ErrEmptyQueryHabitID)
+			return
+		}
+
+		habitInput := model.HabitRequestData{
+			ID:     habitID,
+			UserID: userID,
+		}
+
+		habitResp, err := c.usecase.GetHabitByID(ctx, habitInput)
+
+		switch {
+		case errors.Is(err, le.ErrHabitNotFound):
+			handleResponseError(w, r, log, http.StatusNotFound, le.ErrHabitNotFound)
+			return
+		case err != nil:
+			handleInternalServerError(w, r, log, le.ErrFailedToGetData, err)
+			return
+		default:
+			handleResponseSuccess(w, r, log, "habit received", habitResp, slog.String(key.HabitID, habitID))
+		}
+	}
+}
+
+func (c *habitController) GetHabitsByUserID() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "habit.controller.GetHabitsByUserID"
+
+		ctx := r.Context()
+		log := logger.LogWithRequest(c.logger, op, r)
+
+		userID, err := jwtoken.GetUserID(ctx)
+		if err != nil {
+			handleInternalServerError(w, r, log, le.ErrFailedToGetUserIDFromToken, err)
+			return
+		}
+
+		habitsResp, err := c.usecase.GetHabitsByUserID(ctx, userID)
+
+		switch {
+		case errors.Is(err, le.ErrNoHabitsFound):
+			handleResponseError(w, r, log, http.StatusNotFound, le.ErrNoHabitsFound)
+			return
+		case err != nil:
+			handleInternalServerError(w, r, log, le.ErrFailedToGetHabits, err)
+			return
+		default:
+			handleResponseSuccess(w, r, log, "habits found", habitsResp,
+				slog.Int(key.Count, len(habitsResp)),
+			)
+		}
+	}
+}
+
+func (c *habitController) UpdateHabit() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "habit.controller.UpdateHabit"
+
+		ctx := r.Context()
+		log := logger.LogWithRequest(c.logger, op, r)
+
+		userID, err := jwtoken.GetUserID(ctx)
+		if err != nil {
+			handleInternalServerError(w, r, log, le.ErrFailedToGetUserIDFromToken, err)
+			return
+		}
+
+		habitID := chi.URLParam(r, key.HabitID)
+		if habitID == "" {
+			handleResponseError(w, r, log, http.StatusBadRequest, le.ErrEmptyQueryHabitID)
+			return
+		}
+
+		habitInput := &model.HabitRequestData{}
+		if err = decodeAndValidateJSON(w, r, log, habitInput); err != nil {
+			return
+		}
+
+		habitInput.ID = habitID
+		habitInput.UserID = userID
+
+		habitResp, err := c.usecase.UpdateHabit(ctx, habitInput)
+
+		switch {
+		case errors.Is(err, le.ErrHabitNotFound):
+			handleResponseError(w, r, log, http.StatusNotFound, le.ErrHabitNotFound)
+			return
+		case err != nil:
+			handleInternalServerError(w, r, log, le.ErrFailedToUpdateHabit, err)
+			return
+		default:
+			handleResponseSuccess(w, r, log, "habit updated", habitResp, slog.String(key.HabitID, habitID))
+		}
+	}
+}
+
+func (c *habitController) DeleteHabit() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "habit.controller.DeleteHabit"
+
+		ctx := r.Context()
+		log := logger.LogWithRequest(c.logger, op, r)
+
+		userID, err := jwtoken.GetUserID(ctx)
+		if err != nil {
+			handleInternalServerError(w, r, log, le.ErrFailedToGetUserIDFromToken, err)
+			return
+		}
+
+		habitID := chi.URLParam(r, key.HabitID)
+		if habitID == "" {
+			handleResponseError(w, r, log, http.StatusBadRequest, le.ErrEmptyQueryHabitID)
+			return
+		}
+
+		habitInput := model.HabitRequestData{
+			ID:     habitID,
+			UserID: userID,
+		}
+
+		err = c.usecase.DeleteHabit(ctx, habitInput)
+
+		switch {
+		case errors.Is(err, le.ErrHabitNotFound):
+			handleResponseError(w, r, log, http.StatusNotFound, le.ErrHabitNotFound)
+			return
+		case err != nil:
+			handleInternalServerError(w, r, log, le.ErrFailedToDeleteHabit, err)
+			return
+		default:
+			handleResponseSuccess(w, r, log, "habit deleted", habitID, slog.String(key.HabitID, habitID))
+		}
+	}
+}
+
+func (c *habitController) CheckInHabit() http.HandlerFunc {
+	return c.changeHabitCheckIn("habit.controller.CheckInHabit", "habit checked in", c.usecase.CheckInHabit)
+}
+
+func (c *habitController) UndoHabitCheckIn() http.HandlerFunc {
+	return c.changeHabitCheckIn("habit.controller.UndoHabitCheckIn", "habit check-in removed", c.usecase.UndoHabitCheckIn)
+}
+
+func (c *habitController) changeHabitCheckIn(
+	op, message string,
+	change func(ctx context.Context, data model.HabitCheckInRequestData) (model.HabitResponseData, error),
+) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		log := logger.LogWithRequest(c.logger, op, r)
+
+		userID, err := jwtoken.GetUserID(ctx)
+		if err != nil {
+			handleInternalServerError(w, r, log, le.ErrFailedToGetUserIDFromToken, err)
+			return
+		}
+
+		habitID := chi.URLParam(r, key.HabitID)
+		if habitID == "" {
+			handleResponseError(w, r, log, http.StatusBadRequest, le.ErrEmptyQueryHabitID)
+			return
+		}
+
+		checkInInput := model.HabitCheckInRequestData{
+			HabitID: habitID,
+			Date:    r.URL.Query().Get(key.Date),
+			UserID:  userID,
+		}
+
+		habitResp, err := change(ctx, checkInInput)
+
+		switch {
+		case errors.Is(err, le.ErrHabitNotFound):
+			handleResponseError(w, r, log, http.StatusNotFound, le.ErrHabitNotFound)
+			return
+		case errors.Is(err, le.ErrHabitCheckInNotFound):
+			handleResponseError(w, r, log, http.StatusNotFound, le.ErrHabitCheckInNotFound)
+			return
+		case errors.Is(err, le.ErrInvalidHabitCheckInDate):
+			handleResponseError(w, r, log, http.StatusBadRequest, le.ErrInvalidHabitCheckInDate)
+			return
+		case err != nil:
+			handleInternalServerError(w, r, log, le.ErrFailedToCheckInHabit, err)
+			return
+		default:
+			handleResponseSuccess(w, r, log, message, habitResp,
+				slog.String(key.HabitID, habitID),
+				slog.String(key.Date, checkInInput.Date),
+			)
+		}
+	}
+}
+
+func (c *habitController) GetHabitHeatmap() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "habit.controller.GetHabitHeatmap"
+
+		ctx := r.Context()
+		log := logger.LogWithRequest(c.logger, op, r)
+
+		userID, err := jwtoken.GetUserID(ctx)
+		if err != nil {
+			handleInternalServerError(w, r, log, le.ErrFailedToGetUserIDFromToken, err)
+			return
+		}
+
+		habitID := chi.URLParam(r, key.HabitID)
+		if habitID == "" {
+			handleResponseError(w, r, log, http.StatusBadRequest, le.ErrEmptyQueryHabitID)
+			return
+		}
+
+		heatmapInput := model.HabitHeatmapRequestData{
+			HabitID: habitID,
+			From:    r.URL.Query().Get(key.From),
+			To:      r.URL.Query().Get(key.To),
+			UserID:  userID,
+		}
+
+		heatmapResp, err := c.usecase.GetHabitHeatmap(ctx, heatmapInput)
+
+		switch {
+		case errors.Is(err, le.ErrHabitNotFound):
+			handleResponseError(w, r, log, http.StatusNotFound, le.ErrHabitNotFound)
+			return
+		case errors.Is(err, le.ErrInvalidHeatmapRange):
+			handleResponseError(w, r, log, http.StatusBadRequest, le.ErrInvalidHeatmapRange)
+			return
+		case err != nil:
+			handleInternalServerError(w, r, log, le.ErrFailedToGetHabitHeatmap, err)
+			return
+		default:
+			handleResponseSuccess(w, r, log, "habit heatmap received", heatmapResp,
+				slog.String(key.HabitID, habitID),
+				slog.Int(key.Count, heatmapResp.Total),
+			)
+		}
+	}
+}
@@ -8,6 +8,7 @@ import (
 	"github.com/rshelekhov/reframed/internal/lib/middleware/jwtoken"
 
 	"github.com/go-chi/chi/v5"
+	"github.com/go-playground/validator/v10"
 
 	"github.com/rshelekhov/reframed/internal/lib/constants/key"
 	"github.com/rshelekhov/reframed/internal/lib/constants/le"
@@ -45,7 +46,9 @@ func NewHeadingRoutes(
 			r.Route("/{heading_id}", func(r chi.Router) {
 				r.Get("/", c.GetHeadingByID())
 				r.Put("/", c.UpdateHeading())
+				r.Patch("/", c.PatchHeading())
 				r.Put("/move/", c.MoveHeadingToAnotherList())
+				r.Post("/duplicate", c.DuplicateHeading())
 				r.Delete("/", c.DeleteHeading())
 			})
 		})
@@ -80,6 +83,14 @@ func (c *headingController) CreateHeading() http.HandlerFunc {
 		headingInput.UserID = userID
 
 		headingResponse, err := c.usecase.CreateHeading(ctx, headingInput)
+		if errors.Is(err, le.ErrListNotFound) {
+			handleResponseError(w, r, log, http.StatusNotFound, le.ErrListNotFound)
+			return
+		}
+		if errors.Is(err, le.ErrNoPermissionToEditList) {
+			handleResponseError(w, r, log, http.StatusForbidden, le.ErrNoPermissionToEditList)
+			return
+		}
 		if err != nil {
 			handleInternalServerError(w, r, log, le.ErrFailedToCreateHeading, err)
 			return
@@ -204,6 +215,9 @@ func (c *headingController) UpdateHeading() http.HandlerFunc {
 		case errors.Is(err, le.ErrHeadingNotFound):
 			handleResponseError(w, r, log, http.StatusNotFound, le.ErrHeadingNotFound)
 			return
+		case errors.Is(err, le.ErrNoPermissionToEditList):
+			handleResponseError(w, r, log, http.StatusForbidden, le.ErrNoPermissionToEditList)
+			return
 		case err != nil:
 			handleInternalServerError(w, r, log, le.ErrFailedToUpdateHeading, err)
 			return
@@ -213,6 +227,62 @@ func (c *headingController) UpdateHeading() http.HandlerFunc {
 	}
 }
 
+func (c *headingController) PatchHeading() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "heading.controller.PatchHeading"
+
+		ctx := r.Context()
+		log := logger.LogWithRequest(c.logger, op, r)
+
+		userID, err := jwtoken.GetUserID(ctx)
+		if err != nil {
+			handleInternalServerError(w, r, log, le.ErrFailedToGetUserIDFromToken, err)
+			return
+		}
+
+		headingID := chi.URLParam(r, key.HeadingID)
+		if headingID == "" {
+			handleResponseError(w, r, log, http.StatusBadRequest, le.ErrEmptyQueryHeadingID)
+			return
+		}
+
+		patch, err := decodeMergePatch(w, r, log)
+		if err != nil {
+			return
+		}
+
+		headingInput := model.HeadingRequestData{
+			ID:     headingID,
+			UserID: userID,
+		}
+
+		headingResponse, err := c.usecase.PatchHeading(ctx, headingInput, patch)
+
+		var ve validator.ValidationErrors
+
+		switch {
+		case errors.As(err, &ve):
+			log.Error(le.ErrInvalidData.Error(), logger.Err(err))
+			responseValidationErrors(w, r, ve)
+			return
+		case errors.Is(err, le.ErrInvalidMergePatch):
+			handleResponseError(w, r, log, http.StatusBadRequest, le.ErrInvalidMergePatch)
+			return
+		case errors.Is(err, le.ErrHeadingNotFound):
+			handleResponseError(w, r, log, http.StatusNotFound, le.ErrHeadingNotFound)
+			return
+		case errors.Is(err, le.ErrNoPermissionToEditList):
+			handleResponseError(w, r, log, http.StatusForbidden, le.ErrNoPermissionToEditList)
+			return
+		case err != nil:
+			handleInternalServerError(w, r, log, le.ErrFailedToUpdateHeading, err)
+			return
+		default:
+			handleResponseSuccess(w, r, log, "heading patched", headingResponse, slog.String(key.HeadingID, headingResponse.ID))
+		}
+	}
+}
+
 func (c *headingController) MoveHeadingToAnotherList() http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		const op = "heading.controller.MoveTaskToAnotherList"
@@ -250,6 +320,9 @@ func (c *headingController) MoveHeadingToAnotherList() http.HandlerFunc {
 		case errors.Is(err, le.ErrHeadingNotFound):
 			handleResponseError(w, r, log, http.StatusNotFound, le.ErrHeadingNotFound)
 			return
+		case errors.Is(err, le.ErrNoPermissionToEditList):
+			handleResponseError(w, r, log, http.StatusForbidden, le.ErrNoPermissionToEditList)
+			return
 		case err != nil:
 			handleInternalServerError(w, r, log, le.ErrFailedToMoveHeading, err)
 			return
@@ -278,22 +351,96 @@ func (c *headingController) DeleteHeading() http.HandlerFunc {
 			return
 		}
 
+		headingInput := model.HeadingRequestData{
+			ID:         headingID,
+			UserID:     userID,
+			DeleteMode: model.DeleteMode(r.URL.Query().Get(key.DeleteMode)),
+		}
+
+		undoToken, err := c.usecase.DeleteHeading(ctx, headingInput)
+
+		switch {
+		case errors.Is(err, le.ErrInvalidDeleteMode):
+			handleResponseError(w, r, log, http.StatusBadRequest, le.ErrInvalidDeleteMode)
+			return
+		case errors.Is(err, le.ErrHeadingNotFound):
+			handleResponseError(w, r, log, http.StatusNotFound, le.ErrHeadingNotFound)
+			return
+		case errors.Is(err, le.ErrNoPermissionToEditList):
+			handleResponseError(w, r, log, http.StatusForbidden, le.ErrNoPermissionToEditList)
+			return
+		case errors.Is(err, le.ErrCannotDeleteDefaultHeading):
+			handleResponseError(w, r, log, http.StatusBadRequest, le.ErrCannotDeleteDefaultHeading)
+			return
+		case errors.Is(err, le.ErrHeadingNotEmpty):
+			handleResponseError(w, r, log, http.StatusConflict, le.ErrHeadingNotEmpty)
+			return
+		case err != nil:
+			handleInternalServerError(w, r, log, le.ErrFailedToDeleteHeading, err)
+			return
+		default:
+			handleResponseSuccess(w, r, log, "heading deleted",
+				model.UndoTokenResponseData{ID: headingID, UndoToken: undoToken},
+				slog.String(key.HeadingID, headingID),
+			)
+		}
+	}
+}
+
+func (c *headingController) DuplicateHeading() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "heading.controller.DuplicateHeading"
+
+		ctx := r.Context()
+		log := logger.LogWithRequest(c.logger, op, r)
+
+		userID, err := jwtoken.GetUserID(ctx)
+		if err != nil {
+			handleInternalServerError(w, r, log, le.ErrFailedToGetUserIDFromToken, err)
+			return
+		}
+
+		listID := chi.URLParam(r, key.ListID)
+		if listID == "" {
+			handleResponseError(w, r, log, http.StatusBadRequest, le.ErrEmptyQueryListID)
+			return
+		}
+
+		headingID := chi.URLParam(r, key.HeadingID)
+		if headingID == "" {
+			handleResponseError(w, r, log, http.StatusBadRequest, le.ErrEmptyQueryHeadingID)
+			return
+		}
+
+		opts, err := ParseDuplicateOptions(r)
+		if err != nil {
+			handleResponseError(w, r, log, http.StatusBadRequest, le.ErrInvalidDuplicateOptions)
+			return
+		}
+
 		headingInput := model.HeadingRequestData{
 			ID:     headingID,
+			ListID: listID,
 			UserID: userID,
 		}
 
-		err = c.usecase.DeleteHeading(ctx, headingInput)
+		duplicateResp, err := c.usecase.DuplicateHeading(ctx, headingInput, opts)
 
 		switch {
 		case errors.Is(err, le.ErrHeadingNotFound):
 			handleResponseError(w, r, log, http.StatusNotFound, le.ErrHeadingNotFound)
 			return
+		case errors.Is(err, le.ErrNoPermissionToEditList):
+			handleResponseError(w, r, log, http.StatusForbidden, le.ErrNoPermissionToEditList)
+			return
 		case err != nil:
-			handleInternalServerError(w, r, log, le.ErrFailedToDeleteHeading, err)
+			handleInternalServerError(w, r, log, le.ErrFailedToDuplicateHeading, err)
 			return
 		default:
-			handleResponseSuccess(w, r, log, "heading deleted", headingID, slog.String(key.HeadingID, headingID))
+			handleResponseCreated(w, r, log, "heading duplicated", duplicateResp,
+				slog.String(key.HeadingID, duplicateResp.Heading.ID),
+				slog.Int(key.Count, len(duplicateResp.Tasks)),
+			)
 		}
 	}
 }
@@ -1,6 +1,7 @@
 package v1
 
 import (
+	"bytes"
 	"errors"
 	"fmt"
 	"io"
@@ -12,6 +13,7 @@ import (
 	"github.com/go-playground/validator/v10"
 	"github.com/rshelekhov/reframed/internal/lib/constants/le"
 	"github.com/rshelekhov/reframed/internal/lib/logger"
+	"github.com/rshelekhov/reframed/internal/lib/mergepatch"
 )
 
 // validateData validates the request
@@ -68,6 +70,30 @@ func decodeAndValidateJSON(w http.ResponseWriter, r *http.Request, log logger.In
 	return nil
 }
 
+// decodeMergePatch reads the JSON merge patch (RFC 7396) from the request body
+func decodeMergePatch(w http.ResponseWriter, r *http.Request, log logger.Interface) ([]byte, error) {
+	patch, err := io.ReadAll(r.Body)
+	if err != nil {
+		log.Error(le.ErrInvalidJSON.Error(), logger.Err(err))
+		responseError(w, r, http.StatusBadRequest, le.ErrInvalidJSON)
+		return nil, le.ErrInvalidJSON
+	}
+
+	if len(bytes.TrimSpace(patch)) == 0 {
+		log.Error(le.ErrEmptyRequestBody.Error())
+		responseError(w, r, http.StatusBadRequest, le.ErrEmptyRequestBody)
+		return nil, le.ErrEmptyRequestBody
+	}
+
+	if !mergepatch.IsObject(patch) {
+		log.Error(le.ErrInvalidMergePatch.Error())
+		responseError(w, r, http.StatusBadRequest, le.ErrInvalidMergePatch)
+		return nil, le.ErrInvalidMergePatch
+	}
+
+	return patch, nil
+}
+
 // ValidationError returns a Response with StatusError and a comma-separated list of errors
 func responseValidationErrors(w http.ResponseWriter, r *http.Request, errs validator.ValidationErrors) {
 	var errMessages []string
@@ -0,0 +1,188 @@
+package v1
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"log/slog"
+	"mime"
+	"net/http"
+	"strings"
+
+	"github.com/go-chi/chi/v5/middleware"
+
+	"github.com/rshelekhov/reframed/internal/lib/constants/key"
+	"github.com/rshelekhov/reframed/internal/lib/constants/le"
+	"github.com/rshelekhov/reframed/internal/lib/logger"
+	"github.com/rshelekhov/reframed/internal/lib/middleware/jwtoken"
+	"github.com/rshelekhov/reframed/internal/model"
+	"github.com/rshelekhov/reframed/internal/port"
+)
+
+const (
+	idempotencyKeyHeader     = "Idempotency-Key"
+	idempotentReplayedHeader = "Idempotent-Replayed"
+	idempotencyKeyMaxLength  = 255
+	// idempotentBodyMaxSize is the largest body read into memory to fingerprint the request
+	idempotentBodyMaxSize = 1 << 20
+)
+
+// Idempotency honours the Idempotency-Key header on mutating requests. The first response
+// for the key is stored per user and replayed for the retries of the same request.
+// Reusing the key with a different request is rejected. The file uploads aren't fingerprinted,
+// so the key is ignored for them
+func Idempotency(
+	log logger.Interface,
+	jwt *jwtoken.TokenService,
+	usecase port.IdempotencyUsecase,
+) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		fn := func(w http.ResponseWriter, r *http.Request) {
+			const op = "middleware.Idempotency"
+
+			idempotencyKey := r.Header.Get(idempotencyKeyHeader)
+			if idempotencyKey == "" || !isMutatingMethod(r.Method) || isMultipart(r) {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			ctx := r.Context()
+			log := logger.LogWithRequest(log, op, r)
+
+			// Keys are stored per user, requests without a valid token
+			// are passed through and rejected by the authenticator
+			token, err := jwt.FindToken(r, jwtoken.GetTokenFromHeader, jwtoken.GetTokenFromCookie, jwtoken.GetTokenFromQuery)
+			if err != nil {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			userID, err := jwtoken.GetUserID(jwtoken.WithToken(ctx, token))
+			if err != nil {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			if len(idempotencyKey) > idempotencyKeyMaxLength {
+				handleResponseError(w, r, log, http.StatusBadRequest, le.ErrIdempotencyKeyTooLong)
+				return
+			}
+
+			body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, idempotentBodyMaxSize))
+			if err != nil {
+				var maxBytesErr *http.MaxBytesError
+				if errors.As(err, &maxBytesErr) {
+					handleResponseError(w, r, log, http.StatusRequestEntityTooLarge, le.ErrIdempotentBodyTooLarge)
+					return
+				}
+				handleResponseError(w, r, log, http.StatusBadRequest, le.ErrInvalidJSON, logger.Err(err))
+				return
+			}
+
+			r.Body = io.NopCloser(bytes.NewReader(body))
+
+			requestKey := model.IdempotencyKey{
+				Key:         idempotencyKey,
+				UserID:      userID,
+				Method:      r.Method,
+				Path:        r.URL.Path,
+				RequestHash: hashRequest(r, body),
+			}
+
+			stored, started, err := usecase.StartRequest(ctx, requestKey)
+
+			switch {
+			case errors.Is(err, le.ErrIdempotencyKeyReused):
+				handleResponseError(w, r, log, http.StatusUnprocessableEntity, le.ErrIdempotencyKeyReused,
+					slog.String(key.IdempotencyKey, idempotencyKey))
+				return
+			case errors.Is(err, le.ErrIdempotencyKeyInProgress):
+				handleResponseError(w, r, log, http.StatusConflict, le.ErrIdempotencyKeyInProgress,
+					slog.String(key.IdempotencyKey, idempotencyKey))
+				return
+			case err != nil:
+				handleInternalServerError(w, r, log, le.ErrFailedToProcessIdempotencyKey, err)
+				return
+			case !started:
+				log.Info("replaying stored response", slog.String(key.IdempotencyKey, idempotencyKey))
+				replayResponse(w, stored)
+				return
+			}
+
+			var responseBody bytes.Buffer
+
+			ww := middleware.NewWrapResponseWriter(w, r.ProtoMajor)
+			ww.Tee(&responseBody)
+
+			// The key is released if the handler panics, otherwise the retries are rejected until it expires.
+			// The panic is passed on to the recoverer
+			defer func() {
+				if rec := recover(); rec != nil {
+					if err := usecase.ReleaseKey(context.WithoutCancel(ctx), userID, idempotencyKey); err != nil {
+						log.Error("failed to release idempotency key", logger.Err(err))
+					}
+					panic(rec)
+				}
+			}()
+
+			next.ServeHTTP(ww, r)
+
+			requestKey.StatusCode = ww.Status()
+			if requestKey.StatusCode == 0 {
+				requestKey.StatusCode = http.StatusOK
+			}
+
+			// Server errors are not stored, so the client is able to retry the request
+			if requestKey.StatusCode >= http.StatusInternalServerError {
+				if err = usecase.ReleaseKey(ctx, userID, idempotencyKey); err != nil {
+					log.Error("failed to release idempotency key", logger.Err(err))
+				}
+				return
+			}
+
+			requestKey.ResponseBody = responseBody.Bytes()
+
+			if err = usecase.SaveResponse(ctx, requestKey); err != nil {
+				log.Error("failed to save idempotent response", logger.Err(err))
+			}
+		}
+
+		return http.HandlerFunc(fn)
+	}
+}
+
+func isMutatingMethod(method string) bool {
+	switch method {
+	case http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete:
+		return true
+	default:
+		return false
+	}
+}
+
+func isMultipart(r *http.Request) bool {
+	mediaType, _, err := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	return err == nil && strings.HasPrefix(mediaType, "multipart/")
+}
+
+// hashRequest returns the fingerprint of the request used to detect reused keys
+func hashRequest(r *http.Request, body []byte) string {
+	h := sha256.New()
+
+	h.Write([]byte(r.Method))
+	h.Write([]byte(r.URL.RequestURI()))
+	h.Write(body)
+
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+func replayResponse(w http.ResponseWriter, stored model.IdempotencyKey) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set(idempotentReplayedHeader, "true")
+	w.WriteHeader(stored.StatusCode)
+
+	_, _ = w.Write(stored.ResponseBody)
+}
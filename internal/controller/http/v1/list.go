@@ -8,6 +8,7 @@ import (
 	"github.com/rshelekhov/reframed/internal/lib/middleware/jwtoken"
 
 	"github.com/go-chi/chi/v5"
+	"github.com/go-playground/validator/v10"
 
 	"github.com/rshelekhov/reframed/internal/lib/constants/key"
 	"github.com/rshelekhov/reframed/internal/lib/constants/le"
@@ -42,10 +43,20 @@ func NewListRoutes(
 		r.Route("/user/lists", func(r chi.Router) {
 			r.Get("/", c.GetListsByUserID())
 			r.Post("/", c.CreateList())
+			r.Get("/archived", c.GetArchivedLists())
+			r.Put("/order", c.ReorderLists())
+			r.Post("/from-heading/{heading_id}", c.ConvertHeadingToList())
 
 			r.Route("/{list_id}", func(r chi.Router) {
 				r.Get("/", c.GetListByID())
 				r.Put("/", c.UpdateList())
+				r.Patch("/", c.PatchList())
+				r.Put("/move", c.MoveListToArea())
+				r.Put("/complete", c.CompleteList())
+				r.Put("/archive", c.ArchiveList())
+				r.Put("/restore", c.RestoreList())
+				r.Post("/to-heading", c.ConvertListToHeading())
+				r.Post("/duplicate", c.DuplicateList())
 				r.Delete("/", c.DeleteList())
 			})
 		})
@@ -73,6 +84,14 @@ func (c *listController) CreateList() http.HandlerFunc {
 		listInput.UserID = userID
 
 		list, err := c.usecase.CreateList(ctx, listInput)
+		if errors.Is(err, le.ErrInvalidListDateRange) {
+			handleResponseError(w, r, log, http.StatusBadRequest, le.ErrInvalidListDateRange)
+			return
+		}
+		if errors.Is(err, le.ErrAreaNotFound) {
+			handleResponseError(w, r, log, http.StatusNotFound, le.ErrAreaNotFound)
+			return
+		}
 		if err != nil {
 			handleInternalServerError(w, r, log, le.ErrFailedToCreateList, err)
 			return
@@ -136,7 +155,25 @@ func (c *listController) GetListsByUserID() http.HandlerFunc {
 			return
 		}
 
-		listsResp, err := c.usecase.GetListsByUserID(ctx, userID)
+		if ParseNested(r) {
+			groupedResp, err := c.usecase.GetListsGroupedByAreas(ctx, userID)
+
+			switch {
+			case errors.Is(err, le.ErrNoListsFound):
+				handleResponseError(w, r, log, http.StatusNotFound, le.ErrNoListsFound)
+				return
+			case err != nil:
+				handleInternalServerError(w, r, log, le.ErrFailedToGetLists, err)
+				return
+			default:
+				handleResponseSuccess(w, r, log, "lists found", groupedResp,
+					slog.Int(key.Count, len(groupedResp.Lists)+len(groupedResp.Areas)),
+				)
+			}
+			return
+		}
+
+		listsResp, err := c.usecase.GetListsByUserID(ctx, userID, ParseIncludeArchived(r))
 
 		switch {
 		case errors.Is(err, le.ErrNoListsFound):
@@ -153,6 +190,36 @@ func (c *listController) GetListsByUserID() http.HandlerFunc {
 	}
 }
 
+func (c *listController) GetArchivedLists() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "list.controller.GetArchivedLists"
+
+		ctx := r.Context()
+		log := logger.LogWithRequest(c.logger, op, r)
+
+		userID, err := jwtoken.GetUserID(ctx)
+		if err != nil {
+			handleInternalServerError(w, r, log, le.ErrFailedToGetUserIDFromToken, err)
+			return
+		}
+
+		listsResp, err := c.usecase.GetArchivedLists(ctx, userID)
+
+		switch {
+		case errors.Is(err, le.ErrNoArchivedListsFound):
+			handleResponseError(w, r, log, http.StatusNotFound, le.ErrNoArchivedListsFound)
+			return
+		case err != nil:
+			handleInternalServerError(w, r, log, le.ErrFailedToGetLists, err)
+			return
+		default:
+			handleResponseSuccess(w, r, log, "archived lists found", listsResp,
+				slog.Int(key.Count, len(listsResp)),
+			)
+		}
+	}
+}
+
 func (c *listController) UpdateList() http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		const op = "list.controller.UpdateList"
@@ -183,9 +250,15 @@ func (c *listController) UpdateList() http.HandlerFunc {
 		listResponse, err := c.usecase.UpdateList(ctx, listInput)
 
 		switch {
+		case errors.Is(err, le.ErrInvalidListDateRange):
+			handleResponseError(w, r, log, http.StatusBadRequest, le.ErrInvalidListDateRange)
+			return
 		case errors.Is(err, le.ErrListNotFound):
 			handleResponseError(w, r, log, http.StatusNotFound, le.ErrListNotFound)
 			return
+		case errors.Is(err, le.ErrNoPermissionToEditList):
+			handleResponseError(w, r, log, http.StatusForbidden, le.ErrNoPermissionToEditList)
+			return
 		case err != nil:
 			handleInternalServerError(w, r, log, le.ErrFailedToUpdateList, err)
 			return
@@ -195,6 +268,65 @@ func (c *listController) UpdateList() http.HandlerFunc {
 	}
 }
 
+func (c *listController) PatchList() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "list.controller.PatchList"
+
+		ctx := r.Context()
+		log := logger.LogWithRequest(c.logger, op, r)
+
+		userID, err := jwtoken.GetUserID(ctx)
+		if err != nil {
+			handleInternalServerError(w, r, log, le.ErrFailedToGetUserIDFromToken, err)
+			return
+		}
+
+		listID := chi.URLParam(r, key.ListID)
+		if listID == "" {
+			handleResponseError(w, r, log, http.StatusBadRequest, le.ErrEmptyQueryListID)
+			return
+		}
+
+		patch, err := decodeMergePatch(w, r, log)
+		if err != nil {
+			return
+		}
+
+		listInput := model.ListRequestData{
+			ID:     listID,
+			UserID: userID,
+		}
+
+		listResponse, err := c.usecase.PatchList(ctx, listInput, patch)
+
+		var ve validator.ValidationErrors
+
+		switch {
+		case errors.As(err, &ve):
+			log.Error(le.ErrInvalidData.Error(), logger.Err(err))
+			responseValidationErrors(w, r, ve)
+			return
+		case errors.Is(err, le.ErrInvalidMergePatch):
+			handleResponseError(w, r, log, http.StatusBadRequest, le.ErrInvalidMergePatch)
+			return
+		case errors.Is(err, le.ErrInvalidListDateRange):
+			handleResponseError(w, r, log, http.StatusBadRequest, le.ErrInvalidListDateRange)
+			return
+		case errors.Is(err, le.ErrListNotFound):
+			handleResponseError(w, r, log, http.StatusNotFound, le.ErrListNotFound)
+			return
+		case errors.Is(err, le.ErrNoPermissionToEditList):
+			handleResponseError(w, r, log, http.StatusForbidden, le.ErrNoPermissionToEditList)
+			return
+		case err != nil:
+			handleInternalServerError(w, r, log, le.ErrFailedToUpdateList, err)
+			return
+		default:
+			handleResponseSuccess(w, r, log, "list patched", listResponse, slog.String(key.ListID, listResponse.ID))
+		}
+	}
+}
+
 func (c *listController) DeleteList() http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		const op = "list.controller.DeleteList"
@@ -214,22 +346,421 @@ func (c *listController) DeleteList() http.HandlerFunc {
 			return
 		}
 
+		listInput := model.ListRequestData{
+			ID:         listID,
+			UserID:     userID,
+			DeleteMode: model.DeleteMode(r.URL.Query().Get(key.DeleteMode)),
+		}
+
+		undoToken, err := c.usecase.DeleteList(ctx, listInput)
+
+		switch {
+		case errors.Is(err, le.ErrInvalidDeleteMode):
+			handleResponseError(w, r, log, http.StatusBadRequest, le.ErrInvalidDeleteMode)
+			return
+		case errors.Is(err, le.ErrListNotFound):
+			handleResponseError(w, r, log, http.StatusNotFound, le.ErrListNotFound)
+			return
+		case errors.Is(err, le.ErrNoPermissionToManageList):
+			handleResponseError(w, r, log, http.StatusForbidden, le.ErrNoPermissionToManageList)
+			return
+		case errors.Is(err, le.ErrCannotDeleteDefaultList):
+			handleResponseError(w, r, log, http.StatusBadRequest, le.ErrCannotDeleteDefaultList)
+			return
+		case errors.Is(err, le.ErrListNotEmpty):
+			handleResponseError(w, r, log, http.StatusConflict, le.ErrListNotEmpty)
+			return
+		case err != nil:
+			handleInternalServerError(w, r, log, le.ErrFailedToDeleteList, err)
+			return
+		default:
+			handleResponseSuccess(w, r, log, "list deleted",
+				model.UndoTokenResponseData{ID: listID, UndoToken: undoToken},
+				slog.String(key.ListID, listID),
+			)
+		}
+	}
+}
+
+func (c *listController) CompleteList() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "list.controller.CompleteList"
+
+		ctx := r.Context()
+		log := logger.LogWithRequest(c.logger, op, r)
+
+		userID, err := jwtoken.GetUserID(ctx)
+		if err != nil {
+			handleInternalServerError(w, r, log, le.ErrFailedToGetUserIDFromToken, err)
+			return
+		}
+
+		listID := chi.URLParam(r, key.ListID)
+		if listID == "" {
+			handleResponseError(w, r, log, http.StatusBadRequest, le.ErrEmptyQueryListID)
+			return
+		}
+
+		listInput := model.ListRequestData{
+			ID:            listID,
+			UserID:        userID,
+			CompleteTasks: ParseCompleteTasks(r),
+		}
+
+		listResp, err := c.usecase.CompleteList(ctx, listInput)
+
+		switch {
+		case errors.Is(err, le.ErrListNotFound):
+			handleResponseError(w, r, log, http.StatusNotFound, le.ErrListNotFound)
+			return
+		case errors.Is(err, le.ErrNoPermissionToManageList):
+			handleResponseError(w, r, log, http.StatusForbidden, le.ErrNoPermissionToManageList)
+			return
+		case errors.Is(err, le.ErrCannotArchiveDefaultList):
+			handleResponseError(w, r, log, http.StatusBadRequest, le.ErrCannotArchiveDefaultList)
+			return
+		case err != nil:
+			handleInternalServerError(w, r, log, le.ErrFailedToCompleteList, err)
+			return
+		default:
+			handleResponseSuccess(w, r, log, "list completed", listResp, slog.String(key.ListID, listID))
+		}
+	}
+}
+
+func (c *listController) ArchiveList() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "list.controller.ArchiveList"
+
+		ctx := r.Context()
+		log := logger.LogWithRequest(c.logger, op, r)
+
+		userID, err := jwtoken.GetUserID(ctx)
+		if err != nil {
+			handleInternalServerError(w, r, log, le.ErrFailedToGetUserIDFromToken, err)
+			return
+		}
+
+		listID := chi.URLParam(r, key.ListID)
+		if listID == "" {
+			handleResponseError(w, r, log, http.StatusBadRequest, le.ErrEmptyQueryListID)
+			return
+		}
+
+		listInput := model.ListRequestData{
+			ID:            listID,
+			UserID:        userID,
+			CompleteTasks: ParseCompleteTasks(r),
+		}
+
+		listResp, err := c.usecase.ArchiveList(ctx, listInput)
+
+		switch {
+		case errors.Is(err, le.ErrListNotFound):
+			handleResponseError(w, r, log, http.StatusNotFound, le.ErrListNotFound)
+			return
+		case errors.Is(err, le.ErrNoPermissionToManageList):
+			handleResponseError(w, r, log, http.StatusForbidden, le.ErrNoPermissionToManageList)
+			return
+		case errors.Is(err, le.ErrCannotArchiveDefaultList):
+			handleResponseError(w, r, log, http.StatusBadRequest, le.ErrCannotArchiveDefaultList)
+			return
+		case err != nil:
+			handleInternalServerError(w, r, log, le.ErrFailedToArchiveList, err)
+			return
+		default:
+			handleResponseSuccess(w, r, log, "list archived", listResp, slog.String(key.ListID, listID))
+		}
+	}
+}
+
+func (c *listController) RestoreList() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "list.controller.RestoreList"
+
+		ctx := r.Context()
+		log := logger.LogWithRequest(c.logger, op, r)
+
+		userID, err := jwtoken.GetUserID(ctx)
+		if err != nil {
+			handleInternalServerError(w, r, log, le.ErrFailedToGetUserIDFromToken, err)
+			return
+		}
+
+		listID := chi.URLParam(r, key.ListID)
+		if listID == "" {
+			handleResponseError(w, r, log, http.StatusBadRequest, le.ErrEmptyQueryListID)
+			return
+		}
+
 		listInput := model.ListRequestData{
 			ID:     listID,
 			UserID: userID,
 		}
 
-		err = c.usecase.DeleteList(ctx, listInput)
+		listResp, err := c.usecase.RestoreList(ctx, listInput)
 
 		switch {
 		case errors.Is(err, le.ErrListNotFound):
 			handleResponseError(w, r, log, http.StatusNotFound, le.ErrListNotFound)
 			return
+		case errors.Is(err, le.ErrNoPermissionToManageList):
+			handleResponseError(w, r, log, http.StatusForbidden, le.ErrNoPermissionToManageList)
+			return
+		case errors.Is(err, le.ErrCannotArchiveDefaultList):
+			handleResponseError(w, r, log, http.StatusBadRequest, le.ErrCannotArchiveDefaultList)
+			return
+		case errors.Is(err, le.ErrListNotArchived):
+			handleResponseError(w, r, log, http.StatusConflict, le.ErrListNotArchived)
+			return
 		case err != nil:
-			handleInternalServerError(w, r, log, le.ErrFailedToDeleteList, err)
+			handleInternalServerError(w, r, log, le.ErrFailedToRestoreList, err)
+			return
+		default:
+			handleResponseSuccess(w, r, log, "list restored", listResp, slog.String(key.ListID, listID))
+		}
+	}
+}
+
+func (c *listController) ConvertHeadingToList() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "list.controller.ConvertHeadingToList"
+
+		ctx := r.Context()
+		log := logger.LogWithRequest(c.logger, op, r)
+
+		userID, err := jwtoken.GetUserID(ctx)
+		if err != nil {
+			handleInternalServerError(w, r, log, le.ErrFailedToGetUserIDFromToken, err)
+			return
+		}
+
+		headingID := chi.URLParam(r, key.HeadingID)
+		if headingID == "" {
+			handleResponseError(w, r, log, http.StatusBadRequest, le.ErrEmptyQueryHeadingID)
+			return
+		}
+
+		headingInput := model.HeadingRequestData{
+			ID:     headingID,
+			UserID: userID,
+		}
+
+		listResp, err := c.usecase.ConvertHeadingToList(ctx, headingInput)
+
+		switch {
+		case errors.Is(err, le.ErrHeadingNotFound):
+			handleResponseError(w, r, log, http.StatusNotFound, le.ErrHeadingNotFound)
+			return
+		case errors.Is(err, le.ErrNoPermissionToEditList):
+			handleResponseError(w, r, log, http.StatusForbidden, le.ErrNoPermissionToEditList)
+			return
+		case errors.Is(err, le.ErrCannotConvertDefaultHeading):
+			handleResponseError(w, r, log, http.StatusBadRequest, le.ErrCannotConvertDefaultHeading)
+			return
+		case err != nil:
+			handleInternalServerError(w, r, log, le.ErrFailedToConvertHeading, err)
+			return
+		default:
+			handleResponseCreated(w, r, log, "heading converted to list", listResp,
+				slog.String(key.HeadingID, headingID),
+				slog.String(key.ListID, listResp.ID),
+			)
+		}
+	}
+}
+
+func (c *listController) ConvertListToHeading() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "list.controller.ConvertListToHeading"
+
+		ctx := r.Context()
+		log := logger.LogWithRequest(c.logger, op, r)
+
+		userID, err := jwtoken.GetUserID(ctx)
+		if err != nil {
+			handleInternalServerError(w, r, log, le.ErrFailedToGetUserIDFromToken, err)
+			return
+		}
+
+		listID := chi.URLParam(r, key.ListID)
+		if listID == "" {
+			handleResponseError(w, r, log, http.StatusBadRequest, le.ErrEmptyQueryListID)
+			return
+		}
+
+		targetListID := r.URL.Query().Get(key.ListID)
+		if targetListID == "" {
+			handleResponseError(w, r, log, http.StatusBadRequest, le.ErrEmptyQueryListID)
+			return
+		}
+
+		convertInput := model.ConvertListRequestData{
+			ListID:       listID,
+			TargetListID: targetListID,
+			UserID:       userID,
+		}
+
+		headingResp, err := c.usecase.ConvertListToHeading(ctx, convertInput)
+
+		switch {
+		case errors.Is(err, le.ErrCannotConvertListToItsHeading):
+			handleResponseError(w, r, log, http.StatusBadRequest, le.ErrCannotConvertListToItsHeading)
+			return
+		case errors.Is(err, le.ErrListNotFound):
+			handleResponseError(w, r, log, http.StatusNotFound, le.ErrListNotFound)
+			return
+		case errors.Is(err, le.ErrNoPermissionToManageList):
+			handleResponseError(w, r, log, http.StatusForbidden, le.ErrNoPermissionToManageList)
+			return
+		case errors.Is(err, le.ErrNoPermissionToEditList):
+			handleResponseError(w, r, log, http.StatusForbidden, le.ErrNoPermissionToEditList)
+			return
+		case errors.Is(err, le.ErrCannotConvertDefaultList):
+			handleResponseError(w, r, log, http.StatusBadRequest, le.ErrCannotConvertDefaultList)
+			return
+		case err != nil:
+			handleInternalServerError(w, r, log, le.ErrFailedToConvertList, err)
+			return
+		default:
+			handleResponseCreated(w, r, log, "list converted to heading", headingResp,
+				slog.String(key.ListID, listID),
+				slog.String(key.HeadingID, headingResp.ID),
+			)
+		}
+	}
+}
+
+func (c *listController) MoveListToArea() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "list.controller.MoveListToArea"
+
+		ctx := r.Context()
+		log := logger.LogWithRequest(c.logger, op, r)
+
+		userID, err := jwtoken.GetUserID(ctx)
+		if err != nil {
+			handleInternalServerError(w, r, log, le.ErrFailedToGetUserIDFromToken, err)
+			return
+		}
+
+		listID := chi.URLParam(r, key.ListID)
+		if listID == "" {
+			handleResponseError(w, r, log, http.StatusBadRequest, le.ErrEmptyQueryListID)
+			return
+		}
+
+		// The list is taken out of its area if the area ID is empty
+		areaID := r.URL.Query().Get(key.AreaID)
+
+		listInput := model.ListRequestData{
+			ID:     listID,
+			AreaID: areaID,
+			UserID: userID,
+		}
+
+		listResp, err := c.usecase.MoveListToArea(ctx, listInput)
+
+		switch {
+		case errors.Is(err, le.ErrListNotFound):
+			handleResponseError(w, r, log, http.StatusNotFound, le.ErrListNotFound)
+			return
+		case errors.Is(err, le.ErrAreaNotFound):
+			handleResponseError(w, r, log, http.StatusNotFound, le.ErrAreaNotFound)
+			return
+		case err != nil:
+			handleInternalServerError(w, r, log, le.ErrFailedToMoveList, err)
+			return
+		default:
+			handleResponseSuccess(w, r, log, "list moved to area", listResp,
+				slog.String(key.ListID, listID),
+				slog.String(key.AreaID, areaID),
+			)
+		}
+	}
+}
+
+func (c *listController) ReorderLists() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "list.controller.ReorderLists"
+
+		ctx := r.Context()
+		log := logger.LogWithRequest(c.logger, op, r)
+
+		userID, err := jwtoken.GetUserID(ctx)
+		if err != nil {
+			handleInternalServerError(w, r, log, le.ErrFailedToGetUserIDFromToken, err)
+			return
+		}
+
+		reorderInput := &model.ReorderRequestData{}
+		if err = decodeAndValidateJSON(w, r, log, reorderInput); err != nil {
+			return
+		}
+
+		reorderInput.UserID = userID
+
+		undoToken, err := c.usecase.ReorderLists(ctx, reorderInput)
+
+		switch {
+		case errors.Is(err, le.ErrListNotFound):
+			handleResponseError(w, r, log, http.StatusNotFound, le.ErrListNotFound)
+			return
+		case err != nil:
+			handleInternalServerError(w, r, log, le.ErrFailedToReorderLists, err)
 			return
 		default:
-			handleResponseSuccess(w, r, log, "list deleted", listID, slog.String(key.ListID, listID))
+			handleResponseSuccess(w, r, log, "lists reordered", model.UndoTokenResponseData{UndoToken: undoToken},
+				slog.Int(key.Count, len(reorderInput.IDs)),
+			)
+		}
+	}
+}
+
+func (c *listController) DuplicateList() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "list.controller.DuplicateList"
+
+		ctx := r.Context()
+		log := logger.LogWithRequest(c.logger, op, r)
+
+		userID, err := jwtoken.GetUserID(ctx)
+		if err != nil {
+			handleInternalServerError(w, r, log, le.ErrFailedToGetUserIDFromToken, err)
+			return
+		}
+
+		listID := chi.URLParam(r, key.ListID)
+		if listID == "" {
+			handleResponseError(w, r, log, http.StatusBadRequest, le.ErrEmptyQueryListID)
+			return
+		}
+
+		opts, err := ParseDuplicateOptions(r)
+		if err != nil {
+			handleResponseError(w, r, log, http.StatusBadRequest, le.ErrInvalidDuplicateOptions)
+			return
+		}
+
+		listInput := model.ListRequestData{
+			ID:     listID,
+			UserID: userID,
+		}
+
+		duplicateResp, err := c.usecase.DuplicateList(ctx, listInput, opts)
+
+		switch {
+		case errors.Is(err, le.ErrListNotFound):
+			handleResponseError(w, r, log, http.StatusNotFound, le.ErrListNotFound)
+			return
+		case err != nil:
+			handleInternalServerError(w, r, log, le.ErrFailedToDuplicateList, err)
+			return
+		default:
+			handleResponseCreated(w, r, log, "list duplicated", duplicateResp,
+				slog.String(key.ListID, duplicateResp.List.ID),
+				slog.Int(key.Count, len(duplicateResp.Tasks)),
+			)
 		}
 	}
 }
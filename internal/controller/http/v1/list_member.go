@@ -0,0 +1,477 @@
+package v1
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/rshelekhov/reframed/internal/lib/constants/key"
+	"github.com/rshelekhov/reframed/internal/lib/constants/le"
+	"github.com/rshelekhov/reframed/internal/lib/logger"
+	"github.com/rshelekhov/reframed/internal/lib/middleware/jwtoken"
+	"github.com/rshelekhov/reframed/internal/model"
+	"github.com/rshelekhov/reframed/internal/port"
+)
+
+type listMemberController struct {
+	logger  logger.Interface
+	jwt     *jwtoken.TokenService
+	usecase port.ListMemberUsecase
+}
+
+func NewListMemberRoutes(
+	r *chi.Mux,
+	log logger.Interface,
+	jwt *jwtoken.TokenService,
+	usecase port.ListMemberUsecase,
+) {
+	c := &listMemberController{
+		logger:  log,
+		jwt:     jwt,
+		usecase: usecase,
+	}
+
+	// Protected routes
+	r.Group(func(r chi.Router) {
+		r.Use(jwtoken.Verifier(jwt))
+		r.Use(jwtoken.Authenticator())
+
+		r.Get("/user/lists/{list_id}/members", c.GetListMembers())
+		r.Put("/user/lists/{list_id}/members/{member_id}", c.UpdateListMemberRole())
+		r.Delete("/user/lists/{list_id}/members/{member_id}", c.RemoveListMember())
+
+		r.Get("/user/lists/{list_id}/invitations", c.GetListInvitations())
+		r.Post("/user/lists/{list_id}/invitations", c.InviteToList())
+		r.Delete("/user/lists/{list_id}/invitations/{invitation_id}", c.RevokeListInvitation())
+
+		r.Get("/user/invitations", c.GetInvitationsByUserID())
+		r.Put("/user/invitations/{invitation_id}/accept", c.AcceptListInvitation())
+		r.Put("/user/invitations/{invitation_id}/decline", c.DeclineListInvitation())
+	})
+}
+
+func (c *listMemberController) GetListMembers() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "list_member.controller.GetListMembers"
+
+		ctx := r.Context()
+		log := logger.LogWithRequest(c.logger, op, r)
+
+		userID, err := jwtoken.GetUserID(ctx)
+		if err != nil {
+			handleInternalServerError(w, r, log, le.ErrFailedToGetUserIDFromToken, err)
+			return
+		}
+
+		listID := chi.URLParam(r, key.ListID)
+		if listID == "" {
+			handleResponseError(w, r, log, http.StatusBadRequest, le.ErrEmptyQueryListID)
+			return
+		}
+
+		membersResp, err := c.usecase.GetListMembers(ctx, listID, userID)
+
+		switch {
+		case errors.Is(err, le.ErrListNotFound):
+			handleResponseError(w, r, log, http.StatusNotFound, le.ErrListNotFound)
+			return
+		case err != nil:
+			handleInternalServerError(w, r, log, le.ErrFailedToGetListMembers, err)
+			return
+		default:
+			handleResponseSuccess(w, r, log, "list members found", membersResp,
+				slog.String(key.ListID, listID),
+				slog.Int(key.Count, len(membersResp)),
+			)
+		}
+	}
+}
+
+func (c *listMemberController) UpdateListMemberRole() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "list_member.controller.UpdateListMemberRole"
+
+		ctx := r.Context()
+		log := logger.LogWithRequest(c.logger, op, r)
+
+		userID, err := jwtoken.GetUserID(ctx)
+		if err != nil {
+			handleInternalServerError(w, r, log, le.ErrFailedToGetUserIDFromToken, err)
+			return
+		}
+
+		listID := chi.URLParam(r, key.ListID)
+		if listID == "" {
+			handleResponseError(w, r, log, http.StatusBadRequest, le.ErrEmptyQueryListID)
+			return
+		}
+
+		memberID := chi.URLParam(r, key.MemberID)
+		if memberID == "" {
+			handleResponseError(w, r, log, http.StatusBadRequest, le.ErrEmptyQueryMemberID)
+			return
+		}
+
+		memberInput := &model.ListMemberRequestData{}
+		if err = decodeAndValidateJSON(w, r, log, memberInput); err != nil {
+			return
+		}
+
+		memberInput.ListID = listID
+		memberInput.MemberID = memberID
+		memberInput.UserID = userID
+
+		memberResp, err := c.usecase.UpdateListMemberRole(ctx, memberInput)
+
+		switch {
+		case errors.Is(err, le.ErrListNotFound):
+			handleResponseError(w, r, log, http.StatusNotFound, le.ErrListNotFound)
+			return
+		case errors.Is(err, le.ErrListMemberNotFound):
+			handleResponseError(w, r, log, http.StatusNotFound, le.ErrListMemberNotFound)
+			return
+		case errors.Is(err, le.ErrNoPermissionToManageList):
+			handleResponseError(w, r, log, http.StatusForbidden, le.ErrNoPermissionToManageList)
+			return
+		case errors.Is(err, le.ErrLastListOwner):
+			handleResponseError(w, r, log, http.StatusConflict, le.ErrLastListOwner)
+			return
+		case err != nil:
+			handleInternalServerError(w, r, log, le.ErrFailedToUpdateListMember, err)
+			return
+		default:
+			handleResponseSuccess(w, r, log, "list member updated", memberResp,
+				slog.String(key.ListID, listID),
+				slog.String(key.MemberID, memberID),
+			)
+		}
+	}
+}
+
+func (c *listMemberController) RemoveListMember() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "list_member.controller.RemoveListMember"
+
+		ctx := r.Context()
+		log := logger.LogWithRequest(c.logger, op, r)
+
+		userID, err := jwtoken.GetUserID(ctx)
+		if err != nil {
+			handleInternalServerError(w, r, log, le.ErrFailedToGetUserIDFromToken, err)
+			return
+		}
+
+		listID := chi.URLParam(r, key.ListID)
+		if listID == "" {
+			handleResponseError(w, r, log, http.StatusBadRequest, le.ErrEmptyQueryListID)
+			return
+		}
+
+		memberID := chi.URLParam(r, key.MemberID)
+		if memberID == "" {
+			handleResponseError(w, r, log, http.StatusBadRequest, le.ErrEmptyQueryMemberID)
+			return
+		}
+
+		memberInput := model.ListMemberRequestData{
+			ListID:   listID,
+			MemberID: memberID,
+			UserID:   userID,
+		}
+
+		err = c.usecase.RemoveListMember(ctx, memberInput)
+
+		switch {
+		case errors.Is(err, le.ErrListNotFound):
+			handleResponseError(w, r, log, http.StatusNotFound, le.ErrListNotFound)
+			return
+		case errors.Is(err, le.ErrListMemberNotFound):
+			handleResponseError(w, r, log, http.StatusNotFound, le.ErrListMemberNotFound)
+			return
+		case errors.Is(err, le.ErrNoPermissionToManageList):
+			handleResponseError(w, r, log, http.StatusForbidden, le.ErrNoPermissionToManageList)
+			return
+		case errors.Is(err, le.ErrLastListOwner):
+			handleResponseError(w, r, log, http.StatusConflict, le.ErrLastListOwner)
+			return
+		case err != nil:
+			handleInternalServerError(w, r, log, le.ErrFailedToRemoveListMember, err)
+			return
+		default:
+			handleResponseSuccess(w, r, log, "list member removed", memberInput,
+				slog.String(key.ListID, listID),
+				slog.String(key.MemberID, memberID),
+			)
+		}
+	}
+}
+
+func (c *listMemberController) InviteToList() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "list_member.controller.InviteToList"
+
+		ctx := r.Context()
+		log := logger.LogWithRequest(c.logger, op, r)
+
+		userID, err := jwtoken.GetUserID(ctx)
+		if err != nil {
+			handleInternalServerError(w, r, log, le.ErrFailedToGetUserIDFromToken, err)
+			return
+		}
+
+		listID := chi.URLParam(r, key.ListID)
+		if listID == "" {
+			handleResponseError(w, r, log, http.StatusBadRequest, le.ErrEmptyQueryListID)
+			return
+		}
+
+		invitationInput := &model.ListInvitationRequestData{}
+		if err = decodeAndValidateJSON(w, r, log, invitationInput); err != nil {
+			return
+		}
+
+		invitationInput.ListID = listID
+		invitationInput.UserID = userID
+
+		invitationResp, err := c.usecase.InviteToList(ctx, invitationInput)
+
+		switch {
+		case errors.Is(err, le.ErrListNotFound):
+			handleResponseError(w, r, log, http.StatusNotFound, le.ErrListNotFound)
+			return
+		case errors.Is(err, le.ErrNoPermissionToManageList):
+			handleResponseError(w, r, log, http.StatusForbidden, le.ErrNoPermissionToManageList)
+			return
+		case errors.Is(err, le.ErrCannotShareDefaultList):
+			handleResponseError(w, r, log, http.StatusBadRequest, le.ErrCannotShareDefaultList)
+			return
+		case errors.Is(err, le.ErrCannotInviteYourself):
+			handleResponseError(w, r, log, http.StatusBadRequest, le.ErrCannotInviteYourself)
+			return
+		case errors.Is(err, le.ErrAlreadyListMember):
+			handleResponseError(w, r, log, http.StatusConflict, le.ErrAlreadyListMember)
+			return
+		case err != nil:
+			handleInternalServerError(w, r, log, le.ErrFailedToInviteToList, err)
+			return
+		default:
+			handleResponseCreated(w, r, log, "list invitation created", invitationResp,
+				slog.String(key.ListID, listID),
+				slog.String(key.InvitationID, invitationResp.ID),
+			)
+		}
+	}
+}
+
+func (c *listMemberController) GetListInvitations() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "list_member.controller.GetListInvitations"
+
+		ctx := r.Context()
+		log := logger.LogWithRequest(c.logger, op, r)
+
+		userID, err := jwtoken.GetUserID(ctx)
+		if err != nil {
+			handleInternalServerError(w, r, log, le.ErrFailedToGetUserIDFromToken, err)
+			return
+		}
+
+		listID := chi.URLParam(r, key.ListID)
+		if listID == "" {
+			handleResponseError(w, r, log, http.StatusBadRequest, le.ErrEmptyQueryListID)
+			return
+		}
+
+		invitationsResp, err := c.usecase.GetListInvitations(ctx, listID, userID)
+
+		switch {
+		case errors.Is(err, le.ErrListNotFound):
+			handleResponseError(w, r, log, http.StatusNotFound, le.ErrListNotFound)
+			return
+		case errors.Is(err, le.ErrNoPermissionToManageList):
+			handleResponseError(w, r, log, http.StatusForbidden, le.ErrNoPermissionToManageList)
+			return
+		case errors.Is(err, le.ErrNoListInvitationsFound):
+			handleResponseError(w, r, log, http.StatusNotFound, le.ErrNoListInvitationsFound)
+			return
+		case err != nil:
+			handleInternalServerError(w, r, log, le.ErrFailedToGetListInvitations, err)
+			return
+		default:
+			handleResponseSuccess(w, r, log, "list invitations found", invitationsResp,
+				slog.String(key.ListID, listID),
+				slog.Int(key.Count, len(invitationsResp)),
+			)
+		}
+	}
+}
+
+func (c *listMemberController) RevokeListInvitation() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "list_member.controller.RevokeListInvitation"
+
+		ctx := r.Context()
+		log := logger.LogWithRequest(c.logger, op, r)
+
+		userID, err := jwtoken.GetUserID(ctx)
+		if err != nil {
+			handleInternalServerError(w, r, log, le.ErrFailedToGetUserIDFromToken, err)
+			return
+		}
+
+		listID := chi.URLParam(r, key.ListID)
+		if listID == "" {
+			handleResponseError(w, r, log, http.StatusBadRequest, le.ErrEmptyQueryListID)
+			return
+		}
+
+		invitationID := chi.URLParam(r, key.InvitationID)
+		if invitationID == "" {
+			handleResponseError(w, r, log, http.StatusBadRequest, le.ErrEmptyQueryInvitationID)
+			return
+		}
+
+		invitationInput := model.ListInvitationRequestData{
+			ID:     invitationID,
+			ListID: listID,
+			UserID: userID,
+		}
+
+		err = c.usecase.RevokeListInvitation(ctx, invitationInput)
+
+		switch {
+		case errors.Is(err, le.ErrListNotFound):
+			handleResponseError(w, r, log, http.StatusNotFound, le.ErrListNotFound)
+			return
+		case errors.Is(err, le.ErrListInvitationNotFound):
+			handleResponseError(w, r, log, http.StatusNotFound, le.ErrListInvitationNotFound)
+			return
+		case errors.Is(err, le.ErrNoPermissionToManageList):
+			handleResponseError(w, r, log, http.StatusForbidden, le.ErrNoPermissionToManageList)
+			return
+		case err != nil:
+			handleInternalServerError(w, r, log, le.ErrFailedToRevokeListInvitation, err)
+			return
+		default:
+			handleResponseSuccess(w, r, log, "list invitation revoked", invitationInput,
+				slog.String(key.ListID, listID),
+				slog.String(key.InvitationID, invitationID),
+			)
+		}
+	}
+}
+
+func (c *listMemberController) GetInvitationsByUserID() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "list_member.controller.GetInvitationsByUserID"
+
+		ctx := r.Context()
+		log := logger.LogWithRequest(c.logger, op, r)
+
+		userID, err := jwtoken.GetUserID(ctx)
+		if err != nil {
+			handleInternalServerError(w, r, log, le.ErrFailedToGetUserIDFromToken, err)
+			return
+		}
+
+		invitationsResp, err := c.usecase.GetInvitationsByUserID(ctx, userID)
+
+		switch {
+		case errors.Is(err, le.ErrNoListInvitationsFound):
+			handleResponseError(w, r, log, http.StatusNotFound, le.ErrNoListInvitationsFound)
+			return
+		case err != nil:
+			handleInternalServerError(w, r, log, le.ErrFailedToGetListInvitations, err)
+			return
+		default:
+			handleResponseSuccess(w, r, log, "list invitations found", invitationsResp,
+				slog.Int(key.Count, len(invitationsResp)),
+			)
+		}
+	}
+}
+
+func (c *listMemberController) AcceptListInvitation() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "list_member.controller.AcceptListInvitation"
+
+		ctx := r.Context()
+		log := logger.LogWithRequest(c.logger, op, r)
+
+		userID, err := jwtoken.GetUserID(ctx)
+		if err != nil {
+			handleInternalServerError(w, r, log, le.ErrFailedToGetUserIDFromToken, err)
+			return
+		}
+
+		invitationID := chi.URLParam(r, key.InvitationID)
+		if invitationID == "" {
+			handleResponseError(w, r, log, http.StatusBadRequest, le.ErrEmptyQueryInvitationID)
+			return
+		}
+
+		invitationInput := model.ListInvitationRequestData{
+			ID:     invitationID,
+			UserID: userID,
+		}
+
+		listResp, err := c.usecase.AcceptListInvitation(ctx, invitationInput)
+
+		switch {
+		case errors.Is(err, le.ErrListInvitationNotFound):
+			handleResponseError(w, r, log, http.StatusNotFound, le.ErrListInvitationNotFound)
+			return
+		case err != nil:
+			handleInternalServerError(w, r, log, le.ErrFailedToAcceptListInvitation, err)
+			return
+		default:
+			handleResponseSuccess(w, r, log, "list invitation accepted", listResp,
+				slog.String(key.InvitationID, invitationID),
+				slog.String(key.ListID, listResp.ID),
+			)
+		}
+	}
+}
+
+func (c *listMemberController) DeclineListInvitation() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "list_member.controller.DeclineListInvitation"
+
+		ctx := r.Context()
+		log := logger.LogWithRequest(c.logger, op, r)
+
+		userID, err := jwtoken.GetUserID(ctx)
+		if err != nil {
+			handleInternalServerError(w, r, log, le.ErrFailedToGetUserIDFromToken, err)
+			return
+		}
+
+		invitationID := chi.URLParam(r, key.InvitationID)
+		if invitationID == "" {
+			handleResponseError(w, r, log, http.StatusBadRequest, le.ErrEmptyQueryInvitationID)
+			return
+		}
+
+		invitationInput := model.ListInvitationRequestData{
+			ID:     invitationID,
+			UserID: userID,
+		}
+
+		err = c.usecase.DeclineListInvitation(ctx, invitationInput)
+
+		switch {
+		case errors.Is(err, le.ErrListInvitationNotFound):
+			handleResponseError(w, r, log, http.StatusNotFound, le.ErrListInvitationNotFound)
+			return
+		case err != nil:
+			handleInternalServerError(w, r, log, le.ErrFailedToDeclineListInvitation, err)
+			return
+		default:
+			handleResponseSuccess(w, r, log, "list invitation declined", invitationInput,
+				slog.String(key.InvitationID, invitationID),
+			)
+		}
+	}
+}
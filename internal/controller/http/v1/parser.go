@@ -6,11 +6,18 @@ import (
 	"time"
 
 	c "github.com/rshelekhov/reframed/internal/lib/constants/key"
+	"github.com/rshelekhov/reframed/internal/lib/constants/le"
 	"github.com/rshelekhov/reframed/internal/model"
 )
 
 const (
-	DefaultLimit = 30
+	DefaultLimit     = 30
+	DefaultPlanDays  = 3
+	DefaultStaleDays = 14
+	// DefaultStatsPeriods is how many periods back the statistics are computed for, if the range isn't given
+	DefaultStatsPeriods = 12
+	// MaxStatsPeriods limits the number of periods in the range, so the statistics can't be asked for thousands of them
+	MaxStatsPeriods = 366
 )
 
 func ParseLimitAndAfterID(r *http.Request) model.Pagination {
@@ -43,3 +50,145 @@ func ParseLimitAndAfterDate(r *http.Request) (model.Pagination, error) {
 		AfterDate: afterDate,
 	}, nil
 }
+
+// ParseHideBlocked reports whether the tasks blocked by other tasks should be left out of the view
+func ParseHideBlocked(r *http.Request) bool {
+	hideBlocked, err := strconv.ParseBool(r.URL.Query().Get(c.HideBlocked))
+	if err != nil {
+		return false
+	}
+
+	return hideBlocked
+}
+
+// ParseNested reports whether the lists should be returned nested under their areas
+func ParseNested(r *http.Request) bool {
+	nested, err := strconv.ParseBool(r.URL.Query().Get(c.Nested))
+	if err != nil {
+		return false
+	}
+
+	return nested
+}
+
+// ParseIncludeArchived reports whether the completed and archived lists should be returned along with the active ones
+func ParseIncludeArchived(r *http.Request) bool {
+	includeArchived, err := strconv.ParseBool(r.URL.Query().Get(c.IncludeArchived))
+	if err != nil {
+		return false
+	}
+
+	return includeArchived
+}
+
+// ParseCompleteTasks reports whether the open tasks of the completed or archived list should be completed too
+func ParseCompleteTasks(r *http.Request) bool {
+	completeTasks, err := strconv.ParseBool(r.URL.Query().Get(c.CompleteTasks))
+	if err != nil {
+		return false
+	}
+
+	return completeTasks
+}
+
+// ParsePlanDays reads the number of days ahead which deadlines are proposed for the plan of the day
+func ParsePlanDays(r *http.Request) int {
+	days, err := strconv.Atoi(r.URL.Query().Get(c.Days))
+	if err != nil || days < 0 {
+		return DefaultPlanDays
+	}
+
+	return days
+}
+
+// ParseStaleDays reads how many days the task should stay untouched to be proposed as stale in the review
+func ParseStaleDays(r *http.Request) int {
+	days, err := strconv.Atoi(r.URL.Query().Get(c.StaleDays))
+	if err != nil || days <= 0 {
+		return DefaultStaleDays
+	}
+
+	return days
+}
+
+// ParseStatsRequest reads the period and the range of the statistics. The range is given by dates,
+// the day of To is included. By default, the statistics are computed for the last DefaultStatsPeriods weeks
+func ParseStatsRequest(r *http.Request) (model.StatsRequestData, error) {
+	period := model.StatsPeriodWeek
+	if value := r.URL.Query().Get(c.Period); value != "" {
+		period = model.StatsPeriod(value)
+		if !period.IsValid() {
+			return model.StatsRequestData{}, le.ErrInvalidStatsPeriod
+		}
+	}
+
+	now := time.Now()
+	to := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location()).AddDate(0, 0, 1)
+
+	if value := r.URL.Query().Get(c.To); value != "" {
+		date, err := time.ParseInLocation(time.DateOnly, value, now.Location())
+		if err != nil {
+			return model.StatsRequestData{}, le.ErrInvalidStatsRange
+		}
+
+		to = date.AddDate(0, 0, 1)
+	}
+
+	from := addStatsPeriods(to, period, -DefaultStatsPeriods)
+
+	if value := r.URL.Query().Get(c.From); value != "" {
+		date, err := time.ParseInLocation(time.DateOnly, value, now.Location())
+		if err != nil {
+			return model.StatsRequestData{}, le.ErrInvalidStatsRange
+		}
+
+		from = date
+	}
+
+	if !from.Before(to) || to.After(addStatsPeriods(from, period, MaxStatsPeriods)) {
+		return model.StatsRequestData{}, le.ErrInvalidStatsRange
+	}
+
+	return model.StatsRequestData{
+		Period: period,
+		From:   from,
+		To:     to,
+	}, nil
+}
+
+// addStatsPeriods moves the date by n periods, back if n is negative
+func addStatsPeriods(date time.Time, period model.StatsPeriod, n int) time.Time {
+	switch period {
+	case model.StatsPeriodDay:
+		return date.AddDate(0, 0, n)
+	case model.StatsPeriodMonth:
+		return date.AddDate(0, n, 0)
+	default:
+		return date.AddDate(0, 0, 7*n)
+	}
+}
+
+// ParseDuplicateOptions reads the options of copying the task, the heading or the list
+func ParseDuplicateOptions(r *http.Request) (model.DuplicateOptions, error) {
+	var opts model.DuplicateOptions
+
+	if includeCompleted := r.URL.Query().Get(c.IncludeCompleted); includeCompleted != "" {
+		value, err := strconv.ParseBool(includeCompleted)
+		if err != nil {
+			return model.DuplicateOptions{}, err
+		}
+
+		opts.IncludeCompleted = value
+	}
+
+	if shiftDays := r.URL.Query().Get(c.ShiftDays); shiftDays != "" {
+		value, err := strconv.Atoi(shiftDays)
+		if err != nil {
+			return model.DuplicateOptions{}, err
+		}
+
+		opts.ShiftDays = value
+	}
+
+	return opts, nil
+}
@@ -0,0 +1,279 @@
+package v1
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/rshelekhov/reframed/internal/lib/constants/key"
+	"github.com/rshelekhov/reframed/internal/lib/constants/le"
+	"github.com/rshelekhov/reframed/internal/lib/logger"
+	"github.com/rshelekhov/reframed/internal/lib/middleware/jwtoken"
+	"github.com/rshelekhov/reframed/internal/model"
+	"github.com/rshelekhov/reframed/internal/port"
+)
+
+type reviewController struct {
+	logger  logger.Interface
+	jwt     *jwtoken.TokenService
+	usecase port.ReviewUsecase
+}
+
+func NewReviewRoutes(
+	r *chi.Mux,
+	log logger.Interface,
+	jwt *jwtoken.TokenService,
+	usecase port.ReviewUsecase,
+) {
+	c := &reviewController{
+		logger:  log,
+		jwt:     jwt,
+		usecase: usecase,
+	}
+
+	// Protected routes
+	r.Group(func(r chi.Router) {
+		r.Use(jwtoken.Verifier(jwt))
+		r.Use(jwtoken.Authenticator())
+
+		r.Route("/user/reviews", func(r chi.Router) {
+			r.Post("/", c.StartReview())
+			r.Get("/current", c.GetCurrentReview())
+
+			r.Route("/{review_id}", func(r chi.Router) {
+				r.Get("/", c.GetReviewByID())
+				r.Put("/complete", c.CompleteReview())
+				r.Post("/items/{item_id}", c.ActOnReviewItem())
+			})
+		})
+	})
+}
+
+func (c *reviewController) StartReview() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "review.controller.StartReview"
+
+		ctx := r.Context()
+		log := logger.LogWithRequest(c.logger, op, r)
+
+		userID, err := jwtoken.GetUserID(ctx)
+		if err != nil {
+			handleInternalServerError(w, r, log, le.ErrFailedToGetUserIDFromToken, err)
+			return
+		}
+
+		reviewInput := model.ReviewRequestData{
+			StaleDays: ParseStaleDays(r),
+			UserID:    userID,
+		}
+
+		reviewResp, err := c.usecase.StartReview(ctx, reviewInput)
+
+		switch {
+		case errors.Is(err, le.ErrReviewAlreadyStarted):
+			handleResponseError(w, r, log, http.StatusConflict, le.ErrReviewAlreadyStarted)
+			return
+		case err != nil:
+			handleInternalServerError(w, r, log, le.ErrFailedToStartReview, err)
+			return
+		default:
+			handleResponseCreated(w, r, log, "review started", reviewResp,
+				slog.String(key.ReviewID, reviewResp.ID),
+				slog.Int(key.Count, reviewResp.Progress.Total),
+			)
+		}
+	}
+}
+
+func (c *reviewController) GetCurrentReview() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "review.controller.GetCurrentReview"
+
+		ctx := r.Context()
+		log := logger.LogWithRequest(c.logger, op, r)
+
+		userID, err := jwtoken.GetUserID(ctx)
+		if err != nil {
+			handleInternalServerError(w, r, log, le.ErrFailedToGetUserIDFromToken, err)
+			return
+		}
+
+		reviewResp, err := c.usecase.GetCurrentReview(ctx, userID)
+
+		switch {
+		case errors.Is(err, le.ErrNoReviewInProgress):
+			handleResponseError(w, r, log, http.StatusNotFound, le.ErrNoReviewInProgress)
+			return
+		case err != nil:
+			handleInternalServerError(w, r, log, le.ErrFailedToGetReview, err)
+			return
+		default:
+			handleResponseSuccess(w, r, log, "review received", reviewResp, slog.String(key.ReviewID, reviewResp.ID))
+		}
+	}
+}
+
+func (c *reviewController) GetReviewByID() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "review.controller.GetReviewByID"
+
+		ctx := r.Context()
+		log := logger.LogWithRequest(c.logger, op, r)
+
+		userID, err := jwtoken.GetUserID(ctx)
+		if err != nil {
+			handleInternalServerError(w, r, log, le.ErrFailedToGetUserIDFromToken, err)
+			return
+		}
+
+		reviewID := chi.URLParam(r, key.ReviewID)
+		if reviewID == "" {
+			handleResponseError(w, r, log, http.StatusBadRequest, le.ErrEmptyQueryReviewID)
+			return
+		}
+
+		reviewInput := model.ReviewRequestData{
+			ID:     reviewID,
+			UserID: userID,
+		}
+
+		reviewResp, err := c.usecase.GetReviewByID(ctx, reviewInput)
+
+		switch {
+		case errors.Is(err, le.ErrReviewNotFound):
+			handleResponseError(w, r, log, http.StatusNotFound, le.ErrReviewNotFound)
+			return
+		case err != nil:
+			handleInternalServerError(w, r, log, le.ErrFailedToGetReview, err)
+			return
+		default:
+			handleResponseSuccess(w, r, log, "review received", reviewResp, slog.String(key.ReviewID, reviewID))
+		}
+	}
+}
+
+func (c *reviewController) ActOnReviewItem() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "review.controller.ActOnReviewItem"
+
+		ctx := r.Context()
+		log := logger.LogWithRequest(c.logger, op, r)
+
+		userID, err := jwtoken.GetUserID(ctx)
+		if err != nil {
+			handleInternalServerError(w, r, log, le.ErrFailedToGetUserIDFromToken, err)
+			return
+		}
+
+		reviewID := chi.URLParam(r, key.ReviewID)
+		if reviewID == "" {
+			handleResponseError(w, r, log, http.StatusBadRequest, le.ErrEmptyQueryReviewID)
+			return
+		}
+
+		itemID := chi.URLParam(r, key.ReviewItemID)
+		if itemID == "" {
+			handleResponseError(w, r, log, http.StatusBadRequest, le.ErrEmptyQueryReviewItemID)
+			return
+		}
+
+		actionInput := &model.ReviewItemActionRequestData{}
+		if err = decodeAndValidateJSON(w, r, log, actionInput); err != nil {
+			return
+		}
+
+		actionInput.ReviewID = reviewID
+		actionInput.ItemID = itemID
+		actionInput.UserID = userID
+
+		itemResp, err := c.usecase.ActOnReviewItem(ctx, *actionInput)
+
+		switch {
+		case errors.Is(err, le.ErrReviewNotFound):
+			handleResponseError(w, r, log, http.StatusNotFound, le.ErrReviewNotFound)
+			return
+		case errors.Is(err, le.ErrReviewItemNotFound):
+			handleResponseError(w, r, log, http.StatusNotFound, le.ErrReviewItemNotFound)
+			return
+		case errors.Is(err, le.ErrReviewCompleted):
+			handleResponseError(w, r, log, http.StatusConflict, le.ErrReviewCompleted)
+			return
+		case errors.Is(err, le.ErrInvalidReviewAction):
+			handleResponseError(w, r, log, http.StatusBadRequest, le.ErrInvalidReviewAction)
+			return
+		case errors.Is(err, le.ErrEmptyQueryListID):
+			handleResponseError(w, r, log, http.StatusBadRequest, le.ErrEmptyQueryListID)
+			return
+		case errors.Is(err, le.ErrInvalidSnoozeTime):
+			handleResponseError(w, r, log, http.StatusBadRequest, le.ErrInvalidSnoozeTime)
+			return
+		case errors.Is(err, le.ErrCannotArchiveDefaultList):
+			handleResponseError(w, r, log, http.StatusBadRequest, le.ErrCannotArchiveDefaultList)
+			return
+		case errors.Is(err, le.ErrTaskNotFound):
+			handleResponseError(w, r, log, http.StatusNotFound, le.ErrTaskNotFound)
+			return
+		case errors.Is(err, le.ErrListNotFound):
+			handleResponseError(w, r, log, http.StatusNotFound, le.ErrListNotFound)
+			return
+		case errors.Is(err, le.ErrNoPermissionToEditList):
+			handleResponseError(w, r, log, http.StatusForbidden, le.ErrNoPermissionToEditList)
+			return
+		case errors.Is(err, le.ErrNoPermissionToManageList):
+			handleResponseError(w, r, log, http.StatusForbidden, le.ErrNoPermissionToManageList)
+			return
+		case err != nil:
+			handleInternalServerError(w, r, log, le.ErrFailedToActOnReviewItem, err)
+			return
+		default:
+			handleResponseSuccess(w, r, log, "review item updated", itemResp,
+				slog.String(key.ReviewID, reviewID),
+				slog.String(key.ReviewItemID, itemID),
+			)
+		}
+	}
+}
+
+func (c *reviewController) CompleteReview() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "review.controller.CompleteReview"
+
+		ctx := r.Context()
+		log := logger.LogWithRequest(c.logger, op, r)
+
+		userID, err := jwtoken.GetUserID(ctx)
+		if err != nil {
+			handleInternalServerError(w, r, log, le.ErrFailedToGetUserIDFromToken, err)
+			return
+		}
+
+		reviewID := chi.URLParam(r, key.ReviewID)
+		if reviewID == "" {
+			handleResponseError(w, r, log, http.StatusBadRequest, le.ErrEmptyQueryReviewID)
+			return
+		}
+
+		reviewInput := model.ReviewRequestData{
+			ID:     reviewID,
+			UserID: userID,
+		}
+
+		reviewResp, err := c.usecase.CompleteReview(ctx, reviewInput)
+
+		switch {
+		case errors.Is(err, le.ErrReviewNotFound):
+			handleResponseError(w, r, log, http.StatusNotFound, le.ErrReviewNotFound)
+			return
+		case errors.Is(err, le.ErrReviewCompleted):
+			handleResponseError(w, r, log, http.StatusConflict, le.ErrReviewCompleted)
+			return
+		case err != nil:
+			handleInternalServerError(w, r, log, le.ErrFailedToCompleteReview, err)
+			return
+		default:
+			handleResponseSuccess(w, r, log, "review completed", reviewResp, slog.String(key.ReviewID, reviewID))
+		}
+	}
+}
@@ -23,6 +23,22 @@ func NewRouter(
 	h port.HeadingUsecase,
 	t port.TaskUsecase,
 	tag port.TagUsecase,
+	idempotency port.IdempotencyUsecase,
+	events port.EventBroker,
+	allowedOrigins []string,
+	webhook port.WebhookUsecase,
+	rule port.RuleUsecase,
+	template port.TemplateUsecase,
+	area port.AreaUsecase,
+	goal port.GoalUsecase,
+	member port.ListMemberUsecase,
+	comment port.CommentUsecase,
+	audit port.AuditUsecase,
+	undo port.UndoUsecase,
+	review port.ReviewUsecase,
+	stats port.StatsUsecase,
+	habit port.HabitUsecase,
+	attachment port.AttachmentUsecase,
 ) *chi.Mux {
 	r := chi.NewRouter()
 
@@ -51,6 +67,9 @@ func NewRouter(
 	// Enable httprate request limiter of 100 requests per minute per IP
 	r.Use(httprate.LimitByIP(100, 1*time.Minute))
 
+	// Replay stored responses for retried requests with the Idempotency-Key header
+	r.Use(Idempotency(log, jwt, idempotency))
+
 	// Health check
 	r.Get("/health", HealthRead())
 
@@ -59,6 +78,20 @@ func NewRouter(
 	NewHeadingRoutes(r, log, jwt, h)
 	NewTaskRoutes(r, log, jwt, t)
 	NewTagRoutes(r, log, jwt, tag)
+	NewEventRoutes(r, log, jwt, events, allowedOrigins)
+	NewWebhookRoutes(r, log, jwt, webhook)
+	NewRuleRoutes(r, log, jwt, rule)
+	NewTemplateRoutes(r, log, jwt, template)
+	NewAreaRoutes(r, log, jwt, area)
+	NewGoalRoutes(r, log, jwt, goal)
+	NewListMemberRoutes(r, log, jwt, member)
+	NewCommentRoutes(r, log, jwt, comment)
+	NewAuditRoutes(r, log, jwt, audit)
+	NewUndoRoutes(r, log, jwt, undo)
+	NewReviewRoutes(r, log, jwt, review)
+	NewStatsRoutes(r, log, jwt, stats)
+	NewHabitRoutes(r, log, jwt, habit)
+	NewAttachmentRoutes(r, log, jwt, attachment)
 
 	return r
 }
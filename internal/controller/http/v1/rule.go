@@ -0,0 +1,246 @@
+package v1
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/rshelekhov/reframed/internal/lib/constants/key"
+	"github.com/rshelekhov/reframed/internal/lib/constants/le"
+	"github.com/rshelekhov/reframed/internal/lib/logger"
+	"github.com/rshelekhov/reframed/internal/lib/middleware/jwtoken"
+	"github.com/rshelekhov/reframed/internal/model"
+	"github.com/rshelekhov/reframed/internal/port"
+)
+
+type ruleController struct {
+	logger  logger.Interface
+	jwt     *jwtoken.TokenService
+	usecase port.RuleUsecase
+}
+
+func NewRuleRoutes(
+	r *chi.Mux,
+	log logger.Interface,
+	jwt *jwtoken.TokenService,
+	usecase port.RuleUsecase,
+) {
+	c := &ruleController{
+		logger:  log,
+		jwt:     jwt,
+		usecase: usecase,
+	}
+
+	// Protected routes
+	r.Group(func(r chi.Router) {
+		r.Use(jwtoken.Verifier(jwt))
+		r.Use(jwtoken.Authenticator())
+
+		r.Route("/user/rules", func(r chi.Router) {
+			r.Get("/", c.GetRulesByUserID())
+			r.Post("/", c.CreateRule())
+
+			r.Route("/{rule_id}", func(r chi.Router) {
+				r.Get("/", c.GetRuleByID())
+				r.Put("/", c.UpdateRule())
+				r.Delete("/", c.DeleteRule())
+			})
+		})
+	})
+}
+
+func (c *ruleController) CreateRule() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "rule.controller.CreateRule"
+
+		ctx := r.Context()
+		log := logger.LogWithRequest(c.logger, op, r)
+
+		userID, err := jwtoken.GetUserID(ctx)
+		if err != nil {
+			handleInternalServerError(w, r, log, le.ErrFailedToGetUserIDFromToken, err)
+			return
+		}
+
+		ruleInput := &model.RuleRequestData{}
+		if err = decodeAndValidateJSON(w, r, log, ruleInput); err != nil {
+			return
+		}
+
+		ruleInput.UserID = userID
+
+		ruleResp, err := c.usecase.CreateRule(ctx, ruleInput)
+
+		switch {
+		case errors.Is(err, le.ErrInvalidRuleCondition):
+			handleResponseError(w, r, log, http.StatusBadRequest, le.ErrInvalidRuleCondition)
+			return
+		case errors.Is(err, le.ErrInvalidRuleAction):
+			handleResponseError(w, r, log, http.StatusBadRequest, le.ErrInvalidRuleAction)
+			return
+		case err != nil:
+			handleInternalServerError(w, r, log, le.ErrFailedToCreateRule, err)
+			return
+		default:
+			handleResponseCreated(w, r, log, "rule created", ruleResp, slog.String(key.RuleID, ruleResp.ID))
+		}
+	}
+}
+
+func (c *ruleController) GetRuleByID() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "rule.controller.GetRuleByID"
+
+		ctx := r.Context()
+		log := logger.LogWithRequest(c.logger, op, r)
+
+		userID, err := jwtoken.GetUserID(ctx)
+		if err != nil {
+			handleInternalServerError(w, r, log, le.ErrFailedToGetUserIDFromToken, err)
+			return
+		}
+
+		ruleID := chi.URLParam(r, key.RuleID)
+		if ruleID == "" {
+			handleResponseError(w, r, log, http.StatusBadRequest, le.ErrEmptyQueryRuleID)
+			return
+		}
+
+		ruleInput := model.RuleRequestData{
+			ID:     ruleID,
+			UserID: userID,
+		}
+
+		ruleResp, err := c.usecase.GetRuleByID(ctx, ruleInput)
+
+		switch {
+		case errors.Is(err, le.ErrRuleNotFound):
+			handleResponseError(w, r, log, http.StatusNotFound, le.ErrRuleNotFound)
+			return
+		case err != nil:
+			handleInternalServerError(w, r, log, le.ErrFailedToGetData, err)
+			return
+		default:
+			handleResponseSuccess(w, r, log, "rule received", ruleResp, slog.String(key.RuleID, ruleID))
+		}
+	}
+}
+
+func (c *ruleController) GetRulesByUserID() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "rule.controller.GetRulesByUserID"
+
+		ctx := r.Context()
+		log := logger.LogWithRequest(c.logger, op, r)
+
+		userID, err := jwtoken.GetUserID(ctx)
+		if err != nil {
+			handleInternalServerError(w, r, log, le.ErrFailedToGetUserIDFromToken, err)
+			return
+		}
+
+		rulesResp, err := c.usecase.GetRulesByUserID(ctx, userID)
+
+		switch {
+		case errors.Is(err, le.ErrNoRulesFound):
+			handleResponseError(w, r, log, http.StatusNotFound, le.ErrNoRulesFound)
+			return
+		case err != nil:
+			handleInternalServerError(w, r, log, le.ErrFailedToGetRules, err)
+			return
+		default:
+			handleResponseSuccess(w, r, log, "rules found", rulesResp,
+				slog.Int(key.Count, len(rulesResp)),
+			)
+		}
+	}
+}
+
+func (c *ruleController) UpdateRule() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "rule.controller.UpdateRule"
+
+		ctx := r.Context()
+		log := logger.LogWithRequest(c.logger, op, r)
+
+		userID, err := jwtoken.GetUserID(ctx)
+		if err != nil {
+			handleInternalServerError(w, r, log, le.ErrFailedToGetUserIDFromToken, err)
+			return
+		}
+
+		ruleID := chi.URLParam(r, key.RuleID)
+		if ruleID == "" {
+			handleResponseError(w, r, log, http.StatusBadRequest, le.ErrEmptyQueryRuleID)
+			return
+		}
+
+		ruleInput := &model.RuleRequestData{}
+		if err = decodeAndValidateJSON(w, r, log, ruleInput); err != nil {
+			return
+		}
+
+		ruleInput.ID = ruleID
+		ruleInput.UserID = userID
+
+		ruleResp, err := c.usecase.UpdateRule(ctx, ruleInput)
+
+		switch {
+		case errors.Is(err, le.ErrInvalidRuleCondition):
+			handleResponseError(w, r, log, http.StatusBadRequest, le.ErrInvalidRuleCondition)
+			return
+		case errors.Is(err, le.ErrInvalidRuleAction):
+			handleResponseError(w, r, log, http.StatusBadRequest, le.ErrInvalidRuleAction)
+			return
+		case errors.Is(err, le.ErrRuleNotFound):
+			handleResponseError(w, r, log, http.StatusNotFound, le.ErrRuleNotFound)
+			return
+		case err != nil:
+			handleInternalServerError(w, r, log, le.ErrFailedToUpdateRule, err)
+			return
+		default:
+			handleResponseSuccess(w, r, log, "rule updated", ruleResp, slog.String(key.RuleID, ruleResp.ID))
+		}
+	}
+}
+
+func (c *ruleController) DeleteRule() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "rule.controller.DeleteRule"
+
+		ctx := r.Context()
+		log := logger.LogWithRequest(c.logger, op, r)
+
+		userID, err := jwtoken.GetUserID(ctx)
+		if err != nil {
+			handleInternalServerError(w, r, log, le.ErrFailedToGetUserIDFromToken, err)
+			return
+		}
+
+		ruleID := chi.URLParam(r, key.RuleID)
+		if ruleID == "" {
+			handleResponseError(w, r, log, http.StatusBadRequest, le.ErrEmptyQueryRuleID)
+			return
+		}
+
+		ruleInput := model.RuleRequestData{
+			ID:     ruleID,
+			UserID: userID,
+		}
+
+		err = c.usecase.DeleteRule(ctx, ruleInput)
+
+		switch {
+		case errors.Is(err, le.ErrRuleNotFound):
+			handleResponseError(w, r, log, http.StatusNotFound, le.ErrRuleNotFound)
+			return
+		case err != nil:
+			handleInternalServerError(w, r, log, le.ErrFailedToDeleteRule, err)
+			return
+		default:
+			handleResponseSuccess(w, r, log, "rule deleted", ruleID, slog.String(key.RuleID, ruleID))
+		}
+	}
+}
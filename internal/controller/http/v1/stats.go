@@ -0,0 +1,91 @@
+package v1
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/rshelekhov/reframed/internal/lib/constants/key"
+	"github.com/rshelekhov/reframed/internal/lib/constants/le"
+	"github.com/rshelekhov/reframed/internal/lib/logger"
+	"github.com/rshelekhov/reframed/internal/lib/middleware/jwtoken"
+	"github.com/rshelekhov/reframed/internal/port"
+)
+
+type statsController struct {
+	logger  logger.Interface
+	jwt     *jwtoken.TokenService
+	usecase port.StatsUsecase
+}
+
+func NewStatsRoutes(
+	r *chi.Mux,
+	log logger.Interface,
+	jwt *jwtoken.TokenService,
+	usecase port.StatsUsecase,
+) {
+	c := &statsController{
+		logger:  log,
+		jwt:     jwt,
+		usecase: usecase,
+	}
+
+	// Protected routes
+	r.Group(func(r chi.Router) {
+		r.Use(jwtoken.Verifier(jwt))
+		r.Use(jwtoken.Authenticator())
+
+		r.Get("/user/stats", c.GetStats())
+	})
+}
+
+func (c *statsController) GetStats() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "stats.controller.GetStats"
+
+		ctx := r.Context()
+		log := logger.LogWithRequest(c.logger, op, r)
+
+		userID, err := jwtoken.GetUserID(ctx)
+		if err != nil {
+			handleInternalServerError(w, r, log, le.ErrFailedToGetUserIDFromToken, err)
+			return
+		}
+
+		statsInput, err := ParseStatsRequest(r)
+
+		switch {
+		case errors.Is(err, le.ErrInvalidStatsPeriod):
+			handleResponseError(w, r, log, http.StatusBadRequest, le.ErrInvalidStatsPeriod)
+			return
+		case errors.Is(err, le.ErrInvalidStatsRange):
+			handleResponseError(w, r, log, http.StatusBadRequest, le.ErrInvalidStatsRange)
+			return
+		case err != nil:
+			handleInternalServerError(w, r, log, le.ErrFailedToParseQueryParams, err)
+			return
+		}
+
+		statsInput.UserID = userID
+
+		statsResp, err := c.usecase.GetStats(ctx, statsInput)
+		if err != nil {
+			handleInternalServerError(w, r, log, le.ErrFailedToGetStats, err)
+			return
+		}
+
+		// The statistics are the same until the cached ones expire, so the client can keep them as long
+		if maxAge := int(time.Until(statsResp.ExpiresAt).Seconds()); maxAge > 0 {
+			w.Header().Set("Cache-Control", fmt.Sprintf("private, max-age=%d", maxAge))
+		}
+
+		handleResponseSuccess(w, r, log, "stats received", statsResp,
+			slog.String(key.Period, statsInput.Period.String()),
+			slog.Int(key.Count, len(statsResp.Throughput)),
+		)
+	}
+}
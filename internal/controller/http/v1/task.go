@@ -8,6 +8,7 @@ import (
 	"github.com/rshelekhov/reframed/internal/lib/middleware/jwtoken"
 
 	"github.com/go-chi/chi/v5"
+	"github.com/go-playground/validator/v10"
 
 	"github.com/rshelekhov/reframed/internal/lib/constants/key"
 	"github.com/rshelekhov/reframed/internal/lib/constants/le"
@@ -51,22 +52,44 @@ func NewTaskRoutes(
 			})
 		})
 
+		// Loose tasks of the areas, which aren't in any of their lists
+		r.Get("/user/areas/{area_id}/tasks", c.GetTasksByAreaID())
+		r.Post("/user/areas/{area_id}/tasks", c.CreateTaskInArea())
+
 		r.Route("/user/tasks", func(r chi.Router) {
 			r.Get("/", c.GetTasksByUserID())
-			r.Get("/today", c.GetTasksForToday())      // grouped by list title
+			r.Get("/today", c.GetTasksForToday())      // grouped by list title, the evening tasks in their own section
 			r.Get("/upcoming", c.GetUpcomingTasks())   // grouped by start_date
 			r.Get("/overdue", c.GetOverdueTasks())     // grouped by list title
 			r.Get("/someday", c.GetTasksForSomeday())  // tasks without start_date, grouped by list title
 			r.Get("/completed", c.GetCompletedTasks()) // grouped by month
 			r.Get("/archived", c.GetArchivedTasks())   // grouped by month
+			r.Get("/blocked", c.GetBlockedTasks())     // tasks blocked by the tasks which aren't completed
+			r.Get("/assigned", c.GetAssignedTasks())   // tasks assigned to the user, including the shared lists
+			r.Get("/plan", c.GetDailyPlanCandidates()) // tasks proposed for the plan of the day, grouped by reason
+			r.Post("/plan", c.CommitDailyPlan())
 
 			r.Route("/{task_id}", func(r chi.Router) {
 				r.Get("/", c.GetTaskByID())
 				r.Put("/", c.UpdateTask())
+				r.Patch("/", c.PatchTask())
 				r.Put("/time", c.UpdateTaskTime())
 				r.Put("/move", c.MoveTaskToAnotherList())
 				r.Put("/complete", c.CompleteTask())
+				r.Post("/duplicate", c.DuplicateTask())
+				r.Put("/assignee", c.AssignTask())
+				r.Delete("/assignee", c.UnassignTask())
+				r.Put("/evening", c.PlanTaskForEvening())
+				r.Delete("/evening", c.UnplanTaskForEvening())
+				r.Put("/snooze", c.SnoozeTask())
+				r.Delete("/snooze", c.UnsnoozeTask())
 				r.Delete("/", c.ArchiveTask())
+
+				r.Route("/dependencies", func(r chi.Router) {
+					r.Get("/", c.GetTaskBlockers())
+					r.Post("/", c.AddTaskDependency())
+					r.Delete("/{blocked_by_task_id}", c.RemoveTaskDependency())
+				})
 			})
 		})
 	})
@@ -108,6 +131,9 @@ func (c *taskController) CreateTask() http.HandlerFunc {
 		case errors.Is(err, le.ErrHeadingNotFound):
 			handleResponseError(w, r, log, http.StatusNotFound, le.ErrHeadingNotFound)
 			return
+		case errors.Is(err, le.ErrNoPermissionToEditList):
+			handleResponseError(w, r, log, http.StatusForbidden, le.ErrNoPermissionToEditList)
+			return
 		case err != nil:
 			handleInternalServerError(w, r, log, le.ErrFailedToCreateTask, err)
 			return
@@ -140,9 +166,62 @@ func (c *taskController) CreateTaskInDefaultList() http.HandlerFunc {
 		taskResponse, err := c.usecase.CreateTask(ctx, taskInput)
 
 		switch {
+		case errors.Is(err, le.ErrAreaNotFound):
+			handleResponseError(w, r, log, http.StatusNotFound, le.ErrAreaNotFound)
+			return
 		case errors.Is(err, le.ErrHeadingNotFound):
 			handleResponseError(w, r, log, http.StatusNotFound, le.ErrHeadingNotFound)
 			return
+		case errors.Is(err, le.ErrNoPermissionToEditList):
+			handleResponseError(w, r, log, http.StatusForbidden, le.ErrNoPermissionToEditList)
+			return
+		case err != nil:
+			handleInternalServerError(w, r, log, le.ErrFailedToCreateTask, err)
+			return
+		default:
+			handleResponseCreated(w, r, log, "task created", taskResponse, slog.String(key.TaskID, taskResponse.ID))
+		}
+	}
+}
+
+func (c *taskController) CreateTaskInArea() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "task.controller.CreateTaskInArea"
+
+		ctx := r.Context()
+		log := logger.LogWithRequest(c.logger, op, r)
+
+		userID, err := jwtoken.GetUserID(ctx)
+		if err != nil {
+			handleInternalServerError(w, r, log, le.ErrFailedToGetUserIDFromToken, err)
+			return
+		}
+
+		areaID := chi.URLParam(r, key.AreaID)
+		if areaID == "" {
+			handleResponseError(w, r, log, http.StatusBadRequest, le.ErrEmptyQueryAreaID)
+			return
+		}
+
+		taskInput := &model.TaskRequestData{}
+		if err = decodeAndValidateJSON(w, r, log, taskInput); err != nil {
+			return
+		}
+
+		taskInput.ListID = ""
+		taskInput.HeadingID = ""
+		taskInput.AreaID = areaID
+		taskInput.UserID = userID
+
+		taskResponse, err := c.usecase.CreateTask(ctx, taskInput)
+
+		switch {
+		case errors.Is(err, le.ErrAreaNotFound):
+			handleResponseError(w, r, log, http.StatusNotFound, le.ErrAreaNotFound)
+			return
+		case errors.Is(err, le.ErrNoPermissionToEditList):
+			handleResponseError(w, r, log, http.StatusForbidden, le.ErrNoPermissionToEditList)
+			return
 		case err != nil:
 			handleInternalServerError(w, r, log, le.ErrFailedToCreateTask, err)
 			return
@@ -223,6 +302,38 @@ func (c *taskController) GetTasksByUserID() http.HandlerFunc {
 	}
 }
 
+func (c *taskController) GetAssignedTasks() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "task.controller.GetAssignedTasks"
+
+		ctx := r.Context()
+		log := logger.LogWithRequest(c.logger, op, r)
+
+		userID, err := jwtoken.GetUserID(ctx)
+		if err != nil {
+			handleInternalServerError(w, r, log, le.ErrFailedToGetUserIDFromToken, err)
+			return
+		}
+
+		pagination := ParseLimitAndAfterID(r)
+
+		tasksResp, err := c.usecase.GetAssignedTasks(ctx, userID, pagination)
+
+		switch {
+		case errors.Is(err, le.ErrNoTasksFound):
+			handleResponseError(w, r, log, http.StatusNotFound, le.ErrNoTasksFound)
+			return
+		case err != nil:
+			handleInternalServerError(w, r, log, le.ErrFailedToGetData, err)
+			return
+		default:
+			handleResponseSuccess(w, r, log, "assigned tasks found", tasksResp,
+				slog.Int(key.Count, len(tasksResp)),
+			)
+		}
+	}
+}
+
 func (c *taskController) GetTasksByListID() http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		const op = "task.controller.GetTasksByListID"
@@ -264,6 +375,51 @@ func (c *taskController) GetTasksByListID() http.HandlerFunc {
 	}
 }
 
+func (c *taskController) GetTasksByAreaID() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "task.controller.GetTasksByAreaID"
+
+		ctx := r.Context()
+		log := logger.LogWithRequest(c.logger, op, r)
+
+		userID, err := jwtoken.GetUserID(ctx)
+		if err != nil {
+			handleInternalServerError(w, r, log, le.ErrFailedToGetUserIDFromToken, err)
+			return
+		}
+
+		areaID := chi.URLParam(r, key.AreaID)
+		if areaID == "" {
+			handleResponseError(w, r, log, http.StatusBadRequest, le.ErrEmptyQueryAreaID)
+			return
+		}
+
+		tasksInput := model.TaskRequestData{
+			AreaID: areaID,
+			UserID: userID,
+		}
+
+		tasksResp, err := c.usecase.GetTasksByAreaID(ctx, tasksInput)
+
+		switch {
+		case errors.Is(err, le.ErrAreaNotFound):
+			handleResponseError(w, r, log, http.StatusNotFound, le.ErrAreaNotFound)
+			return
+		case errors.Is(err, le.ErrNoTasksFound):
+			handleResponseError(w, r, log, http.StatusNotFound, le.ErrNoTasksFound)
+			return
+		case err != nil:
+			handleInternalServerError(w, r, log, le.ErrFailedToGetData, err)
+			return
+		default:
+			handleResponseSuccess(w, r, log, "tasks found", tasksResp,
+				slog.String(key.AreaID, areaID),
+				slog.Int(key.Count, len(tasksResp)),
+			)
+		}
+	}
+}
+
 func (c *taskController) GetTasksGroupedByHeadings() http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		const op = "task.controller.GetTasksGroupedByHeadings"
@@ -316,7 +472,37 @@ func (c *taskController) GetTasksForToday() http.HandlerFunc {
 			return
 		}
 
-		tasksResp, err := c.usecase.GetTasksForToday(ctx, userID)
+		tasksResp, err := c.usecase.GetTasksForToday(ctx, userID, ParseHideBlocked(r))
+
+		switch {
+		case errors.Is(err, le.ErrNoTasksFound):
+			handleResponseError(w, r, log, http.StatusNotFound, le.ErrNoTasksFound)
+			return
+		case err != nil:
+			handleInternalServerError(w, r, log, le.ErrFailedToGetData, err)
+			return
+		default:
+			handleResponseSuccess(w, r, log, "tasks for today found", tasksResp,
+				slog.Int(key.Count, len(tasksResp.Today)+len(tasksResp.ThisEvening)),
+			)
+		}
+	}
+}
+
+func (c *taskController) GetDailyPlanCandidates() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "task.controller.GetDailyPlanCandidates"
+
+		ctx := r.Context()
+		log := logger.LogWithRequest(c.logger, op, r)
+
+		userID, err := jwtoken.GetUserID(ctx)
+		if err != nil {
+			handleInternalServerError(w, r, log, le.ErrFailedToGetUserIDFromToken, err)
+			return
+		}
+
+		planResp, err := c.usecase.GetDailyPlanCandidates(ctx, userID, ParsePlanDays(r))
 
 		switch {
 		case errors.Is(err, le.ErrNoTasksFound):
@@ -326,7 +512,52 @@ func (c *taskController) GetTasksForToday() http.HandlerFunc {
 			handleInternalServerError(w, r, log, le.ErrFailedToGetData, err)
 			return
 		default:
-			handleResponseSuccess(w, r, log, "tasks for today found", tasksResp, slog.Int(key.Count, len(tasksResp)))
+			handleResponseSuccess(w, r, log, "daily plan candidates found", planResp,
+				slog.Int(key.Count, len(planResp.Overdue)+len(planResp.Unfinished)+len(planResp.DueSoon)+len(planResp.Scheduled)),
+			)
+		}
+	}
+}
+
+func (c *taskController) CommitDailyPlan() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "task.controller.CommitDailyPlan"
+
+		ctx := r.Context()
+		log := logger.LogWithRequest(c.logger, op, r)
+
+		userID, err := jwtoken.GetUserID(ctx)
+		if err != nil {
+			handleInternalServerError(w, r, log, le.ErrFailedToGetUserIDFromToken, err)
+			return
+		}
+
+		planInput := &model.DailyPlanRequestData{}
+		if err = decodeAndValidateJSON(w, r, log, planInput); err != nil {
+			return
+		}
+
+		planInput.UserID = userID
+
+		todayResp, err := c.usecase.CommitDailyPlan(ctx, *planInput)
+
+		switch {
+		case errors.Is(err, le.ErrEmptyDailyPlan):
+			handleResponseError(w, r, log, http.StatusBadRequest, le.ErrEmptyDailyPlan)
+			return
+		case errors.Is(err, le.ErrTaskNotFound):
+			handleResponseError(w, r, log, http.StatusNotFound, le.ErrTaskNotFound)
+			return
+		case errors.Is(err, le.ErrNoPermissionToEditList):
+			handleResponseError(w, r, log, http.StatusForbidden, le.ErrNoPermissionToEditList)
+			return
+		case err != nil:
+			handleInternalServerError(w, r, log, le.ErrFailedToCommitDailyPlan, err)
+			return
+		default:
+			handleResponseSuccess(w, r, log, "daily plan committed", todayResp,
+				slog.Int(key.Count, len(planInput.Today)+len(planInput.ThisEvening)),
+			)
 		}
 	}
 }
@@ -350,7 +581,7 @@ func (c *taskController) GetUpcomingTasks() http.HandlerFunc {
 			return
 		}
 
-		tasksResp, err := c.usecase.GetUpcomingTasks(ctx, userID, pagination)
+		tasksResp, err := c.usecase.GetUpcomingTasks(ctx, userID, pagination, ParseHideBlocked(r))
 
 		switch {
 		case errors.Is(err, le.ErrNoTasksFound):
@@ -493,6 +724,36 @@ func (c *taskController) GetArchivedTasks() http.HandlerFunc {
 	}
 }
 
+func (c *taskController) GetBlockedTasks() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "task.controller.GetBlockedTasks"
+
+		ctx := r.Context()
+		log := logger.LogWithRequest(c.logger, op, r)
+
+		userID, err := jwtoken.GetUserID(ctx)
+		if err != nil {
+			handleInternalServerError(w, r, log, le.ErrFailedToGetUserIDFromToken, err)
+			return
+		}
+
+		pagination := ParseLimitAndAfterID(r)
+
+		tasksResp, err := c.usecase.GetBlockedTasks(ctx, userID, pagination)
+
+		switch {
+		case errors.Is(err, le.ErrNoTasksFound):
+			handleResponseError(w, r, log, http.StatusNotFound, le.ErrNoTasksFound)
+			return
+		case err != nil:
+			handleInternalServerError(w, r, log, le.ErrFailedToGetData, err)
+			return
+		default:
+			handleResponseSuccess(w, r, log, "blocked tasks found", tasksResp, slog.Int(key.Count, len(tasksResp)))
+		}
+	}
+}
+
 func (c *taskController) UpdateTask() http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		const op = "task.controller.UpdateTask"
@@ -526,6 +787,9 @@ func (c *taskController) UpdateTask() http.HandlerFunc {
 		case errors.Is(err, le.ErrTaskNotFound):
 			handleResponseError(w, r, log, http.StatusNotFound, le.ErrTaskNotFound)
 			return
+		case errors.Is(err, le.ErrNoPermissionToEditList):
+			handleResponseError(w, r, log, http.StatusForbidden, le.ErrNoPermissionToEditList)
+			return
 		case err != nil:
 			handleInternalServerError(w, r, log, le.ErrFailedToUpdateTask, err)
 			return
@@ -535,6 +799,68 @@ func (c *taskController) UpdateTask() http.HandlerFunc {
 	}
 }
 
+func (c *taskController) PatchTask() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "task.controller.PatchTask"
+
+		ctx := r.Context()
+		log := logger.LogWithRequest(c.logger, op, r)
+
+		userID, err := jwtoken.GetUserID(ctx)
+		if err != nil {
+			handleInternalServerError(w, r, log, le.ErrFailedToGetUserIDFromToken, err)
+			return
+		}
+
+		taskID := chi.URLParam(r, key.TaskID)
+		if taskID == "" {
+			handleResponseError(w, r, log, http.StatusBadRequest, le.ErrEmptyQueryTaskID)
+			return
+		}
+
+		patch, err := decodeMergePatch(w, r, log)
+		if err != nil {
+			return
+		}
+
+		taskInput := model.TaskRequestData{
+			ID:     taskID,
+			UserID: userID,
+		}
+
+		taskResponse, err := c.usecase.PatchTask(ctx, taskInput, patch)
+
+		var ve validator.ValidationErrors
+
+		switch {
+		case errors.As(err, &ve):
+			log.Error(le.ErrInvalidData.Error(), logger.Err(err))
+			responseValidationErrors(w, r, ve)
+			return
+		case errors.Is(err, le.ErrInvalidMergePatch):
+			handleResponseError(w, r, log, http.StatusBadRequest, le.ErrInvalidMergePatch)
+			return
+		case errors.Is(err, le.ErrInvalidTaskTimeRange):
+			handleResponseError(w, r, log, http.StatusBadRequest, le.ErrInvalidTaskTimeRange)
+			return
+		case errors.Is(err, le.ErrTaskNotFound):
+			handleResponseError(w, r, log, http.StatusNotFound, le.ErrTaskNotFound)
+			return
+		case errors.Is(err, le.ErrHeadingNotFound):
+			handleResponseError(w, r, log, http.StatusNotFound, le.ErrHeadingNotFound)
+			return
+		case errors.Is(err, le.ErrNoPermissionToEditList):
+			handleResponseError(w, r, log, http.StatusForbidden, le.ErrNoPermissionToEditList)
+			return
+		case err != nil:
+			handleInternalServerError(w, r, log, le.ErrFailedToUpdateTask, err)
+			return
+		default:
+			handleResponseSuccess(w, r, log, "task patched", taskResponse, slog.String(key.TaskID, taskResponse.ID))
+		}
+	}
+}
+
 func (c *taskController) UpdateTaskTime() http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		const op = "task.controller.UpdateTaskTimes"
@@ -568,6 +894,9 @@ func (c *taskController) UpdateTaskTime() http.HandlerFunc {
 		case errors.Is(err, le.ErrTaskNotFound):
 			handleResponseError(w, r, log, http.StatusNotFound, le.ErrTaskNotFound)
 			return
+		case errors.Is(err, le.ErrNoPermissionToEditList):
+			handleResponseError(w, r, log, http.StatusForbidden, le.ErrNoPermissionToEditList)
+			return
 		case err != nil:
 			handleInternalServerError(w, r, log, le.ErrFailedToUpdateTask, err)
 			return
@@ -608,17 +937,23 @@ func (c *taskController) MoveTaskToAnotherList() http.HandlerFunc {
 			UserID: userID,
 		}
 
-		err = c.usecase.MoveTaskToAnotherList(ctx, taskInput)
+		undoToken, err := c.usecase.MoveTaskToAnotherList(ctx, taskInput)
 
 		switch {
 		case errors.Is(err, le.ErrTaskNotFound):
 			handleResponseError(w, r, log, http.StatusNotFound, le.ErrTaskNotFound)
 			return
+		case errors.Is(err, le.ErrNoPermissionToEditList):
+			handleResponseError(w, r, log, http.StatusForbidden, le.ErrNoPermissionToEditList)
+			return
 		case err != nil:
 			handleInternalServerError(w, r, log, le.ErrFailedToMoveTask, err)
 			return
 		default:
-			handleResponseSuccess(w, r, log, "task moved to another list", taskInput, slog.String(key.TaskID, taskInput.ID))
+			handleResponseSuccess(w, r, log, "task moved to another list",
+				model.UndoTokenResponseData{ID: taskInput.ID, UndoToken: undoToken},
+				slog.String(key.TaskID, taskInput.ID),
+			)
 		}
 	}
 }
@@ -647,17 +982,23 @@ func (c *taskController) CompleteTask() http.HandlerFunc {
 			UserID: userID,
 		}
 
-		err = c.usecase.CompleteTask(ctx, taskInput)
+		undoToken, err := c.usecase.CompleteTask(ctx, taskInput)
 
 		switch {
 		case errors.Is(err, le.ErrTaskNotFound):
 			handleResponseError(w, r, log, http.StatusNotFound, le.ErrTaskNotFound)
 			return
+		case errors.Is(err, le.ErrNoPermissionToEditList):
+			handleResponseError(w, r, log, http.StatusForbidden, le.ErrNoPermissionToEditList)
+			return
 		case err != nil:
 			handleInternalServerError(w, r, log, le.ErrFailedToCompleteTask, err)
 			return
 		default:
-			handleResponseSuccess(w, r, log, "task completed", nil, slog.String(key.TaskID, taskID))
+			handleResponseSuccess(w, r, log, "task completed",
+				model.UndoTokenResponseData{ID: taskID, UndoToken: undoToken},
+				slog.String(key.TaskID, taskID),
+			)
 		}
 	}
 }
@@ -686,17 +1027,492 @@ func (c *taskController) ArchiveTask() http.HandlerFunc {
 			UserID: userID,
 		}
 
-		err = c.usecase.ArchiveTask(ctx, taskInput)
+		undoToken, err := c.usecase.ArchiveTask(ctx, taskInput)
 
 		switch {
 		case errors.Is(err, le.ErrTaskNotFound):
 			handleResponseError(w, r, log, http.StatusNotFound, le.ErrTaskNotFound)
 			return
+		case errors.Is(err, le.ErrNoPermissionToEditList):
+			handleResponseError(w, r, log, http.StatusForbidden, le.ErrNoPermissionToEditList)
+			return
 		case err != nil:
 			handleInternalServerError(w, r, log, le.ErrFailedToDeleteTask, err)
 			return
 		default:
-			handleResponseSuccess(w, r, log, "task deleted", taskID, slog.String(key.TaskID, taskID))
+			handleResponseSuccess(w, r, log, "task deleted",
+				model.UndoTokenResponseData{ID: taskID, UndoToken: undoToken},
+				slog.String(key.TaskID, taskID),
+			)
+		}
+	}
+}
+
+func (c *taskController) AssignTask() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "task.controller.AssignTask"
+
+		ctx := r.Context()
+		log := logger.LogWithRequest(c.logger, op, r)
+
+		userID, err := jwtoken.GetUserID(ctx)
+		if err != nil {
+			handleInternalServerError(w, r, log, le.ErrFailedToGetUserIDFromToken, err)
+			return
+		}
+
+		taskID := chi.URLParam(r, key.TaskID)
+		if taskID == "" {
+			handleResponseError(w, r, log, http.StatusBadRequest, le.ErrEmptyQueryTaskID)
+			return
+		}
+
+		assigneeInput := &model.TaskAssigneeRequestData{}
+		if err = decodeAndValidateJSON(w, r, log, assigneeInput); err != nil {
+			return
+		}
+
+		assigneeInput.TaskID = taskID
+		assigneeInput.UserID = userID
+
+		taskResp, err := c.usecase.AssignTask(ctx, *assigneeInput)
+
+		switch {
+		case errors.Is(err, le.ErrTaskNotFound):
+			handleResponseError(w, r, log, http.StatusNotFound, le.ErrTaskNotFound)
+			return
+		case errors.Is(err, le.ErrNoPermissionToEditList):
+			handleResponseError(w, r, log, http.StatusForbidden, le.ErrNoPermissionToEditList)
+			return
+		case errors.Is(err, le.ErrAssigneeNotListMember):
+			handleResponseError(w, r, log, http.StatusBadRequest, le.ErrAssigneeNotListMember)
+			return
+		case err != nil:
+			handleInternalServerError(w, r, log, le.ErrFailedToAssignTask, err)
+			return
+		default:
+			handleResponseSuccess(w, r, log, "task assigned", taskResp,
+				slog.String(key.TaskID, taskID),
+				slog.String(key.AssigneeID, assigneeInput.AssigneeID),
+			)
+		}
+	}
+}
+
+func (c *taskController) UnassignTask() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "task.controller.UnassignTask"
+
+		ctx := r.Context()
+		log := logger.LogWithRequest(c.logger, op, r)
+
+		userID, err := jwtoken.GetUserID(ctx)
+		if err != nil {
+			handleInternalServerError(w, r, log, le.ErrFailedToGetUserIDFromToken, err)
+			return
+		}
+
+		taskID := chi.URLParam(r, key.TaskID)
+		if taskID == "" {
+			handleResponseError(w, r, log, http.StatusBadRequest, le.ErrEmptyQueryTaskID)
+			return
+		}
+
+		taskInput := model.TaskRequestData{
+			ID:     taskID,
+			UserID: userID,
+		}
+
+		taskResp, err := c.usecase.UnassignTask(ctx, taskInput)
+
+		switch {
+		case errors.Is(err, le.ErrTaskNotFound):
+			handleResponseError(w, r, log, http.StatusNotFound, le.ErrTaskNotFound)
+			return
+		case errors.Is(err, le.ErrNoPermissionToEditList):
+			handleResponseError(w, r, log, http.StatusForbidden, le.ErrNoPermissionToEditList)
+			return
+		case err != nil:
+			handleInternalServerError(w, r, log, le.ErrFailedToUnassignTask, err)
+			return
+		default:
+			handleResponseSuccess(w, r, log, "task unassigned", taskResp, slog.String(key.TaskID, taskID))
+		}
+	}
+}
+
+func (c *taskController) PlanTaskForEvening() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "task.controller.PlanTaskForEvening"
+
+		ctx := r.Context()
+		log := logger.LogWithRequest(c.logger, op, r)
+
+		userID, err := jwtoken.GetUserID(ctx)
+		if err != nil {
+			handleInternalServerError(w, r, log, le.ErrFailedToGetUserIDFromToken, err)
+			return
+		}
+
+		taskID := chi.URLParam(r, key.TaskID)
+		if taskID == "" {
+			handleResponseError(w, r, log, http.StatusBadRequest, le.ErrEmptyQueryTaskID)
+			return
+		}
+
+		taskInput := model.TaskRequestData{
+			ID:     taskID,
+			UserID: userID,
+		}
+
+		taskResp, err := c.usecase.PlanTaskForEvening(ctx, taskInput, true)
+
+		switch {
+		case errors.Is(err, le.ErrTaskNotFound):
+			handleResponseError(w, r, log, http.StatusNotFound, le.ErrTaskNotFound)
+			return
+		case errors.Is(err, le.ErrNoPermissionToEditList):
+			handleResponseError(w, r, log, http.StatusForbidden, le.ErrNoPermissionToEditList)
+			return
+		case err != nil:
+			handleInternalServerError(w, r, log, le.ErrFailedToPlanTask, err)
+			return
+		default:
+			handleResponseSuccess(w, r, log, "task planned for this evening", taskResp, slog.String(key.TaskID, taskID))
+		}
+	}
+}
+
+func (c *taskController) UnplanTaskForEvening() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "task.controller.UnplanTaskForEvening"
+
+		ctx := r.Context()
+		log := logger.LogWithRequest(c.logger, op, r)
+
+		userID, err := jwtoken.GetUserID(ctx)
+		if err != nil {
+			handleInternalServerError(w, r, log, le.ErrFailedToGetUserIDFromToken, err)
+			return
+		}
+
+		taskID := chi.URLParam(r, key.TaskID)
+		if taskID == "" {
+			handleResponseError(w, r, log, http.StatusBadRequest, le.ErrEmptyQueryTaskID)
+			return
+		}
+
+		taskInput := model.TaskRequestData{
+			ID:     taskID,
+			UserID: userID,
+		}
+
+		taskResp, err := c.usecase.PlanTaskForEvening(ctx, taskInput, false)
+
+		switch {
+		case errors.Is(err, le.ErrTaskNotFound):
+			handleResponseError(w, r, log, http.StatusNotFound, le.ErrTaskNotFound)
+			return
+		case errors.Is(err, le.ErrNoPermissionToEditList):
+			handleResponseError(w, r, log, http.StatusForbidden, le.ErrNoPermissionToEditList)
+			return
+		case err != nil:
+			handleInternalServerError(w, r, log, le.ErrFailedToPlanTask, err)
+			return
+		default:
+			handleResponseSuccess(w, r, log, "task moved out of this evening", taskResp, slog.String(key.TaskID, taskID))
+		}
+	}
+}
+
+func (c *taskController) SnoozeTask() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "task.controller.SnoozeTask"
+
+		ctx := r.Context()
+		log := logger.LogWithRequest(c.logger, op, r)
+
+		userID, err := jwtoken.GetUserID(ctx)
+		if err != nil {
+			handleInternalServerError(w, r, log, le.ErrFailedToGetUserIDFromToken, err)
+			return
+		}
+
+		taskID := chi.URLParam(r, key.TaskID)
+		if taskID == "" {
+			handleResponseError(w, r, log, http.StatusBadRequest, le.ErrEmptyQueryTaskID)
+			return
+		}
+
+		snoozeInput := &model.TaskSnoozeRequestData{}
+		if err = decodeAndValidateJSON(w, r, log, snoozeInput); err != nil {
+			return
+		}
+
+		snoozeInput.TaskID = taskID
+		snoozeInput.UserID = userID
+
+		taskResp, err := c.usecase.SnoozeTask(ctx, *snoozeInput)
+
+		switch {
+		case errors.Is(err, le.ErrInvalidSnoozeTime):
+			handleResponseError(w, r, log, http.StatusBadRequest, le.ErrInvalidSnoozeTime)
+			return
+		case errors.Is(err, le.ErrTaskNotFound):
+			handleResponseError(w, r, log, http.StatusNotFound, le.ErrTaskNotFound)
+			return
+		case errors.Is(err, le.ErrNoPermissionToEditList):
+			handleResponseError(w, r, log, http.StatusForbidden, le.ErrNoPermissionToEditList)
+			return
+		case err != nil:
+			handleInternalServerError(w, r, log, le.ErrFailedToSnoozeTask, err)
+			return
+		default:
+			handleResponseSuccess(w, r, log, "task snoozed", taskResp, slog.String(key.TaskID, taskID))
+		}
+	}
+}
+
+func (c *taskController) UnsnoozeTask() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "task.controller.UnsnoozeTask"
+
+		ctx := r.Context()
+		log := logger.LogWithRequest(c.logger, op, r)
+
+		userID, err := jwtoken.GetUserID(ctx)
+		if err != nil {
+			handleInternalServerError(w, r, log, le.ErrFailedToGetUserIDFromToken, err)
+			return
+		}
+
+		taskID := chi.URLParam(r, key.TaskID)
+		if taskID == "" {
+			handleResponseError(w, r, log, http.StatusBadRequest, le.ErrEmptyQueryTaskID)
+			return
+		}
+
+		taskInput := model.TaskRequestData{
+			ID:     taskID,
+			UserID: userID,
+		}
+
+		taskResp, err := c.usecase.UnsnoozeTask(ctx, taskInput)
+
+		switch {
+		case errors.Is(err, le.ErrTaskNotFound):
+			handleResponseError(w, r, log, http.StatusNotFound, le.ErrTaskNotFound)
+			return
+		case errors.Is(err, le.ErrNoPermissionToEditList):
+			handleResponseError(w, r, log, http.StatusForbidden, le.ErrNoPermissionToEditList)
+			return
+		case err != nil:
+			handleInternalServerError(w, r, log, le.ErrFailedToUnsnoozeTask, err)
+			return
+		default:
+			handleResponseSuccess(w, r, log, "task unsnoozed", taskResp, slog.String(key.TaskID, taskID))
+		}
+	}
+}
+
+func (c *taskController) AddTaskDependency() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "task.controller.AddTaskDependency"
+
+		ctx := r.Context()
+		log := logger.LogWithRequest(c.logger, op, r)
+
+		userID, err := jwtoken.GetUserID(ctx)
+		if err != nil {
+			handleInternalServerError(w, r, log, le.ErrFailedToGetUserIDFromToken, err)
+			return
+		}
+
+		taskID := chi.URLParam(r, key.TaskID)
+		if taskID == "" {
+			handleResponseError(w, r, log, http.StatusBadRequest, le.ErrEmptyQueryTaskID)
+			return
+		}
+
+		dependencyInput := &model.TaskDependencyRequestData{}
+		if err = decodeAndValidateJSON(w, r, log, dependencyInput); err != nil {
+			return
+		}
+
+		dependencyInput.TaskID = taskID
+		dependencyInput.UserID = userID
+
+		dependencyResp, err := c.usecase.AddTaskDependency(ctx, *dependencyInput)
+
+		switch {
+		case errors.Is(err, le.ErrTaskNotFound):
+			handleResponseError(w, r, log, http.StatusNotFound, le.ErrTaskNotFound)
+			return
+		case errors.Is(err, le.ErrTaskCannotBlockItself):
+			handleResponseError(w, r, log, http.StatusBadRequest, le.ErrTaskCannotBlockItself)
+			return
+		case errors.Is(err, le.ErrTaskDependencyCycle):
+			handleResponseError(w, r, log, http.StatusConflict, le.ErrTaskDependencyCycle)
+			return
+		case errors.Is(err, le.ErrTaskDependencyAlreadyExists):
+			handleResponseError(w, r, log, http.StatusConflict, le.ErrTaskDependencyAlreadyExists)
+			return
+		case errors.Is(err, le.ErrNoPermissionToEditList):
+			handleResponseError(w, r, log, http.StatusForbidden, le.ErrNoPermissionToEditList)
+			return
+		case err != nil:
+			handleInternalServerError(w, r, log, le.ErrFailedToCreateTaskDependency, err)
+			return
+		default:
+			handleResponseCreated(w, r, log, "task dependency created", dependencyResp,
+				slog.String(key.TaskID, taskID),
+				slog.String(key.BlockedByTaskID, dependencyResp.BlockedByTaskID),
+			)
+		}
+	}
+}
+
+func (c *taskController) GetTaskBlockers() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "task.controller.GetTaskBlockers"
+
+		ctx := r.Context()
+		log := logger.LogWithRequest(c.logger, op, r)
+
+		userID, err := jwtoken.GetUserID(ctx)
+		if err != nil {
+			handleInternalServerError(w, r, log, le.ErrFailedToGetUserIDFromToken, err)
+			return
+		}
+
+		taskID := chi.URLParam(r, key.TaskID)
+		if taskID == "" {
+			handleResponseError(w, r, log, http.StatusBadRequest, le.ErrEmptyQueryTaskID)
+			return
+		}
+
+		taskInput := model.TaskRequestData{
+			ID:     taskID,
+			UserID: userID,
+		}
+
+		blockersResp, err := c.usecase.GetTaskBlockers(ctx, taskInput)
+
+		switch {
+		case errors.Is(err, le.ErrTaskNotFound):
+			handleResponseError(w, r, log, http.StatusNotFound, le.ErrTaskNotFound)
+			return
+		case errors.Is(err, le.ErrNoTaskDependenciesFound):
+			handleResponseError(w, r, log, http.StatusNotFound, le.ErrNoTaskDependenciesFound)
+			return
+		case err != nil:
+			handleInternalServerError(w, r, log, le.ErrFailedToGetData, err)
+			return
+		default:
+			handleResponseSuccess(w, r, log, "task blockers found", blockersResp,
+				slog.String(key.TaskID, taskID),
+				slog.Int(key.Count, len(blockersResp)),
+			)
+		}
+	}
+}
+
+func (c *taskController) RemoveTaskDependency() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "task.controller.RemoveTaskDependency"
+
+		ctx := r.Context()
+		log := logger.LogWithRequest(c.logger, op, r)
+
+		userID, err := jwtoken.GetUserID(ctx)
+		if err != nil {
+			handleInternalServerError(w, r, log, le.ErrFailedToGetUserIDFromToken, err)
+			return
+		}
+
+		taskID := chi.URLParam(r, key.TaskID)
+		if taskID == "" {
+			handleResponseError(w, r, log, http.StatusBadRequest, le.ErrEmptyQueryTaskID)
+			return
+		}
+
+		blockedByTaskID := chi.URLParam(r, key.BlockedByTaskID)
+		if blockedByTaskID == "" {
+			handleResponseError(w, r, log, http.StatusBadRequest, le.ErrEmptyQueryBlockedByTaskID)
+			return
+		}
+
+		dependencyInput := model.TaskDependencyRequestData{
+			TaskID:          taskID,
+			BlockedByTaskID: blockedByTaskID,
+			UserID:          userID,
+		}
+
+		err = c.usecase.RemoveTaskDependency(ctx, dependencyInput)
+
+		switch {
+		case errors.Is(err, le.ErrTaskDependencyNotFound):
+			handleResponseError(w, r, log, http.StatusNotFound, le.ErrTaskDependencyNotFound)
+			return
+		case errors.Is(err, le.ErrNoPermissionToEditList):
+			handleResponseError(w, r, log, http.StatusForbidden, le.ErrNoPermissionToEditList)
+			return
+		case err != nil:
+			handleInternalServerError(w, r, log, le.ErrFailedToDeleteTaskDependency, err)
+			return
+		default:
+			handleResponseSuccess(w, r, log, "task dependency deleted", dependencyInput,
+				slog.String(key.TaskID, taskID),
+				slog.String(key.BlockedByTaskID, blockedByTaskID),
+			)
+		}
+	}
+}
+
+func (c *taskController) DuplicateTask() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "task.controller.DuplicateTask"
+
+		ctx := r.Context()
+		log := logger.LogWithRequest(c.logger, op, r)
+
+		userID, err := jwtoken.GetUserID(ctx)
+		if err != nil {
+			handleInternalServerError(w, r, log, le.ErrFailedToGetUserIDFromToken, err)
+			return
+		}
+
+		taskID := chi.URLParam(r, key.TaskID)
+		if taskID == "" {
+			handleResponseError(w, r, log, http.StatusBadRequest, le.ErrEmptyQueryTaskID)
+			return
+		}
+
+		opts, err := ParseDuplicateOptions(r)
+		if err != nil {
+			handleResponseError(w, r, log, http.StatusBadRequest, le.ErrInvalidDuplicateOptions)
+			return
+		}
+
+		taskInput := model.TaskRequestData{
+			ID:     taskID,
+			UserID: userID,
+		}
+
+		taskResp, err := c.usecase.DuplicateTask(ctx, taskInput, opts)
+
+		switch {
+		case errors.Is(err, le.ErrTaskNotFound):
+			handleResponseError(w, r, log, http.StatusNotFound, le.ErrTaskNotFound)
+			return
+		case errors.Is(err, le.ErrNoPermissionToEditList):
+			handleResponseError(w, r, log, http.StatusForbidden, le.ErrNoPermissionToEditList)
+			return
+		case err != nil:
+			handleInternalServerError(w, r, log, le.ErrFailedToDuplicateTask, err)
+			return
+		default:
+			handleResponseCreated(w, r, log, "task duplicated", taskResp, slog.String(key.TaskID, taskResp.ID))
 		}
 	}
 }
@@ -0,0 +1,252 @@
+package v1
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/rshelekhov/reframed/internal/lib/constants/key"
+	"github.com/rshelekhov/reframed/internal/lib/constants/le"
+	"github.com/rshelekhov/reframed/internal/lib/logger"
+	"github.com/rshelekhov/reframed/internal/lib/middleware/jwtoken"
+	"github.com/rshelekhov/reframed/internal/model"
+	"github.com/rshelekhov/reframed/internal/port"
+)
+
+type templateController struct {
+	logger  logger.Interface
+	jwt     *jwtoken.TokenService
+	usecase port.TemplateUsecase
+}
+
+func NewTemplateRoutes(
+	r *chi.Mux,
+	log logger.Interface,
+	jwt *jwtoken.TokenService,
+	usecase port.TemplateUsecase,
+) {
+	c := &templateController{
+		logger:  log,
+		jwt:     jwt,
+		usecase: usecase,
+	}
+
+	// Protected routes
+	r.Group(func(r chi.Router) {
+		r.Use(jwtoken.Verifier(jwt))
+		r.Use(jwtoken.Authenticator())
+
+		r.Route("/user/templates", func(r chi.Router) {
+			r.Get("/", c.GetTemplatesByUserID())
+			r.Post("/", c.CreateTemplate())
+
+			r.Route("/{template_id}", func(r chi.Router) {
+				r.Get("/", c.GetTemplateByID())
+				r.Delete("/", c.DeleteTemplate())
+				r.Post("/instantiate", c.InstantiateTemplate())
+			})
+		})
+	})
+}
+
+func (c *templateController) CreateTemplate() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "template.controller.CreateTemplate"
+
+		ctx := r.Context()
+		log := logger.LogWithRequest(c.logger, op, r)
+
+		userID, err := jwtoken.GetUserID(ctx)
+		if err != nil {
+			handleInternalServerError(w, r, log, le.ErrFailedToGetUserIDFromToken, err)
+			return
+		}
+
+		templateInput := &model.TemplateRequestData{}
+		if err = decodeAndValidateJSON(w, r, log, templateInput); err != nil {
+			return
+		}
+
+		templateInput.UserID = userID
+
+		templateResp, err := c.usecase.CreateTemplate(ctx, templateInput)
+
+		switch {
+		case errors.Is(err, le.ErrInvalidTemplateSource):
+			handleResponseError(w, r, log, http.StatusBadRequest, le.ErrInvalidTemplateSource)
+			return
+		case errors.Is(err, le.ErrListNotFound):
+			handleResponseError(w, r, log, http.StatusNotFound, le.ErrListNotFound)
+			return
+		case errors.Is(err, le.ErrTaskNotFound):
+			handleResponseError(w, r, log, http.StatusNotFound, le.ErrTaskNotFound)
+			return
+		case err != nil:
+			handleInternalServerError(w, r, log, le.ErrFailedToCreateTemplate, err)
+			return
+		default:
+			handleResponseCreated(w, r, log, "template created", templateResp, slog.String(key.TemplateID, templateResp.ID))
+		}
+	}
+}
+
+func (c *templateController) GetTemplateByID() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "template.controller.GetTemplateByID"
+
+		ctx := r.Context()
+		log := logger.LogWithRequest(c.logger, op, r)
+
+		userID, err := jwtoken.GetUserID(ctx)
+		if err != nil {
+			handleInternalServerError(w, r, log, le.ErrFailedToGetUserIDFromToken, err)
+			return
+		}
+
+		templateID := chi.URLParam(r, key.TemplateID)
+		if templateID == "" {
+			handleResponseError(w, r, log, http.StatusBadRequest, le.ErrEmptyQueryTemplateID)
+			return
+		}
+
+		templateInput := model.TemplateRequestData{
+			ID:     templateID,
+			UserID: userID,
+		}
+
+		templateResp, err := c.usecase.GetTemplateByID(ctx, templateInput)
+
+		switch {
+		case errors.Is(err, le.ErrTemplateNotFound):
+			handleResponseError(w, r, log, http.StatusNotFound, le.ErrTemplateNotFound)
+			return
+		case err != nil:
+			handleInternalServerError(w, r, log, le.ErrFailedToGetData, err)
+			return
+		default:
+			handleResponseSuccess(w, r, log, "template received", templateResp, slog.String(key.TemplateID, templateID))
+		}
+	}
+}
+
+func (c *templateController) GetTemplatesByUserID() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "template.controller.GetTemplatesByUserID"
+
+		ctx := r.Context()
+		log := logger.LogWithRequest(c.logger, op, r)
+
+		userID, err := jwtoken.GetUserID(ctx)
+		if err != nil {
+			handleInternalServerError(w, r, log, le.ErrFailedToGetUserIDFromToken, err)
+			return
+		}
+
+		templatesResp, err := c.usecase.GetTemplatesByUserID(ctx, userID)
+
+		switch {
+		case errors.Is(err, le.ErrNoTemplatesFound):
+			handleResponseError(w, r, log, http.StatusNotFound, le.ErrNoTemplatesFound)
+			return
+		case err != nil:
+			handleInternalServerError(w, r, log, le.ErrFailedToGetTemplates, err)
+			return
+		default:
+			handleResponseSuccess(w, r, log, "templates found", templatesResp,
+				slog.Int(key.Count, len(templatesResp)),
+			)
+		}
+	}
+}
+
+func (c *templateController) DeleteTemplate() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "template.controller.DeleteTemplate"
+
+		ctx := r.Context()
+		log := logger.LogWithRequest(c.logger, op, r)
+
+		userID, err := jwtoken.GetUserID(ctx)
+		if err != nil {
+			handleInternalServerError(w, r, log, le.ErrFailedToGetUserIDFromToken, err)
+			return
+		}
+
+		templateID := chi.URLParam(r, key.TemplateID)
+		if templateID == "" {
+			handleResponseError(w, r, log, http.StatusBadRequest, le.ErrEmptyQueryTemplateID)
+			return
+		}
+
+		templateInput := model.TemplateRequestData{
+			ID:     templateID,
+			UserID: userID,
+		}
+
+		err = c.usecase.DeleteTemplate(ctx, templateInput)
+
+		switch {
+		case errors.Is(err, le.ErrTemplateNotFound):
+			handleResponseError(w, r, log, http.StatusNotFound, le.ErrTemplateNotFound)
+			return
+		case err != nil:
+			handleInternalServerError(w, r, log, le.ErrFailedToDeleteTemplate, err)
+			return
+		default:
+			handleResponseSuccess(w, r, log, "template deleted", templateID, slog.String(key.TemplateID, templateID))
+		}
+	}
+}
+
+func (c *templateController) InstantiateTemplate() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "template.controller.InstantiateTemplate"
+
+		ctx := r.Context()
+		log := logger.LogWithRequest(c.logger, op, r)
+
+		userID, err := jwtoken.GetUserID(ctx)
+		if err != nil {
+			handleInternalServerError(w, r, log, le.ErrFailedToGetUserIDFromToken, err)
+			return
+		}
+
+		templateID := chi.URLParam(r, key.TemplateID)
+		if templateID == "" {
+			handleResponseError(w, r, log, http.StatusBadRequest, le.ErrEmptyQueryTemplateID)
+			return
+		}
+
+		instanceInput := &model.TemplateInstanceRequestData{}
+		if err = decodeAndValidateJSON(w, r, log, instanceInput); err != nil {
+			return
+		}
+
+		instanceInput.ID = templateID
+		instanceInput.UserID = userID
+
+		instanceResp, err := c.usecase.InstantiateTemplate(ctx, instanceInput)
+
+		switch {
+		case errors.Is(err, le.ErrTemplateNotFound):
+			handleResponseError(w, r, log, http.StatusNotFound, le.ErrTemplateNotFound)
+			return
+		case errors.Is(err, le.ErrListNotFound):
+			handleResponseError(w, r, log, http.StatusNotFound, le.ErrListNotFound)
+			return
+		case errors.Is(err, le.ErrHeadingNotFound):
+			handleResponseError(w, r, log, http.StatusNotFound, le.ErrHeadingNotFound)
+			return
+		case err != nil:
+			handleInternalServerError(w, r, log, le.ErrFailedToInstantiateTemplate, err)
+			return
+		default:
+			handleResponseCreated(w, r, log, "template instantiated", instanceResp,
+				slog.String(key.TemplateID, templateID),
+				slog.Int(key.Count, len(instanceResp.Tasks)),
+			)
+		}
+	}
+}
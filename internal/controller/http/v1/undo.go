@@ -0,0 +1,98 @@
+package v1
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/rshelekhov/reframed/internal/lib/constants/key"
+	"github.com/rshelekhov/reframed/internal/lib/constants/le"
+	"github.com/rshelekhov/reframed/internal/lib/logger"
+	"github.com/rshelekhov/reframed/internal/lib/middleware/jwtoken"
+	"github.com/rshelekhov/reframed/internal/model"
+	"github.com/rshelekhov/reframed/internal/port"
+)
+
+type undoController struct {
+	logger  logger.Interface
+	jwt     *jwtoken.TokenService
+	usecase port.UndoUsecase
+}
+
+func NewUndoRoutes(
+	r *chi.Mux,
+	log logger.Interface,
+	jwt *jwtoken.TokenService,
+	usecase port.UndoUsecase,
+) {
+	c := &undoController{
+		logger:  log,
+		jwt:     jwt,
+		usecase: usecase,
+	}
+
+	// Protected routes
+	r.Group(func(r chi.Router) {
+		r.Use(jwtoken.Verifier(jwt))
+		r.Use(jwtoken.Authenticator())
+
+		r.Post("/user/undo/{undo_token}", c.Undo())
+	})
+}
+
+func (c *undoController) Undo() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "undo.controller.Undo"
+
+		ctx := r.Context()
+		log := logger.LogWithRequest(c.logger, op, r)
+
+		userID, err := jwtoken.GetUserID(ctx)
+		if err != nil {
+			handleInternalServerError(w, r, log, le.ErrFailedToGetUserIDFromToken, err)
+			return
+		}
+
+		token := chi.URLParam(r, key.UndoToken)
+		if token == "" {
+			handleResponseError(w, r, log, http.StatusBadRequest, le.ErrEmptyQueryUndoToken)
+			return
+		}
+
+		undoResp, err := c.usecase.Undo(ctx, model.UndoRequestData{
+			Token:  token,
+			UserID: userID,
+		})
+
+		switch {
+		case errors.Is(err, le.ErrUndoTokenNotFound):
+			handleResponseError(w, r, log, http.StatusNotFound, le.ErrUndoTokenNotFound)
+			return
+		case errors.Is(err, le.ErrUndoTokenExpired):
+			handleResponseError(w, r, log, http.StatusGone, le.ErrUndoTokenExpired)
+			return
+		case errors.Is(err, le.ErrUndoTokenAlreadyUsed):
+			handleResponseError(w, r, log, http.StatusConflict, le.ErrUndoTokenAlreadyUsed)
+			return
+		case errors.Is(err, le.ErrUndoConflict):
+			handleResponseError(w, r, log, http.StatusConflict, le.ErrUndoConflict)
+			return
+		case errors.Is(err, le.ErrListNotFound):
+			handleResponseError(w, r, log, http.StatusNotFound, le.ErrListNotFound)
+			return
+		case errors.Is(err, le.ErrNoPermissionToEditList):
+			handleResponseError(w, r, log, http.StatusForbidden, le.ErrNoPermissionToEditList)
+			return
+		case err != nil:
+			handleInternalServerError(w, r, log, le.ErrFailedToUndoOperation, err)
+			return
+		default:
+			handleResponseSuccess(w, r, log, "operation undone", undoResp,
+				slog.String(key.UndoToken, token),
+				slog.String(key.EntityID, undoResp.EntityID),
+			)
+		}
+	}
+}
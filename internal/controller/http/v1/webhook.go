@@ -0,0 +1,338 @@
+package v1
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/rshelekhov/reframed/internal/lib/constants/key"
+	"github.com/rshelekhov/reframed/internal/lib/constants/le"
+	"github.com/rshelekhov/reframed/internal/lib/logger"
+	"github.com/rshelekhov/reframed/internal/lib/middleware/jwtoken"
+	"github.com/rshelekhov/reframed/internal/model"
+	"github.com/rshelekhov/reframed/internal/port"
+)
+
+type webhookController struct {
+	logger  logger.Interface
+	jwt     *jwtoken.TokenService
+	usecase port.WebhookUsecase
+}
+
+func NewWebhookRoutes(
+	r *chi.Mux,
+	log logger.Interface,
+	jwt *jwtoken.TokenService,
+	usecase port.WebhookUsecase,
+) {
+	c := &webhookController{
+		logger:  log,
+		jwt:     jwt,
+		usecase: usecase,
+	}
+
+	// Protected routes
+	r.Group(func(r chi.Router) {
+		r.Use(jwtoken.Verifier(jwt))
+		r.Use(jwtoken.Authenticator())
+
+		r.Route("/user/webhooks", func(r chi.Router) {
+			r.Get("/", c.GetWebhooksByUserID())
+			r.Post("/", c.CreateWebhook())
+
+			r.Route("/{webhook_id}", func(r chi.Router) {
+				r.Get("/", c.GetWebhookByID())
+				r.Put("/", c.UpdateWebhook())
+				r.Delete("/", c.DeleteWebhook())
+				r.Get("/deliveries", c.GetWebhookDeliveries())
+				r.Post("/test", c.SendTestEvent())
+			})
+		})
+	})
+}
+
+func (c *webhookController) CreateWebhook() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "webhook.controller.CreateWebhook"
+
+		ctx := r.Context()
+		log := logger.LogWithRequest(c.logger, op, r)
+
+		userID, err := jwtoken.GetUserID(ctx)
+		if err != nil {
+			handleInternalServerError(w, r, log, le.ErrFailedToGetUserIDFromToken, err)
+			return
+		}
+
+		webhookInput := &model.WebhookRequestData{}
+		if err = decodeAndValidateJSON(w, r, log, webhookInput); err != nil {
+			return
+		}
+
+		webhookInput.UserID = userID
+
+		webhookResp, err := c.usecase.CreateWebhook(ctx, webhookInput)
+
+		switch {
+		case errors.Is(err, le.ErrInvalidWebhookEventType):
+			handleResponseError(w, r, log, http.StatusBadRequest, le.ErrInvalidWebhookEventType)
+			return
+		case errors.Is(err, le.ErrInvalidWebhookURL):
+			handleResponseError(w, r, log, http.StatusBadRequest, le.ErrInvalidWebhookURL)
+			return
+		case err != nil:
+			handleInternalServerError(w, r, log, le.ErrFailedToCreateWebhook, err)
+			return
+		default:
+			handleResponseCreated(w, r, log, "webhook created", webhookResp, slog.String(key.WebhookID, webhookResp.ID))
+		}
+	}
+}
+
+func (c *webhookController) GetWebhookByID() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "webhook.controller.GetWebhookByID"
+
+		ctx := r.Context()
+		log := logger.LogWithRequest(c.logger, op, r)
+
+		userID, err := jwtoken.GetUserID(ctx)
+		if err != nil {
+			handleInternalServerError(w, r, log, le.ErrFailedToGetUserIDFromToken, err)
+			return
+		}
+
+		webhookID := chi.URLParam(r, key.WebhookID)
+		if webhookID == "" {
+			handleResponseError(w, r, log, http.StatusBadRequest, le.ErrEmptyQueryWebhookID)
+			return
+		}
+
+		webhookInput := model.WebhookRequestData{
+			ID:     webhookID,
+			UserID: userID,
+		}
+
+		webhookResp, err := c.usecase.GetWebhookByID(ctx, webhookInput)
+
+		switch {
+		case errors.Is(err, le.ErrWebhookNotFound):
+			handleResponseError(w, r, log, http.StatusNotFound, le.ErrWebhookNotFound)
+			return
+		case err != nil:
+			handleInternalServerError(w, r, log, le.ErrFailedToGetData, err)
+			return
+		default:
+			handleResponseSuccess(w, r, log, "webhook received", webhookResp, slog.String(key.WebhookID, webhookID))
+		}
+	}
+}
+
+func (c *webhookController) GetWebhooksByUserID() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "webhook.controller.GetWebhooksByUserID"
+
+		ctx := r.Context()
+		log := logger.LogWithRequest(c.logger, op, r)
+
+		userID, err := jwtoken.GetUserID(ctx)
+		if err != nil {
+			handleInternalServerError(w, r, log, le.ErrFailedToGetUserIDFromToken, err)
+			return
+		}
+
+		webhooksResp, err := c.usecase.GetWebhooksByUserID(ctx, userID)
+
+		switch {
+		case errors.Is(err, le.ErrNoWebhooksFound):
+			handleResponseError(w, r, log, http.StatusNotFound, le.ErrNoWebhooksFound)
+			return
+		case err != nil:
+			handleInternalServerError(w, r, log, le.ErrFailedToGetWebhooks, err)
+			return
+		default:
+			handleResponseSuccess(w, r, log, "webhooks found", webhooksResp,
+				slog.Int(key.Count, len(webhooksResp)),
+			)
+		}
+	}
+}
+
+func (c *webhookController) UpdateWebhook() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "webhook.controller.UpdateWebhook"
+
+		ctx := r.Context()
+		log := logger.LogWithRequest(c.logger, op, r)
+
+		userID, err := jwtoken.GetUserID(ctx)
+		if err != nil {
+			handleInternalServerError(w, r, log, le.ErrFailedToGetUserIDFromToken, err)
+			return
+		}
+
+		webhookID := chi.URLParam(r, key.WebhookID)
+		if webhookID == "" {
+			handleResponseError(w, r, log, http.StatusBadRequest, le.ErrEmptyQueryWebhookID)
+			return
+		}
+
+		webhookInput := &model.WebhookRequestData{}
+		if err = decodeAndValidateJSON(w, r, log, webhookInput); err != nil {
+			return
+		}
+
+		webhookInput.ID = webhookID
+		webhookInput.UserID = userID
+
+		webhookResp, err := c.usecase.UpdateWebhook(ctx, webhookInput)
+
+		switch {
+		case errors.Is(err, le.ErrInvalidWebhookEventType):
+			handleResponseError(w, r, log, http.StatusBadRequest, le.ErrInvalidWebhookEventType)
+			return
+		case errors.Is(err, le.ErrInvalidWebhookURL):
+			handleResponseError(w, r, log, http.StatusBadRequest, le.ErrInvalidWebhookURL)
+			return
+		case errors.Is(err, le.ErrWebhookNotFound):
+			handleResponseError(w, r, log, http.StatusNotFound, le.ErrWebhookNotFound)
+			return
+		case err != nil:
+			handleInternalServerError(w, r, log, le.ErrFailedToUpdateWebhook, err)
+			return
+		default:
+			handleResponseSuccess(w, r, log, "webhook updated", webhookResp, slog.String(key.WebhookID, webhookResp.ID))
+		}
+	}
+}
+
+func (c *webhookController) DeleteWebhook() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "webhook.controller.DeleteWebhook"
+
+		ctx := r.Context()
+		log := logger.LogWithRequest(c.logger, op, r)
+
+		userID, err := jwtoken.GetUserID(ctx)
+		if err != nil {
+			handleInternalServerError(w, r, log, le.ErrFailedToGetUserIDFromToken, err)
+			return
+		}
+
+		webhookID := chi.URLParam(r, key.WebhookID)
+		if webhookID == "" {
+			handleResponseError(w, r, log, http.StatusBadRequest, le.ErrEmptyQueryWebhookID)
+			return
+		}
+
+		webhookInput := model.WebhookRequestData{
+			ID:     webhookID,
+			UserID: userID,
+		}
+
+		err = c.usecase.DeleteWebhook(ctx, webhookInput)
+
+		switch {
+		case errors.Is(err, le.ErrWebhookNotFound):
+			handleResponseError(w, r, log, http.StatusNotFound, le.ErrWebhookNotFound)
+			return
+		case err != nil:
+			handleInternalServerError(w, r, log, le.ErrFailedToDeleteWebhook, err)
+			return
+		default:
+			handleResponseSuccess(w, r, log, "webhook deleted", webhookID, slog.String(key.WebhookID, webhookID))
+		}
+	}
+}
+
+func (c *webhookController) GetWebhookDeliveries() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "webhook.controller.GetWebhookDeliveries"
+
+		ctx := r.Context()
+		log := logger.LogWithRequest(c.logger, op, r)
+
+		userID, err := jwtoken.GetUserID(ctx)
+		if err != nil {
+			handleInternalServerError(w, r, log, le.ErrFailedToGetUserIDFromToken, err)
+			return
+		}
+
+		webhookID := chi.URLParam(r, key.WebhookID)
+		if webhookID == "" {
+			handleResponseError(w, r, log, http.StatusBadRequest, le.ErrEmptyQueryWebhookID)
+			return
+		}
+
+		pagination := ParseLimitAndAfterID(r)
+
+		webhookInput := model.WebhookRequestData{
+			ID:     webhookID,
+			UserID: userID,
+		}
+
+		deliveriesResp, err := c.usecase.GetWebhookDeliveries(ctx, webhookInput, pagination)
+
+		switch {
+		case errors.Is(err, le.ErrWebhookNotFound):
+			handleResponseError(w, r, log, http.StatusNotFound, le.ErrWebhookNotFound)
+			return
+		case errors.Is(err, le.ErrNoWebhookDeliveriesFound):
+			handleResponseError(w, r, log, http.StatusNotFound, le.ErrNoWebhookDeliveriesFound)
+			return
+		case err != nil:
+			handleInternalServerError(w, r, log, le.ErrFailedToGetData, err)
+			return
+		default:
+			handleResponseSuccess(w, r, log, "webhook deliveries found", deliveriesResp,
+				slog.String(key.WebhookID, webhookID),
+				slog.Int(key.Count, len(deliveriesResp)),
+			)
+		}
+	}
+}
+
+func (c *webhookController) SendTestEvent() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "webhook.controller.SendTestEvent"
+
+		ctx := r.Context()
+		log := logger.LogWithRequest(c.logger, op, r)
+
+		userID, err := jwtoken.GetUserID(ctx)
+		if err != nil {
+			handleInternalServerError(w, r, log, le.ErrFailedToGetUserIDFromToken, err)
+			return
+		}
+
+		webhookID := chi.URLParam(r, key.WebhookID)
+		if webhookID == "" {
+			handleResponseError(w, r, log, http.StatusBadRequest, le.ErrEmptyQueryWebhookID)
+			return
+		}
+
+		webhookInput := model.WebhookRequestData{
+			ID:     webhookID,
+			UserID: userID,
+		}
+
+		deliveryResp, err := c.usecase.SendTestEvent(ctx, webhookInput)
+
+		switch {
+		case errors.Is(err, le.ErrWebhookNotFound):
+			handleResponseError(w, r, log, http.StatusNotFound, le.ErrWebhookNotFound)
+			return
+		case err != nil:
+			handleInternalServerError(w, r, log, le.ErrFailedToSendWebhookEvent, err)
+			return
+		default:
+			// The delivery is returned even if the endpoint failed, the result is in the delivery status
+			handleResponseSuccess(w, r, log, "webhook test event sent", deliveryResp,
+				slog.String(key.WebhookID, webhookID),
+				slog.String("status", deliveryResp.Status.String()),
+			)
+		}
+	}
+}
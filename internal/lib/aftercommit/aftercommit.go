@@ -0,0 +1,45 @@
+// Package aftercommit defers the side effects of a change, like the events about it,
+// until the transaction storing the change is committed.
+package aftercommit
+
+import (
+	"context"
+	"sync"
+)
+
+type hooksKey struct{}
+
+type hooks struct {
+	mu  sync.Mutex
+	fns []func(ctx context.Context)
+}
+
+// Begin returns the context collecting the hooks of a transaction and the function running them.
+// The hooks are run with the given context, so they don't take part in the committed transaction
+func Begin(ctx context.Context) (context.Context, func()) {
+	h := &hooks{}
+
+	return context.WithValue(ctx, hooksKey{}, h), func() {
+		h.mu.Lock()
+		fns := h.fns
+		h.fns = nil
+		h.mu.Unlock()
+
+		for _, fn := range fns {
+			fn(ctx)
+		}
+	}
+}
+
+// Do runs fn after the commit of the transaction in the context, or right away if there is no transaction.
+// If the transaction is rolled back, fn is never run
+func Do(ctx context.Context, fn func(ctx context.Context)) {
+	if h, ok := ctx.Value(hooksKey{}).(*hooks); ok {
+		h.mu.Lock()
+		h.fns = append(h.fns, fn)
+		h.mu.Unlock()
+		return
+	}
+
+	fn(ctx)
+}
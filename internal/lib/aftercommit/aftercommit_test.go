@@ -0,0 +1,57 @@
+package aftercommit
+
+import (
+	"context"
+	"testing"
+)
+
+type valueKey struct{}
+
+func TestDoWithoutTransaction(t *testing.T) {
+	var called bool
+
+	Do(context.Background(), func(context.Context) {
+		called = true
+	})
+
+	if !called {
+		t.Error("Do() didn't run fn without a transaction")
+	}
+}
+
+func TestDoDefersUntilCommit(t *testing.T) {
+	outer := context.WithValue(context.Background(), valueKey{}, "outer")
+
+	txCtx, commit := Begin(outer)
+
+	var calls []string
+
+	Do(txCtx, func(ctx context.Context) {
+		if _, ok := ctx.Value(hooksKey{}).(*hooks); ok {
+			t.Error("hook is run with the context of the transaction")
+		}
+		calls = append(calls, ctx.Value(valueKey{}).(string))
+	})
+	Do(txCtx, func(context.Context) {
+		calls = append(calls, "second")
+	})
+
+	if len(calls) != 0 {
+		t.Fatalf("hooks run before the commit: %v", calls)
+	}
+
+	commit()
+	commit()
+
+	if len(calls) != 2 || calls[0] != "outer" || calls[1] != "second" {
+		t.Errorf("calls = %v, want [outer second]", calls)
+	}
+}
+
+func TestDoDiscardedOnRollback(t *testing.T) {
+	txCtx, _ := Begin(context.Background())
+
+	Do(txCtx, func(context.Context) {
+		t.Error("hook of the rolled back transaction is run")
+	})
+}
@@ -0,0 +1,38 @@
+package broker
+
+import (
+	"context"
+	"errors"
+
+	"github.com/rshelekhov/reframed/internal/model"
+	"github.com/rshelekhov/reframed/internal/port"
+)
+
+// Publisher receives every published event, e.g. to queue webhook deliveries
+type Publisher interface {
+	Publish(ctx context.Context, event model.Event) error
+}
+
+// FanoutBroker delivers events to the subscribers of the underlying broker
+// and passes them to the additional publishers
+type FanoutBroker struct {
+	port.EventBroker
+	publishers []Publisher
+}
+
+func NewFanoutBroker(broker port.EventBroker, publishers ...Publisher) *FanoutBroker {
+	return &FanoutBroker{
+		EventBroker: broker,
+		publishers:  publishers,
+	}
+}
+
+func (b *FanoutBroker) Publish(ctx context.Context, event model.Event) error {
+	errs := []error{b.EventBroker.Publish(ctx, event)}
+
+	for _, publisher := range b.publishers {
+		errs = append(errs, publisher.Publish(ctx, event))
+	}
+
+	return errors.Join(errs...)
+}
@@ -0,0 +1,77 @@
+// Package broker provides event brokers used to push change events to the connected clients.
+package broker
+
+import (
+	"context"
+	"sync"
+
+	"github.com/rshelekhov/reframed/internal/model"
+)
+
+// subscriberBufferSize is the number of events buffered for a subscriber
+const subscriberBufferSize = 64
+
+// MemoryBroker is an in-process event broker. It only delivers events to the sessions
+// connected to the same instance of the application
+type MemoryBroker struct {
+	mu          sync.RWMutex
+	subscribers map[string]map[*subscriber]struct{}
+}
+
+type subscriber struct {
+	events chan model.Event
+}
+
+func NewMemoryBroker() *MemoryBroker {
+	return &MemoryBroker{
+		subscribers: make(map[string]map[*subscriber]struct{}),
+	}
+}
+
+// Publish sends the event to all subscribers of the user. Slow subscribers
+// with a full buffer miss the event instead of blocking the publisher
+func (b *MemoryBroker) Publish(_ context.Context, event model.Event) error {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	for s := range b.subscribers[event.UserID] {
+		select {
+		case s.events <- event:
+		default:
+		}
+	}
+
+	return nil
+}
+
+func (b *MemoryBroker) Subscribe(ctx context.Context, userID string) (<-chan model.Event, error) {
+	s := &subscriber{
+		events: make(chan model.Event, subscriberBufferSize),
+	}
+
+	b.mu.Lock()
+	if b.subscribers[userID] == nil {
+		b.subscribers[userID] = make(map[*subscriber]struct{})
+	}
+	b.subscribers[userID][s] = struct{}{}
+	b.mu.Unlock()
+
+	go func() {
+		<-ctx.Done()
+		b.unsubscribe(userID, s)
+	}()
+
+	return s.events, nil
+}
+
+func (b *MemoryBroker) unsubscribe(userID string, s *subscriber) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	delete(b.subscribers[userID], s)
+	if len(b.subscribers[userID]) == 0 {
+		delete(b.subscribers, userID)
+	}
+
+	close(s.events)
+}
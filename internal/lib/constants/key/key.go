@@ -15,11 +15,37 @@ const (
 	//  entities keys
 	// ===========================================================================
 
-	UserID    = "user_id"
-	Email     = "email"
-	ListID    = "list_id"
-	TaskID    = "task_id"
-	HeadingID = "heading_id"
+	UserID          = "user_id"
+	Email           = "email"
+	TimeZone        = "time_zone"
+	ListID          = "list_id"
+	TaskID          = "task_id"
+	HeadingID       = "heading_id"
+	WebhookID       = "webhook_id"
+	RuleID          = "rule_id"
+	BlockedByTaskID = "blocked_by_task_id"
+	TemplateID      = "template_id"
+	AreaID          = "area_id"
+	GoalID          = "goal_id"
+	KeyResultID     = "key_result_id"
+	MemberID        = "member_id"
+	InvitationID    = "invitation_id"
+	AssigneeID      = "assignee_id"
+	CommentID       = "comment_id"
+	EntityType      = "entity_type"
+	EntityID        = "entity_id"
+	UndoToken       = "undo_token"
+	ReviewID        = "review_id"
+	ReviewItemID    = "item_id"
+	HabitID         = "habit_id"
+	AttachmentID    = "attachment_id"
+	Date            = "date"
+
+	// ===========================================================================
+	//  idempotency keys
+	// ===========================================================================
+
+	IdempotencyKey = "idempotency_key"
 
 	// ===========================================================================
 	//  pagination keys
@@ -29,4 +55,32 @@ const (
 	AfterID   = "after_id"
 	AfterDate = "after_date"
 	Limit     = "limit"
+
+	// ===========================================================================
+	//  filter keys
+	// ===========================================================================
+
+	HideBlocked      = "hide_blocked"
+	IncludeCompleted = "include_completed"
+	ShiftDays        = "shift_days"
+	Nested           = "nested"
+	DeleteMode       = "mode"
+	IncludeArchived  = "include_archived"
+	CompleteTasks    = "complete_tasks"
+	Days             = "days"
+	StaleDays        = "stale_days"
+	Period           = "period"
+	From             = "from"
+	To               = "to"
+
+	// ===========================================================================
+	//  attachment keys
+	// ===========================================================================
+
+	File        = "file"
+	FileName    = "file_name"
+	ContentType = "content_type"
+	Size        = "size"
+	Expires     = "expires"
+	Signature   = "signature"
 )
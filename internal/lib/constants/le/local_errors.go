@@ -42,6 +42,7 @@ const (
 	ErrFailedToGetData          LocalError = "failed to get data"
 	ErrFailedToValidateData     LocalError = "failed to validate data"
 	ErrFailedToParseQueryParams LocalError = "failed to parse query params"
+	ErrInvalidMergePatch        LocalError = "invalid merge patch"
 
 	// ===========================================================================
 	//   user errors
@@ -60,14 +61,27 @@ const (
 	//   list errors
 	// ===========================================================================
 
-	ErrNoListsFound             LocalError = "no lists found"
-	ErrListNotFound             LocalError = "list not found"
-	ErrFailedToCreateList       LocalError = "failed to create list"
-	ErrFailedToGetLists         LocalError = "failed to get lists"
-	ErrFailedToGetDefaultListID LocalError = "failed to get default list ID"
-	ErrFailedToUpdateList       LocalError = "failed to update list"
-	ErrFailedToDeleteList       LocalError = "failed to delete list"
-	ErrEmptyQueryListID         LocalError = "list ID is empty in query"
+	ErrNoListsFound                  LocalError = "no lists found"
+	ErrListNotFound                  LocalError = "list not found"
+	ErrFailedToCreateList            LocalError = "failed to create list"
+	ErrFailedToGetLists              LocalError = "failed to get lists"
+	ErrFailedToGetDefaultListID      LocalError = "failed to get default list ID"
+	ErrFailedToUpdateList            LocalError = "failed to update list"
+	ErrFailedToDeleteList            LocalError = "failed to delete list"
+	ErrEmptyQueryListID              LocalError = "list ID is empty in query"
+	ErrCannotDeleteDefaultList       LocalError = "cannot delete default list"
+	ErrListNotEmpty                  LocalError = "list is not empty"
+	ErrInvalidDeleteMode             LocalError = "invalid delete mode"
+	ErrCannotConvertDefaultList      LocalError = "cannot convert default list"
+	ErrCannotConvertListToItsHeading LocalError = "cannot convert list into its own heading"
+	ErrFailedToConvertList           LocalError = "failed to convert list to heading"
+	ErrInvalidListDateRange          LocalError = "list deadline is before its start date"
+	ErrNoArchivedListsFound          LocalError = "no archived lists found"
+	ErrCannotArchiveDefaultList      LocalError = "cannot complete or archive default list"
+	ErrListNotArchived               LocalError = "list is not completed or archived"
+	ErrFailedToCompleteList          LocalError = "failed to complete list"
+	ErrFailedToArchiveList           LocalError = "failed to archive list"
+	ErrFailedToRestoreList           LocalError = "failed to restore list"
 
 	// ===========================================================================
 	//   heading errors
@@ -81,21 +95,31 @@ const (
 	ErrFailedToMoveHeading         LocalError = "failed to move heading"
 	ErrFailedToDeleteHeading       LocalError = "failed to delete heading"
 	ErrEmptyQueryHeadingID         LocalError = "heading ID is empty in query"
+	ErrCannotDeleteDefaultHeading  LocalError = "cannot delete default heading"
+	ErrHeadingNotEmpty             LocalError = "heading is not empty"
+	ErrCannotConvertDefaultHeading LocalError = "cannot convert default heading"
+	ErrFailedToConvertHeading      LocalError = "failed to convert heading to list"
 
 	// ===========================================================================
 	//   task errors
 	// ===========================================================================
 
-	ErrNoTasksFound         LocalError = "no tasks found"
-	ErrTaskNotFound         LocalError = "task not found"
-	ErrTaskStatusNotFound   LocalError = "task status not found"
-	ErrFailedToCreateTask   LocalError = "failed to create task"
-	ErrFailedToUpdateTask   LocalError = "failed to update task"
-	ErrFailedToCompleteTask LocalError = "failed to complete task"
-	ErrFailedToMoveTask     LocalError = "failed to move task"
-	ErrFailedToDeleteTask   LocalError = "failed to delete task"
-	ErrEmptyQueryTaskID     LocalError = "task ID is empty in query"
-	ErrInvalidTaskTimeRange LocalError = "invalid task time range"
+	ErrNoTasksFound            LocalError = "no tasks found"
+	ErrTaskNotFound            LocalError = "task not found"
+	ErrTaskStatusNotFound      LocalError = "task status not found"
+	ErrFailedToCreateTask      LocalError = "failed to create task"
+	ErrFailedToUpdateTask      LocalError = "failed to update task"
+	ErrFailedToCompleteTask    LocalError = "failed to complete task"
+	ErrFailedToMoveTask        LocalError = "failed to move task"
+	ErrFailedToDeleteTask      LocalError = "failed to delete task"
+	ErrEmptyQueryTaskID        LocalError = "task ID is empty in query"
+	ErrInvalidTaskTimeRange    LocalError = "invalid task time range"
+	ErrInvalidSnoozeTime       LocalError = "snooze time must be in the future"
+	ErrFailedToSnoozeTask      LocalError = "failed to snooze task"
+	ErrFailedToUnsnoozeTask    LocalError = "failed to unsnooze task"
+	ErrEmptyDailyPlan          LocalError = "daily plan has no tasks"
+	ErrFailedToCommitDailyPlan LocalError = "failed to commit daily plan"
+	ErrFailedToPlanTask        LocalError = "failed to plan task for today"
 
 	// ===========================================================================
 	//   tag errors
@@ -107,6 +131,249 @@ const (
 	ErrFailedToDeleteTag      LocalError = "failed to delete tag"
 	ErrFailedToLinkTagsToTask LocalError = "failed to link tags to task"
 
+	// ===========================================================================
+	//   idempotency errors
+	// ===========================================================================
+
+	ErrIdempotencyKeyNotFound        LocalError = "idempotency key not found"
+	ErrIdempotencyKeyTooLong         LocalError = "idempotency key is too long"
+	ErrIdempotencyKeyReused          LocalError = "idempotency key was already used for a different request"
+	ErrIdempotencyKeyInProgress      LocalError = "request with this idempotency key is still in progress"
+	ErrIdempotentBodyTooLarge        LocalError = "request body is too large to be sent with idempotency key"
+	ErrFailedToProcessIdempotencyKey LocalError = "failed to process idempotency key"
+
+	// ===========================================================================
+	//   event errors
+	// ===========================================================================
+
+	ErrStreamingNotSupported     LocalError = "streaming is not supported"
+	ErrFailedToSubscribeToEvents LocalError = "failed to subscribe to events"
+	ErrWebSocketOriginNotAllowed LocalError = "websocket origin is not allowed"
+
+	// ===========================================================================
+	//   webhook errors
+	// ===========================================================================
+
+	ErrNoWebhooksFound          LocalError = "no webhooks found"
+	ErrWebhookNotFound          LocalError = "webhook not found"
+	ErrNoWebhookDeliveriesFound LocalError = "no webhook deliveries found"
+	ErrInvalidWebhookEventType  LocalError = "invalid webhook event type"
+	ErrInvalidWebhookURL        LocalError = "webhook URL must be an absolute http or https URL"
+	ErrWebhookDisabled          LocalError = "webhook is disabled"
+	ErrFailedToCreateWebhook    LocalError = "failed to create webhook"
+	ErrFailedToGetWebhooks      LocalError = "failed to get webhooks"
+	ErrFailedToUpdateWebhook    LocalError = "failed to update webhook"
+	ErrFailedToDeleteWebhook    LocalError = "failed to delete webhook"
+	ErrFailedToSendWebhookEvent LocalError = "failed to send webhook event"
+	ErrEmptyQueryWebhookID      LocalError = "webhook ID is empty in query"
+
+	// ===========================================================================
+	//   task dependency errors
+	// ===========================================================================
+
+	ErrNoTaskDependenciesFound      LocalError = "no task dependencies found"
+	ErrTaskDependencyNotFound       LocalError = "task dependency not found"
+	ErrTaskDependencyAlreadyExists  LocalError = "task dependency already exists"
+	ErrTaskCannotBlockItself        LocalError = "task can't block itself"
+	ErrTaskDependencyCycle          LocalError = "task dependency creates a cycle"
+	ErrFailedToCreateTaskDependency LocalError = "failed to create task dependency"
+	ErrFailedToDeleteTaskDependency LocalError = "failed to delete task dependency"
+	ErrEmptyQueryBlockedByTaskID    LocalError = "blocked by task ID is empty in query"
+
+	// ===========================================================================
+	//   rule errors
+	// ===========================================================================
+
+	ErrNoRulesFound         LocalError = "no rules found"
+	ErrRuleNotFound         LocalError = "rule not found"
+	ErrInvalidRuleCondition LocalError = "invalid rule condition"
+	ErrInvalidRuleAction    LocalError = "invalid rule action"
+	ErrFailedToCreateRule   LocalError = "failed to create rule"
+	ErrFailedToGetRules     LocalError = "failed to get rules"
+	ErrFailedToUpdateRule   LocalError = "failed to update rule"
+	ErrFailedToDeleteRule   LocalError = "failed to delete rule"
+	ErrEmptyQueryRuleID     LocalError = "rule ID is empty in query"
+
+	// ===========================================================================
+	//   template errors
+	// ===========================================================================
+
+	ErrNoTemplatesFound            LocalError = "no templates found"
+	ErrTemplateNotFound            LocalError = "template not found"
+	ErrInvalidTemplateSource       LocalError = "template must be created from either a list or a task"
+	ErrFailedToCreateTemplate      LocalError = "failed to create template"
+	ErrFailedToGetTemplates        LocalError = "failed to get templates"
+	ErrFailedToDeleteTemplate      LocalError = "failed to delete template"
+	ErrFailedToInstantiateTemplate LocalError = "failed to instantiate template"
+	ErrEmptyQueryTemplateID        LocalError = "template ID is empty in query"
+
+	// ===========================================================================
+	//   duplicate errors
+	// ===========================================================================
+
+	ErrInvalidDuplicateOptions  LocalError = "invalid duplicate options"
+	ErrFailedToDuplicateTask    LocalError = "failed to duplicate task"
+	ErrFailedToDuplicateHeading LocalError = "failed to duplicate heading"
+	ErrFailedToDuplicateList    LocalError = "failed to duplicate list"
+
+	// ===========================================================================
+	//   area errors
+	// ===========================================================================
+
+	ErrNoAreasFound         LocalError = "no areas found"
+	ErrAreaNotFound         LocalError = "area not found"
+	ErrFailedToCreateArea   LocalError = "failed to create area"
+	ErrFailedToGetAreas     LocalError = "failed to get areas"
+	ErrFailedToUpdateArea   LocalError = "failed to update area"
+	ErrFailedToDeleteArea   LocalError = "failed to delete area"
+	ErrFailedToReorderAreas LocalError = "failed to reorder areas"
+	ErrFailedToReorderLists LocalError = "failed to reorder lists"
+	ErrFailedToMoveList     LocalError = "failed to move list"
+	ErrEmptyQueryAreaID     LocalError = "area ID is empty in query"
+
+	// ===========================================================================
+	//   goal errors
+	// ===========================================================================
+
+	ErrNoGoalsFound            LocalError = "no goals found"
+	ErrGoalNotFound            LocalError = "goal not found"
+	ErrKeyResultNotFound       LocalError = "key result not found"
+	ErrInvalidKeyResult        LocalError = "target value of the manual key result must differ from its start value"
+	ErrFailedToCreateGoal      LocalError = "failed to create goal"
+	ErrFailedToGetGoals        LocalError = "failed to get goals"
+	ErrFailedToUpdateGoal      LocalError = "failed to update goal"
+	ErrFailedToDeleteGoal      LocalError = "failed to delete goal"
+	ErrFailedToCreateKeyResult LocalError = "failed to create key result"
+	ErrFailedToUpdateKeyResult LocalError = "failed to update key result"
+	ErrFailedToDeleteKeyResult LocalError = "failed to delete key result"
+	ErrFailedToLinkGoal        LocalError = "failed to link goal"
+	ErrFailedToUnlinkGoal      LocalError = "failed to unlink goal"
+	ErrEmptyQueryGoalID        LocalError = "goal ID is empty in query"
+	ErrEmptyQueryKeyResultID   LocalError = "key result ID is empty in query"
+
+	// ===========================================================================
+	//   sharing errors
+	// ===========================================================================
+
+	ErrNoPermissionToEditList        LocalError = "no permission to edit list"
+	ErrNoPermissionToManageList      LocalError = "no permission to manage list"
+	ErrListMemberNotFound            LocalError = "list member not found"
+	ErrListInvitationNotFound        LocalError = "list invitation not found"
+	ErrNoListInvitationsFound        LocalError = "no list invitations found"
+	ErrAlreadyListMember             LocalError = "user is already a member of the list"
+	ErrCannotInviteYourself          LocalError = "cannot invite yourself"
+	ErrCannotShareDefaultList        LocalError = "cannot share default list"
+	ErrLastListOwner                 LocalError = "list must have at least one owner"
+	ErrFailedToInviteToList          LocalError = "failed to invite to list"
+	ErrFailedToGetListMembers        LocalError = "failed to get list members"
+	ErrFailedToUpdateListMember      LocalError = "failed to update list member"
+	ErrFailedToRemoveListMember      LocalError = "failed to remove list member"
+	ErrFailedToGetListInvitations    LocalError = "failed to get list invitations"
+	ErrFailedToAcceptListInvitation  LocalError = "failed to accept list invitation"
+	ErrFailedToDeclineListInvitation LocalError = "failed to decline list invitation"
+	ErrFailedToRevokeListInvitation  LocalError = "failed to revoke list invitation"
+	ErrEmptyQueryMemberID            LocalError = "member ID is empty in query"
+	ErrEmptyQueryInvitationID        LocalError = "invitation ID is empty in query"
+	ErrAssigneeNotListMember         LocalError = "assignee is not a member of the list"
+	ErrFailedToAssignTask            LocalError = "failed to assign task"
+	ErrFailedToUnassignTask          LocalError = "failed to unassign task"
+
+	// ===========================================================================
+	//   comment errors
+	// ===========================================================================
+
+	ErrCommentNotFound           LocalError = "comment not found"
+	ErrNoCommentsFound           LocalError = "no comments found"
+	ErrNoPermissionToEditComment LocalError = "no permission to edit comment"
+	ErrFailedToCreateComment     LocalError = "failed to create comment"
+	ErrFailedToGetComments       LocalError = "failed to get comments"
+	ErrFailedToUpdateComment     LocalError = "failed to update comment"
+	ErrFailedToDeleteComment     LocalError = "failed to delete comment"
+	ErrEmptyQueryCommentID       LocalError = "comment ID is empty in query"
+
+	// ===========================================================================
+	//   audit errors
+	// ===========================================================================
+
+	ErrNoAuditEntriesFound     LocalError = "no audit entries found"
+	ErrInvalidAuditEntityType  LocalError = "invalid audit entity type"
+	ErrFailedToGetAuditEntries LocalError = "failed to get audit entries"
+	ErrEmptyQueryEntityType    LocalError = "entity type is empty in query"
+	ErrEmptyQueryEntityID      LocalError = "entity ID is empty in query"
+
+	// ===========================================================================
+	//   undo errors
+	// ===========================================================================
+
+	ErrUndoTokenNotFound     LocalError = "undo token not found"
+	ErrUndoTokenExpired      LocalError = "undo token expired"
+	ErrUndoTokenAlreadyUsed  LocalError = "operation already undone"
+	ErrUndoConflict          LocalError = "entities were changed after the operation, it can't be undone"
+	ErrFailedToUndoOperation LocalError = "failed to undo operation"
+	ErrEmptyQueryUndoToken   LocalError = "undo token is empty in query"
+
+	// ===========================================================================
+	//   review errors
+	// ===========================================================================
+
+	ErrReviewNotFound          LocalError = "review not found"
+	ErrReviewItemNotFound      LocalError = "review item not found"
+	ErrNoReviewInProgress      LocalError = "no review in progress"
+	ErrReviewAlreadyStarted    LocalError = "review already started"
+	ErrReviewCompleted         LocalError = "review already completed"
+	ErrInvalidReviewAction     LocalError = "invalid review action"
+	ErrFailedToStartReview     LocalError = "failed to start review"
+	ErrFailedToGetReview       LocalError = "failed to get review"
+	ErrFailedToActOnReviewItem LocalError = "failed to act on review item"
+	ErrFailedToCompleteReview  LocalError = "failed to complete review"
+	ErrEmptyQueryReviewID      LocalError = "review ID is empty in query"
+	ErrEmptyQueryReviewItemID  LocalError = "review item ID is empty in query"
+
+	// ===========================================================================
+	//   stats errors
+	// ===========================================================================
+
+	ErrInvalidStatsPeriod LocalError = "invalid stats period, use day, week or month"
+	ErrInvalidStatsRange  LocalError = "invalid stats range, from and to should be dates, from should not be after to and the range should not exceed 366 periods"
+	ErrFailedToGetStats   LocalError = "failed to get stats"
+
+	// ===========================================================================
+	//   habit errors
+	// ===========================================================================
+
+	ErrHabitNotFound           LocalError = "habit not found"
+	ErrNoHabitsFound           LocalError = "no habits found"
+	ErrHabitCheckInNotFound    LocalError = "habit check-in not found"
+	ErrInvalidHabitCheckInDate LocalError = "invalid check-in date, it should be a date not after today"
+	ErrInvalidHeatmapRange     LocalError = "invalid heatmap range, from and to should be dates and from should not be after to"
+	ErrFailedToCreateHabit     LocalError = "failed to create habit"
+	ErrFailedToGetHabits       LocalError = "failed to get habits"
+	ErrFailedToUpdateHabit     LocalError = "failed to update habit"
+	ErrFailedToDeleteHabit     LocalError = "failed to delete habit"
+	ErrFailedToCheckInHabit    LocalError = "failed to check in habit"
+	ErrFailedToGetHabitHeatmap LocalError = "failed to get habit heatmap"
+	ErrEmptyQueryHabitID       LocalError = "habit ID is empty in query"
+
+	// ===========================================================================
+	//   attachment errors
+	// ===========================================================================
+
+	ErrAttachmentNotFound         LocalError = "attachment not found"
+	ErrNoAttachmentsFound         LocalError = "no attachments found"
+	ErrAttachmentFileRequired     LocalError = "file is required, it should be sent in the file field of multipart form"
+	ErrAttachmentTooLarge         LocalError = "file is too large"
+	ErrAttachmentTypeNotAllowed   LocalError = "file type is not allowed"
+	ErrAttachmentQuotaExceeded    LocalError = "attachment quota exceeded"
+	ErrBlobNotFound               LocalError = "blob not found"
+	ErrInvalidBlobSignature       LocalError = "invalid download link signature"
+	ErrBlobURLExpired             LocalError = "download link expired"
+	ErrFailedToUploadAttachment   LocalError = "failed to upload attachment"
+	ErrFailedToGetAttachments     LocalError = "failed to get attachments"
+	ErrFailedToDeleteAttachment   LocalError = "failed to delete attachment"
+	ErrFailedToGetAttachmentUsage LocalError = "failed to get attachment usage"
+	ErrFailedToDownloadBlob       LocalError = "failed to download file"
+	ErrEmptyQueryAttachmentID     LocalError = "attachment ID is empty in query"
+
 	// ===========================================================================
 	//   other errors
 	// ===========================================================================
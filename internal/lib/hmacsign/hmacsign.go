@@ -0,0 +1,35 @@
+// Package hmacsign signs outbound payloads with HMAC-SHA256, so receivers
+// can check that the payload was sent by us and wasn't changed.
+package hmacsign
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"strconv"
+	"strings"
+)
+
+const signaturePrefix = "sha256="
+
+// Sign returns the signature of the payload sent at the timestamp (unix seconds).
+// The signed message is "<timestamp>.<payload>", so a captured request can't be replayed
+// with another timestamp
+func Sign(secret string, timestamp int64, payload []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+
+	mac.Write([]byte(strconv.FormatInt(timestamp, 10)))
+	mac.Write([]byte("."))
+	mac.Write(payload)
+
+	return signaturePrefix + hex.EncodeToString(mac.Sum(nil))
+}
+
+// Verify checks the signature in constant time
+func Verify(secret, signature string, timestamp int64, payload []byte) bool {
+	if !strings.HasPrefix(signature, signaturePrefix) {
+		return false
+	}
+
+	return hmac.Equal([]byte(signature), []byte(Sign(secret, timestamp, payload)))
+}
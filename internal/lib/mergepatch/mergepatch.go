@@ -0,0 +1,62 @@
+// Package mergepatch implements JSON Merge Patch as described in RFC 7396.
+package mergepatch
+
+import (
+	"encoding/json"
+	"errors"
+)
+
+var ErrPatchIsNotObject = errors.New("merge patch must be a JSON object")
+
+// Apply applies the merge patch to the original JSON document and returns the result.
+// Members of the patch set to null are removed from the document, objects are merged
+// recursively, and any other value replaces the original one.
+func Apply(original, patch []byte) ([]byte, error) {
+	var patchDoc any
+	if err := json.Unmarshal(patch, &patchDoc); err != nil {
+		return nil, err
+	}
+
+	if _, ok := patchDoc.(map[string]any); !ok {
+		return nil, ErrPatchIsNotObject
+	}
+
+	var originalDoc any
+	if len(original) > 0 {
+		if err := json.Unmarshal(original, &originalDoc); err != nil {
+			return nil, err
+		}
+	}
+
+	return json.Marshal(merge(originalDoc, patchDoc))
+}
+
+// IsObject reports whether the data is a JSON object, i.e. a valid merge patch
+func IsObject(data []byte) bool {
+	var doc map[string]json.RawMessage
+
+	return json.Unmarshal(data, &doc) == nil && doc != nil
+}
+
+func merge(target, patch any) any {
+	patchObj, ok := patch.(map[string]any)
+	if !ok {
+		return patch
+	}
+
+	targetObj, ok := target.(map[string]any)
+	if !ok {
+		targetObj = make(map[string]any, len(patchObj))
+	}
+
+	for name, value := range patchObj {
+		if value == nil {
+			delete(targetObj, name)
+			continue
+		}
+
+		targetObj[name] = merge(targetObj[name], value)
+	}
+
+	return targetObj
+}
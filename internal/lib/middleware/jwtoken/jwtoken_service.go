@@ -71,11 +71,13 @@ var (
 	ErrUnexpectedSigningMethod  = errors.New("unexpected signing method")
 	ErrNoTokenFoundInCtx        = errors.New("token not found in context")
 	ErrUserIDNotFoundInCtx      = errors.New("user id not found in context")
+	ErrDeviceIDNotFoundInCtx    = errors.New("device id not found in context")
 	ErrFailedToParseTokenClaims = errors.New("failed to parse token claims from context")
 )
 
 const (
-	ContextUserID = "user_id"
+	ContextUserID   = "user_id"
+	ContextDeviceID = "device_id"
 )
 
 func (j *TokenService) NewAccessToken(additionalClaims map[string]interface{}) (string, error) {
@@ -112,8 +114,7 @@ func (j *TokenService) Verify(findTokenFns ...func(r *http.Request) string) func
 				return
 			}
 
-			ctx := r.Context()
-			ctx = context.WithValue(ctx, TokenCtxKey, token)
+			ctx := WithToken(r.Context(), token)
 
 			next.ServeHTTP(w, r.WithContext(ctx))
 		})
@@ -257,6 +258,11 @@ func GetTokenFromQuery(r *http.Request) string {
 	return r.URL.Query().Get("jwtoken")
 }
 
+// WithToken returns a copy of the context with the token stored in it
+func WithToken(ctx context.Context, token *jwt.Token) context.Context {
+	return context.WithValue(ctx, TokenCtxKey, token)
+}
+
 func GetTokenFromContext(ctx context.Context) (*jwt.Token, error) {
 	token, ok := ctx.Value(TokenCtxKey).(*jwt.Token)
 	if !ok {
@@ -294,6 +300,21 @@ func GetUserID(ctx context.Context) (string, error) {
 	return userID.(string), nil
 }
 
+// GetDeviceID returns the device of the session the access token was issued for
+func GetDeviceID(ctx context.Context) (string, error) {
+	claims, err := GetClaimsFromToken(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	deviceID, ok := claims[ContextDeviceID].(string)
+	if !ok {
+		return "", ErrDeviceIDNotFoundInCtx
+	}
+
+	return deviceID, nil
+}
+
 func SetTokenCookie(w http.ResponseWriter, name, value, domain, path string, expiresAt time.Time, httpOnly bool) {
 	http.SetCookie(w, &http.Cookie{
 		Name:     name,
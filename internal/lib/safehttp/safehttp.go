@@ -0,0 +1,89 @@
+// Package safehttp provides an HTTP client for requests to URLs given by users.
+// The client only connects to public addresses, so a user can't make the server
+// reach the services of the internal network or the cloud metadata endpoint.
+package safehttp
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/netip"
+	"syscall"
+	"time"
+)
+
+var ErrAddressNotAllowed = errors.New("address is not allowed")
+
+// reservedPrefixes are the ranges not covered by the checks of netip.Addr that aren't routable on the internet
+var reservedPrefixes = []netip.Prefix{
+	netip.MustParsePrefix("0.0.0.0/8"),     // "this network"
+	netip.MustParsePrefix("100.64.0.0/10"), // carrier-grade NAT
+}
+
+// NewClient returns the client that refuses to connect to loopback, private, link-local
+// and other non-public addresses and doesn't follow redirects.
+// The addresses are checked after DNS resolution, right before connecting,
+// so a hostname resolving to an internal address is rejected too
+func NewClient(timeout time.Duration) *http.Client {
+	dialer := &net.Dialer{
+		Timeout: timeout,
+		Control: func(network, address string, _ syscall.RawConn) error {
+			return checkAddress(address)
+		},
+	}
+
+	return &http.Client{
+		Timeout: timeout,
+		Transport: &http.Transport{
+			// The proxy from the environment would connect on our behalf and bypass the dialer check
+			Proxy:                 nil,
+			DialContext:           dialer.DialContext,
+			ForceAttemptHTTP2:     true,
+			MaxIdleConns:          100,
+			IdleConnTimeout:       90 * time.Second,
+			TLSHandshakeTimeout:   10 * time.Second,
+			ExpectContinueTimeout: 1 * time.Second,
+		},
+		// A redirect could point to an internal address the user isn't allowed to reach,
+		// the redirect response is returned as is
+		CheckRedirect: func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+}
+
+func checkAddress(address string) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+
+	addr, err := netip.ParseAddr(host)
+	if err != nil {
+		return err
+	}
+
+	if !IsPublicAddr(addr) {
+		return fmt.Errorf("%w: %s", ErrAddressNotAllowed, addr)
+	}
+
+	return nil
+}
+
+// IsPublicAddr reports whether the address can be reached on the internet
+func IsPublicAddr(addr netip.Addr) bool {
+	addr = addr.Unmap()
+
+	if !addr.IsGlobalUnicast() || addr.IsPrivate() {
+		return false
+	}
+
+	for _, prefix := range reservedPrefixes {
+		if prefix.Contains(addr) {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,44 @@
+package model
+
+import (
+	"time"
+)
+
+// Area DB model
+type (
+	Area struct {
+		ID        string    `db:"id"`
+		Title     string    `db:"title"`
+		Position  int       `db:"position"`
+		UserID    string    `db:"user_id"`
+		UpdatedAt time.Time `db:"updated_at"`
+		DeletedAt time.Time `db:"deleted_at"`
+	}
+
+	AreaRequestData struct {
+		ID     string `json:"id"`
+		Title  string `json:"title" validate:"required"`
+		UserID string `json:"user_id"`
+	}
+
+	AreaResponseData struct {
+		ID        string             `json:"id"`
+		Title     string             `json:"title"`
+		Position  int                `json:"position"`
+		UserID    string             `json:"user_id"`
+		Lists     []ListResponseData `json:"lists,omitempty"`
+		UpdatedAt time.Time          `json:"updated_at"`
+	}
+
+	// ListsGroupedByAreas contains the areas with their lists, and the lists which aren't in any area
+	ListsGroupedByAreas struct {
+		Areas []AreaResponseData `json:"areas"`
+		Lists []ListResponseData `json:"lists"`
+	}
+
+	// ReorderRequestData sets the positions of the areas or the lists in the order of their IDs
+	ReorderRequestData struct {
+		IDs    []string `json:"ids" validate:"required,min=1"`
+		UserID string   `json:"user_id"`
+	}
+)
@@ -0,0 +1,74 @@
+package model
+
+import (
+	"io"
+	"time"
+)
+
+// Attachment DB model
+type (
+	Attachment struct {
+		ID          string    `db:"id"`
+		TaskID      string    `db:"task_id"`
+		FileName    string    `db:"file_name"`
+		ContentType string    `db:"content_type"`
+		Size        int64     `db:"size"`
+		BlobKey     string    `db:"blob_key"`
+		UserID      string    `db:"user_id"`
+		CreatedAt   time.Time `db:"created_at"`
+		DeletedAt   time.Time `db:"deleted_at"`
+	}
+
+	AttachmentRequestData struct {
+		ID     string `json:"id"`
+		TaskID string `json:"task_id"`
+		UserID string `json:"user_id"`
+	}
+
+	// AttachmentUploadData is the file uploaded to the task. The content type of the file
+	// is sniffed from its content, the one sent by the client isn't trusted
+	AttachmentUploadData struct {
+		TaskID   string
+		FileName string
+		Size     int64
+		Content  io.Reader
+		UserID   string
+	}
+
+	AttachmentResponseData struct {
+		ID          string `json:"id"`
+		TaskID      string `json:"task_id"`
+		FileName    string `json:"file_name"`
+		ContentType string `json:"content_type"`
+		Size        int64  `json:"size"`
+		// URL is the signed link to download the file, it works without authorization until URLExpiresAt
+		URL          string    `json:"url"`
+		URLExpiresAt time.Time `json:"url_expires_at"`
+		UserID       string    `json:"user_id"`
+		CreatedAt    time.Time `json:"created_at"`
+	}
+
+	// AttachmentUsageResponseData is the space taken by the files uploaded by the user, in bytes
+	AttachmentUsageResponseData struct {
+		Used      int64 `json:"used"`
+		Quota     int64 `json:"quota"`
+		Remaining int64 `json:"remaining"`
+	}
+)
+
+// BlobURLOptions are signed into the download link of the blob,
+// so they can't be changed by the one who has the link
+type BlobURLOptions struct {
+	FileName    string
+	ContentType string
+	ExpiresAt   time.Time
+}
+
+// SignedBlobRequestData is the download link of the blob served by the application itself
+type SignedBlobRequestData struct {
+	Key         string
+	FileName    string
+	ContentType string
+	Expires     int64
+	Signature   string
+}
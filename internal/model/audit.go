@@ -0,0 +1,95 @@
+package model
+
+import "time"
+
+type AuditAction string
+
+const (
+	AuditActionCreate   AuditAction = "create"
+	AuditActionUpdate   AuditAction = "update"
+	AuditActionMove     AuditAction = "move"
+	AuditActionComplete AuditAction = "complete"
+	AuditActionArchive  AuditAction = "archive"
+	AuditActionDelete   AuditAction = "delete"
+	AuditActionRestore  AuditAction = "restore"
+)
+
+func (a AuditAction) String() string {
+	return string(a)
+}
+
+type AuditEntityType string
+
+const (
+	AuditEntityUser    AuditEntityType = "user"
+	AuditEntityList    AuditEntityType = "list"
+	AuditEntityHeading AuditEntityType = "heading"
+	AuditEntityTask    AuditEntityType = "task"
+	AuditEntityTag     AuditEntityType = "tag"
+)
+
+func (t AuditEntityType) String() string {
+	return string(t)
+}
+
+func (t AuditEntityType) IsValid() bool {
+	switch t {
+	case AuditEntityUser, AuditEntityList, AuditEntityHeading, AuditEntityTask, AuditEntityTag:
+		return true
+	default:
+		return false
+	}
+}
+
+// AuditEntry DB model
+type (
+	AuditEntry struct {
+		ID         string                 `db:"id"`
+		EntityType AuditEntityType        `db:"entity_type"`
+		EntityID   string                 `db:"entity_id"`
+		Action     AuditAction            `db:"action"`
+		Changes    map[string]AuditChange `db:"changes"`
+		UserID     string                 `db:"user_id"`
+		Email      string                 `db:"email"`
+		DeviceID   string                 `db:"device_id"`
+		RequestID  string                 `db:"request_id"`
+		CreatedAt  time.Time              `db:"created_at"`
+	}
+
+	// AuditChange is the value of the field before and after the change,
+	// Old is empty for the created entities and New is empty for the deleted ones
+	AuditChange struct {
+		Old any `json:"old,omitempty"`
+		New any `json:"new,omitempty"`
+	}
+
+	// AuditRecordData describes the change made by the user, Before and After are
+	// the states of the entity which are compared field by field
+	AuditRecordData struct {
+		EntityType AuditEntityType
+		EntityID   string
+		Action     AuditAction
+		UserID     string
+		Before     any
+		After      any
+	}
+
+	AuditRequestData struct {
+		EntityType AuditEntityType `json:"entity_type"`
+		EntityID   string          `json:"entity_id"`
+		UserID     string          `json:"user_id"`
+	}
+
+	AuditEntryResponseData struct {
+		ID         string                 `json:"id"`
+		EntityType AuditEntityType        `json:"entity_type"`
+		EntityID   string                 `json:"entity_id"`
+		Action     AuditAction            `json:"action"`
+		Changes    map[string]AuditChange `json:"changes"`
+		UserID     string                 `json:"user_id"`
+		Email      string                 `json:"email,omitempty"`
+		DeviceID   string                 `json:"device_id,omitempty"`
+		RequestID  string                 `json:"request_id,omitempty"`
+		CreatedAt  time.Time              `json:"created_at"`
+	}
+)
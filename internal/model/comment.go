@@ -0,0 +1,100 @@
+package model
+
+import "time"
+
+type TaskActivityKind string
+
+const (
+	TaskActivityCreated       TaskActivityKind = "created"
+	TaskActivityStatusChanged TaskActivityKind = "status_changed"
+	TaskActivityMoved         TaskActivityKind = "moved"
+	TaskActivityDateChanged   TaskActivityKind = "date_changed"
+	TaskActivityAssigned      TaskActivityKind = "assigned"
+	TaskActivitySnoozed       TaskActivityKind = "snoozed"
+)
+
+func (k TaskActivityKind) String() string {
+	return string(k)
+}
+
+type TimelineItemType string
+
+const (
+	TimelineItemComment  TimelineItemType = "comment"
+	TimelineItemActivity TimelineItemType = "activity"
+)
+
+// Comment DB model
+type (
+	Comment struct {
+		ID        string    `db:"id"`
+		TaskID    string    `db:"task_id"`
+		Body      string    `db:"body"`
+		Mentions  []string  `db:"mentions"`
+		UserID    string    `db:"user_id"`
+		Email     string    `db:"email"`
+		CreatedAt time.Time `db:"created_at"`
+		UpdatedAt time.Time `db:"updated_at"`
+		DeletedAt time.Time `db:"deleted_at"`
+	}
+
+	// CommentRequestData contains the Markdown body of the comment.
+	// The members of the list can be mentioned in the body by their email, like @user@example.com
+	CommentRequestData struct {
+		ID     string `json:"id"`
+		TaskID string `json:"task_id"`
+		Body   string `json:"body" validate:"required,max=10000"`
+		UserID string `json:"user_id"`
+	}
+
+	CommentResponseData struct {
+		ID        string    `json:"id"`
+		TaskID    string    `json:"task_id"`
+		Body      string    `json:"body"`
+		Mentions  []string  `json:"mentions,omitempty"`
+		UserID    string    `json:"user_id"`
+		Email     string    `json:"email,omitempty"`
+		CreatedAt time.Time `json:"created_at"`
+		UpdatedAt time.Time `json:"updated_at"`
+	}
+
+	// CommentMentionData is sent to the users mentioned in the comment
+	CommentMentionData struct {
+		Comment CommentResponseData `json:"comment"`
+		ListID  string              `json:"list_id"`
+	}
+)
+
+// TaskActivity DB model
+type (
+	TaskActivity struct {
+		ID        string           `db:"id"`
+		TaskID    string           `db:"task_id"`
+		Kind      TaskActivityKind `db:"kind"`
+		Field     string           `db:"field"`
+		OldValue  string           `db:"old_value"`
+		NewValue  string           `db:"new_value"`
+		UserID    string           `db:"user_id"`
+		Email     string           `db:"email"`
+		CreatedAt time.Time        `db:"created_at"`
+	}
+
+	TaskActivityResponseData struct {
+		ID        string           `json:"id"`
+		Kind      TaskActivityKind `json:"kind"`
+		Field     string           `json:"field,omitempty"`
+		OldValue  string           `json:"old_value,omitempty"`
+		NewValue  string           `json:"new_value,omitempty"`
+		UserID    string           `json:"user_id"`
+		Email     string           `json:"email,omitempty"`
+		CreatedAt time.Time        `json:"created_at"`
+	}
+
+	// TimelineItem is a comment or a change of the task, in the order they happened
+	TimelineItem struct {
+		Type      TimelineItemType          `json:"type"`
+		CreatedAt time.Time                 `json:"created_at"`
+		Comment   *CommentResponseData      `json:"comment,omitempty"`
+		Activity  *TaskActivityResponseData `json:"activity,omitempty"`
+	}
+)
@@ -0,0 +1,53 @@
+package model
+
+// PlanReason tells why the task is proposed for the plan of the day
+type PlanReason string
+
+const (
+	// PlanReasonOverdue is the task which deadline has come
+	PlanReasonOverdue PlanReason = "overdue"
+	// PlanReasonUnfinished is the task planned for yesterday and not completed
+	PlanReasonUnfinished PlanReason = "unfinished"
+	// PlanReasonDueSoon is the task which deadline comes within the planning horizon
+	PlanReasonDueSoon PlanReason = "due_soon"
+	// PlanReasonScheduled is the task starting today or which snooze ends today
+	PlanReasonScheduled PlanReason = "scheduled"
+)
+
+func (r PlanReason) String() string {
+	return string(r)
+}
+
+type (
+	// DailyPlanCandidate is the task proposed for the plan of the day
+	DailyPlanCandidate struct {
+		Task   Task
+		Reason PlanReason
+	}
+
+	// DailyPlanCandidatesResponseData groups the proposed tasks by the reason they are proposed
+	DailyPlanCandidatesResponseData struct {
+		Overdue    []TaskResponseData `json:"overdue"`
+		Unfinished []TaskResponseData `json:"unfinished"`
+		DueSoon    []TaskResponseData `json:"due_soon"`
+		Scheduled  []TaskResponseData `json:"scheduled"`
+	}
+
+	// DailyPlanRequestData commits the plan of the day, the tasks are started today.
+	// A task in both sections is planned for the evening
+	DailyPlanRequestData struct {
+		Today       []string `json:"today" validate:"dive,required"`
+		ThisEvening []string `json:"this_evening" validate:"dive,required"`
+		UserID      string   `json:"user_id"`
+	}
+
+	// TodayTasksResponseData splits the tasks for today into the day and the evening sections,
+	// both grouped by list
+	TodayTasksResponseData struct {
+		Today       []TaskGroup `json:"today"`
+		ThisEvening []TaskGroup `json:"this_evening"`
+		// Habits are the habits to do today, they are shown alongside the tasks
+		Habits    []HabitResponseData `json:"habits"`
+		UndoToken string              `json:"undo_token,omitempty"`
+	}
+)
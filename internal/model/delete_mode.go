@@ -0,0 +1,27 @@
+package model
+
+// DeleteMode tells what happens to the headings and tasks of the deleted list or heading
+type DeleteMode string
+
+const (
+	// DeleteModeArchive archives the tasks along with the deleted container
+	DeleteModeArchive DeleteMode = "archive"
+	// DeleteModeMove moves the tasks of the deleted list created by its owner to their Inbox and archives the rest,
+	// the tasks of the deleted heading are moved to the default heading of its list
+	DeleteModeMove DeleteMode = "move"
+	// DeleteModeRestrict refuses to delete the container while it has tasks or headings
+	DeleteModeRestrict DeleteMode = "restrict"
+)
+
+func (m DeleteMode) String() string {
+	return string(m)
+}
+
+func (m DeleteMode) IsValid() bool {
+	switch m {
+	case DeleteModeArchive, DeleteModeMove, DeleteModeRestrict:
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,20 @@
+package model
+
+type (
+	// DuplicateOptions control which tasks are copied and how their dates are changed
+	DuplicateOptions struct {
+		// IncludeCompleted copies the completed tasks of the heading or the list too
+		IncludeCompleted bool
+		// ShiftDays moves the start dates and the deadlines of the copied tasks by the number of days
+		ShiftDays int
+	}
+
+	DuplicateResponseData struct {
+		List     *ListResponseData     `json:"list,omitempty"`
+		Heading  *HeadingResponseData  `json:"heading,omitempty"`
+		Headings []HeadingResponseData `json:"headings,omitempty"`
+		Tasks    []TaskResponseData    `json:"tasks"`
+		// UndoToken reverts the whole copy
+		UndoToken string `json:"undo_token,omitempty"`
+	}
+)
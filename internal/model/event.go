@@ -0,0 +1,69 @@
+package model
+
+import "time"
+
+type EventType string
+
+const (
+	EventTaskCreated   EventType = "task.created"
+	EventTaskUpdated   EventType = "task.updated"
+	EventTaskMoved     EventType = "task.moved"
+	EventTaskCompleted EventType = "task.completed"
+	EventTaskArchived  EventType = "task.archived"
+	EventTaskUnblocked EventType = "task.unblocked"
+	EventTaskAssigned  EventType = "task.assigned"
+
+	EventListCreated EventType = "list.created"
+	EventListUpdated EventType = "list.updated"
+	EventListDeleted EventType = "list.deleted"
+
+	EventHeadingCreated EventType = "heading.created"
+	EventHeadingUpdated EventType = "heading.updated"
+	EventHeadingMoved   EventType = "heading.moved"
+	EventHeadingDeleted EventType = "heading.deleted"
+
+	EventAreaCreated EventType = "area.created"
+	EventAreaUpdated EventType = "area.updated"
+	EventAreaDeleted EventType = "area.deleted"
+
+	EventCommentCreated   EventType = "comment.created"
+	EventCommentUpdated   EventType = "comment.updated"
+	EventCommentDeleted   EventType = "comment.deleted"
+	EventCommentMentioned EventType = "comment.mentioned"
+
+	// EventOperationUndone is sent when the operation is reverted with the undo token,
+	// the entities changed by the operation should be reloaded
+	EventOperationUndone EventType = "operation.undone"
+
+	// EventWebhookTest is only sent to the webhook on request, users can't subscribe to it
+	EventWebhookTest EventType = "webhook.test"
+)
+
+func (t EventType) String() string {
+	return string(t)
+}
+
+// IsValid reports whether users can subscribe to the event type
+func (t EventType) IsValid() bool {
+	switch t {
+	case EventTaskCreated, EventTaskUpdated, EventTaskMoved, EventTaskCompleted, EventTaskArchived, EventTaskUnblocked, EventTaskAssigned,
+		EventListCreated, EventListUpdated, EventListDeleted,
+		EventHeadingCreated, EventHeadingUpdated, EventHeadingMoved, EventHeadingDeleted,
+		EventAreaCreated, EventAreaUpdated, EventAreaDeleted,
+		EventCommentCreated, EventCommentUpdated, EventCommentDeleted, EventCommentMentioned,
+		EventOperationUndone:
+		return true
+	default:
+		return false
+	}
+}
+
+// Event is a change of the user data pushed to the connected sessions of the user
+type Event struct {
+	ID        string    `json:"id"`
+	Type      EventType `json:"type"`
+	UserID    string    `json:"-"`
+	EntityID  string    `json:"entity_id"`
+	Data      any       `json:"data,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+}
@@ -0,0 +1,111 @@
+package model
+
+import "time"
+
+type KeyResultKind string
+
+const (
+	// KeyResultKindTasks is measured by the share of the completed tasks linked to the goal
+	KeyResultKindTasks KeyResultKind = "tasks"
+	// KeyResultKindManual is measured by the value the user updates, from the start value to the target value
+	KeyResultKindManual KeyResultKind = "manual"
+)
+
+func (k KeyResultKind) String() string {
+	return string(k)
+}
+
+// Goal DB model
+type (
+	Goal struct {
+		ID          string    `db:"id"`
+		Title       string    `db:"title"`
+		Description string    `db:"description"`
+		TargetDate  time.Time `db:"target_date"`
+		UserID      string    `db:"user_id"`
+		UpdatedAt   time.Time `db:"updated_at"`
+		DeletedAt   time.Time `db:"deleted_at"`
+	}
+
+	KeyResult struct {
+		ID           string        `db:"id"`
+		GoalID       string        `db:"goal_id"`
+		Title        string        `db:"title"`
+		Kind         KeyResultKind `db:"kind"`
+		StartValue   float64       `db:"start_value"`
+		TargetValue  float64       `db:"target_value"`
+		CurrentValue float64       `db:"current_value"`
+		UserID       string        `db:"user_id"`
+		UpdatedAt    time.Time     `db:"updated_at"`
+		DeletedAt    time.Time     `db:"deleted_at"`
+	}
+
+	// GoalTaskCounts is the number of the tasks linked to the goal, directly or through their lists
+	GoalTaskCounts struct {
+		Total     int
+		Completed int
+	}
+
+	GoalProgress struct {
+		Progress   float64   `db:"progress" json:"progress"`
+		RecordedAt time.Time `db:"recorded_at" json:"recorded_at"`
+	}
+
+	GoalRequestData struct {
+		ID          string    `json:"id"`
+		Title       string    `json:"title" validate:"required"`
+		Description string    `json:"description"`
+		TargetDate  time.Time `json:"target_date"`
+		UserID      string    `json:"user_id"`
+	}
+
+	// GoalResponseData contains the progress of the goal from 0 to 1. It is the average progress
+	// of the key results, or the share of the completed linked tasks if the goal has no key results
+	GoalResponseData struct {
+		ID             string                  `json:"id"`
+		Title          string                  `json:"title"`
+		Description    string                  `json:"description,omitempty"`
+		TargetDate     time.Time               `json:"target_date,omitempty"`
+		Progress       float64                 `json:"progress"`
+		TasksTotal     int                     `json:"tasks_total"`
+		TasksCompleted int                     `json:"tasks_completed"`
+		KeyResults     []KeyResultResponseData `json:"key_results"`
+		ListIDs        []string                `json:"list_ids"`
+		TaskIDs        []string                `json:"task_ids"`
+		UserID         string                  `json:"user_id"`
+		UpdatedAt      time.Time               `json:"updated_at"`
+	}
+
+	KeyResultRequestData struct {
+		ID           string        `json:"id"`
+		GoalID       string        `json:"goal_id"`
+		Title        string        `json:"title" validate:"required"`
+		Kind         KeyResultKind `json:"kind" validate:"required,oneof=tasks manual"`
+		StartValue   float64       `json:"start_value"`
+		TargetValue  float64       `json:"target_value"`
+		CurrentValue float64       `json:"current_value"`
+		UserID       string        `json:"user_id"`
+	}
+
+	// KeyResultResponseData contains the progress of the key result from 0 to 1. The values of the
+	// key result measured by tasks are the numbers of the linked tasks, completed and total
+	KeyResultResponseData struct {
+		ID           string        `json:"id"`
+		GoalID       string        `json:"goal_id"`
+		Title        string        `json:"title"`
+		Kind         KeyResultKind `json:"kind"`
+		StartValue   float64       `json:"start_value"`
+		TargetValue  float64       `json:"target_value"`
+		CurrentValue float64       `json:"current_value"`
+		Progress     float64       `json:"progress"`
+		UpdatedAt    time.Time     `json:"updated_at"`
+	}
+
+	// GoalLinkRequestData links the list or the task to the goal
+	GoalLinkRequestData struct {
+		GoalID string `json:"goal_id"`
+		ListID string `json:"list_id"`
+		TaskID string `json:"task_id"`
+		UserID string `json:"user_id"`
+	}
+)
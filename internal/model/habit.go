@@ -0,0 +1,97 @@
+package model
+
+import "time"
+
+type HabitFrequency string
+
+const (
+	// HabitFrequencyDaily is the habit which should be checked in every day, the streak is counted in days
+	HabitFrequencyDaily HabitFrequency = "daily"
+	// HabitFrequencyWeekly is the habit which should be checked in TimesPerWeek days of the week,
+	// the streak is counted in weeks
+	HabitFrequencyWeekly HabitFrequency = "weekly"
+)
+
+func (f HabitFrequency) String() string {
+	return string(f)
+}
+
+type StreakUnit string
+
+const (
+	StreakUnitDays  StreakUnit = "days"
+	StreakUnitWeeks StreakUnit = "weeks"
+)
+
+// Habit DB model
+type (
+	Habit struct {
+		ID           string         `db:"id"`
+		Title        string         `db:"title"`
+		Notes        string         `db:"notes"`
+		Frequency    HabitFrequency `db:"frequency"`
+		TimesPerWeek int            `db:"times_per_week"`
+		UserID       string         `db:"user_id"`
+		UpdatedAt    time.Time      `db:"updated_at"`
+		DeletedAt    time.Time      `db:"deleted_at"`
+	}
+
+	// HabitCheckIn is the day the habit was done on, in the time zone of the user
+	HabitCheckIn struct {
+		HabitID string    `db:"habit_id"`
+		Day     time.Time `db:"day"`
+	}
+
+	HabitRequestData struct {
+		ID           string         `json:"id"`
+		Title        string         `json:"title" validate:"required"`
+		Notes        string         `json:"notes"`
+		Frequency    HabitFrequency `json:"frequency" validate:"required,oneof=daily weekly"`
+		TimesPerWeek int            `json:"times_per_week" validate:"omitempty,min=1,max=7"`
+		UserID       string         `json:"user_id"`
+	}
+
+	// HabitCheckInRequestData checks the habit in on Date (YYYY-MM-DD), today of the user if it's empty
+	HabitCheckInRequestData struct {
+		HabitID string `json:"habit_id"`
+		Date    string `json:"date"`
+		UserID  string `json:"user_id"`
+	}
+
+	// HabitHeatmapRequestData asks for the check-ins of the habit between the dates From and To, both included
+	HabitHeatmapRequestData struct {
+		HabitID string `json:"habit_id"`
+		From    string `json:"from"`
+		To      string `json:"to"`
+		UserID  string `json:"user_id"`
+	}
+
+	HabitResponseData struct {
+		ID           string         `json:"id"`
+		Title        string         `json:"title"`
+		Notes        string         `json:"notes,omitempty"`
+		Frequency    HabitFrequency `json:"frequency"`
+		TimesPerWeek int            `json:"times_per_week,omitempty"`
+		// Today is the date in the time zone of the user the habit is shown for
+		Today          string     `json:"today"`
+		CheckedInToday bool       `json:"checked_in_today"`
+		WeekCheckIns   int        `json:"week_check_ins"`
+		CurrentStreak  int        `json:"current_streak"`
+		LongestStreak  int        `json:"longest_streak"`
+		StreakUnit     StreakUnit `json:"streak_unit"`
+		UpdatedAt      time.Time  `json:"updated_at"`
+	}
+
+	HabitHeatmapResponseData struct {
+		HabitID string            `json:"habit_id"`
+		From    string            `json:"from"`
+		To      string            `json:"to"`
+		Total   int               `json:"total"`
+		Days    []HabitHeatmapDay `json:"days"`
+	}
+
+	HabitHeatmapDay struct {
+		Date      string `json:"date"`
+		CheckedIn bool   `json:"checked_in"`
+	}
+)
@@ -21,6 +21,8 @@ type (
 		Title  string `json:"title" validate:"required"`
 		ListID string `json:"list_id"`
 		UserID string `json:"user_id"`
+		// DeleteMode is what happens to the tasks when the heading is deleted
+		DeleteMode DeleteMode `json:"-"`
 	}
 
 	HeadingResponseData struct {
@@ -29,6 +31,7 @@ type (
 		ListID    string    `json:"list_id,omitempty"`
 		UserID    string    `json:"user_id,omitempty"`
 		UpdatedAt time.Time `json:"updated_at"`
+		UndoToken string    `json:"undo_token,omitempty"`
 	}
 )
 
@@ -0,0 +1,23 @@
+package model
+
+import (
+	"time"
+)
+
+// IdempotencyKey DB model
+type IdempotencyKey struct {
+	Key          string    `db:"key"`
+	UserID       string    `db:"user_id"`
+	Method       string    `db:"method"`
+	Path         string    `db:"path"`
+	RequestHash  string    `db:"request_hash"`
+	StatusCode   int       `db:"status_code"`
+	ResponseBody []byte    `db:"response_body"`
+	CreatedAt    time.Time `db:"created_at"`
+	ExpiresAt    time.Time `db:"expires_at"`
+}
+
+// IsCompleted reports whether the response for the key has already been stored
+func (k IdempotencyKey) IsCompleted() bool {
+	return k.StatusCode != 0
+}
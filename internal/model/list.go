@@ -7,25 +7,75 @@ import (
 // List DB model
 type (
 	List struct {
-		ID        string    `db:"id"`
-		Title     string    `db:"headingTitle"`
-		UserID    string    `db:"user_id"`
-		IsDefault bool      `db:"is_default"`
-		UpdatedAt time.Time `db:"updated_at"`
-		DeletedAt time.Time `db:"deleted_at"`
+		ID         string     `db:"id"`
+		Title      string     `db:"headingTitle"`
+		UserID     string     `db:"user_id"`
+		IsDefault  bool       `db:"is_default"`
+		AreaID     string     `db:"area_id"`
+		Position   int        `db:"position"`
+		Color      string     `db:"color"`
+		Icon       string     `db:"icon"`
+		Notes      string     `db:"notes"`
+		StartDate  time.Time  `db:"start_date"`
+		Deadline   time.Time  `db:"deadline"`
+		Status     ListStatus `db:"status"`
+		ArchivedAt time.Time  `db:"archived_at"`
+		Role       ListRole   `db:"role"`
+		UpdatedAt  time.Time  `db:"updated_at"`
+		DeletedAt  time.Time  `db:"deleted_at"`
+		// OpenTasks and CompletedTasks aren't stored, they are counted when the list is read
+		OpenTasks      int
+		CompletedTasks int
 	}
 
 	ListRequestData struct {
-		ID     string `json:"id"`
-		Title  string `json:"title" validate:"required"`
-		UserID string `json:"user_id"`
+		ID        string    `json:"id"`
+		Title     string    `json:"title" validate:"required"`
+		AreaID    string    `json:"area_id"`
+		Color     string    `json:"color" validate:"omitempty,hexcolor"`
+		Icon      string    `json:"icon" validate:"max=32"`
+		Notes     string    `json:"notes"`
+		StartDate time.Time `json:"start_date"`
+		Deadline  time.Time `json:"deadline"`
+		UserID    string    `json:"user_id"`
+		// DeleteMode is what happens to the headings and tasks when the list is deleted
+		DeleteMode DeleteMode `json:"-"`
+		// CompleteTasks completes the open tasks of the list when it is completed or archived
+		CompleteTasks bool `json:"-"`
+	}
+
+	// ConvertListRequestData describes the list which becomes a heading of the target list
+	ConvertListRequestData struct {
+		ListID       string `json:"list_id"`
+		TargetListID string `json:"target_list_id"`
+		UserID       string `json:"user_id"`
 	}
 
 	ListResponseData struct {
-		ID        string    `json:"id"`
-		Title     string    `json:"title"`
-		UserID    string    `json:"user_id"`
-		UpdatedAt time.Time `json:"updated_at"`
+		ID         string       `json:"id"`
+		Title      string       `json:"title"`
+		AreaID     string       `json:"area_id,omitempty"`
+		Position   int          `json:"position"`
+		Color      string       `json:"color,omitempty"`
+		Icon       string       `json:"icon,omitempty"`
+		Notes      string       `json:"notes,omitempty"`
+		StartDate  time.Time    `json:"start_date"`
+		Deadline   time.Time    `json:"deadline"`
+		Progress   ListProgress `json:"progress"`
+		Status     ListStatus   `json:"status"`
+		ArchivedAt time.Time    `json:"archived_at"`
+		UserID     string       `json:"user_id"`
+		Role       ListRole     `json:"role,omitempty"`
+		UpdatedAt  time.Time    `json:"updated_at"`
+		UndoToken  string       `json:"undo_token,omitempty"`
+	}
+
+	// ListProgress counts the tasks of the list, the archived tasks aren't counted
+	ListProgress struct {
+		OpenTasks      int `json:"open_tasks"`
+		CompletedTasks int `json:"completed_tasks"`
+		// Percent is the share of the completed tasks, rounded down
+		Percent int `json:"percent"`
 	}
 )
 
@@ -37,4 +87,5 @@ func (t listTitle) String() string {
 
 const (
 	DefaultInboxList listTitle = "Inbox"
+	DefaultAreaList  listTitle = "Area tasks"
 )
@@ -0,0 +1,110 @@
+package model
+
+import "time"
+
+type ListRole string
+
+const (
+	// ListRoleOwner can edit the list, manage its members and delete it
+	ListRoleOwner ListRole = "owner"
+	// ListRoleEditor can edit the list, its headings and tasks
+	ListRoleEditor ListRole = "editor"
+	// ListRoleViewer can only read the list, its headings and tasks
+	ListRoleViewer ListRole = "viewer"
+)
+
+func (r ListRole) String() string {
+	return string(r)
+}
+
+func (r ListRole) rank() int {
+	switch r {
+	case ListRoleOwner:
+		return 3
+	case ListRoleEditor:
+		return 2
+	case ListRoleViewer:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// Allows reports whether the role grants at least the permissions of the required role
+func (r ListRole) Allows(required ListRole) bool {
+	return r.rank() >= required.rank()
+}
+
+type InvitationStatus string
+
+const (
+	InvitationStatusPending  InvitationStatus = "pending"
+	InvitationStatusAccepted InvitationStatus = "accepted"
+	InvitationStatusDeclined InvitationStatus = "declined"
+	InvitationStatusRevoked  InvitationStatus = "revoked"
+)
+
+func (s InvitationStatus) String() string {
+	return string(s)
+}
+
+// ListMember DB model
+type (
+	ListMember struct {
+		ListID    string    `db:"list_id"`
+		UserID    string    `db:"user_id"`
+		Email     string    `db:"email"`
+		Role      ListRole  `db:"role"`
+		CreatedAt time.Time `db:"created_at"`
+	}
+
+	ListMemberRequestData struct {
+		ListID   string   `json:"list_id"`
+		MemberID string   `json:"member_id"`
+		Role     ListRole `json:"role" validate:"required,oneof=owner editor viewer"`
+		UserID   string   `json:"user_id"`
+	}
+
+	ListMemberResponseData struct {
+		ListID    string    `json:"list_id"`
+		UserID    string    `json:"user_id"`
+		Email     string    `json:"email"`
+		Role      ListRole  `json:"role"`
+		CreatedAt time.Time `json:"created_at"`
+	}
+)
+
+// ListInvitation DB model
+type (
+	ListInvitation struct {
+		ID        string           `db:"id"`
+		ListID    string           `db:"list_id"`
+		ListTitle string           `db:"list_title"`
+		Email     string           `db:"email"`
+		Role      ListRole         `db:"role"`
+		Status    InvitationStatus `db:"status"`
+		InvitedBy string           `db:"invited_by"`
+		CreatedAt time.Time        `db:"created_at"`
+		UpdatedAt time.Time        `db:"updated_at"`
+	}
+
+	ListInvitationRequestData struct {
+		ID     string   `json:"id"`
+		ListID string   `json:"list_id"`
+		Email  string   `json:"email" validate:"required,email"`
+		Role   ListRole `json:"role" validate:"required,oneof=owner editor viewer"`
+		UserID string   `json:"user_id"`
+	}
+
+	ListInvitationResponseData struct {
+		ID        string           `json:"id"`
+		ListID    string           `json:"list_id"`
+		ListTitle string           `json:"list_title,omitempty"`
+		Email     string           `json:"email"`
+		Role      ListRole         `json:"role"`
+		Status    InvitationStatus `json:"status"`
+		InvitedBy string           `json:"invited_by"`
+		CreatedAt time.Time        `json:"created_at"`
+		UpdatedAt time.Time        `json:"updated_at"`
+	}
+)
@@ -0,0 +1,15 @@
+package model
+
+// ListStatus tells whether the list is in use or put away. The completed and archived lists
+// are hidden from the lists of the user until they are restored
+type ListStatus string
+
+const (
+	ListStatusActive    ListStatus = "active"
+	ListStatusCompleted ListStatus = "completed"
+	ListStatusArchived  ListStatus = "archived"
+)
+
+func (s ListStatus) String() string {
+	return string(s)
+}
@@ -0,0 +1,140 @@
+package model
+
+import "time"
+
+type ReviewSection string
+
+const (
+	// ReviewSectionCompleted is the tasks completed during the week
+	ReviewSectionCompleted ReviewSection = "completed"
+	// ReviewSectionSlipped is the open tasks which deadline passed during the week
+	ReviewSectionSlipped ReviewSection = "slipped"
+	// ReviewSectionStale is the open tasks which weren't changed for the stale days of the review
+	ReviewSectionStale ReviewSection = "stale"
+	// ReviewSectionNoNextAction is the active lists without open tasks
+	ReviewSectionNoNextAction ReviewSection = "no_next_action"
+	// ReviewSectionInbox is the open tasks in Inbox to process
+	ReviewSectionInbox ReviewSection = "inbox"
+)
+
+func (s ReviewSection) String() string {
+	return string(s)
+}
+
+type ReviewEntityType string
+
+const (
+	ReviewEntityTask ReviewEntityType = "task"
+	ReviewEntityList ReviewEntityType = "list"
+)
+
+func (t ReviewEntityType) String() string {
+	return string(t)
+}
+
+// ReviewAction is what the user decided about the item of the review
+type ReviewAction string
+
+const (
+	// ReviewActionKeep leaves the item as it is
+	ReviewActionKeep ReviewAction = "keep"
+	// ReviewActionDefer snoozes the task until the given time
+	ReviewActionDefer ReviewAction = "defer"
+	// ReviewActionArchive archives the task or the list
+	ReviewActionArchive ReviewAction = "archive"
+	// ReviewActionMove moves the task to the given list
+	ReviewActionMove ReviewAction = "move"
+)
+
+func (a ReviewAction) String() string {
+	return string(a)
+}
+
+// IsValidFor reports whether the action can be applied to the entity, the lists can only be kept or archived
+func (a ReviewAction) IsValidFor(entityType ReviewEntityType) bool {
+	switch a {
+	case ReviewActionKeep, ReviewActionArchive:
+		return true
+	case ReviewActionDefer, ReviewActionMove:
+		return entityType == ReviewEntityTask
+	default:
+		return false
+	}
+}
+
+// ReviewSession DB model
+type (
+	ReviewSession struct {
+		ID          string    `db:"id"`
+		UserID      string    `db:"user_id"`
+		StaleDays   int       `db:"stale_days"`
+		StartedAt   time.Time `db:"started_at"`
+		CompletedAt time.Time `db:"completed_at"`
+	}
+
+	// ReviewItem is the task or the list to walk through, the title is kept as it was when the review started
+	ReviewItem struct {
+		ID         string           `db:"id"`
+		ReviewID   string           `db:"review_id"`
+		Section    ReviewSection    `db:"section"`
+		EntityType ReviewEntityType `db:"entity_type"`
+		EntityID   string           `db:"entity_id"`
+		Title      string           `db:"title"`
+		Position   int              `db:"position"`
+		Action     ReviewAction     `db:"action"`
+		ActedAt    time.Time        `db:"acted_at"`
+	}
+
+	// ReviewRequestData starts the review, the tasks not changed for StaleDays are proposed as stale
+	ReviewRequestData struct {
+		ID        string `json:"id"`
+		StaleDays int    `json:"stale_days"`
+		UserID    string `json:"user_id"`
+	}
+
+	// ReviewItemActionRequestData applies the action to the item of the review. DeferredUntil is required
+	// to defer the task, ListID to move it
+	ReviewItemActionRequestData struct {
+		ReviewID      string       `json:"review_id"`
+		ItemID        string       `json:"item_id"`
+		Action        ReviewAction `json:"action" validate:"required"`
+		DeferredUntil time.Time    `json:"deferred_until"`
+		ListID        string       `json:"list_id"`
+		UserID        string       `json:"user_id"`
+	}
+
+	ReviewResponseData struct {
+		ID          string         `json:"id"`
+		StaleDays   int            `json:"stale_days"`
+		StartedAt   time.Time      `json:"started_at"`
+		CompletedAt time.Time      `json:"completed_at"`
+		Sections    ReviewSections `json:"sections"`
+		Progress    ReviewProgress `json:"progress"`
+		UserID      string         `json:"user_id"`
+	}
+
+	ReviewSections struct {
+		Completed    []ReviewItemResponseData `json:"completed"`
+		Slipped      []ReviewItemResponseData `json:"slipped"`
+		Stale        []ReviewItemResponseData `json:"stale"`
+		NoNextAction []ReviewItemResponseData `json:"no_next_action"`
+		Inbox        []ReviewItemResponseData `json:"inbox"`
+	}
+
+	// ReviewProgress counts the items of the review and the ones the user already acted on
+	ReviewProgress struct {
+		Total    int `json:"total"`
+		Reviewed int `json:"reviewed"`
+	}
+
+	ReviewItemResponseData struct {
+		ID         string           `json:"id"`
+		Section    ReviewSection    `json:"section"`
+		EntityType ReviewEntityType `json:"entity_type"`
+		EntityID   string           `json:"entity_id"`
+		Title      string           `json:"title"`
+		Action     ReviewAction     `json:"action,omitempty"`
+		ActedAt    time.Time        `json:"acted_at"`
+		UndoToken  string           `json:"undo_token,omitempty"`
+	}
+)
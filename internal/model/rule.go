@@ -0,0 +1,115 @@
+package model
+
+import "time"
+
+type (
+	RuleTrigger        string
+	RuleConditionField string
+	RuleOperator       string
+	RuleActionType     string
+)
+
+const (
+	RuleTriggerTaskCreated    RuleTrigger = "task_created"
+	RuleTriggerTaskUpdated    RuleTrigger = "task_updated"
+	RuleTriggerTaskCompleted  RuleTrigger = "task_completed"
+	RuleTriggerTagAdded       RuleTrigger = "tag_added"
+	RuleTriggerDeadlinePassed RuleTrigger = "deadline_passed"
+)
+
+func (t RuleTrigger) String() string {
+	return string(t)
+}
+
+const (
+	RuleFieldTitle     RuleConditionField = "title"
+	RuleFieldList      RuleConditionField = "list"
+	RuleFieldHeading   RuleConditionField = "heading"
+	RuleFieldTag       RuleConditionField = "tag"
+	RuleFieldAddedTag  RuleConditionField = "added_tag"
+	RuleFieldStartDate RuleConditionField = "start_date"
+	RuleFieldDeadline  RuleConditionField = "deadline"
+)
+
+const (
+	RuleOperatorEquals    RuleOperator = "eq"
+	RuleOperatorNotEquals RuleOperator = "neq"
+	RuleOperatorContains  RuleOperator = "contains"
+	RuleOperatorIsSet     RuleOperator = "is_set"
+	RuleOperatorIsNotSet  RuleOperator = "is_not_set"
+)
+
+const (
+	RuleActionMoveToHeading RuleActionType = "move_to_heading"
+	RuleActionMoveToList    RuleActionType = "move_to_list"
+	RuleActionAddTag        RuleActionType = "add_tag"
+	RuleActionRemoveTag     RuleActionType = "remove_tag"
+	RuleActionComplete      RuleActionType = "complete"
+)
+
+// Rule DB model
+type (
+	Rule struct {
+		ID         string          `db:"id"`
+		Title      string          `db:"title"`
+		Trigger    RuleTrigger     `db:"trigger"`
+		Conditions []RuleCondition `db:"conditions"`
+		Actions    []RuleAction    `db:"actions"`
+		IsActive   bool            `db:"is_active"`
+		UserID     string          `db:"user_id"`
+		UpdatedAt  time.Time       `db:"updated_at"`
+		DeletedAt  time.Time       `db:"deleted_at"`
+	}
+
+	// RuleCondition compares the field of the task with the value. Lists and headings
+	// are matched by ID or title, tags by title
+	RuleCondition struct {
+		Field    RuleConditionField `json:"field" validate:"required,oneof=title list heading tag added_tag start_date deadline"`
+		Operator RuleOperator       `json:"operator" validate:"required,oneof=eq neq contains is_set is_not_set"`
+		Value    string             `json:"value"`
+	}
+
+	// RuleAction changes the task. The heading is looked up by title in the list of the task,
+	// the list is looked up by ID or title
+	RuleAction struct {
+		Type  RuleActionType `json:"type" validate:"required,oneof=move_to_heading move_to_list add_tag remove_tag complete"`
+		Value string         `json:"value"`
+	}
+
+	RuleRequestData struct {
+		ID         string          `json:"id"`
+		Title      string          `json:"title" validate:"required"`
+		Trigger    RuleTrigger     `json:"trigger" validate:"required,oneof=task_created task_updated task_completed tag_added deadline_passed"`
+		Conditions []RuleCondition `json:"conditions" validate:"dive"`
+		Actions    []RuleAction    `json:"actions" validate:"required,min=1,dive"`
+		IsActive   bool            `json:"is_active"`
+		UserID     string          `json:"user_id"`
+	}
+
+	RuleResponseData struct {
+		ID         string          `json:"id"`
+		Title      string          `json:"title"`
+		Trigger    RuleTrigger     `json:"trigger"`
+		Conditions []RuleCondition `json:"conditions"`
+		Actions    []RuleAction    `json:"actions"`
+		IsActive   bool            `json:"is_active"`
+		UserID     string          `json:"user_id"`
+		UpdatedAt  time.Time       `json:"updated_at"`
+	}
+)
+
+// RuleTriggerData describes the change of the task the rules are evaluated for
+type RuleTriggerData struct {
+	Trigger   RuleTrigger
+	TaskID    string
+	UserID    string
+	AddedTags []string
+}
+
+// RuleDeadlineRun is the task with the passed deadline the deadline rule has to run for
+type RuleDeadlineRun struct {
+	RuleID   string
+	TaskID   string
+	UserID   string
+	Deadline time.Time
+}
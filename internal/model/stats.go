@@ -0,0 +1,85 @@
+package model
+
+import "time"
+
+// StatsPeriod is the size of the buckets the created and completed tasks are counted in
+type StatsPeriod string
+
+const (
+	StatsPeriodDay   StatsPeriod = "day"
+	StatsPeriodWeek  StatsPeriod = "week"
+	StatsPeriodMonth StatsPeriod = "month"
+)
+
+func (p StatsPeriod) String() string {
+	return string(p)
+}
+
+func (p StatsPeriod) IsValid() bool {
+	switch p {
+	case StatsPeriodDay, StatsPeriodWeek, StatsPeriodMonth:
+		return true
+	default:
+		return false
+	}
+}
+
+type (
+	// StatsRequestData asks for the statistics of the tasks created or completed in [From, To)
+	StatsRequestData struct {
+		Period StatsPeriod `json:"period"`
+		From   time.Time   `json:"from"`
+		To     time.Time   `json:"to"`
+		UserID string      `json:"user_id"`
+	}
+
+	StatsResponseData struct {
+		Period           StatsPeriod           `json:"period"`
+		From             time.Time             `json:"from"`
+		To               time.Time             `json:"to"`
+		Created          int64                 `json:"created"`
+		Completed        int64                 `json:"completed"`
+		Throughput       []StatsThroughput     `json:"throughput"`
+		ByList           []StatsCompletionRate `json:"by_list"`
+		ByTag            []StatsCompletionRate `json:"by_tag"`
+		AvgLeadTimeHours float64               `json:"avg_lead_time_hours"`
+		Due              int64                 `json:"due"`
+		Overdue          int64                 `json:"overdue"`
+		OverdueRate      float64               `json:"overdue_rate"`
+		Streaks          StatsStreaks          `json:"streaks"`
+		GeneratedAt      time.Time             `json:"generated_at"`
+		// ExpiresAt is when the cached statistics are computed again
+		ExpiresAt time.Time `json:"expires_at"`
+	}
+
+	// StatsThroughput is the number of tasks created and completed in the period starting at PeriodStart
+	StatsThroughput struct {
+		PeriodStart time.Time `json:"period_start"`
+		Created     int64     `json:"created"`
+		Completed   int64     `json:"completed"`
+	}
+
+	// StatsCompletionRate is the share of the tasks of the list or the tag which were completed
+	StatsCompletionRate struct {
+		ID        string  `json:"id"`
+		Title     string  `json:"title"`
+		Total     int64   `json:"total"`
+		Completed int64   `json:"completed"`
+		Rate      float64 `json:"rate"`
+	}
+
+	// StatsSummary is the totals of the period, the due tasks are the ones which deadline already passed
+	StatsSummary struct {
+		Created            int64
+		Completed          int64
+		AvgLeadTimeSeconds float64
+		Due                int64
+		Overdue            int64
+	}
+
+	// StatsStreaks is the number of days in a row with at least one completed task
+	StatsStreaks struct {
+		Current int `json:"current"`
+		Longest int `json:"longest"`
+	}
+)
@@ -4,9 +4,9 @@ type StatusName string
 
 const (
 	StatusNotStarted StatusName = "Not started"
-	StatusPlanned    StatusName = "StatusPlanned"
-	StatusCompleted  StatusName = "StatusCompleted"
-	StatusArchived   StatusName = "StatusArchived"
+	StatusPlanned    StatusName = "Planned"
+	StatusCompleted  StatusName = "Completed"
+	StatusArchived   StatusName = "Archived"
 )
 
 func (s StatusName) String() string {
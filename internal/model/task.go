@@ -17,11 +17,17 @@ type (
 		StatusID    int       `db:"status_id"`
 		ListID      string    `db:"list_id"`
 		HeadingID   string    `db:"heading_id"`
+		AssigneeID  string    `db:"assignee_id"`
 		UserID      string    `db:"user_id"`
-		Tags        []string
-		Overdue     bool
-		UpdatedAt   time.Time `db:"updated_at"`
-		DeletedAt   time.Time `db:"deleted_at"`
+		// DeferredUntil hides the snoozed task from the views until the time comes, the start date is kept
+		DeferredUntil time.Time `db:"deferred_until"`
+		SnoozeCount   int       `db:"snooze_count"`
+		Tags          []string
+		Overdue       bool
+		Blocked       bool
+		ThisEvening   bool
+		UpdatedAt     time.Time `db:"updated_at"`
+		DeletedAt     time.Time `db:"deleted_at"`
 	}
 
 	TaskRequestData struct {
@@ -35,25 +41,34 @@ type (
 		StatusID    int       `json:"status_id"`
 		ListID      string    `json:"list_id"`
 		HeadingID   string    `json:"heading_id"`
+		AreaID      string    `json:"area_id"`
 		UserID      string    `json:"user_id"`
 		Tags        []string  `json:"tags"`
 	}
 
 	TaskResponseData struct {
-		ID          string    `json:"id,omitempty"`
-		Title       string    `json:"title,omitempty"`
-		Description string    `json:"description,omitempty"`
-		StartDate   time.Time `json:"start_date"`
-		Deadline    time.Time `json:"deadline"`
-		StartTime   time.Time `json:"start_time"`
-		EndTime     time.Time `json:"end_time"`
-		StatusID    int       `json:"status_id,omitempty"`
-		ListID      string    `json:"list_id,omitempty"`
-		HeadingID   string    `json:"heading_id,omitempty"`
-		UserID      string    `json:"user_id,omitempty"`
-		Tags        []string  `json:"tags,omitempty"`
-		Overdue     bool      `json:"overdue,omitempty"`
-		UpdatedAt   time.Time `json:"updated_at"`
+		ID            string         `json:"id,omitempty"`
+		Title         string         `json:"title,omitempty"`
+		Description   string         `json:"description,omitempty"`
+		StartDate     time.Time      `json:"start_date"`
+		Deadline      time.Time      `json:"deadline"`
+		StartTime     time.Time      `json:"start_time"`
+		EndTime       time.Time      `json:"end_time"`
+		StatusID      int            `json:"status_id,omitempty"`
+		ListID        string         `json:"list_id,omitempty"`
+		HeadingID     string         `json:"heading_id,omitempty"`
+		AreaID        string         `json:"area_id,omitempty"`
+		AssigneeID    string         `json:"assignee_id,omitempty"`
+		UserID        string         `json:"user_id,omitempty"`
+		DeferredUntil time.Time      `json:"deferred_until"`
+		SnoozeCount   int            `json:"snooze_count,omitempty"`
+		ThisEvening   bool           `json:"this_evening,omitempty"`
+		Tags          []string       `json:"tags,omitempty"`
+		Overdue       bool           `json:"overdue,omitempty"`
+		Blocked       bool           `json:"blocked,omitempty"`
+		UpdatedAt     time.Time      `json:"updated_at"`
+		Timeline      []TimelineItem `json:"timeline,omitempty"`
+		UndoToken     string         `json:"undo_token,omitempty"`
 	}
 
 	TaskRequestTimeData struct {
@@ -69,6 +84,27 @@ type (
 		EndTime   time.Time `json:"end_time"`
 		UserID    string    `json:"user_id"`
 		UpdatedAt time.Time `json:"updated_at"`
+		UndoToken string    `json:"undo_token,omitempty"`
+	}
+
+	// TaskAssigneeRequestData assigns the task to a member of its list
+	TaskAssigneeRequestData struct {
+		TaskID     string `json:"task_id"`
+		AssigneeID string `json:"assignee_id" validate:"required"`
+		UserID     string `json:"user_id"`
+	}
+
+	// TaskSnoozeRequestData hides the task until the given time
+	TaskSnoozeRequestData struct {
+		TaskID        string    `json:"task_id"`
+		DeferredUntil time.Time `json:"deferred_until" validate:"required"`
+		UserID        string    `json:"user_id"`
+	}
+
+	// TaskAssignedData is sent to the assignee when the task is assigned to them
+	TaskAssignedData struct {
+		Task       TaskResponseData `json:"task"`
+		AssignedBy string           `json:"assigned_by"`
 	}
 
 	TaskGroup struct {
@@ -76,6 +112,8 @@ type (
 		Month     int32              `json:"month,omitempty"`
 		ListID    string             `json:"list_id,omitempty"`
 		HeadingID string             `json:"heading_id,omitempty"`
+		AreaID    string             `json:"area_id,omitempty"`
+		AreaTitle string             `json:"area_title,omitempty"`
 		Tasks     []TaskResponseData `json:"tasks"`
 	}
 )
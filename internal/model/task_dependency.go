@@ -0,0 +1,32 @@
+package model
+
+import "time"
+
+// TaskDependency DB model
+type (
+	TaskDependency struct {
+		TaskID          string    `db:"task_id"`
+		BlockedByTaskID string    `db:"blocked_by_task_id"`
+		UserID          string    `db:"user_id"`
+		CreatedAt       time.Time `db:"created_at"`
+	}
+
+	TaskDependencyRequestData struct {
+		TaskID          string `json:"task_id"`
+		BlockedByTaskID string `json:"blocked_by_task_id" validate:"required"`
+		UserID          string `json:"user_id"`
+	}
+
+	TaskDependencyResponseData struct {
+		TaskID          string    `json:"task_id"`
+		BlockedByTaskID string    `json:"blocked_by_task_id"`
+		UserID          string    `json:"user_id"`
+		CreatedAt       time.Time `json:"created_at"`
+	}
+
+	// TaskUnblockedData is sent with the event when the last task blocking the task is completed
+	TaskUnblockedData struct {
+		TaskID            string `json:"task_id"`
+		UnblockedByTaskID string `json:"unblocked_by_task_id"`
+	}
+)
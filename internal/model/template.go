@@ -0,0 +1,92 @@
+package model
+
+import "time"
+
+type TemplateKind string
+
+const (
+	// TemplateKindList is the template of the list with its headings and tasks
+	TemplateKindList TemplateKind = "list"
+	// TemplateKindTask is the template of the single task, instantiated in the existing list
+	TemplateKindTask TemplateKind = "task"
+)
+
+func (k TemplateKind) String() string {
+	return string(k)
+}
+
+// Template DB model
+type (
+	Template struct {
+		ID        string          `db:"id"`
+		Title     string          `db:"title"`
+		Kind      TemplateKind    `db:"kind"`
+		Content   TemplateContent `db:"content"`
+		UserID    string          `db:"user_id"`
+		UpdatedAt time.Time       `db:"updated_at"`
+		DeletedAt time.Time       `db:"deleted_at"`
+	}
+
+	// TemplateContent is the skeleton of the list. The tasks of the task template
+	// are kept in the default heading
+	TemplateContent struct {
+		ListTitle string            `json:"list_title,omitempty"`
+		Headings  []TemplateHeading `json:"headings"`
+	}
+
+	TemplateHeading struct {
+		Title     string         `json:"title"`
+		IsDefault bool           `json:"is_default,omitempty"`
+		Tasks     []TemplateTask `json:"tasks"`
+	}
+
+	// TemplateTask keeps the dates of the task as the number of days from the anchor date of the template
+	TemplateTask struct {
+		Title           string    `json:"title"`
+		Description     string    `json:"description,omitempty"`
+		StartDateOffset *int      `json:"start_date_offset,omitempty"`
+		DeadlineOffset  *int      `json:"deadline_offset,omitempty"`
+		StartTime       time.Time `json:"start_time"`
+		EndTime         time.Time `json:"end_time"`
+		Tags            []string  `json:"tags,omitempty"`
+	}
+
+	// TemplateRequestData saves the list or the task as the template. The dates are saved
+	// relative to the anchor date, by default the earliest date of the tasks
+	TemplateRequestData struct {
+		ID         string    `json:"id"`
+		Title      string    `json:"title" validate:"required"`
+		ListID     string    `json:"list_id"`
+		TaskID     string    `json:"task_id"`
+		AnchorDate time.Time `json:"anchor_date"`
+		UserID     string    `json:"user_id"`
+	}
+
+	TemplateResponseData struct {
+		ID        string            `json:"id"`
+		Title     string            `json:"title"`
+		Kind      TemplateKind      `json:"kind"`
+		ListTitle string            `json:"list_title,omitempty"`
+		Headings  []TemplateHeading `json:"headings"`
+		UserID    string            `json:"user_id"`
+		UpdatedAt time.Time         `json:"updated_at"`
+	}
+
+	// TemplateInstanceRequestData creates the list or the tasks from the template, shifting
+	// their dates to the anchor date. The tasks of the task template are created in the list
+	// and the heading, by default in the default list
+	TemplateInstanceRequestData struct {
+		ID         string    `json:"id"`
+		Title      string    `json:"title"`
+		AnchorDate time.Time `json:"anchor_date" validate:"required"`
+		ListID     string    `json:"list_id"`
+		HeadingID  string    `json:"heading_id"`
+		UserID     string    `json:"user_id"`
+	}
+
+	TemplateInstanceResponseData struct {
+		List     *ListResponseData     `json:"list,omitempty"`
+		Headings []HeadingResponseData `json:"headings,omitempty"`
+		Tasks    []TaskResponseData    `json:"tasks"`
+	}
+)
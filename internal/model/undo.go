@@ -0,0 +1,69 @@
+package model
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// UndoOperation DB model
+type (
+	UndoOperation struct {
+		ID         string          `db:"id"`
+		UserID     string          `db:"user_id"`
+		EntityType AuditEntityType `db:"entity_type"`
+		EntityID   string          `db:"entity_id"`
+		Action     AuditAction     `db:"action"`
+		Snapshot   UndoSnapshot    `db:"snapshot"`
+		CreatedAt  time.Time       `db:"created_at"`
+		ExpiresAt  time.Time       `db:"expires_at"`
+		UndoneAt   time.Time       `db:"undone_at"`
+	}
+
+	// UndoSnapshot is the state of the entities before the operation. The rows of the lists,
+	// headings and tasks are kept as JSON arrays, so they are restored with all their columns
+	UndoSnapshot struct {
+		Lists    json.RawMessage `json:"lists,omitempty"`
+		Headings json.RawMessage `json:"headings,omitempty"`
+		Tasks    json.RawMessage `json:"tasks,omitempty"`
+		TaskTags json.RawMessage `json:"task_tags,omitempty"`
+		// Created is set for the operations which create the entity, the entity is deleted on undo.
+		// The operation can change the other entities as well, their rows are restored after the deletion
+		Created bool `json:"created,omitempty"`
+	}
+
+	// UndoRecordData describes the operation which is going to be made, ListIDs, HeadingIDs and TaskIDs
+	// are the entities it changes. Their state is saved before the changes and restored on undo
+	UndoRecordData struct {
+		EntityType AuditEntityType
+		EntityID   string
+		Action     AuditAction
+		UserID     string
+		ListIDs    []string
+		HeadingIDs []string
+		TaskIDs    []string
+		// WithHeadingTasks adds the tasks of the headings, for the operations which change them along with the headings
+		WithHeadingTasks bool
+		// WithListContent adds the headings and tasks of the lists, for the operations which change them along with the lists
+		WithListContent bool
+		Created         bool
+	}
+
+	UndoRequestData struct {
+		Token  string `json:"token"`
+		UserID string `json:"user_id"`
+	}
+
+	// UndoTokenResponseData is returned by the operations which have no other data to respond with
+	UndoTokenResponseData struct {
+		ID        string `json:"id,omitempty"`
+		UndoToken string `json:"undo_token"`
+	}
+
+	UndoResponseData struct {
+		Token      string          `json:"token"`
+		EntityType AuditEntityType `json:"entity_type"`
+		EntityID   string          `json:"entity_id"`
+		Action     AuditAction     `json:"action"`
+		UndoneAt   time.Time       `json:"undone_at"`
+	}
+)
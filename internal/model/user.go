@@ -10,6 +10,7 @@ type (
 		ID           string    `db:"id"`
 		Email        string    `db:"email"`
 		PasswordHash string    `db:"password_hash"`
+		TimeZone     string    `db:"time_zone"`
 		UpdatedAt    time.Time `db:"updated_at"`
 		DeletedAt    time.Time `db:"deleted_at"`
 	}
@@ -19,9 +20,15 @@ type (
 		Password string `json:"password" validate:"required,min=8"`
 	}
 
+	// UserTimeZoneRequestData sets the IANA time zone the days of the user are counted in, e.g. Europe/Berlin
+	UserTimeZoneRequestData struct {
+		TimeZone string `json:"time_zone" validate:"required,timezone"`
+	}
+
 	UserResponseData struct {
 		ID        string    `json:"id,omitempty"`
 		Email     string    `json:"email,omitempty"`
+		TimeZone  string    `json:"time_zone,omitempty"`
 		UpdatedAt time.Time `json:"updated_at"`
 	}
 )
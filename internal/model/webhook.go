@@ -0,0 +1,95 @@
+package model
+
+import (
+	"encoding/json"
+	"time"
+)
+
+type WebhookDeliveryStatus string
+
+const (
+	WebhookDeliveryPending   WebhookDeliveryStatus = "pending"
+	WebhookDeliverySucceeded WebhookDeliveryStatus = "succeeded"
+	WebhookDeliveryFailed    WebhookDeliveryStatus = "failed"
+)
+
+func (s WebhookDeliveryStatus) String() string {
+	return string(s)
+}
+
+// Webhook DB model
+type (
+	Webhook struct {
+		ID           string    `db:"id"`
+		URL          string    `db:"url"`
+		EventTypes   []string  `db:"event_types"`
+		Secret       string    `db:"secret"`
+		UserID       string    `db:"user_id"`
+		IsActive     bool      `db:"is_active"`
+		FailureCount int       `db:"failure_count"`
+		DisabledAt   time.Time `db:"disabled_at"`
+		UpdatedAt    time.Time `db:"updated_at"`
+		DeletedAt    time.Time `db:"deleted_at"`
+	}
+
+	WebhookRequestData struct {
+		ID         string   `json:"id"`
+		URL        string   `json:"url" validate:"required,url"`
+		EventTypes []string `json:"event_types"`
+		Secret     string   `json:"secret"`
+		IsActive   bool     `json:"is_active"`
+		UserID     string   `json:"user_id"`
+	}
+
+	WebhookResponseData struct {
+		ID           string    `json:"id,omitempty"`
+		URL          string    `json:"url,omitempty"`
+		EventTypes   []string  `json:"event_types"`
+		Secret       string    `json:"secret,omitempty"`
+		IsActive     bool      `json:"is_active"`
+		FailureCount int       `json:"failure_count"`
+		DisabledAt   time.Time `json:"disabled_at"`
+		UserID       string    `json:"user_id,omitempty"`
+		UpdatedAt    time.Time `json:"updated_at"`
+	}
+)
+
+// WebhookDelivery DB model
+type (
+	WebhookDelivery struct {
+		ID             string                `db:"id"`
+		WebhookID      string                `db:"webhook_id"`
+		UserID         string                `db:"user_id"`
+		EventID        string                `db:"event_id"`
+		EventType      string                `db:"event_type"`
+		Payload        []byte                `db:"payload"`
+		Status         WebhookDeliveryStatus `db:"status"`
+		Attempt        int                   `db:"attempt"`
+		ResponseStatus int                   `db:"response_status"`
+		Error          string                `db:"error"`
+		NextAttemptAt  time.Time             `db:"next_attempt_at"`
+		DeliveredAt    time.Time             `db:"delivered_at"`
+		CreatedAt      time.Time             `db:"created_at"`
+		UpdatedAt      time.Time             `db:"updated_at"`
+		// Endpoint of the webhook, filled when the delivery is claimed for sending
+		URL           string
+		Secret        string
+		WebhookActive bool
+	}
+
+	WebhookDeliveryResponseData struct {
+		ID             string                `json:"id,omitempty"`
+		WebhookID      string                `json:"webhook_id,omitempty"`
+		EventID        string                `json:"event_id,omitempty"`
+		EventType      string                `json:"event_type,omitempty"`
+		Payload        json.RawMessage       `json:"payload,omitempty"`
+		Status         WebhookDeliveryStatus `json:"status,omitempty"`
+		Attempt        int                   `json:"attempt"`
+		ResponseStatus int                   `json:"response_status,omitempty"`
+		Error          string                `json:"error,omitempty"`
+		NextAttemptAt  time.Time             `json:"next_attempt_at"`
+		DeliveredAt    time.Time             `json:"delivered_at"`
+		CreatedAt      time.Time             `json:"created_at"`
+		UpdatedAt      time.Time             `json:"updated_at"`
+	}
+)
@@ -0,0 +1,28 @@
+package port
+
+import (
+	"context"
+
+	"github.com/rshelekhov/reframed/internal/model"
+)
+
+type (
+	AreaUsecase interface {
+		CreateArea(ctx context.Context, data *model.AreaRequestData) (model.AreaResponseData, error)
+		GetAreaByID(ctx context.Context, data model.AreaRequestData) (model.AreaResponseData, error)
+		GetAreasByUserID(ctx context.Context, userID string) ([]model.AreaResponseData, error)
+		UpdateArea(ctx context.Context, data *model.AreaRequestData) (model.AreaResponseData, error)
+		ReorderAreas(ctx context.Context, data *model.ReorderRequestData) error
+		DeleteArea(ctx context.Context, data model.AreaRequestData) error
+	}
+
+	AreaStorage interface {
+		Transaction(ctx context.Context, fn func(ctx context.Context) error) error
+		CreateArea(ctx context.Context, area model.Area) (int, error)
+		GetAreaByID(ctx context.Context, areaID, userID string) (model.Area, error)
+		GetAreasByUserID(ctx context.Context, userID string) ([]model.Area, error)
+		UpdateArea(ctx context.Context, area model.Area) error
+		UpdateAreaPosition(ctx context.Context, area model.Area) error
+		DeleteArea(ctx context.Context, area model.Area) error
+	}
+)
@@ -0,0 +1,47 @@
+package port
+
+import (
+	"context"
+	"io"
+	"time"
+
+	"github.com/rshelekhov/reframed/internal/model"
+)
+
+type (
+	AttachmentUsecase interface {
+		UploadAttachment(ctx context.Context, data model.AttachmentUploadData) (model.AttachmentResponseData, error)
+		GetAttachmentByID(ctx context.Context, data model.AttachmentRequestData) (model.AttachmentResponseData, error)
+		GetAttachmentsByTaskID(ctx context.Context, data model.AttachmentRequestData) ([]model.AttachmentResponseData, error)
+		DeleteAttachment(ctx context.Context, data model.AttachmentRequestData) error
+		GetAttachmentUsage(ctx context.Context, userID string) (model.AttachmentUsageResponseData, error)
+		OpenSignedBlob(ctx context.Context, data model.SignedBlobRequestData) (io.ReadCloser, error)
+		PurgeAttachments(ctx context.Context) (int64, error)
+		MaxUploadSize() int64
+	}
+
+	AttachmentStorage interface {
+		Transaction(ctx context.Context, fn func(ctx context.Context) error) error
+		CreateAttachment(ctx context.Context, attachment model.Attachment) error
+		GetAttachmentByID(ctx context.Context, attachmentID, taskID string) (model.Attachment, error)
+		GetAttachmentsByTaskID(ctx context.Context, taskID string) ([]model.Attachment, error)
+		DeleteAttachment(ctx context.Context, attachment model.Attachment) error
+		LockUserAttachments(ctx context.Context, userID string) error
+		GetUserAttachmentsSize(ctx context.Context, userID string) (int64, error)
+		GetAttachmentsToPurge(ctx context.Context, purgeBefore time.Time, limit int32) ([]model.Attachment, error)
+		PurgeAttachment(ctx context.Context, attachmentID string) error
+	}
+
+	// BlobStore keeps the content of the files, the metadata of the files is kept by AttachmentStorage
+	BlobStore interface {
+		Put(ctx context.Context, key string, content io.Reader, size int64, contentType string) error
+		Open(ctx context.Context, key string) (io.ReadCloser, error)
+		// Delete removes the blob, removing the missing blob isn't an error
+		Delete(ctx context.Context, key string) error
+		// SignedURL returns the link to download the blob without authorization until opts.ExpiresAt
+		SignedURL(ctx context.Context, key string, opts model.BlobURLOptions) (string, error)
+		// VerifySignedURL checks the download link served by the application itself,
+		// the stores which serve the links on their own never accept it
+		VerifySignedURL(data model.SignedBlobRequestData) error
+	}
+)
@@ -0,0 +1,21 @@
+package port
+
+import (
+	"context"
+
+	"github.com/rshelekhov/reframed/internal/model"
+)
+
+type (
+	AuditUsecase interface {
+		RecordChange(ctx context.Context, data model.AuditRecordData) error
+		GetAuditEntriesByEntity(ctx context.Context, data model.AuditRequestData, pgn model.Pagination) ([]model.AuditEntryResponseData, error)
+		GetAuditEntriesByUserID(ctx context.Context, userID string, pgn model.Pagination) ([]model.AuditEntryResponseData, error)
+	}
+
+	AuditStorage interface {
+		CreateAuditEntry(ctx context.Context, entry model.AuditEntry) error
+		GetAuditEntriesByEntity(ctx context.Context, entityType model.AuditEntityType, entityID, userID string, pgn model.Pagination) ([]model.AuditEntry, error)
+		GetAuditEntriesByUserID(ctx context.Context, userID string, pgn model.Pagination) ([]model.AuditEntry, error)
+	}
+)
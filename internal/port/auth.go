@@ -19,11 +19,12 @@ type (
 		LogoutUser(ctx context.Context, userID string, data model.UserDeviceRequestData) error
 		GetUserByID(ctx context.Context, id string) (model.UserResponseData, error)
 		UpdateUser(ctx context.Context, jwt *jwtoken.TokenService, data *model.UserRequestData, userID string) error
+		UpdateUserTimeZone(ctx context.Context, data model.UserTimeZoneRequestData, userID string) (model.UserResponseData, error)
 		DeleteUser(ctx context.Context, userUD string, data model.UserDeviceRequestData) error
 	}
 
 	AuthStorage interface {
-		Transaction(ctx context.Context, fn func(storage AuthStorage) error) error
+		Transaction(ctx context.Context, fn func(ctx context.Context) error) error
 		CreateUser(ctx context.Context, user model.User) error
 		AddDevice(ctx context.Context, device model.UserDevice) error
 		SaveSession(ctx context.Context, session model.Session) error
@@ -37,6 +38,7 @@ type (
 		GetUserByID(ctx context.Context, userID string) (model.User, error)
 		CheckEmailUniqueness(ctx context.Context, user model.User) error
 		UpdateUser(ctx context.Context, user model.User) error
+		UpdateUserTimeZone(ctx context.Context, user model.User) error
 		DeleteUser(ctx context.Context, user model.User) error
 	}
 )
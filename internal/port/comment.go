@@ -0,0 +1,31 @@
+package port
+
+import (
+	"context"
+
+	"github.com/rshelekhov/reframed/internal/model"
+)
+
+type (
+	CommentUsecase interface {
+		CreateComment(ctx context.Context, data *model.CommentRequestData) (model.CommentResponseData, error)
+		GetCommentsByTaskID(ctx context.Context, data model.CommentRequestData, pgn model.Pagination) ([]model.CommentResponseData, error)
+		UpdateComment(ctx context.Context, data *model.CommentRequestData) (model.CommentResponseData, error)
+		DeleteComment(ctx context.Context, data model.CommentRequestData) error
+
+		RecordTaskActivity(ctx context.Context, activity model.TaskActivity) error
+		GetTaskTimeline(ctx context.Context, taskID string) ([]model.TimelineItem, error)
+	}
+
+	CommentStorage interface {
+		CreateComment(ctx context.Context, comment model.Comment) error
+		GetCommentByID(ctx context.Context, commentID, taskID string) (model.Comment, error)
+		GetCommentsByTaskID(ctx context.Context, taskID string, pgn model.Pagination) ([]model.Comment, error)
+		GetAllCommentsByTaskID(ctx context.Context, taskID string) ([]model.Comment, error)
+		UpdateComment(ctx context.Context, comment model.Comment) error
+		DeleteComment(ctx context.Context, comment model.Comment) error
+
+		CreateTaskActivity(ctx context.Context, activity model.TaskActivity) error
+		GetTaskActivities(ctx context.Context, taskID string) ([]model.TaskActivity, error)
+	}
+)
@@ -0,0 +1,17 @@
+package port
+
+import (
+	"context"
+
+	"github.com/rshelekhov/reframed/internal/model"
+)
+
+type (
+	// EventBroker delivers change events to every connected session of the user
+	EventBroker interface {
+		Publish(ctx context.Context, event model.Event) error
+		// Subscribe returns the channel of the user events. The subscription is canceled
+		// and the channel is closed when the context is done
+		Subscribe(ctx context.Context, userID string) (<-chan model.Event, error)
+	}
+)
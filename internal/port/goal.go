@@ -0,0 +1,55 @@
+package port
+
+import (
+	"context"
+	"time"
+
+	"github.com/rshelekhov/reframed/internal/model"
+)
+
+type (
+	GoalUsecase interface {
+		CreateGoal(ctx context.Context, data *model.GoalRequestData) (model.GoalResponseData, error)
+		GetGoalByID(ctx context.Context, data model.GoalRequestData) (model.GoalResponseData, error)
+		GetGoalsByUserID(ctx context.Context, userID string) ([]model.GoalResponseData, error)
+		GetGoalProgressHistory(ctx context.Context, data model.GoalRequestData) ([]model.GoalProgress, error)
+		UpdateGoal(ctx context.Context, data *model.GoalRequestData) (model.GoalResponseData, error)
+		DeleteGoal(ctx context.Context, data model.GoalRequestData) error
+
+		CreateKeyResult(ctx context.Context, data *model.KeyResultRequestData) (model.GoalResponseData, error)
+		UpdateKeyResult(ctx context.Context, data *model.KeyResultRequestData) (model.GoalResponseData, error)
+		DeleteKeyResult(ctx context.Context, data model.KeyResultRequestData) (model.GoalResponseData, error)
+
+		LinkListToGoal(ctx context.Context, data model.GoalLinkRequestData) (model.GoalResponseData, error)
+		UnlinkListFromGoal(ctx context.Context, data model.GoalLinkRequestData) (model.GoalResponseData, error)
+		LinkTaskToGoal(ctx context.Context, data model.GoalLinkRequestData) (model.GoalResponseData, error)
+		UnlinkTaskFromGoal(ctx context.Context, data model.GoalLinkRequestData) (model.GoalResponseData, error)
+	}
+
+	GoalStorage interface {
+		Transaction(ctx context.Context, fn func(ctx context.Context) error) error
+		CreateGoal(ctx context.Context, goal model.Goal) error
+		GetGoalByID(ctx context.Context, goalID, userID string) (model.Goal, error)
+		GetGoalsByUserID(ctx context.Context, userID string) ([]model.Goal, error)
+		UpdateGoal(ctx context.Context, goal model.Goal) error
+		DeleteGoal(ctx context.Context, goal model.Goal) error
+
+		CreateKeyResult(ctx context.Context, keyResult model.KeyResult) error
+		GetKeyResultsByGoalID(ctx context.Context, goalID, userID string) ([]model.KeyResult, error)
+		UpdateKeyResult(ctx context.Context, keyResult model.KeyResult) error
+		DeleteKeyResult(ctx context.Context, keyResult model.KeyResult) error
+
+		LinkListToGoal(ctx context.Context, data model.GoalLinkRequestData, updatedAt time.Time) error
+		UnlinkListFromGoal(ctx context.Context, data model.GoalLinkRequestData, updatedAt time.Time) error
+		LinkTaskToGoal(ctx context.Context, data model.GoalLinkRequestData, updatedAt time.Time) error
+		UnlinkTaskFromGoal(ctx context.Context, data model.GoalLinkRequestData, updatedAt time.Time) error
+		UnlinkGoal(ctx context.Context, goalID, userID string) error
+		GetGoalListIDs(ctx context.Context, goalID, userID string) ([]string, error)
+		GetGoalTaskIDs(ctx context.Context, goalID, userID string) ([]string, error)
+		GetGoalTaskCounts(ctx context.Context, goalID, userID string) (model.GoalTaskCounts, error)
+		GetGoalsByTaskID(ctx context.Context, taskID string) ([]model.Goal, error)
+
+		CreateGoalProgress(ctx context.Context, goalID string, progress model.GoalProgress) error
+		GetGoalProgressHistory(ctx context.Context, goalID string) ([]model.GoalProgress, error)
+	}
+)
@@ -0,0 +1,36 @@
+package port
+
+import (
+	"context"
+	"time"
+
+	"github.com/rshelekhov/reframed/internal/model"
+)
+
+type (
+	HabitUsecase interface {
+		CreateHabit(ctx context.Context, data *model.HabitRequestData) (model.HabitResponseData, error)
+		GetHabitByID(ctx context.Context, data model.HabitRequestData) (model.HabitResponseData, error)
+		GetHabitsByUserID(ctx context.Context, userID string) ([]model.HabitResponseData, error)
+		GetHabitsForToday(ctx context.Context, userID string) ([]model.HabitResponseData, error)
+		UpdateHabit(ctx context.Context, data *model.HabitRequestData) (model.HabitResponseData, error)
+		DeleteHabit(ctx context.Context, data model.HabitRequestData) error
+
+		CheckInHabit(ctx context.Context, data model.HabitCheckInRequestData) (model.HabitResponseData, error)
+		UndoHabitCheckIn(ctx context.Context, data model.HabitCheckInRequestData) (model.HabitResponseData, error)
+		GetHabitHeatmap(ctx context.Context, data model.HabitHeatmapRequestData) (model.HabitHeatmapResponseData, error)
+	}
+
+	HabitStorage interface {
+		CreateHabit(ctx context.Context, habit model.Habit) error
+		GetHabitByID(ctx context.Context, habitID, userID string) (model.Habit, error)
+		GetHabitsByUserID(ctx context.Context, userID string) ([]model.Habit, error)
+		UpdateHabit(ctx context.Context, habit model.Habit) error
+		DeleteHabit(ctx context.Context, habit model.Habit) error
+
+		CreateHabitCheckIn(ctx context.Context, checkIn model.HabitCheckIn) error
+		DeleteHabitCheckIn(ctx context.Context, checkIn model.HabitCheckIn) error
+		GetHabitCheckIns(ctx context.Context, userID string) (map[string][]time.Time, error)
+		GetHabitCheckInsInRange(ctx context.Context, habitID string, from, to time.Time) ([]time.Time, error)
+	}
+)
@@ -14,8 +14,10 @@ type (
 		GetDefaultHeadingID(ctx context.Context, data model.HeadingRequestData) (string, error)
 		GetHeadingsByListID(ctx context.Context, data model.HeadingRequestData) ([]model.HeadingResponseData, error)
 		UpdateHeading(ctx context.Context, data *model.HeadingRequestData) (model.HeadingResponseData, error)
+		PatchHeading(ctx context.Context, data model.HeadingRequestData, patch []byte) (model.HeadingResponseData, error)
 		MoveHeadingToAnotherList(ctx context.Context, data model.HeadingRequestData) (model.HeadingResponseData, error)
-		DeleteHeading(ctx context.Context, data model.HeadingRequestData) error
+		DeleteHeading(ctx context.Context, data model.HeadingRequestData) (string, error)
+		DuplicateHeading(ctx context.Context, data model.HeadingRequestData, opts model.DuplicateOptions) (model.DuplicateResponseData, error)
 	}
 
 	HeadingStorage interface {
@@ -26,5 +28,8 @@ type (
 		UpdateHeading(ctx context.Context, heading model.Heading) error
 		MoveHeadingToAnotherList(ctx context.Context, heading model.Heading, task model.Task) error
 		DeleteHeading(ctx context.Context, heading model.Heading) error
+		HeadingHasTasks(ctx context.Context, headingID string) (bool, error)
+		ArchiveTasksByHeadingID(ctx context.Context, heading model.Heading) error
+		MoveTasksToHeading(ctx context.Context, fromHeadingID string, task model.Task) error
 	}
 )
@@ -0,0 +1,25 @@
+package port
+
+import (
+	"context"
+	"time"
+
+	"github.com/rshelekhov/reframed/internal/model"
+)
+
+type (
+	IdempotencyUsecase interface {
+		StartRequest(ctx context.Context, data model.IdempotencyKey) (model.IdempotencyKey, bool, error)
+		SaveResponse(ctx context.Context, data model.IdempotencyKey) error
+		ReleaseKey(ctx context.Context, userID, key string) error
+		DeleteExpiredKeys(ctx context.Context) (int64, error)
+	}
+
+	IdempotencyStorage interface {
+		CreateIdempotencyKey(ctx context.Context, key model.IdempotencyKey) (bool, error)
+		GetIdempotencyKey(ctx context.Context, userID, key string) (model.IdempotencyKey, error)
+		SaveIdempotentResponse(ctx context.Context, key model.IdempotencyKey) error
+		DeleteIdempotencyKey(ctx context.Context, userID, key string) error
+		DeleteExpiredIdempotencyKeys(ctx context.Context, now time.Time) (int64, error)
+	}
+)
@@ -10,19 +10,46 @@ type (
 	ListUsecase interface {
 		CreateList(ctx context.Context, data *model.ListRequestData) (model.ListResponseData, error)
 		CreateDefaultList(ctx context.Context, userID string) error
+		CreateAreaDefaultList(ctx context.Context, areaID, userID string) error
 		GetListByID(ctx context.Context, data model.ListRequestData) (model.ListResponseData, error)
-		GetListsByUserID(ctx context.Context, userID string) ([]model.ListResponseData, error)
+		GetListsByUserID(ctx context.Context, userID string, includeArchived bool) ([]model.ListResponseData, error)
+		GetArchivedLists(ctx context.Context, userID string) ([]model.ListResponseData, error)
+		GetListsGroupedByAreas(ctx context.Context, userID string) (model.ListsGroupedByAreas, error)
 		GetDefaultListID(ctx context.Context, userID string) (string, error)
+		GetAreaDefaultListID(ctx context.Context, areaID, userID string) (string, error)
 		UpdateList(ctx context.Context, data *model.ListRequestData) (model.ListResponseData, error)
-		DeleteList(ctx context.Context, data model.ListRequestData) error
+		PatchList(ctx context.Context, data model.ListRequestData, patch []byte) (model.ListResponseData, error)
+		MoveListToArea(ctx context.Context, data model.ListRequestData) (model.ListResponseData, error)
+		ReorderLists(ctx context.Context, data *model.ReorderRequestData) (string, error)
+		DetachListsFromArea(ctx context.Context, area model.Area) error
+		DeleteList(ctx context.Context, data model.ListRequestData) (string, error)
+		CompleteList(ctx context.Context, data model.ListRequestData) (model.ListResponseData, error)
+		ArchiveList(ctx context.Context, data model.ListRequestData) (model.ListResponseData, error)
+		RestoreList(ctx context.Context, data model.ListRequestData) (model.ListResponseData, error)
+		ConvertHeadingToList(ctx context.Context, data model.HeadingRequestData) (model.ListResponseData, error)
+		ConvertListToHeading(ctx context.Context, data model.ConvertListRequestData) (model.HeadingResponseData, error)
+		DuplicateList(ctx context.Context, data model.ListRequestData, opts model.DuplicateOptions) (model.DuplicateResponseData, error)
 	}
 
 	ListStorage interface {
-		CreateList(ctx context.Context, list model.List) error
+		Transaction(ctx context.Context, fn func(ctx context.Context) error) error
+		CreateList(ctx context.Context, list model.List) (int, error)
 		GetListByID(ctx context.Context, listID, userID string) (model.List, error)
-		GetListsByUserID(ctx context.Context, userID string) ([]model.List, error)
+		GetListsByUserID(ctx context.Context, userID string, includeArchived bool) ([]model.List, error)
+		GetArchivedListsByUserID(ctx context.Context, userID string) ([]model.List, error)
 		GetDefaultListID(ctx context.Context, userID string) (string, error)
+		GetAreaDefaultListID(ctx context.Context, areaID, userID string) (string, error)
 		UpdateList(ctx context.Context, list model.List) error
+		MoveListToArea(ctx context.Context, list model.List) error
+		UpdateListPosition(ctx context.Context, list model.List) error
+		UpdateListStatus(ctx context.Context, list model.List) error
+		DetachListsFromArea(ctx context.Context, area model.Area) error
 		DeleteList(ctx context.Context, list model.List) error
+		ListHasContent(ctx context.Context, listID string) (bool, error)
+		ArchiveTasksByListID(ctx context.Context, list model.List) error
+		MoveTasksToList(ctx context.Context, fromListID string, task model.Task) error
+		MoveUserTasksToList(ctx context.Context, fromListID string, task model.Task) error
+		DeleteHeadingsByListID(ctx context.Context, list model.List) error
+		CompleteTasksByListID(ctx context.Context, list model.List) error
 	}
 )
@@ -0,0 +1,44 @@
+package port
+
+import (
+	"context"
+	"time"
+
+	"github.com/rshelekhov/reframed/internal/model"
+)
+
+type (
+	ListMemberUsecase interface {
+		GetListMembers(ctx context.Context, listID, userID string) ([]model.ListMemberResponseData, error)
+		UpdateListMemberRole(ctx context.Context, data *model.ListMemberRequestData) (model.ListMemberResponseData, error)
+		RemoveListMember(ctx context.Context, data model.ListMemberRequestData) error
+
+		InviteToList(ctx context.Context, data *model.ListInvitationRequestData) (model.ListInvitationResponseData, error)
+		GetListInvitations(ctx context.Context, listID, userID string) ([]model.ListInvitationResponseData, error)
+		GetInvitationsByUserID(ctx context.Context, userID string) ([]model.ListInvitationResponseData, error)
+		AcceptListInvitation(ctx context.Context, data model.ListInvitationRequestData) (model.ListResponseData, error)
+		DeclineListInvitation(ctx context.Context, data model.ListInvitationRequestData) error
+		RevokeListInvitation(ctx context.Context, data model.ListInvitationRequestData) error
+	}
+
+	ListMemberStorage interface {
+		Transaction(ctx context.Context, fn func(ctx context.Context) error) error
+		CreateListMember(ctx context.Context, member model.ListMember) error
+		GetListMemberRole(ctx context.Context, listID, userID string) (model.ListRole, error)
+		GetListMemberRoleWithDeleted(ctx context.Context, listID, userID string) (model.ListRole, error)
+		GetHeadingMemberRole(ctx context.Context, headingID, userID string) (model.ListRole, error)
+		GetTaskMemberRole(ctx context.Context, taskID, userID string) (model.ListRole, error)
+		GetListMembers(ctx context.Context, listID string) ([]model.ListMember, error)
+		GetTaskMemberIDs(ctx context.Context, taskID string) ([]string, error)
+		CountListOwners(ctx context.Context, listID string) (int, error)
+		UpdateListMemberRole(ctx context.Context, member model.ListMember) error
+		DeleteListMember(ctx context.Context, listID, userID string) error
+		UnassignNonMemberTasks(ctx context.Context, listID string) error
+
+		CreateListInvitation(ctx context.Context, invitation model.ListInvitation) (model.ListInvitation, error)
+		GetListInvitationByID(ctx context.Context, invitationID string) (model.ListInvitation, error)
+		GetPendingListInvitationsByListID(ctx context.Context, listID string) ([]model.ListInvitation, error)
+		GetPendingListInvitationsByEmail(ctx context.Context, email string) ([]model.ListInvitation, error)
+		UpdateListInvitationStatus(ctx context.Context, invitationID string, status model.InvitationStatus, updatedAt time.Time) error
+	}
+)
@@ -0,0 +1,31 @@
+package port
+
+import (
+	"context"
+	"time"
+
+	"github.com/rshelekhov/reframed/internal/model"
+)
+
+type (
+	ReviewUsecase interface {
+		StartReview(ctx context.Context, data model.ReviewRequestData) (model.ReviewResponseData, error)
+		GetCurrentReview(ctx context.Context, userID string) (model.ReviewResponseData, error)
+		GetReviewByID(ctx context.Context, data model.ReviewRequestData) (model.ReviewResponseData, error)
+		ActOnReviewItem(ctx context.Context, data model.ReviewItemActionRequestData) (model.ReviewItemResponseData, error)
+		CompleteReview(ctx context.Context, data model.ReviewRequestData) (model.ReviewResponseData, error)
+	}
+
+	ReviewStorage interface {
+		Transaction(ctx context.Context, fn func(ctx context.Context) error) error
+		CreateReviewSession(ctx context.Context, review model.ReviewSession) error
+		GetReviewSessionByID(ctx context.Context, reviewID, userID string) (model.ReviewSession, error)
+		GetOpenReviewSession(ctx context.Context, userID string) (model.ReviewSession, error)
+		CompleteReviewSession(ctx context.Context, review model.ReviewSession) error
+		GetReviewCandidates(ctx context.Context, userID string, since, staleBefore time.Time) ([]model.ReviewItem, error)
+		CreateReviewItem(ctx context.Context, item model.ReviewItem) error
+		GetReviewItems(ctx context.Context, reviewID string) ([]model.ReviewItem, error)
+		GetReviewItemByID(ctx context.Context, itemID, reviewID, userID string) (model.ReviewItem, error)
+		UpdateReviewItemAction(ctx context.Context, item model.ReviewItem, userID string) error
+	}
+)
@@ -0,0 +1,31 @@
+package port
+
+import (
+	"context"
+	"time"
+
+	"github.com/rshelekhov/reframed/internal/model"
+)
+
+type (
+	RuleUsecase interface {
+		CreateRule(ctx context.Context, data *model.RuleRequestData) (model.RuleResponseData, error)
+		GetRuleByID(ctx context.Context, data model.RuleRequestData) (model.RuleResponseData, error)
+		GetRulesByUserID(ctx context.Context, userID string) ([]model.RuleResponseData, error)
+		UpdateRule(ctx context.Context, data *model.RuleRequestData) (model.RuleResponseData, error)
+		DeleteRule(ctx context.Context, data model.RuleRequestData) error
+		RunRules(ctx context.Context, triggers ...model.RuleTriggerData) (bool, error)
+		RunDeadlineRules(ctx context.Context) error
+	}
+
+	RuleStorage interface {
+		CreateRule(ctx context.Context, rule model.Rule) error
+		GetRuleByID(ctx context.Context, ruleID, userID string) (model.Rule, error)
+		GetRulesByUserID(ctx context.Context, userID string) ([]model.Rule, error)
+		GetActiveRulesByTrigger(ctx context.Context, userID string, trigger model.RuleTrigger) ([]model.Rule, error)
+		UpdateRule(ctx context.Context, rule model.Rule) error
+		DeleteRule(ctx context.Context, rule model.Rule) error
+		GetPendingDeadlineRuleRuns(ctx context.Context, now time.Time, limit int) ([]model.RuleDeadlineRun, error)
+		CreateRuleDeadlineRun(ctx context.Context, run model.RuleDeadlineRun, ranAt time.Time) (bool, error)
+	}
+)
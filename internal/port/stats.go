@@ -0,0 +1,21 @@
+package port
+
+import (
+	"context"
+
+	"github.com/rshelekhov/reframed/internal/model"
+)
+
+type (
+	StatsUsecase interface {
+		GetStats(ctx context.Context, data model.StatsRequestData) (model.StatsResponseData, error)
+	}
+
+	StatsStorage interface {
+		GetTaskThroughput(ctx context.Context, data model.StatsRequestData) ([]model.StatsThroughput, error)
+		GetCompletionRatesByList(ctx context.Context, data model.StatsRequestData) ([]model.StatsCompletionRate, error)
+		GetCompletionRatesByTag(ctx context.Context, data model.StatsRequestData) ([]model.StatsCompletionRate, error)
+		GetTaskSummaryStats(ctx context.Context, data model.StatsRequestData) (model.StatsSummary, error)
+		GetCompletionStreaks(ctx context.Context, userID string) (model.StatsStreaks, error)
+	}
+)
@@ -11,39 +11,71 @@ type (
 		CreateTask(ctx context.Context, data *model.TaskRequestData) (model.TaskResponseData, error)
 		GetTaskByID(ctx context.Context, data model.TaskRequestData) (model.TaskResponseData, error)
 		GetTasksByUserID(ctx context.Context, userID string, pgn model.Pagination) ([]model.TaskResponseData, error)
+		GetAssignedTasks(ctx context.Context, userID string, pgn model.Pagination) ([]model.TaskResponseData, error)
 		GetTasksByListID(ctx context.Context, data model.TaskRequestData) ([]model.TaskResponseData, error)
+		GetAllTasksByListID(ctx context.Context, data model.TaskRequestData) ([]model.TaskResponseData, error)
+		GetTasksByAreaID(ctx context.Context, data model.TaskRequestData) ([]model.TaskResponseData, error)
 		GetTasksGroupedByHeadings(ctx context.Context, data model.TaskRequestData) ([]model.TaskGroup, error)
-		GetTasksForToday(ctx context.Context, userID string) ([]model.TaskGroup, error)
-		GetUpcomingTasks(ctx context.Context, userID string, pgn model.Pagination) ([]model.TaskGroup, error)
+		GetTasksForToday(ctx context.Context, userID string, hideBlocked bool) (model.TodayTasksResponseData, error)
+		GetDailyPlanCandidates(ctx context.Context, userID string, days int) (model.DailyPlanCandidatesResponseData, error)
+		CommitDailyPlan(ctx context.Context, data model.DailyPlanRequestData) (model.TodayTasksResponseData, error)
+		PlanTaskForEvening(ctx context.Context, data model.TaskRequestData, thisEvening bool) (model.TaskResponseData, error)
+		GetUpcomingTasks(ctx context.Context, userID string, pgn model.Pagination, hideBlocked bool) ([]model.TaskGroup, error)
 		GetOverdueTasks(ctx context.Context, userID string, pgn model.Pagination) ([]model.TaskGroup, error)
 		GetTasksForSomeday(ctx context.Context, userID string, pgn model.Pagination) ([]model.TaskGroup, error)
 		GetCompletedTasks(ctx context.Context, userID string, pgn model.Pagination) ([]model.TaskGroup, error)
 		GetArchivedTasks(ctx context.Context, userID string, pgn model.Pagination) ([]model.TaskGroup, error)
+		GetBlockedTasks(ctx context.Context, userID string, pgn model.Pagination) ([]model.TaskResponseData, error)
 		UpdateTask(ctx context.Context, data *model.TaskRequestData) (model.TaskResponseData, error)
+		PatchTask(ctx context.Context, data model.TaskRequestData, patch []byte) (model.TaskResponseData, error)
 		UpdateTaskTime(ctx context.Context, data *model.TaskRequestTimeData) (model.TaskResponseTimeData, error)
-		MoveTaskToAnotherList(ctx context.Context, data model.TaskRequestData) error
-		CompleteTask(ctx context.Context, data model.TaskRequestData) error
-		ArchiveTask(ctx context.Context, data model.TaskRequestData) error
+		MoveTaskToAnotherList(ctx context.Context, data model.TaskRequestData) (string, error)
+		CompleteTask(ctx context.Context, data model.TaskRequestData) (string, error)
+		ArchiveTask(ctx context.Context, data model.TaskRequestData) (string, error)
+		AssignTask(ctx context.Context, data model.TaskAssigneeRequestData) (model.TaskResponseData, error)
+		UnassignTask(ctx context.Context, data model.TaskRequestData) (model.TaskResponseData, error)
+		SnoozeTask(ctx context.Context, data model.TaskSnoozeRequestData) (model.TaskResponseData, error)
+		UnsnoozeTask(ctx context.Context, data model.TaskRequestData) (model.TaskResponseData, error)
+		AddTaskDependency(ctx context.Context, data model.TaskDependencyRequestData) (model.TaskDependencyResponseData, error)
+		GetTaskBlockers(ctx context.Context, data model.TaskRequestData) ([]model.TaskResponseData, error)
+		RemoveTaskDependency(ctx context.Context, data model.TaskDependencyRequestData) error
+		DuplicateTask(ctx context.Context, data model.TaskRequestData, opts model.DuplicateOptions) (model.TaskResponseData, error)
 	}
 
 	TaskStorage interface {
-		Transaction(ctx context.Context, fn func(storage TaskStorage) error) error
+		Transaction(ctx context.Context, fn func(ctx context.Context) error) error
 		CreateTask(ctx context.Context, task model.Task) error
 		GetTaskStatusID(ctx context.Context, status model.StatusName) (int, error)
 		GetTaskByID(ctx context.Context, taskID, userID string) (model.Task, error)
 		GetTasksByUserID(ctx context.Context, userID string, pgn model.Pagination) ([]model.Task, error)
+		GetAssignedTasks(ctx context.Context, userID string, pgn model.Pagination) ([]model.Task, error)
 		GetTasksByListID(ctx context.Context, listID, userID string) ([]model.Task, error)
+		GetAllTasksByListID(ctx context.Context, listID, userID string) ([]model.Task, error)
 		GetTasksGroupedByHeadings(ctx context.Context, listID, userID string) ([]model.TaskGroup, error)
 		GetTasksForToday(ctx context.Context, userID string) ([]model.TaskGroup, error)
+		GetDailyPlanCandidates(ctx context.Context, userID string, days int) ([]model.DailyPlanCandidate, error)
+		PlanTaskForToday(ctx context.Context, task model.Task) error
 		GetUpcomingTasks(ctx context.Context, userID string, pgn model.Pagination) ([]model.TaskGroup, error)
 		GetOverdueTasks(ctx context.Context, userID string, pgn model.Pagination) ([]model.TaskGroup, error)
 		GetTasksForSomeday(ctx context.Context, userID string, pgn model.Pagination) ([]model.TaskGroup, error)
 		GetCompletedTasks(ctx context.Context, userID string, pgn model.Pagination) ([]model.TaskGroup, error)
 		GetArchivedTasks(ctx context.Context, userID string, pgn model.Pagination) ([]model.TaskGroup, error)
 		UpdateTask(ctx context.Context, task model.Task) error
+		PatchTask(ctx context.Context, task model.Task) error
 		UpdateTaskTime(ctx context.Context, task model.Task) error
 		MoveTaskToAnotherList(ctx context.Context, task model.Task) error
+		AssignTask(ctx context.Context, task model.Task) error
+		SnoozeTask(ctx context.Context, task model.Task) error
+		UnsnoozeTask(ctx context.Context, task model.Task) error
 		MarkAsCompleted(ctx context.Context, task model.Task) error
 		MarkAsArchived(ctx context.Context, task model.Task) error
+		LockTaskDependencies(ctx context.Context) error
+		CreateTaskDependency(ctx context.Context, dependency model.TaskDependency) error
+		DeleteTaskDependency(ctx context.Context, dependency model.TaskDependency) error
+		HasTaskDependencyPath(ctx context.Context, fromTaskID, toTaskID string) (bool, error)
+		GetTaskBlockers(ctx context.Context, taskID, userID string) ([]model.Task, error)
+		GetDependentTaskIDs(ctx context.Context, taskID, userID string) ([]string, error)
+		GetBlockedTaskIDs(ctx context.Context, userID string, taskIDs []string) ([]string, error)
+		GetBlockedTasks(ctx context.Context, userID string, pgn model.Pagination) ([]model.Task, error)
 	}
 )
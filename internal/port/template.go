@@ -0,0 +1,25 @@
+package port
+
+import (
+	"context"
+
+	"github.com/rshelekhov/reframed/internal/model"
+)
+
+type (
+	TemplateUsecase interface {
+		CreateTemplate(ctx context.Context, data *model.TemplateRequestData) (model.TemplateResponseData, error)
+		GetTemplateByID(ctx context.Context, data model.TemplateRequestData) (model.TemplateResponseData, error)
+		GetTemplatesByUserID(ctx context.Context, userID string) ([]model.TemplateResponseData, error)
+		DeleteTemplate(ctx context.Context, data model.TemplateRequestData) error
+		InstantiateTemplate(ctx context.Context, data *model.TemplateInstanceRequestData) (model.TemplateInstanceResponseData, error)
+	}
+
+	TemplateStorage interface {
+		Transaction(ctx context.Context, fn func(ctx context.Context) error) error
+		CreateTemplate(ctx context.Context, template model.Template) error
+		GetTemplateByID(ctx context.Context, templateID, userID string) (model.Template, error)
+		GetTemplatesByUserID(ctx context.Context, userID string) ([]model.Template, error)
+		DeleteTemplate(ctx context.Context, template model.Template) error
+	}
+)
@@ -0,0 +1,28 @@
+package port
+
+import (
+	"context"
+	"time"
+
+	"github.com/rshelekhov/reframed/internal/model"
+)
+
+type (
+	UndoUsecase interface {
+		CreateUndoToken(ctx context.Context, data model.UndoRecordData) (string, error)
+		Undo(ctx context.Context, data model.UndoRequestData) (model.UndoResponseData, error)
+		DeleteExpiredUndoOperations(ctx context.Context) (int64, error)
+	}
+
+	UndoStorage interface {
+		Transaction(ctx context.Context, fn func(ctx context.Context) error) error
+		GetUndoSnapshot(ctx context.Context, data model.UndoRecordData) (model.UndoSnapshot, error)
+		CreateUndoOperation(ctx context.Context, operation model.UndoOperation) error
+		GetUndoOperation(ctx context.Context, id, userID string) (model.UndoOperation, error)
+		GetUndoListIDs(ctx context.Context, operation model.UndoOperation) ([]string, error)
+		HasUndoConflicts(ctx context.Context, operation model.UndoOperation) (bool, error)
+		RestoreUndoSnapshot(ctx context.Context, operation model.UndoOperation, now time.Time) error
+		MarkUndoOperationAsUndone(ctx context.Context, id string, undoneAt time.Time) error
+		DeleteExpiredUndoOperations(ctx context.Context, now time.Time) (int64, error)
+	}
+)
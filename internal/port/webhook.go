@@ -0,0 +1,37 @@
+package port
+
+import (
+	"context"
+	"time"
+
+	"github.com/rshelekhov/reframed/internal/model"
+)
+
+type (
+	WebhookUsecase interface {
+		CreateWebhook(ctx context.Context, data *model.WebhookRequestData) (model.WebhookResponseData, error)
+		GetWebhookByID(ctx context.Context, data model.WebhookRequestData) (model.WebhookResponseData, error)
+		GetWebhooksByUserID(ctx context.Context, userID string) ([]model.WebhookResponseData, error)
+		UpdateWebhook(ctx context.Context, data *model.WebhookRequestData) (model.WebhookResponseData, error)
+		DeleteWebhook(ctx context.Context, data model.WebhookRequestData) error
+		GetWebhookDeliveries(ctx context.Context, data model.WebhookRequestData, pgn model.Pagination) ([]model.WebhookDeliveryResponseData, error)
+		SendTestEvent(ctx context.Context, data model.WebhookRequestData) (model.WebhookDeliveryResponseData, error)
+		Publish(ctx context.Context, event model.Event) error
+		DeliverPendingWebhooks(ctx context.Context) error
+	}
+
+	WebhookStorage interface {
+		CreateWebhook(ctx context.Context, webhook model.Webhook) error
+		GetWebhookByID(ctx context.Context, webhookID, userID string) (model.Webhook, error)
+		GetWebhooksByUserID(ctx context.Context, userID string) ([]model.Webhook, error)
+		GetActiveWebhooksByEventType(ctx context.Context, userID, eventType string) ([]model.Webhook, error)
+		UpdateWebhook(ctx context.Context, webhook model.Webhook) error
+		DeleteWebhook(ctx context.Context, webhook model.Webhook) error
+		ResetWebhookFailureCount(ctx context.Context, webhookID string) error
+		IncrementWebhookFailureCount(ctx context.Context, webhookID string, maxFailures int, failedAt time.Time) (bool, error)
+		CreateWebhookDelivery(ctx context.Context, delivery model.WebhookDelivery) error
+		ClaimWebhookDeliveries(ctx context.Context, now, leaseUntil time.Time, limit int) ([]model.WebhookDelivery, error)
+		UpdateWebhookDelivery(ctx context.Context, delivery model.WebhookDelivery) error
+		GetWebhookDeliveries(ctx context.Context, webhookID, userID string, pgn model.Pagination) ([]model.WebhookDelivery, error)
+	}
+)
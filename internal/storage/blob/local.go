@@ -0,0 +1,166 @@
+// Package blob provides the blob stores the content of the attached files is kept in.
+package blob
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/rshelekhov/reframed/internal/lib/constants/key"
+	"github.com/rshelekhov/reframed/internal/lib/constants/le"
+	"github.com/rshelekhov/reframed/internal/lib/hmacsign"
+	"github.com/rshelekhov/reframed/internal/model"
+)
+
+// LocalStore keeps the blobs in the directory on the local filesystem.
+// Its download links are served by the application itself and signed with HMAC-SHA256
+type LocalStore struct {
+	dir        string
+	baseURL    string
+	signingKey string
+}
+
+// NewLocalStore creates the directory of the blobs if it doesn't exist.
+// baseURL is the public address of the route serving the download links, the key of the blob is appended to it
+func NewLocalStore(dir, baseURL, signingKey string) (*LocalStore, error) {
+	const op = "blob.local.NewLocalStore"
+
+	if signingKey == "" {
+		return nil, fmt.Errorf("%s: signing key is not set", op)
+	}
+
+	if err := os.MkdirAll(dir, 0o750); err != nil {
+		return nil, fmt.Errorf("%s: failed to create directory of blobs: %w", op, err)
+	}
+
+	return &LocalStore{
+		dir:        dir,
+		baseURL:    strings.TrimSuffix(baseURL, "/"),
+		signingKey: signingKey,
+	}, nil
+}
+
+// Put writes the blob to a temporary file first, so a failed upload never leaves a partial blob
+func (s *LocalStore) Put(_ context.Context, blobKey string, content io.Reader, size int64, _ string) error {
+	const op = "blob.local.Put"
+
+	path, err := s.path(blobKey)
+	if err != nil {
+		return err
+	}
+
+	if err = os.MkdirAll(filepath.Dir(path), 0o750); err != nil {
+		return fmt.Errorf("%s: failed to create directory of blob: %w", op, err)
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".upload-*")
+	if err != nil {
+		return fmt.Errorf("%s: failed to create temporary file: %w", op, err)
+	}
+	defer os.Remove(tmp.Name())
+
+	written, err := io.Copy(tmp, content)
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return fmt.Errorf("%s: failed to write blob: %w", op, err)
+	}
+	if written != size {
+		return fmt.Errorf("%s: blob size mismatch, expected %d bytes, written %d", op, size, written)
+	}
+
+	if err = os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("%s: failed to save blob: %w", op, err)
+	}
+	return nil
+}
+
+func (s *LocalStore) Open(_ context.Context, blobKey string) (io.ReadCloser, error) {
+	const op = "blob.local.Open"
+
+	path, err := s.path(blobKey)
+	if err != nil {
+		return nil, err
+	}
+
+	file, err := os.Open(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, le.ErrBlobNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to open blob: %w", op, err)
+	}
+	return file, nil
+}
+
+func (s *LocalStore) Delete(_ context.Context, blobKey string) error {
+	const op = "blob.local.Delete"
+
+	path, err := s.path(blobKey)
+	if err != nil {
+		return err
+	}
+
+	if err = os.Remove(path); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("%s: failed to delete blob: %w", op, err)
+	}
+	return nil
+}
+
+func (s *LocalStore) SignedURL(_ context.Context, blobKey string, opts model.BlobURLOptions) (string, error) {
+	if _, err := s.path(blobKey); err != nil {
+		return "", err
+	}
+
+	expires := opts.ExpiresAt.Unix()
+
+	query := url.Values{}
+	query.Set(key.Expires, strconv.FormatInt(expires, 10))
+	query.Set(key.FileName, opts.FileName)
+	query.Set(key.ContentType, opts.ContentType)
+	query.Set(key.Signature, s.sign(blobKey, opts.FileName, opts.ContentType, expires))
+
+	return s.baseURL + "/" + uriEncode(blobKey, false) + "?" + query.Encode(), nil
+}
+
+// VerifySignedURL checks the signature before the expiration, so nothing is disclosed about forged links
+func (s *LocalStore) VerifySignedURL(data model.SignedBlobRequestData) error {
+	if !hmacsign.Verify(s.signingKey, data.Signature, data.Expires, signedBlobPayload(data.Key, data.FileName, data.ContentType)) {
+		return le.ErrInvalidBlobSignature
+	}
+	if time.Now().Unix() > data.Expires {
+		return le.ErrBlobURLExpired
+	}
+	return nil
+}
+
+func (s *LocalStore) sign(blobKey, fileName, contentType string, expires int64) string {
+	return hmacsign.Sign(s.signingKey, expires, signedBlobPayload(blobKey, fileName, contentType))
+}
+
+// path maps the key of the blob to the file in the directory of the blobs, the keys escaping the directory are rejected
+func (s *LocalStore) path(blobKey string) (string, error) {
+	if blobKey == "" || strings.HasPrefix(blobKey, "/") || strings.Contains(blobKey, `\`) {
+		return "", le.ErrBlobNotFound
+	}
+	for _, part := range strings.Split(blobKey, "/") {
+		if part == "" || part == "." || part == ".." {
+			return "", le.ErrBlobNotFound
+		}
+	}
+	return filepath.Join(s.dir, filepath.FromSlash(blobKey)), nil
+}
+
+// signedBlobPayload joins the signed parts of the link with the newline, which can't be a part of any of them
+func signedBlobPayload(blobKey, fileName, contentType string) []byte {
+	return []byte(blobKey + "\n" + fileName + "\n" + contentType)
+}
@@ -0,0 +1,313 @@
+package blob
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"math"
+	"mime"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/rshelekhov/reframed/config"
+	"github.com/rshelekhov/reframed/internal/lib/constants/le"
+	"github.com/rshelekhov/reframed/internal/model"
+)
+
+const (
+	s3Algorithm      = "AWS4-HMAC-SHA256"
+	s3Service        = "s3"
+	s3UnsignedBody   = "UNSIGNED-PAYLOAD"
+	s3TimeFormat     = "20060102T150405Z"
+	s3DateFormat     = "20060102"
+	s3MaxURLLifetime = 7 * 24 * time.Hour
+
+	// s3ErrorBodyLimit is how much of the error response is kept in the error message
+	s3ErrorBodyLimit = 1024
+)
+
+// S3Store keeps the blobs in the bucket of an S3-compatible storage. The requests are signed
+// with AWS Signature Version 4, so it works with AWS S3 as well as with MinIO and the like.
+// Its download links are presigned and served by the storage itself
+type S3Store struct {
+	client          *http.Client
+	endpoint        *url.URL
+	region          string
+	bucket          string
+	accessKeyID     string
+	secretAccessKey string
+	usePathStyle    bool
+}
+
+func NewS3Store(client *http.Client, cfg config.S3BlobConfig) (*S3Store, error) {
+	const op = "blob.s3.NewS3Store"
+
+	if cfg.Bucket == "" {
+		return nil, fmt.Errorf("%s: bucket is not set", op)
+	}
+
+	endpoint, err := url.Parse(cfg.Endpoint)
+	if err != nil || endpoint.Host == "" {
+		return nil, fmt.Errorf("%s: invalid endpoint %q", op, cfg.Endpoint)
+	}
+
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	return &S3Store{
+		client:          client,
+		endpoint:        endpoint,
+		region:          cfg.Region,
+		bucket:          cfg.Bucket,
+		accessKeyID:     cfg.AccessKeyID,
+		secretAccessKey: cfg.SecretAccessKey,
+		usePathStyle:    cfg.UsePathStyle,
+	}, nil
+}
+
+func (s *S3Store) Put(ctx context.Context, key string, content io.Reader, size int64, contentType string) error {
+	const op = "blob.s3.Put"
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, s.objectURL(key).String(), content)
+	if err != nil {
+		return fmt.Errorf("%s: failed to create request: %w", op, err)
+	}
+
+	// The storage doesn't accept the chunked uploads without the length of the whole blob
+	req.ContentLength = size
+	req.Header.Set("Content-Type", contentType)
+
+	resp, err := s.do(req)
+	if err != nil {
+		return fmt.Errorf("%s: failed to put blob: %w", op, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("%s: failed to put blob: %w", op, unexpectedStatus(resp))
+	}
+	return nil
+}
+
+func (s *S3Store) Open(ctx context.Context, key string) (io.ReadCloser, error) {
+	const op = "blob.s3.Open"
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, s.objectURL(key).String(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to create request: %w", op, err)
+	}
+
+	resp, err := s.do(req)
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to get blob: %w", op, err)
+	}
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+		return resp.Body, nil
+	case http.StatusNotFound:
+		resp.Body.Close()
+		return nil, le.ErrBlobNotFound
+	default:
+		defer resp.Body.Close()
+		return nil, fmt.Errorf("%s: failed to get blob: %w", op, unexpectedStatus(resp))
+	}
+}
+
+func (s *S3Store) Delete(ctx context.Context, key string) error {
+	const op = "blob.s3.Delete"
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodDelete, s.objectURL(key).String(), nil)
+	if err != nil {
+		return fmt.Errorf("%s: failed to create request: %w", op, err)
+	}
+
+	resp, err := s.do(req)
+	if err != nil {
+		return fmt.Errorf("%s: failed to delete blob: %w", op, err)
+	}
+	defer resp.Body.Close()
+
+	// S3 answers 204 for the missing objects too, some compatible storages answer 404
+	if resp.StatusCode != http.StatusNoContent && resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusNotFound {
+		return fmt.Errorf("%s: failed to delete blob: %w", op, unexpectedStatus(resp))
+	}
+	return nil
+}
+
+// SignedURL presigns the download link with the query parameters, the storage serves the file
+// with the content type and the file name signed into the link
+func (s *S3Store) SignedURL(_ context.Context, key string, opts model.BlobURLOptions) (string, error) {
+	return s.presign(key, opts, time.Now().UTC()), nil
+}
+
+func (s *S3Store) presign(key string, opts model.BlobURLOptions, now time.Time) string {
+	lifetime := opts.ExpiresAt.Sub(now)
+	if lifetime > s3MaxURLLifetime {
+		lifetime = s3MaxURLLifetime
+	}
+	expires := int64(math.Ceil(lifetime.Seconds()))
+	if expires < 1 {
+		expires = 1
+	}
+
+	u := s.objectURL(key)
+
+	query := map[string]string{
+		"X-Amz-Algorithm":     s3Algorithm,
+		"X-Amz-Credential":    s.accessKeyID + "/" + s.scope(now),
+		"X-Amz-Date":          now.Format(s3TimeFormat),
+		"X-Amz-Expires":       strconv.FormatInt(expires, 10),
+		"X-Amz-SignedHeaders": "host",
+	}
+	if opts.ContentType != "" {
+		query["response-content-type"] = opts.ContentType
+	}
+	if opts.FileName != "" {
+		query["response-content-disposition"] = mime.FormatMediaType("attachment", map[string]string{"filename": opts.FileName})
+	}
+
+	canonicalQuery := canonicalQueryString(query)
+
+	canonicalRequest := strings.Join([]string{
+		http.MethodGet,
+		u.EscapedPath(),
+		canonicalQuery,
+		"host:" + u.Host + "\n",
+		"host",
+		s3UnsignedBody,
+	}, "\n")
+
+	u.RawQuery = canonicalQuery + "&X-Amz-Signature=" + s.signature(now, canonicalRequest)
+
+	return u.String()
+}
+
+// VerifySignedURL never accepts the link, the presigned links are checked by the storage itself
+func (s *S3Store) VerifySignedURL(_ model.SignedBlobRequestData) error {
+	return le.ErrInvalidBlobSignature
+}
+
+// do signs the request with the Authorization header and sends it. The body isn't hashed,
+// so the blobs are streamed to the storage without reading them twice
+func (s *S3Store) do(req *http.Request) (*http.Response, error) {
+	s.sign(req, time.Now().UTC())
+
+	return s.client.Do(req)
+}
+
+func (s *S3Store) sign(req *http.Request, now time.Time) {
+	amzDate := now.Format(s3TimeFormat)
+
+	req.Header.Set("X-Amz-Date", amzDate)
+	req.Header.Set("X-Amz-Content-Sha256", s3UnsignedBody)
+
+	const signedHeaders = "host;x-amz-content-sha256;x-amz-date"
+
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		req.URL.EscapedPath(),
+		"",
+		"host:" + req.URL.Host + "\n" +
+			"x-amz-content-sha256:" + s3UnsignedBody + "\n" +
+			"x-amz-date:" + amzDate + "\n",
+		signedHeaders,
+		s3UnsignedBody,
+	}, "\n")
+
+	req.Header.Set("Authorization", fmt.Sprintf("%s Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		s3Algorithm, s.accessKeyID, s.scope(now), signedHeaders, s.signature(now, canonicalRequest),
+	))
+}
+
+// objectURL addresses the blob in the bucket by the path (http://host/bucket/key), which is usually
+// needed for the local storages, or by the virtual host (http://bucket.host/key)
+func (s *S3Store) objectURL(key string) *url.URL {
+	u := *s.endpoint
+	u.RawQuery = ""
+
+	if s.usePathStyle {
+		u.Path = "/" + s.bucket + "/" + key
+		u.RawPath = "/" + uriEncode(s.bucket, true) + "/" + uriEncode(key, false)
+	} else {
+		u.Host = s.bucket + "." + u.Host
+		u.Path = "/" + key
+		u.RawPath = "/" + uriEncode(key, false)
+	}
+	return &u
+}
+
+func (s *S3Store) scope(t time.Time) string {
+	return t.Format(s3DateFormat) + "/" + s.region + "/" + s3Service + "/aws4_request"
+}
+
+func (s *S3Store) signature(t time.Time, canonicalRequest string) string {
+	hash := sha256.Sum256([]byte(canonicalRequest))
+
+	stringToSign := strings.Join([]string{
+		s3Algorithm,
+		t.Format(s3TimeFormat),
+		s.scope(t),
+		hex.EncodeToString(hash[:]),
+	}, "\n")
+
+	signingKey := hmacSHA256([]byte("AWS4"+s.secretAccessKey), t.Format(s3DateFormat))
+	signingKey = hmacSHA256(signingKey, s.region)
+	signingKey = hmacSHA256(signingKey, s3Service)
+	signingKey = hmacSHA256(signingKey, "aws4_request")
+
+	return hex.EncodeToString(hmacSHA256(signingKey, stringToSign))
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
+
+// canonicalQueryString sorts the parameters by name and encodes them as the signature requires
+func canonicalQueryString(query map[string]string) string {
+	names := make([]string, 0, len(query))
+	for name := range query {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	parts := make([]string, 0, len(names))
+	for _, name := range names {
+		parts = append(parts, uriEncode(name, true)+"="+uriEncode(query[name], true))
+	}
+	return strings.Join(parts, "&")
+}
+
+// uriEncode percent-encodes everything but the unreserved characters of RFC 3986,
+// unlike url.QueryEscape it encodes the space as %20
+func uriEncode(s string, encodeSlash bool) string {
+	var b strings.Builder
+
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case 'A' <= c && c <= 'Z', 'a' <= c && c <= 'z', '0' <= c && c <= '9', c == '-', c == '_', c == '.', c == '~':
+			b.WriteByte(c)
+		case c == '/' && !encodeSlash:
+			b.WriteByte(c)
+		default:
+			fmt.Fprintf(&b, "%%%02X", c)
+		}
+	}
+	return b.String()
+}
+
+func unexpectedStatus(resp *http.Response) error {
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, s3ErrorBodyLimit))
+	return fmt.Errorf("unexpected status %d: %s", resp.StatusCode, strings.TrimSpace(string(body)))
+}
@@ -0,0 +1,152 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/rshelekhov/reframed/internal/lib/constants/le"
+	"github.com/rshelekhov/reframed/internal/model"
+	"github.com/rshelekhov/reframed/internal/storage/postgres/sqlc"
+)
+
+type AreaStorage struct {
+	*pgxpool.Pool
+	*sqlc.Queries
+}
+
+func NewAreaStorage(pool *pgxpool.Pool) *AreaStorage {
+	return &AreaStorage{
+		Pool:    pool,
+		Queries: sqlc.New(newDB(pool)),
+	}
+}
+
+// Transaction runs fn in a transaction, the storages called with the context passed to fn take part in it
+func (s *AreaStorage) Transaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	return transaction(ctx, s.Pool, fn)
+}
+
+// CreateArea creates the area after the other areas of the user and returns its position
+func (s *AreaStorage) CreateArea(ctx context.Context, area model.Area) (int, error) {
+	const op = "area.storage.CreateArea"
+
+	position, err := s.Queries.CreateArea(ctx, sqlc.CreateAreaParams{
+		ID:        area.ID,
+		Title:     area.Title,
+		UserID:    area.UserID,
+		UpdatedAt: area.UpdatedAt,
+	})
+	if err != nil {
+		return 0, fmt.Errorf("%s: failed to create area: %w", op, err)
+	}
+	return int(position), nil
+}
+
+func (s *AreaStorage) GetAreaByID(ctx context.Context, areaID, userID string) (model.Area, error) {
+	const op = "area.storage.GetAreaByID"
+
+	area, err := s.Queries.GetAreaByID(ctx, sqlc.GetAreaByIDParams{
+		ID:     areaID,
+		UserID: userID,
+	})
+	if errors.Is(err, pgx.ErrNoRows) {
+		return model.Area{}, le.ErrAreaNotFound
+	}
+	if err != nil {
+		return model.Area{}, fmt.Errorf("%s: failed to get area: %w", op, err)
+	}
+
+	return model.Area{
+		ID:        area.ID,
+		Title:     area.Title,
+		Position:  int(area.Position),
+		UserID:    area.UserID,
+		UpdatedAt: area.UpdatedAt,
+	}, nil
+}
+
+func (s *AreaStorage) GetAreasByUserID(ctx context.Context, userID string) ([]model.Area, error) {
+	const op = "area.storage.GetAreasByUserID"
+
+	items, err := s.Queries.GetAreasByUserID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to get areas: %w", op, err)
+	}
+	if len(items) == 0 {
+		return nil, le.ErrNoAreasFound
+	}
+
+	var areas []model.Area
+
+	for _, item := range items {
+		areas = append(areas, model.Area{
+			ID:        item.ID,
+			Title:     item.Title,
+			Position:  int(item.Position),
+			UserID:    item.UserID,
+			UpdatedAt: item.UpdatedAt,
+		})
+	}
+	return areas, nil
+}
+
+func (s *AreaStorage) UpdateArea(ctx context.Context, area model.Area) error {
+	const op = "area.storage.UpdateArea"
+
+	rows, err := s.Queries.UpdateArea(ctx, sqlc.UpdateAreaParams{
+		Title:     area.Title,
+		UpdatedAt: area.UpdatedAt,
+		ID:        area.ID,
+		UserID:    area.UserID,
+	})
+	if err != nil {
+		return fmt.Errorf("%s: failed to update area: %w", op, err)
+	}
+	if rows == 0 {
+		return le.ErrAreaNotFound
+	}
+	return nil
+}
+
+func (s *AreaStorage) UpdateAreaPosition(ctx context.Context, area model.Area) error {
+	const op = "area.storage.UpdateAreaPosition"
+
+	rows, err := s.Queries.UpdateAreaPosition(ctx, sqlc.UpdateAreaPositionParams{
+		Position:  int32(area.Position),
+		UpdatedAt: area.UpdatedAt,
+		ID:        area.ID,
+		UserID:    area.UserID,
+	})
+	if err != nil {
+		return fmt.Errorf("%s: failed to update area position: %w", op, err)
+	}
+	if rows == 0 {
+		return le.ErrAreaNotFound
+	}
+	return nil
+}
+
+func (s *AreaStorage) DeleteArea(ctx context.Context, area model.Area) error {
+	const op = "area.storage.DeleteArea"
+
+	rows, err := s.Queries.DeleteArea(ctx, sqlc.DeleteAreaParams{
+		DeletedAt: pgtype.Timestamptz{
+			Time:  area.DeletedAt,
+			Valid: true,
+		},
+		ID:     area.ID,
+		UserID: area.UserID,
+	})
+	if err != nil {
+		return fmt.Errorf("%s: failed to delete area: %w", op, err)
+	}
+	if rows == 0 {
+		return le.ErrAreaNotFound
+	}
+	return nil
+}
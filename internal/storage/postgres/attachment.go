@@ -0,0 +1,173 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/rshelekhov/reframed/internal/lib/constants/le"
+	"github.com/rshelekhov/reframed/internal/model"
+	"github.com/rshelekhov/reframed/internal/storage/postgres/sqlc"
+)
+
+type AttachmentStorage struct {
+	*pgxpool.Pool
+	*sqlc.Queries
+}
+
+func NewAttachmentStorage(pool *pgxpool.Pool) *AttachmentStorage {
+	return &AttachmentStorage{
+		Pool:    pool,
+		Queries: sqlc.New(newDB(pool)),
+	}
+}
+
+// Transaction runs fn in a transaction, the storages called with the context passed to fn take part in it
+func (s *AttachmentStorage) Transaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	return transaction(ctx, s.Pool, fn)
+}
+
+func (s *AttachmentStorage) CreateAttachment(ctx context.Context, attachment model.Attachment) error {
+	const op = "attachment.storage.CreateAttachment"
+
+	if err := s.Queries.CreateAttachment(ctx, sqlc.CreateAttachmentParams{
+		ID:          attachment.ID,
+		TaskID:      attachment.TaskID,
+		FileName:    attachment.FileName,
+		ContentType: attachment.ContentType,
+		Size:        attachment.Size,
+		BlobKey:     attachment.BlobKey,
+		UserID:      attachment.UserID,
+		CreatedAt:   attachment.CreatedAt,
+	}); err != nil {
+		return fmt.Errorf("%s: failed to create attachment: %w", op, err)
+	}
+	return nil
+}
+
+func (s *AttachmentStorage) GetAttachmentByID(ctx context.Context, attachmentID, taskID string) (model.Attachment, error) {
+	const op = "attachment.storage.GetAttachmentByID"
+
+	attachment, err := s.Queries.GetAttachmentByID(ctx, sqlc.GetAttachmentByIDParams{
+		ID:     attachmentID,
+		TaskID: taskID,
+	})
+	if errors.Is(err, pgx.ErrNoRows) {
+		return model.Attachment{}, le.ErrAttachmentNotFound
+	}
+	if err != nil {
+		return model.Attachment{}, fmt.Errorf("%s: failed to get attachment: %w", op, err)
+	}
+
+	return mapAttachmentRowToModel(attachment), nil
+}
+
+func (s *AttachmentStorage) GetAttachmentsByTaskID(ctx context.Context, taskID string) ([]model.Attachment, error) {
+	const op = "attachment.storage.GetAttachmentsByTaskID"
+
+	items, err := s.Queries.GetAttachmentsByTaskID(ctx, taskID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to get attachments: %w", op, err)
+	}
+
+	var attachments []model.Attachment
+
+	for _, item := range items {
+		attachments = append(attachments, mapAttachmentRowToModel(item))
+	}
+	return attachments, nil
+}
+
+func (s *AttachmentStorage) DeleteAttachment(ctx context.Context, attachment model.Attachment) error {
+	const op = "attachment.storage.DeleteAttachment"
+
+	rows, err := s.Queries.DeleteAttachment(ctx, sqlc.DeleteAttachmentParams{
+		DeletedAt: pgtype.Timestamptz{
+			Time:  attachment.DeletedAt,
+			Valid: true,
+		},
+		ID:     attachment.ID,
+		TaskID: attachment.TaskID,
+	})
+	if err != nil {
+		return fmt.Errorf("%s: failed to delete attachment: %w", op, err)
+	}
+	if rows == 0 {
+		return le.ErrAttachmentNotFound
+	}
+	return nil
+}
+
+// LockUserAttachments serializes the uploads of the user until the end of the transaction,
+// so concurrent uploads can't exceed the quota together
+func (s *AttachmentStorage) LockUserAttachments(ctx context.Context, userID string) error {
+	const op = "attachment.storage.LockUserAttachments"
+
+	if err := s.Queries.LockUserAttachments(ctx, userID); err != nil {
+		return fmt.Errorf("%s: failed to lock attachments: %w", op, err)
+	}
+	return nil
+}
+
+// GetUserAttachmentsSize returns the size of the files uploaded by the user which aren't deleted.
+// The files of the deleted tasks are counted until they are purged
+func (s *AttachmentStorage) GetUserAttachmentsSize(ctx context.Context, userID string) (int64, error) {
+	const op = "attachment.storage.GetUserAttachmentsSize"
+
+	size, err := s.Queries.GetUserAttachmentsSize(ctx, userID)
+	if err != nil {
+		return 0, fmt.Errorf("%s: failed to get size of attachments: %w", op, err)
+	}
+	return size, nil
+}
+
+// GetAttachmentsToPurge returns the deleted attachments and the attachments of the tasks deleted before purgeBefore
+func (s *AttachmentStorage) GetAttachmentsToPurge(ctx context.Context, purgeBefore time.Time, limit int32) ([]model.Attachment, error) {
+	const op = "attachment.storage.GetAttachmentsToPurge"
+
+	items, err := s.Queries.GetAttachmentsToPurge(ctx, sqlc.GetAttachmentsToPurgeParams{
+		PurgeBefore: pgtype.Timestamptz{
+			Time:  purgeBefore,
+			Valid: true,
+		},
+		LimitCount: limit,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to get attachments to purge: %w", op, err)
+	}
+
+	var attachments []model.Attachment
+
+	for _, item := range items {
+		attachments = append(attachments, mapAttachmentRowToModel(item))
+	}
+	return attachments, nil
+}
+
+func (s *AttachmentStorage) PurgeAttachment(ctx context.Context, attachmentID string) error {
+	const op = "attachment.storage.PurgeAttachment"
+
+	if err := s.Queries.PurgeAttachment(ctx, attachmentID); err != nil {
+		return fmt.Errorf("%s: failed to purge attachment: %w", op, err)
+	}
+	return nil
+}
+
+func mapAttachmentRowToModel(attachment sqlc.Attachment) model.Attachment {
+	return model.Attachment{
+		ID:          attachment.ID,
+		TaskID:      attachment.TaskID,
+		FileName:    attachment.FileName,
+		ContentType: attachment.ContentType,
+		Size:        attachment.Size,
+		BlobKey:     attachment.BlobKey,
+		UserID:      attachment.UserID,
+		CreatedAt:   attachment.CreatedAt,
+		DeletedAt:   attachment.DeletedAt.Time,
+	}
+}
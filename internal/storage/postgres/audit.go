@@ -0,0 +1,144 @@
+package postgres
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/rshelekhov/reframed/internal/lib/constants/le"
+	"github.com/rshelekhov/reframed/internal/model"
+	"github.com/rshelekhov/reframed/internal/storage/postgres/sqlc"
+)
+
+type AuditStorage struct {
+	*pgxpool.Pool
+	*sqlc.Queries
+}
+
+func NewAuditStorage(pool *pgxpool.Pool) *AuditStorage {
+	return &AuditStorage{
+		Pool:    pool,
+		Queries: sqlc.New(newDB(pool)),
+	}
+}
+
+// CreateAuditEntry appends the entry to the audit log, the entries are never changed afterwards
+func (s *AuditStorage) CreateAuditEntry(ctx context.Context, entry model.AuditEntry) error {
+	const op = "audit.storage.CreateAuditEntry"
+
+	if entry.Changes == nil {
+		entry.Changes = map[string]model.AuditChange{}
+	}
+
+	changes, err := json.Marshal(entry.Changes)
+	if err != nil {
+		return fmt.Errorf("%s: failed to marshal audit changes: %w", op, err)
+	}
+
+	if err = s.Queries.CreateAuditEntry(ctx, sqlc.CreateAuditEntryParams{
+		ID:         entry.ID,
+		EntityType: entry.EntityType.String(),
+		EntityID:   entry.EntityID,
+		Action:     entry.Action.String(),
+		Changes:    changes,
+		UserID:     entry.UserID,
+		DeviceID: pgtype.Text{
+			String: entry.DeviceID,
+			Valid:  entry.DeviceID != "",
+		},
+		RequestID: entry.RequestID,
+		CreatedAt: entry.CreatedAt,
+	}); err != nil {
+		return fmt.Errorf("%s: failed to create audit entry: %w", op, err)
+	}
+	return nil
+}
+
+// GetAuditEntriesByEntity returns the entries of the entity visible to the user. The entries of the lists,
+// headings and tasks are visible to the members of their lists, even after the entity was deleted,
+// the entries of the other entities only to the users who made them
+func (s *AuditStorage) GetAuditEntriesByEntity(
+	ctx context.Context,
+	entityType model.AuditEntityType,
+	entityID, userID string,
+	pgn model.Pagination,
+) ([]model.AuditEntry, error) {
+	const op = "audit.storage.GetAuditEntriesByEntity"
+
+	items, err := s.Queries.GetAuditEntriesByEntity(ctx, sqlc.GetAuditEntriesByEntityParams{
+		EntityType: entityType.String(),
+		EntityID:   entityID,
+		UserID:     userID,
+		Limit:      pgn.Limit,
+		AfterID:    pgn.AfterID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to get audit entries: %w", op, err)
+	}
+	if len(items) == 0 {
+		return nil, le.ErrNoAuditEntriesFound
+	}
+
+	var entries []model.AuditEntry
+
+	for _, item := range items {
+		entry, err := mapAuditRowToModel(sqlc.GetAuditEntriesByUserIDRow(item))
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+
+		entries = append(entries, entry)
+	}
+	return entries, nil
+}
+
+func (s *AuditStorage) GetAuditEntriesByUserID(ctx context.Context, userID string, pgn model.Pagination) ([]model.AuditEntry, error) {
+	const op = "audit.storage.GetAuditEntriesByUserID"
+
+	items, err := s.Queries.GetAuditEntriesByUserID(ctx, sqlc.GetAuditEntriesByUserIDParams{
+		UserID:  userID,
+		Limit:   pgn.Limit,
+		AfterID: pgn.AfterID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to get audit entries: %w", op, err)
+	}
+	if len(items) == 0 {
+		return nil, le.ErrNoAuditEntriesFound
+	}
+
+	var entries []model.AuditEntry
+
+	for _, item := range items {
+		entry, err := mapAuditRowToModel(item)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+
+		entries = append(entries, entry)
+	}
+	return entries, nil
+}
+
+func mapAuditRowToModel(row sqlc.GetAuditEntriesByUserIDRow) (model.AuditEntry, error) {
+	entry := model.AuditEntry{
+		ID:         row.ID,
+		EntityType: model.AuditEntityType(row.EntityType),
+		EntityID:   row.EntityID,
+		Action:     model.AuditAction(row.Action),
+		UserID:     row.UserID,
+		Email:      row.Email,
+		DeviceID:   row.DeviceID.String,
+		RequestID:  row.RequestID,
+		CreatedAt:  row.CreatedAt,
+	}
+
+	if err := json.Unmarshal(row.Changes, &entry.Changes); err != nil {
+		return model.AuditEntry{}, fmt.Errorf("failed to unmarshal audit changes: %w", err)
+	}
+
+	return entry, nil
+}
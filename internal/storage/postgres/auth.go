@@ -25,29 +25,13 @@ type AuthStorage struct {
 func NewAuthStorage(pool *pgxpool.Pool) port.AuthStorage {
 	return &AuthStorage{
 		Pool:    pool,
-		Queries: sqlc.New(pool),
+		Queries: sqlc.New(newDB(pool)),
 	}
 }
 
-func (s *AuthStorage) Transaction(ctx context.Context, fn func(storage port.AuthStorage) error) error {
-	tx, err := s.Pool.Begin(ctx)
-	if err != nil {
-		return err
-	}
-
-	defer func() {
-		if err != nil {
-			if rbErr := tx.Rollback(ctx); rbErr != nil {
-				err = fmt.Errorf("tx err: %v, rb err: %v", err, rbErr)
-			}
-		} else {
-			err = tx.Commit(ctx)
-		}
-	}()
-
-	err = fn(s)
-
-	return err
+// Transaction runs fn in a transaction, the storages called with the context passed to fn take part in it
+func (s *AuthStorage) Transaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	return transaction(ctx, s.Pool, fn)
 }
 
 // CreateUser creates a new user
@@ -186,10 +170,28 @@ func (s *AuthStorage) GetUserByID(ctx context.Context, userID string) (model.Use
 	return model.User{
 		ID:        user.ID,
 		Email:     user.Email,
+		TimeZone:  user.TimeZone,
 		UpdatedAt: user.UpdatedAt,
 	}, nil
 }
 
+func (s *AuthStorage) UpdateUserTimeZone(ctx context.Context, user model.User) error {
+	const op = "user.storage.UpdateUserTimeZone"
+
+	rows, err := s.Queries.UpdateUserTimeZone(ctx, sqlc.UpdateUserTimeZoneParams{
+		TimeZone:  user.TimeZone,
+		UpdatedAt: user.UpdatedAt,
+		ID:        user.ID,
+	})
+	if err != nil {
+		return fmt.Errorf("%s: failed to update user time zone: %w", op, err)
+	}
+	if rows == 0 {
+		return le.ErrUserNotFound
+	}
+	return nil
+}
+
 // UpdateUser updates a user by ID
 func (s *AuthStorage) UpdateUser(ctx context.Context, user model.User) error {
 	const op = "UpdateUser.storage.UpdateUser"
@@ -213,7 +215,7 @@ func (s *AuthStorage) UpdateUser(ctx context.Context, user model.User) error {
 	queryParams = append(queryParams, user.ID)
 
 	// Execute the update query
-	_, err := s.Exec(ctx, queryUpdate, queryParams...)
+	_, err := conn(ctx, s.Pool).Exec(ctx, queryUpdate, queryParams...)
 	if errors.Is(err, pgx.ErrNoRows) {
 		return le.ErrUserNotFound
 	}
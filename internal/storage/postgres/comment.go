@@ -0,0 +1,220 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/rshelekhov/reframed/internal/lib/constants/le"
+	"github.com/rshelekhov/reframed/internal/model"
+	"github.com/rshelekhov/reframed/internal/storage/postgres/sqlc"
+)
+
+type CommentStorage struct {
+	*pgxpool.Pool
+	*sqlc.Queries
+}
+
+func NewCommentStorage(pool *pgxpool.Pool) *CommentStorage {
+	return &CommentStorage{
+		Pool:    pool,
+		Queries: sqlc.New(newDB(pool)),
+	}
+}
+
+func (s *CommentStorage) CreateComment(ctx context.Context, comment model.Comment) error {
+	const op = "comment.storage.CreateComment"
+
+	if err := s.Queries.CreateTaskComment(ctx, sqlc.CreateTaskCommentParams{
+		ID:        comment.ID,
+		TaskID:    comment.TaskID,
+		Body:      comment.Body,
+		Mentions:  nonNilStrings(comment.Mentions),
+		UserID:    comment.UserID,
+		CreatedAt: comment.CreatedAt,
+	}); err != nil {
+		return fmt.Errorf("%s: failed to create comment: %w", op, err)
+	}
+	return nil
+}
+
+func (s *CommentStorage) GetCommentByID(ctx context.Context, commentID, taskID string) (model.Comment, error) {
+	const op = "comment.storage.GetCommentByID"
+
+	item, err := s.Queries.GetTaskCommentByID(ctx, sqlc.GetTaskCommentByIDParams{
+		ID:     commentID,
+		TaskID: taskID,
+	})
+	if errors.Is(err, pgx.ErrNoRows) {
+		return model.Comment{}, le.ErrCommentNotFound
+	}
+	if err != nil {
+		return model.Comment{}, fmt.Errorf("%s: failed to get comment: %w", op, err)
+	}
+
+	return model.Comment{
+		ID:        item.ID,
+		TaskID:    item.TaskID,
+		Body:      item.Body,
+		Mentions:  item.Mentions,
+		UserID:    item.UserID,
+		Email:     item.Email,
+		CreatedAt: item.CreatedAt,
+		UpdatedAt: item.UpdatedAt,
+	}, nil
+}
+
+func (s *CommentStorage) GetCommentsByTaskID(ctx context.Context, taskID string, pgn model.Pagination) ([]model.Comment, error) {
+	const op = "comment.storage.GetCommentsByTaskID"
+
+	items, err := s.Queries.GetTaskComments(ctx, sqlc.GetTaskCommentsParams{
+		TaskID:  taskID,
+		Limit:   pgn.Limit,
+		AfterID: pgn.AfterID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to get comments: %w", op, err)
+	}
+	if len(items) == 0 {
+		return nil, le.ErrNoCommentsFound
+	}
+
+	var comments []model.Comment
+
+	for _, item := range items {
+		comments = append(comments, model.Comment{
+			ID:        item.ID,
+			TaskID:    item.TaskID,
+			Body:      item.Body,
+			Mentions:  item.Mentions,
+			UserID:    item.UserID,
+			Email:     item.Email,
+			CreatedAt: item.CreatedAt,
+			UpdatedAt: item.UpdatedAt,
+		})
+	}
+	return comments, nil
+}
+
+// GetAllCommentsByTaskID returns every comment of the task for the timeline
+func (s *CommentStorage) GetAllCommentsByTaskID(ctx context.Context, taskID string) ([]model.Comment, error) {
+	const op = "comment.storage.GetAllCommentsByTaskID"
+
+	items, err := s.Queries.GetAllTaskComments(ctx, taskID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to get comments: %w", op, err)
+	}
+
+	var comments []model.Comment
+
+	for _, item := range items {
+		comments = append(comments, model.Comment{
+			ID:        item.ID,
+			TaskID:    item.TaskID,
+			Body:      item.Body,
+			Mentions:  item.Mentions,
+			UserID:    item.UserID,
+			Email:     item.Email,
+			CreatedAt: item.CreatedAt,
+			UpdatedAt: item.UpdatedAt,
+		})
+	}
+	return comments, nil
+}
+
+// UpdateComment changes the body of the comment, only its author can do it
+func (s *CommentStorage) UpdateComment(ctx context.Context, comment model.Comment) error {
+	const op = "comment.storage.UpdateComment"
+
+	rows, err := s.Queries.UpdateTaskComment(ctx, sqlc.UpdateTaskCommentParams{
+		Body:      comment.Body,
+		Mentions:  nonNilStrings(comment.Mentions),
+		UpdatedAt: comment.UpdatedAt,
+		ID:        comment.ID,
+		TaskID:    comment.TaskID,
+		UserID:    comment.UserID,
+	})
+	if err != nil {
+		return fmt.Errorf("%s: failed to update comment: %w", op, err)
+	}
+	if rows == 0 {
+		return le.ErrCommentNotFound
+	}
+	return nil
+}
+
+func (s *CommentStorage) DeleteComment(ctx context.Context, comment model.Comment) error {
+	const op = "comment.storage.DeleteComment"
+
+	rows, err := s.Queries.DeleteTaskComment(ctx, sqlc.DeleteTaskCommentParams{
+		DeletedAt: pgtype.Timestamptz{
+			Time:  comment.DeletedAt,
+			Valid: true,
+		},
+		ID:     comment.ID,
+		TaskID: comment.TaskID,
+	})
+	if err != nil {
+		return fmt.Errorf("%s: failed to delete comment: %w", op, err)
+	}
+	if rows == 0 {
+		return le.ErrCommentNotFound
+	}
+	return nil
+}
+
+func (s *CommentStorage) CreateTaskActivity(ctx context.Context, activity model.TaskActivity) error {
+	const op = "comment.storage.CreateTaskActivity"
+
+	if err := s.Queries.CreateTaskActivity(ctx, sqlc.CreateTaskActivityParams{
+		ID:        activity.ID,
+		TaskID:    activity.TaskID,
+		Kind:      activity.Kind.String(),
+		Field:     activity.Field,
+		OldValue:  activity.OldValue,
+		NewValue:  activity.NewValue,
+		UserID:    activity.UserID,
+		CreatedAt: activity.CreatedAt,
+	}); err != nil {
+		return fmt.Errorf("%s: failed to create task activity: %w", op, err)
+	}
+	return nil
+}
+
+func (s *CommentStorage) GetTaskActivities(ctx context.Context, taskID string) ([]model.TaskActivity, error) {
+	const op = "comment.storage.GetTaskActivities"
+
+	items, err := s.Queries.GetTaskActivities(ctx, taskID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to get task activities: %w", op, err)
+	}
+
+	var activities []model.TaskActivity
+
+	for _, item := range items {
+		activities = append(activities, model.TaskActivity{
+			ID:        item.ID,
+			TaskID:    item.TaskID,
+			Kind:      model.TaskActivityKind(item.Kind),
+			Field:     item.Field,
+			OldValue:  item.OldValue,
+			NewValue:  item.NewValue,
+			UserID:    item.UserID,
+			Email:     item.Email,
+			CreatedAt: item.CreatedAt,
+		})
+	}
+	return activities, nil
+}
+
+// nonNilStrings keeps the NOT NULL array columns from receiving NULL for an empty slice
+func nonNilStrings(values []string) []string {
+	if values == nil {
+		return []string{}
+	}
+	return values
+}
@@ -0,0 +1,462 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/rshelekhov/reframed/internal/lib/constants/le"
+	"github.com/rshelekhov/reframed/internal/model"
+	"github.com/rshelekhov/reframed/internal/storage/postgres/sqlc"
+)
+
+type GoalStorage struct {
+	*pgxpool.Pool
+	*sqlc.Queries
+}
+
+func NewGoalStorage(pool *pgxpool.Pool) *GoalStorage {
+	return &GoalStorage{
+		Pool:    pool,
+		Queries: sqlc.New(newDB(pool)),
+	}
+}
+
+// Transaction runs fn in a transaction, the storages called with the context passed to fn take part in it
+func (s *GoalStorage) Transaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	return transaction(ctx, s.Pool, fn)
+}
+
+func (s *GoalStorage) CreateGoal(ctx context.Context, goal model.Goal) error {
+	const op = "goal.storage.CreateGoal"
+
+	if err := s.Queries.CreateGoal(ctx, sqlc.CreateGoalParams{
+		ID:    goal.ID,
+		Title: goal.Title,
+		Description: pgtype.Text{
+			String: goal.Description,
+			Valid:  goal.Description != "",
+		},
+		TargetDate: pgtype.Timestamptz{
+			Time:  goal.TargetDate,
+			Valid: !goal.TargetDate.IsZero(),
+		},
+		UserID:    goal.UserID,
+		UpdatedAt: goal.UpdatedAt,
+	}); err != nil {
+		return fmt.Errorf("%s: failed to create goal: %w", op, err)
+	}
+	return nil
+}
+
+func (s *GoalStorage) GetGoalByID(ctx context.Context, goalID, userID string) (model.Goal, error) {
+	const op = "goal.storage.GetGoalByID"
+
+	goal, err := s.Queries.GetGoalByID(ctx, sqlc.GetGoalByIDParams{
+		ID:     goalID,
+		UserID: userID,
+	})
+	if errors.Is(err, pgx.ErrNoRows) {
+		return model.Goal{}, le.ErrGoalNotFound
+	}
+	if err != nil {
+		return model.Goal{}, fmt.Errorf("%s: failed to get goal: %w", op, err)
+	}
+
+	return model.Goal{
+		ID:          goal.ID,
+		Title:       goal.Title,
+		Description: goal.Description.String,
+		TargetDate:  goal.TargetDate.Time,
+		UserID:      goal.UserID,
+		UpdatedAt:   goal.UpdatedAt,
+	}, nil
+}
+
+func (s *GoalStorage) GetGoalsByUserID(ctx context.Context, userID string) ([]model.Goal, error) {
+	const op = "goal.storage.GetGoalsByUserID"
+
+	items, err := s.Queries.GetGoalsByUserID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to get goals: %w", op, err)
+	}
+	if len(items) == 0 {
+		return nil, le.ErrNoGoalsFound
+	}
+
+	var goals []model.Goal
+
+	for _, item := range items {
+		goals = append(goals, model.Goal{
+			ID:          item.ID,
+			Title:       item.Title,
+			Description: item.Description.String,
+			TargetDate:  item.TargetDate.Time,
+			UserID:      item.UserID,
+			UpdatedAt:   item.UpdatedAt,
+		})
+	}
+	return goals, nil
+}
+
+func (s *GoalStorage) UpdateGoal(ctx context.Context, goal model.Goal) error {
+	const op = "goal.storage.UpdateGoal"
+
+	rows, err := s.Queries.UpdateGoal(ctx, sqlc.UpdateGoalParams{
+		Title: goal.Title,
+		Description: pgtype.Text{
+			String: goal.Description,
+			Valid:  goal.Description != "",
+		},
+		TargetDate: pgtype.Timestamptz{
+			Time:  goal.TargetDate,
+			Valid: !goal.TargetDate.IsZero(),
+		},
+		UpdatedAt: goal.UpdatedAt,
+		ID:        goal.ID,
+		UserID:    goal.UserID,
+	})
+	if err != nil {
+		return fmt.Errorf("%s: failed to update goal: %w", op, err)
+	}
+	if rows == 0 {
+		return le.ErrGoalNotFound
+	}
+	return nil
+}
+
+func (s *GoalStorage) DeleteGoal(ctx context.Context, goal model.Goal) error {
+	const op = "goal.storage.DeleteGoal"
+
+	rows, err := s.Queries.DeleteGoal(ctx, sqlc.DeleteGoalParams{
+		DeletedAt: pgtype.Timestamptz{
+			Time:  goal.DeletedAt,
+			Valid: true,
+		},
+		ID:     goal.ID,
+		UserID: goal.UserID,
+	})
+	if err != nil {
+		return fmt.Errorf("%s: failed to delete goal: %w", op, err)
+	}
+	if rows == 0 {
+		return le.ErrGoalNotFound
+	}
+	return nil
+}
+
+func (s *GoalStorage) CreateKeyResult(ctx context.Context, keyResult model.KeyResult) error {
+	const op = "goal.storage.CreateKeyResult"
+
+	if err := s.Queries.CreateKeyResult(ctx, sqlc.CreateKeyResultParams{
+		ID:           keyResult.ID,
+		GoalID:       keyResult.GoalID,
+		Title:        keyResult.Title,
+		Kind:         keyResult.Kind.String(),
+		StartValue:   keyResult.StartValue,
+		TargetValue:  keyResult.TargetValue,
+		CurrentValue: keyResult.CurrentValue,
+		UserID:       keyResult.UserID,
+		UpdatedAt:    keyResult.UpdatedAt,
+	}); err != nil {
+		return fmt.Errorf("%s: failed to create key result: %w", op, err)
+	}
+	return nil
+}
+
+func (s *GoalStorage) GetKeyResultsByGoalID(ctx context.Context, goalID, userID string) ([]model.KeyResult, error) {
+	const op = "goal.storage.GetKeyResultsByGoalID"
+
+	items, err := s.Queries.GetKeyResultsByGoalID(ctx, sqlc.GetKeyResultsByGoalIDParams{
+		GoalID: goalID,
+		UserID: userID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to get key results: %w", op, err)
+	}
+
+	var keyResults []model.KeyResult
+
+	for _, item := range items {
+		keyResults = append(keyResults, model.KeyResult{
+			ID:           item.ID,
+			GoalID:       item.GoalID,
+			Title:        item.Title,
+			Kind:         model.KeyResultKind(item.Kind),
+			StartValue:   item.StartValue,
+			TargetValue:  item.TargetValue,
+			CurrentValue: item.CurrentValue,
+			UserID:       userID,
+			UpdatedAt:    item.UpdatedAt,
+		})
+	}
+	return keyResults, nil
+}
+
+func (s *GoalStorage) UpdateKeyResult(ctx context.Context, keyResult model.KeyResult) error {
+	const op = "goal.storage.UpdateKeyResult"
+
+	rows, err := s.Queries.UpdateKeyResult(ctx, sqlc.UpdateKeyResultParams{
+		Title:        keyResult.Title,
+		Kind:         keyResult.Kind.String(),
+		StartValue:   keyResult.StartValue,
+		TargetValue:  keyResult.TargetValue,
+		CurrentValue: keyResult.CurrentValue,
+		UpdatedAt:    keyResult.UpdatedAt,
+		ID:           keyResult.ID,
+		GoalID:       keyResult.GoalID,
+		UserID:       keyResult.UserID,
+	})
+	if err != nil {
+		return fmt.Errorf("%s: failed to update key result: %w", op, err)
+	}
+	if rows == 0 {
+		return le.ErrKeyResultNotFound
+	}
+	return nil
+}
+
+func (s *GoalStorage) DeleteKeyResult(ctx context.Context, keyResult model.KeyResult) error {
+	const op = "goal.storage.DeleteKeyResult"
+
+	rows, err := s.Queries.DeleteKeyResult(ctx, sqlc.DeleteKeyResultParams{
+		DeletedAt: pgtype.Timestamptz{
+			Time:  keyResult.DeletedAt,
+			Valid: true,
+		},
+		ID:     keyResult.ID,
+		GoalID: keyResult.GoalID,
+		UserID: keyResult.UserID,
+	})
+	if err != nil {
+		return fmt.Errorf("%s: failed to delete key result: %w", op, err)
+	}
+	if rows == 0 {
+		return le.ErrKeyResultNotFound
+	}
+	return nil
+}
+
+func (s *GoalStorage) LinkListToGoal(ctx context.Context, data model.GoalLinkRequestData, updatedAt time.Time) error {
+	const op = "goal.storage.LinkListToGoal"
+
+	rows, err := s.Queries.LinkListToGoal(ctx, sqlc.LinkListToGoalParams{
+		GoalID: pgtype.Text{
+			String: data.GoalID,
+			Valid:  true,
+		},
+		UpdatedAt: updatedAt,
+		ID:        data.ListID,
+		UserID:    data.UserID,
+	})
+	if err != nil {
+		return fmt.Errorf("%s: failed to link list to goal: %w", op, err)
+	}
+	if rows == 0 {
+		return le.ErrListNotFound
+	}
+	return nil
+}
+
+// UnlinkListFromGoal returns le.ErrListNotFound if the list isn't linked to the goal
+func (s *GoalStorage) UnlinkListFromGoal(ctx context.Context, data model.GoalLinkRequestData, updatedAt time.Time) error {
+	const op = "goal.storage.UnlinkListFromGoal"
+
+	rows, err := s.Queries.UnlinkListFromGoal(ctx, sqlc.UnlinkListFromGoalParams{
+		UpdatedAt: updatedAt,
+		ID:        data.ListID,
+		GoalID: pgtype.Text{
+			String: data.GoalID,
+			Valid:  true,
+		},
+		UserID: data.UserID,
+	})
+	if err != nil {
+		return fmt.Errorf("%s: failed to unlink list from goal: %w", op, err)
+	}
+	if rows == 0 {
+		return le.ErrListNotFound
+	}
+	return nil
+}
+
+func (s *GoalStorage) LinkTaskToGoal(ctx context.Context, data model.GoalLinkRequestData, updatedAt time.Time) error {
+	const op = "goal.storage.LinkTaskToGoal"
+
+	rows, err := s.Queries.LinkTaskToGoal(ctx, sqlc.LinkTaskToGoalParams{
+		GoalID: pgtype.Text{
+			String: data.GoalID,
+			Valid:  true,
+		},
+		UpdatedAt: updatedAt,
+		ID:        data.TaskID,
+		UserID:    data.UserID,
+	})
+	if err != nil {
+		return fmt.Errorf("%s: failed to link task to goal: %w", op, err)
+	}
+	if rows == 0 {
+		return le.ErrTaskNotFound
+	}
+	return nil
+}
+
+// UnlinkTaskFromGoal returns le.ErrTaskNotFound if the task isn't linked to the goal
+func (s *GoalStorage) UnlinkTaskFromGoal(ctx context.Context, data model.GoalLinkRequestData, updatedAt time.Time) error {
+	const op = "goal.storage.UnlinkTaskFromGoal"
+
+	rows, err := s.Queries.UnlinkTaskFromGoal(ctx, sqlc.UnlinkTaskFromGoalParams{
+		UpdatedAt: updatedAt,
+		ID:        data.TaskID,
+		GoalID: pgtype.Text{
+			String: data.GoalID,
+			Valid:  true,
+		},
+		UserID: data.UserID,
+	})
+	if err != nil {
+		return fmt.Errorf("%s: failed to unlink task from goal: %w", op, err)
+	}
+	if rows == 0 {
+		return le.ErrTaskNotFound
+	}
+	return nil
+}
+
+// UnlinkGoal unlinks all the lists and the tasks from the goal
+func (s *GoalStorage) UnlinkGoal(ctx context.Context, goalID, userID string) error {
+	const op = "goal.storage.UnlinkGoal"
+
+	goal := pgtype.Text{
+		String: goalID,
+		Valid:  true,
+	}
+
+	if err := s.Queries.UnlinkListsFromGoal(ctx, sqlc.UnlinkListsFromGoalParams{
+		GoalID: goal,
+		UserID: userID,
+	}); err != nil {
+		return fmt.Errorf("%s: failed to unlink lists from goal: %w", op, err)
+	}
+
+	if err := s.Queries.UnlinkTasksFromGoal(ctx, sqlc.UnlinkTasksFromGoalParams{
+		GoalID: goal,
+		UserID: userID,
+	}); err != nil {
+		return fmt.Errorf("%s: failed to unlink tasks from goal: %w", op, err)
+	}
+	return nil
+}
+
+func (s *GoalStorage) GetGoalListIDs(ctx context.Context, goalID, userID string) ([]string, error) {
+	const op = "goal.storage.GetGoalListIDs"
+
+	listIDs, err := s.Queries.GetGoalListIDs(ctx, sqlc.GetGoalListIDsParams{
+		GoalID: pgtype.Text{
+			String: goalID,
+			Valid:  true,
+		},
+		UserID: userID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to get goal list IDs: %w", op, err)
+	}
+	return listIDs, nil
+}
+
+func (s *GoalStorage) GetGoalTaskIDs(ctx context.Context, goalID, userID string) ([]string, error) {
+	const op = "goal.storage.GetGoalTaskIDs"
+
+	taskIDs, err := s.Queries.GetGoalTaskIDs(ctx, sqlc.GetGoalTaskIDsParams{
+		GoalID: pgtype.Text{
+			String: goalID,
+			Valid:  true,
+		},
+		UserID: userID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to get goal task IDs: %w", op, err)
+	}
+	return taskIDs, nil
+}
+
+// GetGoalTaskCounts returns the number of the tasks linked to the goal, directly or through their lists
+func (s *GoalStorage) GetGoalTaskCounts(ctx context.Context, goalID, userID string) (model.GoalTaskCounts, error) {
+	const op = "goal.storage.GetGoalTaskCounts"
+
+	counts, err := s.Queries.GetGoalTaskCounts(ctx, sqlc.GetGoalTaskCountsParams{
+		CompletedStatusTitle: model.StatusCompleted.String(),
+		GoalID:               goalID,
+		UserID:               userID,
+	})
+	if err != nil {
+		return model.GoalTaskCounts{}, fmt.Errorf("%s: failed to get goal task counts: %w", op, err)
+	}
+
+	return model.GoalTaskCounts{
+		Total:     int(counts.Total),
+		Completed: int(counts.Completed),
+	}, nil
+}
+
+// GetGoalsByTaskID returns the goals the task is linked to, directly or through its list,
+// with only the ID and the owner set. The goals are found by the links alone, whoever changed the task
+func (s *GoalStorage) GetGoalsByTaskID(ctx context.Context, taskID string) ([]model.Goal, error) {
+	const op = "goal.storage.GetGoalsByTaskID"
+
+	items, err := s.Queries.GetGoalsByTaskID(ctx, taskID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to get goals: %w", op, err)
+	}
+
+	var goals []model.Goal
+
+	for _, item := range items {
+		goals = append(goals, model.Goal{
+			ID:     item.ID,
+			UserID: item.UserID,
+		})
+	}
+	return goals, nil
+}
+
+// CreateGoalProgress records the snapshot of the goal progress, unless it hasn't changed since the latest one
+func (s *GoalStorage) CreateGoalProgress(ctx context.Context, goalID string, progress model.GoalProgress) error {
+	const op = "goal.storage.CreateGoalProgress"
+
+	if _, err := s.Queries.CreateGoalProgress(ctx, sqlc.CreateGoalProgressParams{
+		GoalID:   goalID,
+		Progress: progress.Progress,
+		RecordedAt: pgtype.Timestamptz{
+			Time:  progress.RecordedAt,
+			Valid: true,
+		},
+	}); err != nil {
+		return fmt.Errorf("%s: failed to create goal progress: %w", op, err)
+	}
+	return nil
+}
+
+func (s *GoalStorage) GetGoalProgressHistory(ctx context.Context, goalID string) ([]model.GoalProgress, error) {
+	const op = "goal.storage.GetGoalProgressHistory"
+
+	items, err := s.Queries.GetGoalProgressHistory(ctx, goalID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to get goal progress history: %w", op, err)
+	}
+
+	var history []model.GoalProgress
+
+	for _, item := range items {
+		history = append(history, model.GoalProgress{
+			Progress:   item.Progress,
+			RecordedAt: item.RecordedAt,
+		})
+	}
+	return history, nil
+}
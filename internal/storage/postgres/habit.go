@@ -0,0 +1,204 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/rshelekhov/reframed/internal/lib/constants/le"
+	"github.com/rshelekhov/reframed/internal/model"
+	"github.com/rshelekhov/reframed/internal/storage/postgres/sqlc"
+)
+
+type HabitStorage struct {
+	*pgxpool.Pool
+	*sqlc.Queries
+}
+
+func NewHabitStorage(pool *pgxpool.Pool) *HabitStorage {
+	return &HabitStorage{
+		Pool:    pool,
+		Queries: sqlc.New(newDB(pool)),
+	}
+}
+
+func (s *HabitStorage) CreateHabit(ctx context.Context, habit model.Habit) error {
+	const op = "habit.storage.CreateHabit"
+
+	if err := s.Queries.CreateHabit(ctx, sqlc.CreateHabitParams{
+		ID:           habit.ID,
+		Title:        habit.Title,
+		Notes:        habit.Notes,
+		Frequency:    habit.Frequency.String(),
+		TimesPerWeek: int32(habit.TimesPerWeek),
+		UserID:       habit.UserID,
+		UpdatedAt:    habit.UpdatedAt,
+	}); err != nil {
+		return fmt.Errorf("%s: failed to create habit: %w", op, err)
+	}
+	return nil
+}
+
+func (s *HabitStorage) GetHabitByID(ctx context.Context, habitID, userID string) (model.Habit, error) {
+	const op = "habit.storage.GetHabitByID"
+
+	habit, err := s.Queries.GetHabitByID(ctx, sqlc.GetHabitByIDParams{
+		ID:     habitID,
+		UserID: userID,
+	})
+	if errors.Is(err, pgx.ErrNoRows) {
+		return model.Habit{}, le.ErrHabitNotFound
+	}
+	if err != nil {
+		return model.Habit{}, fmt.Errorf("%s: failed to get habit: %w", op, err)
+	}
+
+	return mapHabitRowToModel(habit), nil
+}
+
+func (s *HabitStorage) GetHabitsByUserID(ctx context.Context, userID string) ([]model.Habit, error) {
+	const op = "habit.storage.GetHabitsByUserID"
+
+	items, err := s.Queries.GetHabitsByUserID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to get habits: %w", op, err)
+	}
+
+	var habits []model.Habit
+
+	for _, item := range items {
+		habits = append(habits, mapHabitRowToModel(item))
+	}
+	return habits, nil
+}
+
+func (s *HabitStorage) UpdateHabit(ctx context.Context, habit model.Habit) error {
+	const op = "habit.storage.UpdateHabit"
+
+	rows, err := s.Queries.UpdateHabit(ctx, sqlc.UpdateHabitParams{
+		Title:        habit.Title,
+		Notes:        habit.Notes,
+		Frequency:    habit.Frequency.String(),
+		TimesPerWeek: int32(habit.TimesPerWeek),
+		UpdatedAt:    habit.UpdatedAt,
+		ID:           habit.ID,
+		UserID:       habit.UserID,
+	})
+	if err != nil {
+		return fmt.Errorf("%s: failed to update habit: %w", op, err)
+	}
+	if rows == 0 {
+		return le.ErrHabitNotFound
+	}
+	return nil
+}
+
+func (s *HabitStorage) DeleteHabit(ctx context.Context, habit model.Habit) error {
+	const op = "habit.storage.DeleteHabit"
+
+	rows, err := s.Queries.DeleteHabit(ctx, sqlc.DeleteHabitParams{
+		DeletedAt: pgtype.Timestamptz{
+			Time:  habit.DeletedAt,
+			Valid: true,
+		},
+		ID:     habit.ID,
+		UserID: habit.UserID,
+	})
+	if err != nil {
+		return fmt.Errorf("%s: failed to delete habit: %w", op, err)
+	}
+	if rows == 0 {
+		return le.ErrHabitNotFound
+	}
+	return nil
+}
+
+func (s *HabitStorage) CreateHabitCheckIn(ctx context.Context, checkIn model.HabitCheckIn) error {
+	const op = "habit.storage.CreateHabitCheckIn"
+
+	if err := s.Queries.CreateHabitCheckIn(ctx, sqlc.CreateHabitCheckInParams{
+		HabitID: checkIn.HabitID,
+		Day:     dateParam(checkIn.Day),
+	}); err != nil {
+		return fmt.Errorf("%s: failed to create habit check-in: %w", op, err)
+	}
+	return nil
+}
+
+func (s *HabitStorage) DeleteHabitCheckIn(ctx context.Context, checkIn model.HabitCheckIn) error {
+	const op = "habit.storage.DeleteHabitCheckIn"
+
+	rows, err := s.Queries.DeleteHabitCheckIn(ctx, sqlc.DeleteHabitCheckInParams{
+		HabitID: checkIn.HabitID,
+		Day:     dateParam(checkIn.Day),
+	})
+	if err != nil {
+		return fmt.Errorf("%s: failed to delete habit check-in: %w", op, err)
+	}
+	if rows == 0 {
+		return le.ErrHabitCheckInNotFound
+	}
+	return nil
+}
+
+// GetHabitCheckIns returns the check-in days of the habits of the user, keyed by habit ID and sorted by day
+func (s *HabitStorage) GetHabitCheckIns(ctx context.Context, userID string) (map[string][]time.Time, error) {
+	const op = "habit.storage.GetHabitCheckIns"
+
+	items, err := s.Queries.GetHabitCheckIns(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to get habit check-ins: %w", op, err)
+	}
+
+	checkIns := make(map[string][]time.Time)
+
+	for _, item := range items {
+		checkIns[item.HabitID] = append(checkIns[item.HabitID], item.Day.Time)
+	}
+	return checkIns, nil
+}
+
+func (s *HabitStorage) GetHabitCheckInsInRange(ctx context.Context, habitID string, from, to time.Time) ([]time.Time, error) {
+	const op = "habit.storage.GetHabitCheckInsInRange"
+
+	items, err := s.Queries.GetHabitCheckInsInRange(ctx, sqlc.GetHabitCheckInsInRangeParams{
+		HabitID: habitID,
+		FromDay: dateParam(from),
+		ToDay:   dateParam(to),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to get habit check-ins: %w", op, err)
+	}
+
+	days := make([]time.Time, 0, len(items))
+
+	for _, item := range items {
+		days = append(days, item.Time)
+	}
+	return days, nil
+}
+
+func mapHabitRowToModel(habit sqlc.Habit) model.Habit {
+	return model.Habit{
+		ID:           habit.ID,
+		Title:        habit.Title,
+		Notes:        habit.Notes,
+		Frequency:    model.HabitFrequency(habit.Frequency),
+		TimesPerWeek: int(habit.TimesPerWeek),
+		UserID:       habit.UserID,
+		UpdatedAt:    habit.UpdatedAt,
+		DeletedAt:    habit.DeletedAt.Time,
+	}
+}
+
+func dateParam(day time.Time) pgtype.Date {
+	return pgtype.Date{
+		Time:  day,
+		Valid: true,
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"fmt"
 
 	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
 	"github.com/jackc/pgx/v5/pgxpool"
 
 	"github.com/rshelekhov/reframed/internal/lib/constants/le"
@@ -21,7 +22,7 @@ type HeadingStorage struct {
 func NewHeadingStorage(pool *pgxpool.Pool) *HeadingStorage {
 	return &HeadingStorage{
 		Pool:    pool,
-		Queries: sqlc.New(pool),
+		Queries: sqlc.New(newDB(pool)),
 	}
 }
 
@@ -78,6 +79,7 @@ func (s *HeadingStorage) GetHeadingByID(ctx context.Context, headingID, userID s
 		Title:     heading.Title,
 		ListID:    heading.ListID,
 		UserID:    heading.UserID,
+		IsDefault: heading.IsDefault,
 		UpdatedAt: heading.UpdatedAt,
 	}, nil
 }
@@ -165,6 +167,10 @@ func (s *HeadingStorage) DeleteHeading(ctx context.Context, heading model.Headin
 	const op = "heading.storage.DeleteHeading"
 
 	err := s.Queries.DeleteHeading(ctx, sqlc.DeleteHeadingParams{
+		DeletedAt: pgtype.Timestamptz{
+			Time:  heading.DeletedAt,
+			Valid: true,
+		},
 		ID:     heading.ID,
 		UserID: heading.UserID,
 	})
@@ -177,3 +183,48 @@ func (s *HeadingStorage) DeleteHeading(ctx context.Context, heading model.Headin
 
 	return nil
 }
+
+func (s *HeadingStorage) HeadingHasTasks(ctx context.Context, headingID string) (bool, error) {
+	const op = "heading.storage.HeadingHasTasks"
+
+	hasTasks, err := s.Queries.HeadingHasTasks(ctx, headingID)
+	if err != nil {
+		return false, fmt.Errorf("%s: failed to check heading tasks: %w", op, err)
+	}
+	return hasTasks, nil
+}
+
+// ArchiveTasksByHeadingID archives the tasks of the deleted heading
+func (s *HeadingStorage) ArchiveTasksByHeadingID(ctx context.Context, heading model.Heading) error {
+	const op = "heading.storage.ArchiveTasksByHeadingID"
+
+	if err := s.Queries.ArchiveTasksByHeadingID(ctx, sqlc.ArchiveTasksByHeadingIDParams{
+		StatusTitle: model.StatusArchived.String(),
+		UpdatedAt:   heading.DeletedAt,
+		DeletedAt: pgtype.Timestamptz{
+			Time:  heading.DeletedAt,
+			Valid: true,
+		},
+		HeadingID: heading.ID,
+		UserID:    heading.UserID,
+	}); err != nil {
+		return fmt.Errorf("%s: failed to archive tasks: %w", op, err)
+	}
+	return nil
+}
+
+// MoveTasksToHeading moves the tasks of the heading to the list and heading of the task
+func (s *HeadingStorage) MoveTasksToHeading(ctx context.Context, fromHeadingID string, task model.Task) error {
+	const op = "heading.storage.MoveTasksToHeading"
+
+	if err := s.Queries.MoveTasksToHeading(ctx, sqlc.MoveTasksToHeadingParams{
+		ListID:        task.ListID,
+		HeadingID:     task.HeadingID,
+		UpdatedAt:     task.UpdatedAt,
+		FromHeadingID: fromHeadingID,
+		UserID:        task.UserID,
+	}); err != nil {
+		return fmt.Errorf("%s: failed to move tasks: %w", op, err)
+	}
+	return nil
+}
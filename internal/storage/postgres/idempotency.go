@@ -0,0 +1,111 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/rshelekhov/reframed/internal/lib/constants/le"
+	"github.com/rshelekhov/reframed/internal/model"
+	"github.com/rshelekhov/reframed/internal/storage/postgres/sqlc"
+)
+
+type IdempotencyStorage struct {
+	*pgxpool.Pool
+	*sqlc.Queries
+}
+
+func NewIdempotencyStorage(pool *pgxpool.Pool) *IdempotencyStorage {
+	return &IdempotencyStorage{
+		Pool:    pool,
+		Queries: sqlc.New(newDB(pool)),
+	}
+}
+
+// CreateIdempotencyKey reserves the key for the user. It returns false if the key
+// is already reserved and not expired yet
+func (s *IdempotencyStorage) CreateIdempotencyKey(ctx context.Context, key model.IdempotencyKey) (bool, error) {
+	const op = "idempotency.storage.CreateIdempotencyKey"
+
+	rows, err := s.Queries.CreateIdempotencyKey(ctx, sqlc.CreateIdempotencyKeyParams{
+		Key:         key.Key,
+		UserID:      key.UserID,
+		Method:      key.Method,
+		Path:        key.Path,
+		RequestHash: key.RequestHash,
+		CreatedAt:   key.CreatedAt,
+		ExpiresAt:   key.ExpiresAt,
+	})
+	if err != nil {
+		return false, fmt.Errorf("%s: failed to create idempotency key: %w", op, err)
+	}
+
+	return rows > 0, nil
+}
+
+func (s *IdempotencyStorage) GetIdempotencyKey(ctx context.Context, userID, key string) (model.IdempotencyKey, error) {
+	const op = "idempotency.storage.GetIdempotencyKey"
+
+	item, err := s.Queries.GetIdempotencyKey(ctx, sqlc.GetIdempotencyKeyParams{
+		UserID: userID,
+		Key:    key,
+	})
+	if errors.Is(err, pgx.ErrNoRows) {
+		return model.IdempotencyKey{}, le.ErrIdempotencyKeyNotFound
+	}
+	if err != nil {
+		return model.IdempotencyKey{}, fmt.Errorf("%s: failed to get idempotency key: %w", op, err)
+	}
+
+	return model.IdempotencyKey{
+		Key:          item.Key,
+		UserID:       item.UserID,
+		Method:       item.Method,
+		Path:         item.Path,
+		RequestHash:  item.RequestHash,
+		StatusCode:   int(item.StatusCode),
+		ResponseBody: item.ResponseBody,
+		CreatedAt:    item.CreatedAt,
+		ExpiresAt:    item.ExpiresAt,
+	}, nil
+}
+
+func (s *IdempotencyStorage) SaveIdempotentResponse(ctx context.Context, key model.IdempotencyKey) error {
+	const op = "idempotency.storage.SaveIdempotentResponse"
+
+	if err := s.Queries.SaveIdempotentResponse(ctx, sqlc.SaveIdempotentResponseParams{
+		StatusCode:   int32(key.StatusCode),
+		ResponseBody: key.ResponseBody,
+		UserID:       key.UserID,
+		Key:          key.Key,
+	}); err != nil {
+		return fmt.Errorf("%s: failed to save response: %w", op, err)
+	}
+	return nil
+}
+
+func (s *IdempotencyStorage) DeleteIdempotencyKey(ctx context.Context, userID, key string) error {
+	const op = "idempotency.storage.DeleteIdempotencyKey"
+
+	if err := s.Queries.DeleteIdempotencyKey(ctx, sqlc.DeleteIdempotencyKeyParams{
+		UserID: userID,
+		Key:    key,
+	}); err != nil {
+		return fmt.Errorf("%s: failed to delete idempotency key: %w", op, err)
+	}
+	return nil
+}
+
+func (s *IdempotencyStorage) DeleteExpiredIdempotencyKeys(ctx context.Context, now time.Time) (int64, error) {
+	const op = "idempotency.storage.DeleteExpiredIdempotencyKeys"
+
+	deleted, err := s.Queries.DeleteExpiredIdempotencyKeys(ctx, now)
+	if err != nil {
+		return 0, fmt.Errorf("%s: failed to delete expired idempotency keys: %w", op, err)
+	}
+	return deleted, nil
+}
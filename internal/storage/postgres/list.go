@@ -6,6 +6,7 @@ import (
 	"fmt"
 
 	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
 	"github.com/jackc/pgx/v5/pgxpool"
 
 	"github.com/rshelekhov/reframed/internal/lib/constants/le"
@@ -21,23 +22,45 @@ type ListStorage struct {
 func NewListStorage(pool *pgxpool.Pool) *ListStorage {
 	return &ListStorage{
 		Pool:    pool,
-		Queries: sqlc.New(pool),
+		Queries: sqlc.New(newDB(pool)),
 	}
 }
 
-func (s *ListStorage) CreateList(ctx context.Context, list model.List) error {
+// Transaction runs fn in a transaction, the storages called with the context passed to fn take part in it
+func (s *ListStorage) Transaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	return transaction(ctx, s.Pool, fn)
+}
+
+// CreateList creates the list at the end of its area and returns its position
+func (s *ListStorage) CreateList(ctx context.Context, list model.List) (int, error) {
 	const op = "list.storage.CreateList"
 
-	if err := s.Queries.CreateList(ctx, sqlc.CreateListParams{
+	position, err := s.Queries.CreateList(ctx, sqlc.CreateListParams{
 		ID:        list.ID,
 		Title:     list.Title,
 		IsDefault: list.IsDefault,
-		UserID:    list.UserID,
+		AreaID: pgtype.Text{
+			String: list.AreaID,
+			Valid:  list.AreaID != "",
+		},
+		UserID: list.UserID,
+		Color:  list.Color,
+		Icon:   list.Icon,
+		Notes:  list.Notes,
+		StartDate: pgtype.Timestamptz{
+			Time:  list.StartDate,
+			Valid: !list.StartDate.IsZero(),
+		},
+		Deadline: pgtype.Timestamptz{
+			Time:  list.Deadline,
+			Valid: !list.Deadline.IsZero(),
+		},
 		UpdatedAt: list.UpdatedAt,
-	}); err != nil {
-		return fmt.Errorf("%s: failed to create new list: %w", op, err)
+	})
+	if err != nil {
+		return 0, fmt.Errorf("%s: failed to create new list: %w", op, err)
 	}
-	return nil
+	return int(position), nil
 }
 
 func (s *ListStorage) GetListByID(ctx context.Context, listID, userID string) (model.List, error) {
@@ -55,16 +78,33 @@ func (s *ListStorage) GetListByID(ctx context.Context, listID, userID string) (m
 	}
 
 	return model.List{
-		ID:        list.ID,
-		Title:     list.Title,
-		UpdatedAt: list.UpdatedAt,
+		ID:             list.ID,
+		Title:          list.Title,
+		IsDefault:      list.IsDefault,
+		AreaID:         list.AreaID.String,
+		Position:       int(list.Position),
+		Color:          list.Color,
+		Icon:           list.Icon,
+		Notes:          list.Notes,
+		StartDate:      list.StartDate.Time,
+		Deadline:       list.Deadline.Time,
+		Status:         model.ListStatus(list.Status),
+		ArchivedAt:     list.ArchivedAt.Time,
+		Role:           model.ListRole(list.Role),
+		UpdatedAt:      list.UpdatedAt,
+		OpenTasks:      int(list.OpenTasks),
+		CompletedTasks: int(list.CompletedTasks),
 	}, nil
 }
 
-func (s *ListStorage) GetListsByUserID(ctx context.Context, userID string) ([]model.List, error) {
+// GetListsByUserID returns the lists of the user, the completed and archived lists are returned only if includeArchived is set
+func (s *ListStorage) GetListsByUserID(ctx context.Context, userID string, includeArchived bool) ([]model.List, error) {
 	const op = "list.storage.GetListsByUserID"
 
-	items, err := s.Queries.GetListsByUserID(ctx, userID)
+	items, err := s.Queries.GetListsByUserID(ctx, sqlc.GetListsByUserIDParams{
+		UserID:          userID,
+		IncludeArchived: includeArchived,
+	})
 	if err != nil {
 		return nil, fmt.Errorf("%s: failed to get lists: %w", op, err)
 	}
@@ -75,11 +115,68 @@ func (s *ListStorage) GetListsByUserID(ctx context.Context, userID string) ([]mo
 	var lists []model.List
 
 	for _, item := range items {
-		lists = append(lists, model.List{
-			ID:        item.ID,
-			Title:     item.Title,
-			UpdatedAt: item.UpdatedAt,
-		})
+		list := model.List{
+			ID:             item.ID,
+			Title:          item.Title,
+			UserID:         item.UserID,
+			Position:       int(item.Position),
+			Color:          item.Color,
+			Icon:           item.Icon,
+			Notes:          item.Notes,
+			StartDate:      item.StartDate.Time,
+			Deadline:       item.Deadline.Time,
+			Status:         model.ListStatus(item.Status),
+			ArchivedAt:     item.ArchivedAt.Time,
+			Role:           model.ListRole(item.Role),
+			UpdatedAt:      item.UpdatedAt,
+			OpenTasks:      int(item.OpenTasks),
+			CompletedTasks: int(item.CompletedTasks),
+		}
+		// Areas are personal, so a list shared with the user is shown outside of the owner's area
+		if item.UserID == userID {
+			list.AreaID = item.AreaID.String
+		}
+		lists = append(lists, list)
+	}
+	return lists, nil
+}
+
+// GetArchivedListsByUserID returns the completed and archived lists of the user, the recently archived first
+func (s *ListStorage) GetArchivedListsByUserID(ctx context.Context, userID string) ([]model.List, error) {
+	const op = "list.storage.GetArchivedListsByUserID"
+
+	items, err := s.Queries.GetArchivedListsByUserID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to get archived lists: %w", op, err)
+	}
+	if len(items) == 0 {
+		return nil, le.ErrNoArchivedListsFound
+	}
+
+	var lists []model.List
+
+	for _, item := range items {
+		list := model.List{
+			ID:             item.ID,
+			Title:          item.Title,
+			UserID:         item.UserID,
+			Position:       int(item.Position),
+			Color:          item.Color,
+			Icon:           item.Icon,
+			Notes:          item.Notes,
+			StartDate:      item.StartDate.Time,
+			Deadline:       item.Deadline.Time,
+			Status:         model.ListStatus(item.Status),
+			ArchivedAt:     item.ArchivedAt.Time,
+			Role:           model.ListRole(item.Role),
+			UpdatedAt:      item.UpdatedAt,
+			OpenTasks:      int(item.OpenTasks),
+			CompletedTasks: int(item.CompletedTasks),
+		}
+		if item.UserID == userID {
+			list.AreaID = item.AreaID.String
+		}
+		lists = append(lists, list)
 	}
 	return lists, nil
 }
@@ -97,11 +194,41 @@ func (s *ListStorage) GetDefaultListID(ctx context.Context, userID string) (stri
 	return listID, nil
 }
 
+func (s *ListStorage) GetAreaDefaultListID(ctx context.Context, areaID, userID string) (string, error) {
+	const op = "list.storage.GetAreaDefaultListID"
+
+	listID, err := s.Queries.GetAreaDefaultListID(ctx, sqlc.GetAreaDefaultListIDParams{
+		AreaID: pgtype.Text{
+			String: areaID,
+			Valid:  true,
+		},
+		UserID: userID,
+	})
+	if errors.Is(err, pgx.ErrNoRows) {
+		return "", le.ErrAreaNotFound
+	}
+	if err != nil {
+		return "", fmt.Errorf("%s: failed to get default list of area: %w", op, err)
+	}
+	return listID, nil
+}
+
 func (s *ListStorage) UpdateList(ctx context.Context, list model.List) error {
 	const op = "list.storage.UpdateList"
 
 	err := s.Queries.UpdateList(ctx, sqlc.UpdateListParams{
-		Title:     list.Title,
+		Title: list.Title,
+		Color: list.Color,
+		Icon:  list.Icon,
+		Notes: list.Notes,
+		StartDate: pgtype.Timestamptz{
+			Time:  list.StartDate,
+			Valid: !list.StartDate.IsZero(),
+		},
+		Deadline: pgtype.Timestamptz{
+			Time:  list.Deadline,
+			Valid: !list.Deadline.IsZero(),
+		},
 		UpdatedAt: list.UpdatedAt,
 		ID:        list.ID,
 		UserID:    list.UserID,
@@ -115,10 +242,37 @@ func (s *ListStorage) UpdateList(ctx context.Context, list model.List) error {
 	return nil
 }
 
+// UpdateListStatus completes, archives or restores the list. The default lists can't change their status
+func (s *ListStorage) UpdateListStatus(ctx context.Context, list model.List) error {
+	const op = "list.storage.UpdateListStatus"
+
+	rows, err := s.Queries.UpdateListStatus(ctx, sqlc.UpdateListStatusParams{
+		Status: list.Status.String(),
+		ArchivedAt: pgtype.Timestamptz{
+			Time:  list.ArchivedAt,
+			Valid: !list.ArchivedAt.IsZero(),
+		},
+		UpdatedAt: list.UpdatedAt,
+		ID:        list.ID,
+		UserID:    list.UserID,
+	})
+	if err != nil {
+		return fmt.Errorf("%s: failed to update list status: %w", op, err)
+	}
+	if rows == 0 {
+		return le.ErrListNotFound
+	}
+	return nil
+}
+
 func (s *ListStorage) DeleteList(ctx context.Context, list model.List) error {
 	const op = "list.storage.DeleteList"
 
 	err := s.Queries.DeleteList(ctx, sqlc.DeleteListParams{
+		DeletedAt: pgtype.Timestamptz{
+			Time:  list.DeletedAt,
+			Valid: true,
+		},
 		ID:     list.ID,
 		UserID: list.UserID,
 	})
@@ -130,3 +284,155 @@ func (s *ListStorage) DeleteList(ctx context.Context, list model.List) error {
 	}
 	return nil
 }
+
+// MoveListToArea moves the list to the end of the area. If the area ID is empty,
+// the list is taken out of its area. The default lists can't be moved
+func (s *ListStorage) MoveListToArea(ctx context.Context, list model.List) error {
+	const op = "list.storage.MoveListToArea"
+
+	rows, err := s.Queries.MoveListToArea(ctx, sqlc.MoveListToAreaParams{
+		AreaID: pgtype.Text{
+			String: list.AreaID,
+			Valid:  list.AreaID != "",
+		},
+		UpdatedAt: list.UpdatedAt,
+		ID:        list.ID,
+		UserID:    list.UserID,
+	})
+	if err != nil {
+		return fmt.Errorf("%s: failed to move list to area: %w", op, err)
+	}
+	if rows == 0 {
+		return le.ErrListNotFound
+	}
+	return nil
+}
+
+func (s *ListStorage) UpdateListPosition(ctx context.Context, list model.List) error {
+	const op = "list.storage.UpdateListPosition"
+
+	rows, err := s.Queries.UpdateListPosition(ctx, sqlc.UpdateListPositionParams{
+		Position:  int32(list.Position),
+		UpdatedAt: list.UpdatedAt,
+		ID:        list.ID,
+		UserID:    list.UserID,
+	})
+	if err != nil {
+		return fmt.Errorf("%s: failed to update list position: %w", op, err)
+	}
+	if rows == 0 {
+		return le.ErrListNotFound
+	}
+	return nil
+}
+
+// DetachListsFromArea takes the lists out of the area. The default list of the area
+// with its loose tasks becomes a regular list with the title of the area
+func (s *ListStorage) DetachListsFromArea(ctx context.Context, area model.Area) error {
+	const op = "list.storage.DetachListsFromArea"
+
+	if err := s.Queries.DetachListsFromArea(ctx, sqlc.DetachListsFromAreaParams{
+		AreaTitle: area.Title,
+		UpdatedAt: area.UpdatedAt,
+		AreaID: pgtype.Text{
+			String: area.ID,
+			Valid:  true,
+		},
+		UserID: area.UserID,
+	}); err != nil {
+		return fmt.Errorf("%s: failed to detach lists from area: %w", op, err)
+	}
+	return nil
+}
+
+// ListHasContent reports whether the list has tasks or headings other than the default one
+func (s *ListStorage) ListHasContent(ctx context.Context, listID string) (bool, error) {
+	const op = "list.storage.ListHasContent"
+
+	hasContent, err := s.Queries.ListHasContent(ctx, listID)
+	if err != nil {
+		return false, fmt.Errorf("%s: failed to check list content: %w", op, err)
+	}
+	return hasContent, nil
+}
+
+// ArchiveTasksByListID archives the tasks of the deleted list
+func (s *ListStorage) ArchiveTasksByListID(ctx context.Context, list model.List) error {
+	const op = "list.storage.ArchiveTasksByListID"
+
+	if err := s.Queries.ArchiveTasksByListID(ctx, sqlc.ArchiveTasksByListIDParams{
+		StatusTitle: model.StatusArchived.String(),
+		UpdatedAt:   list.DeletedAt,
+		DeletedAt: pgtype.Timestamptz{
+			Time:  list.DeletedAt,
+			Valid: true,
+		},
+		ListID: list.ID,
+		UserID: list.UserID,
+	}); err != nil {
+		return fmt.Errorf("%s: failed to archive tasks: %w", op, err)
+	}
+	return nil
+}
+
+// MoveTasksToList moves the tasks of the list to the list and heading of the task
+func (s *ListStorage) MoveTasksToList(ctx context.Context, fromListID string, task model.Task) error {
+	const op = "list.storage.MoveTasksToList"
+
+	if err := s.Queries.MoveTasksToList(ctx, sqlc.MoveTasksToListParams{
+		ListID:     task.ListID,
+		HeadingID:  task.HeadingID,
+		UpdatedAt:  task.UpdatedAt,
+		FromListID: fromListID,
+		UserID:     task.UserID,
+	}); err != nil {
+		return fmt.Errorf("%s: failed to move tasks: %w", op, err)
+	}
+	return nil
+}
+
+// MoveUserTasksToList moves the tasks of the list created by the user of the task to the list and heading of the task
+func (s *ListStorage) MoveUserTasksToList(ctx context.Context, fromListID string, task model.Task) error {
+	const op = "list.storage.MoveUserTasksToList"
+
+	if err := s.Queries.MoveUserTasksToList(ctx, sqlc.MoveUserTasksToListParams{
+		ListID:     task.ListID,
+		HeadingID:  task.HeadingID,
+		UpdatedAt:  task.UpdatedAt,
+		FromListID: fromListID,
+		UserID:     task.UserID,
+	}); err != nil {
+		return fmt.Errorf("%s: failed to move tasks: %w", op, err)
+	}
+	return nil
+}
+
+func (s *ListStorage) DeleteHeadingsByListID(ctx context.Context, list model.List) error {
+	const op = "list.storage.DeleteHeadingsByListID"
+
+	if err := s.Queries.DeleteHeadingsByListID(ctx, sqlc.DeleteHeadingsByListIDParams{
+		DeletedAt: pgtype.Timestamptz{
+			Time:  list.DeletedAt,
+			Valid: true,
+		},
+		ListID: list.ID,
+	}); err != nil {
+		return fmt.Errorf("%s: failed to delete headings: %w", op, err)
+	}
+	return nil
+}
+
+// CompleteTasksByListID completes the open tasks of the completed or archived list
+func (s *ListStorage) CompleteTasksByListID(ctx context.Context, list model.List) error {
+	const op = "list.storage.CompleteTasksByListID"
+
+	if err := s.Queries.CompleteTasksByListID(ctx, sqlc.CompleteTasksByListIDParams{
+		StatusTitle: model.StatusCompleted.String(),
+		UpdatedAt:   list.UpdatedAt,
+		ListID:      list.ID,
+		UserID:      list.UserID,
+	}); err != nil {
+		return fmt.Errorf("%s: failed to complete tasks: %w", op, err)
+	}
+	return nil
+}
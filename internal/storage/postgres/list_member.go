@@ -0,0 +1,325 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/rshelekhov/reframed/internal/lib/constants/le"
+	"github.com/rshelekhov/reframed/internal/model"
+	"github.com/rshelekhov/reframed/internal/storage/postgres/sqlc"
+)
+
+type ListMemberStorage struct {
+	*pgxpool.Pool
+	*sqlc.Queries
+}
+
+func NewListMemberStorage(pool *pgxpool.Pool) *ListMemberStorage {
+	return &ListMemberStorage{
+		Pool:    pool,
+		Queries: sqlc.New(newDB(pool)),
+	}
+}
+
+// Transaction runs fn in a transaction, the storages called with the context passed to fn take part in it
+func (s *ListMemberStorage) Transaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	return transaction(ctx, s.Pool, fn)
+}
+
+// CreateListMember adds the user to the list, the role of an existing member is replaced
+func (s *ListMemberStorage) CreateListMember(ctx context.Context, member model.ListMember) error {
+	const op = "list_member.storage.CreateListMember"
+
+	if err := s.Queries.CreateListMember(ctx, sqlc.CreateListMemberParams{
+		ListID:    member.ListID,
+		UserID:    member.UserID,
+		Role:      member.Role.String(),
+		CreatedAt: member.CreatedAt,
+	}); err != nil {
+		return fmt.Errorf("%s: failed to create list member: %w", op, err)
+	}
+	return nil
+}
+
+func (s *ListMemberStorage) GetListMemberRole(ctx context.Context, listID, userID string) (model.ListRole, error) {
+	const op = "list_member.storage.GetListMemberRole"
+
+	role, err := s.Queries.GetListMemberRole(ctx, sqlc.GetListMemberRoleParams{
+		ListID: listID,
+		UserID: userID,
+	})
+	if errors.Is(err, pgx.ErrNoRows) {
+		return "", le.ErrListMemberNotFound
+	}
+	if err != nil {
+		return "", fmt.Errorf("%s: failed to get list member role: %w", op, err)
+	}
+	return model.ListRole(role), nil
+}
+
+// GetListMemberRoleWithDeleted returns the role of the user in the list, even if the list is deleted.
+// The members of the deleted list are kept, so the role is known when the list is restored
+func (s *ListMemberStorage) GetListMemberRoleWithDeleted(ctx context.Context, listID, userID string) (model.ListRole, error) {
+	const op = "list_member.storage.GetListMemberRoleWithDeleted"
+
+	role, err := s.Queries.GetListMemberRoleWithDeleted(ctx, sqlc.GetListMemberRoleWithDeletedParams{
+		ListID: listID,
+		UserID: userID,
+	})
+	if errors.Is(err, pgx.ErrNoRows) {
+		return "", le.ErrListMemberNotFound
+	}
+	if err != nil {
+		return "", fmt.Errorf("%s: failed to get list member role: %w", op, err)
+	}
+	return model.ListRole(role), nil
+}
+
+// GetHeadingMemberRole returns the role of the user in the list of the heading
+func (s *ListMemberStorage) GetHeadingMemberRole(ctx context.Context, headingID, userID string) (model.ListRole, error) {
+	const op = "list_member.storage.GetHeadingMemberRole"
+
+	role, err := s.Queries.GetHeadingMemberRole(ctx, sqlc.GetHeadingMemberRoleParams{
+		ID:     headingID,
+		UserID: userID,
+	})
+	if errors.Is(err, pgx.ErrNoRows) {
+		return "", le.ErrListMemberNotFound
+	}
+	if err != nil {
+		return "", fmt.Errorf("%s: failed to get heading member role: %w", op, err)
+	}
+	return model.ListRole(role), nil
+}
+
+// GetTaskMemberRole returns the role of the user in the list of the task
+func (s *ListMemberStorage) GetTaskMemberRole(ctx context.Context, taskID, userID string) (model.ListRole, error) {
+	const op = "list_member.storage.GetTaskMemberRole"
+
+	role, err := s.Queries.GetTaskMemberRole(ctx, sqlc.GetTaskMemberRoleParams{
+		ID:     taskID,
+		UserID: userID,
+	})
+	if errors.Is(err, pgx.ErrNoRows) {
+		return "", le.ErrListMemberNotFound
+	}
+	if err != nil {
+		return "", fmt.Errorf("%s: failed to get task member role: %w", op, err)
+	}
+	return model.ListRole(role), nil
+}
+
+func (s *ListMemberStorage) GetListMembers(ctx context.Context, listID string) ([]model.ListMember, error) {
+	const op = "list_member.storage.GetListMembers"
+
+	items, err := s.Queries.GetListMembers(ctx, listID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to get list members: %w", op, err)
+	}
+
+	var members []model.ListMember
+
+	for _, item := range items {
+		members = append(members, model.ListMember{
+			ListID:    item.ListID,
+			UserID:    item.UserID,
+			Email:     item.Email,
+			Role:      model.ListRole(item.Role),
+			CreatedAt: item.CreatedAt,
+		})
+	}
+	return members, nil
+}
+
+// GetTaskMemberIDs returns the IDs of the members of the list of the task
+func (s *ListMemberStorage) GetTaskMemberIDs(ctx context.Context, taskID string) ([]string, error) {
+	const op = "list_member.storage.GetTaskMemberIDs"
+
+	userIDs, err := s.Queries.GetTaskMemberIDs(ctx, taskID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to get task members: %w", op, err)
+	}
+	return userIDs, nil
+}
+
+func (s *ListMemberStorage) CountListOwners(ctx context.Context, listID string) (int, error) {
+	const op = "list_member.storage.CountListOwners"
+
+	count, err := s.Queries.CountListOwners(ctx, listID)
+	if err != nil {
+		return 0, fmt.Errorf("%s: failed to count list owners: %w", op, err)
+	}
+	return int(count), nil
+}
+
+func (s *ListMemberStorage) UpdateListMemberRole(ctx context.Context, member model.ListMember) error {
+	const op = "list_member.storage.UpdateListMemberRole"
+
+	rows, err := s.Queries.UpdateListMemberRole(ctx, sqlc.UpdateListMemberRoleParams{
+		Role:   member.Role.String(),
+		ListID: member.ListID,
+		UserID: member.UserID,
+	})
+	if err != nil {
+		return fmt.Errorf("%s: failed to update list member role: %w", op, err)
+	}
+	if rows == 0 {
+		return le.ErrListMemberNotFound
+	}
+	return nil
+}
+
+func (s *ListMemberStorage) DeleteListMember(ctx context.Context, listID, userID string) error {
+	const op = "list_member.storage.DeleteListMember"
+
+	rows, err := s.Queries.DeleteListMember(ctx, sqlc.DeleteListMemberParams{
+		ListID: listID,
+		UserID: userID,
+	})
+	if err != nil {
+		return fmt.Errorf("%s: failed to delete list member: %w", op, err)
+	}
+	if rows == 0 {
+		return le.ErrListMemberNotFound
+	}
+	return nil
+}
+
+// CreateListInvitation creates the pending invitation, or updates the role of the pending invitation
+// sent to the same email, and returns the stored invitation
+func (s *ListMemberStorage) CreateListInvitation(ctx context.Context, invitation model.ListInvitation) (model.ListInvitation, error) {
+	const op = "list_member.storage.CreateListInvitation"
+
+	row, err := s.Queries.CreateListInvitation(ctx, sqlc.CreateListInvitationParams{
+		ID:        invitation.ID,
+		ListID:    invitation.ListID,
+		Email:     invitation.Email,
+		Role:      invitation.Role.String(),
+		InvitedBy: invitation.InvitedBy,
+		CreatedAt: invitation.UpdatedAt,
+	})
+	if err != nil {
+		return model.ListInvitation{}, fmt.Errorf("%s: failed to create list invitation: %w", op, err)
+	}
+
+	invitation.ID = row.ID
+	invitation.CreatedAt = row.CreatedAt
+	invitation.Status = model.InvitationStatusPending
+
+	return invitation, nil
+}
+
+func (s *ListMemberStorage) GetListInvitationByID(ctx context.Context, invitationID string) (model.ListInvitation, error) {
+	const op = "list_member.storage.GetListInvitationByID"
+
+	item, err := s.Queries.GetListInvitationByID(ctx, invitationID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return model.ListInvitation{}, le.ErrListInvitationNotFound
+	}
+	if err != nil {
+		return model.ListInvitation{}, fmt.Errorf("%s: failed to get list invitation: %w", op, err)
+	}
+
+	return model.ListInvitation{
+		ID:        item.ID,
+		ListID:    item.ListID,
+		ListTitle: item.ListTitle,
+		Email:     item.Email,
+		Role:      model.ListRole(item.Role),
+		Status:    model.InvitationStatus(item.Status),
+		InvitedBy: item.InvitedBy,
+		CreatedAt: item.CreatedAt,
+		UpdatedAt: item.UpdatedAt,
+	}, nil
+}
+
+func (s *ListMemberStorage) GetPendingListInvitationsByListID(ctx context.Context, listID string) ([]model.ListInvitation, error) {
+	const op = "list_member.storage.GetPendingListInvitationsByListID"
+
+	items, err := s.Queries.GetPendingListInvitationsByListID(ctx, listID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to get list invitations: %w", op, err)
+	}
+	if len(items) == 0 {
+		return nil, le.ErrNoListInvitationsFound
+	}
+
+	var invitations []model.ListInvitation
+
+	for _, item := range items {
+		invitations = append(invitations, model.ListInvitation{
+			ID:        item.ID,
+			ListID:    item.ListID,
+			ListTitle: item.ListTitle,
+			Email:     item.Email,
+			Role:      model.ListRole(item.Role),
+			Status:    model.InvitationStatus(item.Status),
+			InvitedBy: item.InvitedBy,
+			CreatedAt: item.CreatedAt,
+			UpdatedAt: item.UpdatedAt,
+		})
+	}
+	return invitations, nil
+}
+
+func (s *ListMemberStorage) GetPendingListInvitationsByEmail(ctx context.Context, email string) ([]model.ListInvitation, error) {
+	const op = "list_member.storage.GetPendingListInvitationsByEmail"
+
+	items, err := s.Queries.GetPendingListInvitationsByEmail(ctx, email)
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to get list invitations: %w", op, err)
+	}
+	if len(items) == 0 {
+		return nil, le.ErrNoListInvitationsFound
+	}
+
+	var invitations []model.ListInvitation
+
+	for _, item := range items {
+		invitations = append(invitations, model.ListInvitation{
+			ID:        item.ID,
+			ListID:    item.ListID,
+			ListTitle: item.ListTitle,
+			Email:     item.Email,
+			Role:      model.ListRole(item.Role),
+			Status:    model.InvitationStatus(item.Status),
+			InvitedBy: item.InvitedBy,
+			CreatedAt: item.CreatedAt,
+			UpdatedAt: item.UpdatedAt,
+		})
+	}
+	return invitations, nil
+}
+
+// UpdateListInvitationStatus changes the status of the pending invitation
+func (s *ListMemberStorage) UpdateListInvitationStatus(ctx context.Context, invitationID string, status model.InvitationStatus, updatedAt time.Time) error {
+	const op = "list_member.storage.UpdateListInvitationStatus"
+
+	rows, err := s.Queries.UpdateListInvitationStatus(ctx, sqlc.UpdateListInvitationStatusParams{
+		Status:    status.String(),
+		UpdatedAt: updatedAt,
+		ID:        invitationID,
+	})
+	if err != nil {
+		return fmt.Errorf("%s: failed to update list invitation status: %w", op, err)
+	}
+	if rows == 0 {
+		return le.ErrListInvitationNotFound
+	}
+	return nil
+}
+
+// UnassignNonMemberTasks clears the assignee of the tasks in the list assigned to users who aren't its members
+func (s *ListMemberStorage) UnassignNonMemberTasks(ctx context.Context, listID string) error {
+	const op = "list_member.storage.UnassignNonMemberTasks"
+
+	if err := s.Queries.UnassignNonMemberTasks(ctx, listID); err != nil {
+		return fmt.Errorf("%s: failed to unassign tasks: %w", op, err)
+	}
+	return nil
+}
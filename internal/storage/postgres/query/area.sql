@@ -0,0 +1,44 @@
+-- name: CreateArea :one
+INSERT INTO areas (id, title, position, user_id, updated_at)
+VALUES ($1, $2, (
+    SELECT COALESCE(MAX(position) + 1, 0)
+    FROM areas
+    WHERE user_id = $3
+      AND deleted_at IS NULL
+), $3, $4)
+RETURNING position;
+
+-- name: GetAreaByID :one
+SELECT id, title, position, user_id, updated_at
+FROM areas
+WHERE id = $1
+  AND user_id = $2
+  AND deleted_at IS NULL;
+
+-- name: GetAreasByUserID :many
+SELECT id, title, position, user_id, updated_at
+FROM areas
+WHERE user_id = $1
+  AND deleted_at IS NULL
+ORDER BY position, id;
+
+-- name: UpdateArea :execrows
+UPDATE areas
+SET title = $1, updated_at = $2
+WHERE id = $3
+  AND user_id = $4
+  AND deleted_at IS NULL;
+
+-- name: UpdateAreaPosition :execrows
+UPDATE areas
+SET position = $1, updated_at = $2
+WHERE id = $3
+  AND user_id = $4
+  AND deleted_at IS NULL;
+
+-- name: DeleteArea :execrows
+UPDATE areas
+SET deleted_at = $1
+WHERE id = $2
+  AND user_id = $3
+  AND deleted_at IS NULL;
\ No newline at end of file
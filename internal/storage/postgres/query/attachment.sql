@@ -0,0 +1,47 @@
+-- name: CreateAttachment :exec
+INSERT INTO attachments (id, task_id, file_name, content_type, size, blob_key, user_id, created_at)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8);
+
+-- name: GetAttachmentByID :one
+SELECT id, task_id, file_name, content_type, size, blob_key, user_id, created_at, deleted_at
+FROM attachments
+WHERE id = $1
+  AND task_id = $2
+  AND deleted_at IS NULL;
+
+-- name: GetAttachmentsByTaskID :many
+SELECT id, task_id, file_name, content_type, size, blob_key, user_id, created_at, deleted_at
+FROM attachments
+WHERE task_id = $1
+  AND deleted_at IS NULL
+ORDER BY created_at, id;
+
+-- name: DeleteAttachment :execrows
+UPDATE attachments
+SET deleted_at = $1
+WHERE id = $2
+  AND task_id = $3
+  AND deleted_at IS NULL;
+
+-- name: LockUserAttachments :exec
+SELECT pg_advisory_xact_lock(hashtext('attachments'), hashtext(@user_id::varchar));
+
+-- name: GetUserAttachmentsSize :one
+SELECT COALESCE(SUM(size), 0)::bigint AS size
+FROM attachments
+WHERE user_id = $1
+  AND deleted_at IS NULL;
+
+-- name: GetAttachmentsToPurge :many
+SELECT a.id, a.task_id, a.file_name, a.content_type, a.size, a.blob_key, a.user_id, a.created_at, a.deleted_at
+FROM attachments a
+    JOIN tasks t
+        ON t.id = a.task_id
+WHERE a.deleted_at IS NOT NULL
+   OR t.deleted_at < @purge_before
+ORDER BY a.id
+LIMIT @limit_count;
+
+-- name: PurgeAttachment :exec
+DELETE FROM attachments
+WHERE id = $1;
@@ -0,0 +1,38 @@
+-- name: CreateAuditEntry :exec
+INSERT INTO audit_log (id, entity_type, entity_id, action, changes, user_id, device_id, request_id, created_at)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9);
+
+-- name: GetAuditEntriesByEntity :many
+SELECT a.id, a.entity_type, a.entity_id, a.action, a.changes, a.user_id, u.email, a.device_id, a.request_id, a.created_at
+FROM audit_log a
+    JOIN users u
+        ON u.id = a.user_id
+WHERE a.entity_type = $1
+  AND a.entity_id = $2
+  AND (a.user_id = $3
+    OR (a.entity_type = 'list' AND a.entity_id IN (SELECT list_id
+                                                    FROM list_members
+                                                    WHERE user_id = $3))
+    OR (a.entity_type = 'heading' AND a.entity_id IN (SELECT h.id
+                                                       FROM headings h
+                                                           JOIN list_members m
+                                                               ON m.list_id = h.list_id
+                                                       WHERE m.user_id = $3))
+    OR (a.entity_type = 'task' AND a.entity_id IN (SELECT t.id
+                                                    FROM tasks t
+                                                        JOIN list_members m
+                                                            ON m.list_id = t.list_id
+                                                    WHERE m.user_id = $3)))
+  AND a.id > @after_id::varchar
+ORDER BY a.id
+LIMIT $4;
+
+-- name: GetAuditEntriesByUserID :many
+SELECT a.id, a.entity_type, a.entity_id, a.action, a.changes, a.user_id, u.email, a.device_id, a.request_id, a.created_at
+FROM audit_log a
+    JOIN users u
+        ON u.id = a.user_id
+WHERE a.user_id = $1
+  AND a.id > @after_id::varchar
+ORDER BY a.id
+LIMIT $2;
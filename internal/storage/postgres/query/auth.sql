@@ -35,7 +35,7 @@ WHERE email = $1
   AND deleted_at IS NULL;
 
 -- name: GetUserByID :one
-SELECT id, email, updated_at
+SELECT id, email, time_zone, updated_at
 FROM users
 WHERE id = $1
   AND deleted_at IS NULL;
@@ -85,4 +85,11 @@ WHERE refresh_token = $1;
 -- name: DeleteSession :exec
 DELETE FROM refresh_sessions
 WHERE user_id = $1
-  AND device_id = $2;
\ No newline at end of file
+  AND device_id = $2;
+
+-- name: UpdateUserTimeZone :execrows
+UPDATE users
+SET time_zone = $1,
+    updated_at = $2
+WHERE id = $3
+  AND deleted_at IS NULL;
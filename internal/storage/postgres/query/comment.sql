@@ -0,0 +1,61 @@
+-- name: CreateTaskComment :exec
+INSERT INTO task_comments (id, task_id, body, mentions, user_id, created_at, updated_at)
+VALUES ($1, $2, $3, $4, $5, $6, $6);
+
+-- name: GetTaskCommentByID :one
+SELECT c.id, c.task_id, c.body, c.mentions, c.user_id, u.email, c.created_at, c.updated_at
+FROM task_comments c
+    JOIN users u
+        ON u.id = c.user_id
+WHERE c.id = $1
+  AND c.task_id = $2
+  AND c.deleted_at IS NULL;
+
+-- name: GetTaskComments :many
+SELECT c.id, c.task_id, c.body, c.mentions, c.user_id, u.email, c.created_at, c.updated_at
+FROM task_comments c
+    JOIN users u
+        ON u.id = c.user_id
+WHERE c.task_id = $1
+  AND c.deleted_at IS NULL
+  AND c.id > @after_id::varchar
+ORDER BY c.id
+LIMIT $2;
+
+-- name: GetAllTaskComments :many
+SELECT c.id, c.task_id, c.body, c.mentions, c.user_id, u.email, c.created_at, c.updated_at
+FROM task_comments c
+    JOIN users u
+        ON u.id = c.user_id
+WHERE c.task_id = $1
+  AND c.deleted_at IS NULL
+ORDER BY c.id;
+
+-- name: UpdateTaskComment :execrows
+UPDATE task_comments
+SET body = $1,
+    mentions = $2,
+    updated_at = $3
+WHERE id = $4
+  AND task_id = $5
+  AND user_id = $6
+  AND deleted_at IS NULL;
+
+-- name: DeleteTaskComment :execrows
+UPDATE task_comments
+SET deleted_at = $1
+WHERE id = $2
+  AND task_id = $3
+  AND deleted_at IS NULL;
+
+-- name: CreateTaskActivity :exec
+INSERT INTO task_activities (id, task_id, kind, field, old_value, new_value, user_id, created_at)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8);
+
+-- name: GetTaskActivities :many
+SELECT a.id, a.task_id, a.kind, a.field, a.old_value, a.new_value, a.user_id, u.email, a.created_at
+FROM task_activities a
+    JOIN users u
+        ON u.id = a.user_id
+WHERE a.task_id = $1
+ORDER BY a.created_at, a.id;
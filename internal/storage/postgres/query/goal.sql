@@ -0,0 +1,180 @@
+-- name: CreateGoal :exec
+INSERT INTO goals (id, title, description, target_date, user_id, updated_at)
+VALUES ($1, $2, $3, $4, $5, $6);
+
+-- name: GetGoalByID :one
+SELECT id, title, description, target_date, user_id, updated_at
+FROM goals
+WHERE id = $1
+  AND user_id = $2
+  AND deleted_at IS NULL;
+
+-- name: GetGoalsByUserID :many
+SELECT id, title, description, target_date, user_id, updated_at
+FROM goals
+WHERE user_id = $1
+  AND deleted_at IS NULL
+ORDER BY target_date NULLS LAST, id;
+
+-- name: UpdateGoal :execrows
+UPDATE goals
+SET title = $1, description = $2, target_date = $3, updated_at = $4
+WHERE id = $5
+  AND user_id = $6
+  AND deleted_at IS NULL;
+
+-- name: DeleteGoal :execrows
+UPDATE goals
+SET deleted_at = $1
+WHERE id = $2
+  AND user_id = $3
+  AND deleted_at IS NULL;
+
+-- name: CreateKeyResult :exec
+INSERT INTO key_results (
+    id,
+    goal_id,
+    title,
+    kind,
+    start_value,
+    target_value,
+    current_value,
+    user_id,
+    updated_at
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7, $8, $9
+);
+
+-- name: GetKeyResultsByGoalID :many
+SELECT id, goal_id, title, kind, start_value, target_value, current_value, updated_at
+FROM key_results
+WHERE goal_id = $1
+  AND user_id = $2
+  AND deleted_at IS NULL
+ORDER BY id;
+
+-- name: UpdateKeyResult :execrows
+UPDATE key_results
+SET title = $1,
+    kind = $2,
+    start_value = $3,
+    target_value = $4,
+    current_value = $5,
+    updated_at = $6
+WHERE id = $7
+  AND goal_id = $8
+  AND user_id = $9
+  AND deleted_at IS NULL;
+
+-- name: DeleteKeyResult :execrows
+UPDATE key_results
+SET deleted_at = $1
+WHERE id = $2
+  AND goal_id = $3
+  AND user_id = $4
+  AND deleted_at IS NULL;
+
+-- name: LinkListToGoal :execrows
+UPDATE lists
+SET goal_id = $1, updated_at = $2
+WHERE id = $3
+  AND user_id = $4
+  AND deleted_at IS NULL;
+
+-- name: UnlinkListFromGoal :execrows
+UPDATE lists
+SET goal_id = NULL, updated_at = $1
+WHERE id = $2
+  AND goal_id = $3
+  AND user_id = $4
+  AND deleted_at IS NULL;
+
+-- name: LinkTaskToGoal :execrows
+UPDATE tasks
+SET goal_id = $1, updated_at = $2
+WHERE id = $3
+  AND user_id = $4
+  AND deleted_at IS NULL;
+
+-- name: UnlinkTaskFromGoal :execrows
+UPDATE tasks
+SET goal_id = NULL, updated_at = $1
+WHERE id = $2
+  AND goal_id = $3
+  AND user_id = $4
+  AND deleted_at IS NULL;
+
+-- name: UnlinkListsFromGoal :exec
+UPDATE lists
+SET goal_id = NULL
+WHERE goal_id = $1
+  AND user_id = $2;
+
+-- name: UnlinkTasksFromGoal :exec
+UPDATE tasks
+SET goal_id = NULL
+WHERE goal_id = $1
+  AND user_id = $2;
+
+-- name: GetGoalListIDs :many
+SELECT id
+FROM lists
+WHERE goal_id = $1
+  AND user_id = $2
+  AND deleted_at IS NULL
+ORDER BY id;
+
+-- name: GetGoalTaskIDs :many
+SELECT id
+FROM tasks
+WHERE goal_id = $1
+  AND user_id = $2
+  AND deleted_at IS NULL
+ORDER BY id;
+
+-- name: GetGoalTaskCounts :one
+SELECT
+    COUNT(t.id) AS total,
+    COUNT(t.id) FILTER (
+        WHERE t.status_id = (SELECT id
+                             FROM statuses
+                             WHERE title = @completed_status_title::varchar)
+    ) AS completed
+FROM tasks t
+    LEFT JOIN lists l
+        ON l.id = t.list_id
+        AND l.deleted_at IS NULL
+WHERE (t.goal_id = @goal_id::varchar OR l.goal_id = @goal_id::varchar)
+  AND t.user_id = @user_id
+  AND t.deleted_at IS NULL;
+
+-- name: GetGoalsByTaskID :many
+SELECT DISTINCT g.id, g.user_id
+FROM tasks t
+    LEFT JOIN lists l
+        ON l.id = t.list_id
+    JOIN goals g
+        ON g.id IN (t.goal_id, l.goal_id)
+        AND g.deleted_at IS NULL
+WHERE t.id = $1;
+
+-- name: CreateGoalProgress :execrows
+INSERT INTO goal_progress (goal_id, progress, recorded_at)
+SELECT @goal_id::varchar, @progress::float8, @recorded_at::timestamptz
+WHERE NOT EXISTS (
+    SELECT 1
+    FROM (
+        SELECT progress
+        FROM goal_progress
+        WHERE goal_id = @goal_id::varchar
+        ORDER BY recorded_at DESC, id DESC
+        LIMIT 1
+    ) latest
+    WHERE latest.progress = @progress::float8
+);
+
+-- name: GetGoalProgressHistory :many
+SELECT progress, recorded_at
+FROM goal_progress
+WHERE goal_id = $1
+ORDER BY recorded_at, id;
@@ -0,0 +1,62 @@
+-- name: CreateHabit :exec
+INSERT INTO habits (id, title, notes, frequency, times_per_week, user_id, updated_at)
+VALUES ($1, $2, $3, $4, $5, $6, $7);
+
+-- name: GetHabitByID :one
+SELECT id, title, notes, frequency, times_per_week, user_id, updated_at, deleted_at
+FROM habits
+WHERE id = $1
+  AND user_id = $2
+  AND deleted_at IS NULL;
+
+-- name: GetHabitsByUserID :many
+SELECT id, title, notes, frequency, times_per_week, user_id, updated_at, deleted_at
+FROM habits
+WHERE user_id = $1
+  AND deleted_at IS NULL
+ORDER BY title, id;
+
+-- name: UpdateHabit :execrows
+UPDATE habits
+SET title = $1,
+    notes = $2,
+    frequency = $3,
+    times_per_week = $4,
+    updated_at = $5
+WHERE id = $6
+  AND user_id = $7
+  AND deleted_at IS NULL;
+
+-- name: DeleteHabit :execrows
+UPDATE habits
+SET deleted_at = $1
+WHERE id = $2
+  AND user_id = $3
+  AND deleted_at IS NULL;
+
+-- name: CreateHabitCheckIn :exec
+INSERT INTO habit_check_ins (habit_id, day)
+VALUES ($1, $2)
+ON CONFLICT (habit_id, day) DO NOTHING;
+
+-- name: DeleteHabitCheckIn :execrows
+DELETE FROM habit_check_ins
+WHERE habit_id = $1
+  AND day = $2;
+
+-- name: GetHabitCheckIns :many
+SELECT c.habit_id, c.day
+FROM habit_check_ins c
+    JOIN habits h
+        ON h.id = c.habit_id
+WHERE h.user_id = $1
+  AND h.deleted_at IS NULL
+ORDER BY c.habit_id, c.day;
+
+-- name: GetHabitCheckInsInRange :many
+SELECT day
+FROM habit_check_ins
+WHERE habit_id = $1
+  AND day >= @from_day
+  AND day <= @to_day
+ORDER BY day;
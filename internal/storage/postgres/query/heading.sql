@@ -6,45 +6,87 @@ VALUES($1, $2, $3, $4, $5, $6);
 SELECT id
 FROM headings
 WHERE list_id = $1
-  AND user_id = $2
+  AND list_id IN (SELECT list_id
+                  FROM list_members
+                  WHERE user_id = $2)
   AND is_default = TRUE
   AND deleted_at IS NULL;
 
 -- name: GetHeadingByID :one
-SELECT id, title, list_id, user_id, updated_at
+SELECT id, title, list_id, user_id, is_default, updated_at
 FROM headings
 WHERE id = $1
-  AND user_id = $2
+  AND list_id IN (SELECT list_id
+                  FROM list_members
+                  WHERE user_id = $2)
   AND deleted_at IS NULL;
 
 -- name: GetHeadingsByListID :many
 SELECT id, title, list_id, user_id, updated_at
 FROM headings
 WHERE list_id = $1
-  AND user_id = $2
+  AND list_id IN (SELECT list_id
+                  FROM list_members
+                  WHERE user_id = $2)
   AND deleted_at IS NULL;
 
 -- name: UpdateHeading :exec
 UPDATE headings
 SET title = $1, updated_at = $2
 WHERE id = $3
-  AND user_id = $4;
+  AND list_id IN (SELECT list_id
+                  FROM list_editors_view
+                  WHERE user_id = $4);
 
 -- name: MoveHeadingToAnotherList :exec
 UPDATE headings
 SET list_id = $1, updated_at = $2
 WHERE id = $3
-  AND user_id = $4;
+  AND list_id IN (SELECT list_id
+                  FROM list_editors_view
+                  WHERE user_id = $4);
 
 -- name: UpdateTasksListID :exec
 UPDATE tasks
 SET list_id = $1, updated_at = $2
 WHERE heading_id = $3
-  AND user_id = $4;
+  AND list_id IN (SELECT list_id
+                  FROM list_editors_view
+                  WHERE user_id = $4);
 
 -- name: DeleteHeading :exec
 UPDATE headings
 SET deleted_at = $1
 WHERE id = $2
-  AND user_id = $3
-  AND deleted_at IS NULL;
\ No newline at end of file
+  AND list_id IN (SELECT list_id
+                  FROM list_editors_view
+                  WHERE user_id = $3)
+  AND deleted_at IS NULL;
+
+-- name: HeadingHasTasks :one
+SELECT EXISTS (SELECT 1
+               FROM tasks
+               WHERE heading_id = $1
+                 AND deleted_at IS NULL);
+
+-- name: ArchiveTasksByHeadingID :exec
+UPDATE tasks
+SET status_id = (SELECT s.id
+                 FROM statuses s
+                 WHERE s.title = @status_title),
+    updated_at = @updated_at,
+    deleted_at = @deleted_at
+WHERE heading_id = @heading_id
+  AND list_id IN (SELECT list_id
+                  FROM list_editors_view
+                  WHERE user_id = @user_id)
+  AND deleted_at IS NULL;
+
+-- name: MoveTasksToHeading :exec
+UPDATE tasks
+SET list_id = @list_id, heading_id = @heading_id, updated_at = @updated_at
+WHERE heading_id = @from_heading_id
+  AND list_id IN (SELECT list_id
+                  FROM list_editors_view
+                  WHERE user_id = @user_id)
+  AND deleted_at IS NULL;
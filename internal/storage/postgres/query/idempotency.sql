@@ -0,0 +1,33 @@
+-- name: CreateIdempotencyKey :execrows
+INSERT INTO idempotency_keys (key, user_id, method, path, request_hash, created_at, expires_at)
+VALUES ($1, $2, $3, $4, $5, $6, $7)
+ON CONFLICT (user_id, key) DO UPDATE
+SET method = EXCLUDED.method,
+    path = EXCLUDED.path,
+    request_hash = EXCLUDED.request_hash,
+    status_code = 0,
+    response_body = NULL,
+    created_at = EXCLUDED.created_at,
+    expires_at = EXCLUDED.expires_at
+WHERE idempotency_keys.expires_at <= EXCLUDED.created_at;
+
+-- name: GetIdempotencyKey :one
+SELECT key, user_id, method, path, request_hash, status_code, response_body, created_at, expires_at
+FROM idempotency_keys
+WHERE user_id = $1
+  AND key = $2;
+
+-- name: SaveIdempotentResponse :exec
+UPDATE idempotency_keys
+SET status_code = $1, response_body = $2
+WHERE user_id = $3
+  AND key = $4;
+
+-- name: DeleteIdempotencyKey :exec
+DELETE FROM idempotency_keys
+WHERE user_id = $1
+  AND key = $2;
+
+-- name: DeleteExpiredIdempotencyKeys :execrows
+DELETE FROM idempotency_keys
+WHERE expires_at <= $1;
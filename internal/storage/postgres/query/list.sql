@@ -1,36 +1,239 @@
--- name: CreateList :exec
-INSERT INTO lists (id, title, user_id, is_default, updated_at)
-VALUES ($1, $2, $3, $4, $5);
+-- name: CreateList :one
+INSERT INTO lists (id, title, user_id, is_default, area_id, position, color, icon, notes, start_date, deadline, updated_at)
+VALUES ($1, $2, $3, $4, $5, (
+    SELECT COALESCE(MAX(position) + 1, 0)
+    FROM lists
+    WHERE user_id = $3
+      AND area_id IS NOT DISTINCT FROM $5
+      AND deleted_at IS NULL
+), $6, $7, $8, $9, $10, $11)
+RETURNING position;
 
 -- name: GetListByID :one
-SELECT id, title, user_id, updated_at
-FROM lists
-WHERE id = $1
-  AND user_id = $2
-  AND deleted_at IS NULL;
+SELECT
+    l.id,
+    l.title,
+    l.user_id,
+    l.is_default,
+    l.area_id,
+    l.position,
+    l.color,
+    l.icon,
+    l.notes,
+    l.start_date,
+    l.deadline,
+    l.status,
+    l.archived_at,
+    l.updated_at,
+    m.role,
+    COALESCE(p.open_tasks, 0)::int AS open_tasks,
+    COALESCE(p.completed_tasks, 0)::int AS completed_tasks
+FROM lists l
+    JOIN list_members m
+        ON m.list_id = l.id
+    LEFT JOIN list_progress_view p
+        ON p.list_id = l.id
+WHERE l.id = $1
+  AND m.user_id = $2
+  AND l.deleted_at IS NULL;
 
 -- name: GetListsByUserID :many
-SELECT id, title, updated_at
-FROM lists
-WHERE user_id = $1
-  AND deleted_at IS NULL
-ORDER BY id;
+SELECT
+    l.id,
+    l.title,
+    l.user_id,
+    l.area_id,
+    l.position,
+    l.color,
+    l.icon,
+    l.notes,
+    l.start_date,
+    l.deadline,
+    l.status,
+    l.archived_at,
+    l.updated_at,
+    m.role,
+    COALESCE(p.open_tasks, 0)::int AS open_tasks,
+    COALESCE(p.completed_tasks, 0)::int AS completed_tasks
+FROM lists l
+    JOIN list_members m
+        ON m.list_id = l.id
+    LEFT JOIN list_progress_view p
+        ON p.list_id = l.id
+WHERE m.user_id = @user_id
+  AND l.deleted_at IS NULL
+  AND NOT (l.is_default AND l.area_id IS NOT NULL)
+  AND (l.status = 'active' OR @include_archived::bool)
+ORDER BY l.position, l.id;
+
+-- name: GetArchivedListsByUserID :many
+SELECT
+    l.id,
+    l.title,
+    l.user_id,
+    l.area_id,
+    l.position,
+    l.color,
+    l.icon,
+    l.notes,
+    l.start_date,
+    l.deadline,
+    l.status,
+    l.archived_at,
+    l.updated_at,
+    m.role,
+    COALESCE(p.open_tasks, 0)::int AS open_tasks,
+    COALESCE(p.completed_tasks, 0)::int AS completed_tasks
+FROM lists l
+    JOIN list_members m
+        ON m.list_id = l.id
+    LEFT JOIN list_progress_view p
+        ON p.list_id = l.id
+WHERE m.user_id = $1
+  AND l.deleted_at IS NULL
+  AND l.status <> 'active'
+ORDER BY l.archived_at DESC, l.id;
 
 -- name: GetDefaultListID :one
 SELECT id
 FROM lists
 WHERE user_id = $1
+  AND is_default = TRUE
+  AND area_id IS NULL
+  AND deleted_at IS NULL;
+
+-- name: GetAreaDefaultListID :one
+SELECT id
+FROM lists
+WHERE area_id = $1
+  AND user_id = $2
   AND is_default = TRUE
   AND deleted_at IS NULL;
 
 -- name: UpdateList :exec
 UPDATE lists
-SET title = $1,	updated_at = $2
-WHERE id = $3
-  AND user_id = $4;
+SET title = $1,
+    color = $2,
+    icon = $3,
+    notes = $4,
+    start_date = $5,
+    deadline = $6,
+    updated_at = $7
+WHERE id = $8
+  AND id IN (SELECT list_id
+             FROM list_editors_view
+             WHERE user_id = $9);
 
 -- name: DeleteList :exec
 UPDATE lists
 SET deleted_at = $1
 WHERE id = $2
-  AND user_id = $3;
\ No newline at end of file
+  AND id IN (SELECT list_id
+             FROM list_members
+             WHERE user_id = $3
+               AND role = 'owner');
+
+-- name: MoveListToArea :execrows
+UPDATE lists
+SET area_id = $1, position = (
+    SELECT COALESCE(MAX(l.position) + 1, 0)
+    FROM lists l
+    WHERE l.user_id = $4
+      AND l.area_id IS NOT DISTINCT FROM $1
+      AND l.deleted_at IS NULL
+), updated_at = $2
+WHERE id = $3
+  AND user_id = $4
+  AND is_default = FALSE
+  AND deleted_at IS NULL;
+
+-- name: UpdateListPosition :execrows
+UPDATE lists
+SET position = $1, updated_at = $2
+WHERE id = $3
+  AND user_id = $4
+  AND deleted_at IS NULL;
+
+-- name: DetachListsFromArea :exec
+UPDATE lists
+SET area_id = NULL,
+    is_default = FALSE,
+    title = CASE WHEN is_default THEN @area_title::varchar ELSE title END,
+    updated_at = @updated_at
+WHERE area_id = @area_id
+  AND user_id = @user_id
+  AND deleted_at IS NULL;
+
+-- name: ListHasContent :one
+SELECT EXISTS (SELECT 1
+               FROM tasks t
+               WHERE t.list_id = $1
+                 AND t.deleted_at IS NULL)
+    OR EXISTS (SELECT 1
+               FROM headings h
+               WHERE h.list_id = $1
+                 AND h.is_default = FALSE
+                 AND h.deleted_at IS NULL);
+
+-- name: ArchiveTasksByListID :exec
+UPDATE tasks
+SET status_id = (SELECT s.id
+                 FROM statuses s
+                 WHERE s.title = @status_title),
+    updated_at = @updated_at,
+    deleted_at = @deleted_at
+WHERE list_id = @list_id
+  AND list_id IN (SELECT list_id
+                  FROM list_editors_view
+                  WHERE user_id = @user_id)
+  AND deleted_at IS NULL;
+
+-- name: MoveTasksToList :exec
+UPDATE tasks
+SET list_id = @list_id, heading_id = @heading_id, updated_at = @updated_at
+WHERE list_id = @from_list_id
+  AND list_id IN (SELECT list_id
+                  FROM list_editors_view
+                  WHERE user_id = @user_id)
+  AND deleted_at IS NULL;
+
+-- name: MoveUserTasksToList :exec
+UPDATE tasks
+SET list_id = @list_id, heading_id = @heading_id, updated_at = @updated_at
+WHERE list_id = @from_list_id
+  AND user_id = @user_id
+  AND list_id IN (SELECT list_id
+                  FROM list_editors_view
+                  WHERE user_id = @user_id)
+  AND deleted_at IS NULL;
+
+-- name: DeleteHeadingsByListID :exec
+UPDATE headings
+SET deleted_at = $1
+WHERE list_id = $2
+  AND deleted_at IS NULL;
+
+-- name: UpdateListStatus :execrows
+UPDATE lists
+SET status = @status, archived_at = @archived_at, updated_at = @updated_at
+WHERE id = @id
+  AND id IN (SELECT list_id
+             FROM list_editors_view
+             WHERE user_id = @user_id)
+  AND is_default = FALSE
+  AND deleted_at IS NULL;
+
+-- name: CompleteTasksByListID :exec
+UPDATE tasks
+SET status_id = (SELECT s.id
+                 FROM statuses s
+                 WHERE s.title = @status_title),
+    updated_at = @updated_at
+WHERE list_id = @list_id
+  AND list_id IN (SELECT list_id
+                  FROM list_editors_view
+                  WHERE user_id = @user_id)
+  AND status_id <> (SELECT s.id
+                    FROM statuses s
+                    WHERE s.title = @status_title)
+  AND deleted_at IS NULL;
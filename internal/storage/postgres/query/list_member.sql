@@ -0,0 +1,151 @@
+-- name: CreateListMember :exec
+INSERT INTO list_members (list_id, user_id, role, created_at)
+VALUES ($1, $2, $3, $4)
+ON CONFLICT (list_id, user_id) DO UPDATE
+SET role = EXCLUDED.role;
+
+-- name: GetListMemberRole :one
+SELECT m.role
+FROM list_members m
+    JOIN lists l
+        ON l.id = m.list_id
+WHERE m.list_id = $1
+  AND m.user_id = $2
+  AND l.deleted_at IS NULL;
+
+-- name: GetListMemberRoleWithDeleted :one
+SELECT m.role
+FROM list_members m
+WHERE m.list_id = $1
+  AND m.user_id = $2;
+
+-- name: GetHeadingMemberRole :one
+SELECT m.role
+FROM headings h
+    JOIN list_members m
+        ON m.list_id = h.list_id
+WHERE h.id = $1
+  AND m.user_id = $2
+  AND h.deleted_at IS NULL;
+
+-- name: GetTaskMemberRole :one
+SELECT m.role
+FROM tasks t
+    JOIN list_members m
+        ON m.list_id = t.list_id
+WHERE t.id = $1
+  AND m.user_id = $2
+  AND t.deleted_at IS NULL;
+
+-- name: GetListMembers :many
+SELECT m.list_id, m.user_id, u.email, m.role, m.created_at
+FROM list_members m
+    JOIN users u
+        ON u.id = m.user_id
+WHERE m.list_id = $1
+ORDER BY m.created_at, m.user_id;
+
+-- name: GetTaskMemberIDs :many
+SELECT m.user_id
+FROM tasks t
+    JOIN list_members m
+        ON m.list_id = t.list_id
+WHERE t.id = $1
+ORDER BY m.user_id;
+
+-- name: CountListOwners :one
+SELECT COUNT(*)
+FROM list_members
+WHERE list_id = $1
+  AND role = 'owner';
+
+-- name: UpdateListMemberRole :execrows
+UPDATE list_members
+SET role = $1
+WHERE list_id = $2
+  AND user_id = $3;
+
+-- name: DeleteListMember :execrows
+DELETE FROM list_members
+WHERE list_id = $1
+  AND user_id = $2;
+
+-- name: CreateListInvitation :one
+INSERT INTO list_invitations (id, list_id, email, role, invited_by, created_at, updated_at)
+VALUES ($1, $2, $3, $4, $5, $6, $6)
+ON CONFLICT (list_id, email) WHERE status = 'pending' DO UPDATE
+SET role = EXCLUDED.role,
+    invited_by = EXCLUDED.invited_by,
+    updated_at = EXCLUDED.updated_at
+RETURNING id, created_at;
+
+-- name: GetListInvitationByID :one
+SELECT
+    i.id,
+    i.list_id,
+    l.title AS list_title,
+    i.email,
+    i.role,
+    i.status,
+    i.invited_by,
+    i.created_at,
+    i.updated_at
+FROM list_invitations i
+    JOIN lists l
+        ON l.id = i.list_id
+WHERE i.id = $1
+  AND l.deleted_at IS NULL;
+
+-- name: GetPendingListInvitationsByListID :many
+SELECT
+    i.id,
+    i.list_id,
+    l.title AS list_title,
+    i.email,
+    i.role,
+    i.status,
+    i.invited_by,
+    i.created_at,
+    i.updated_at
+FROM list_invitations i
+    JOIN lists l
+        ON l.id = i.list_id
+WHERE i.list_id = $1
+  AND i.status = 'pending'
+  AND l.deleted_at IS NULL
+ORDER BY i.created_at, i.id;
+
+-- name: GetPendingListInvitationsByEmail :many
+SELECT
+    i.id,
+    i.list_id,
+    l.title AS list_title,
+    i.email,
+    i.role,
+    i.status,
+    i.invited_by,
+    i.created_at,
+    i.updated_at
+FROM list_invitations i
+    JOIN lists l
+        ON l.id = i.list_id
+WHERE i.email = $1
+  AND i.status = 'pending'
+  AND l.deleted_at IS NULL
+ORDER BY i.created_at, i.id;
+
+-- name: UpdateListInvitationStatus :execrows
+UPDATE list_invitations
+SET status = $1, updated_at = $2
+WHERE id = $3
+  AND status = 'pending';
+
+-- name: UnassignNonMemberTasks :exec
+UPDATE tasks t
+SET assignee_id = NULL
+WHERE t.list_id = $1
+  AND t.assignee_id IS NOT NULL
+  AND NOT EXISTS (SELECT 1
+                  FROM list_members m
+                  WHERE m.list_id = t.list_id
+                    AND m.user_id = t.assignee_id);
@@ -0,0 +1,129 @@
+-- name: CreateReviewSession :exec
+INSERT INTO review_sessions (id, user_id, stale_days, started_at)
+VALUES ($1, $2, $3, $4);
+
+-- name: GetReviewSessionByID :one
+SELECT id, user_id, stale_days, started_at, completed_at
+FROM review_sessions
+WHERE id = $1
+  AND user_id = $2;
+
+-- name: GetOpenReviewSession :one
+SELECT id, user_id, stale_days, started_at, completed_at
+FROM review_sessions
+WHERE user_id = $1
+  AND completed_at IS NULL
+ORDER BY started_at DESC
+LIMIT 1;
+
+-- name: CompleteReviewSession :execrows
+UPDATE review_sessions
+SET completed_at = $1
+WHERE id = $2
+  AND user_id = $3
+  AND completed_at IS NULL;
+
+-- name: GetReviewCandidates :many
+SELECT
+    c.section,
+    c.entity_type,
+    c.entity_id,
+    c.title
+FROM (
+    SELECT 'completed' AS section, 'task' AS entity_type, t.id AS entity_id, t.title, 1 AS section_order
+    FROM tasks t
+    WHERE t.list_id IN (SELECT list_id
+                        FROM list_members
+                        WHERE user_id = @user_id)
+      AND t.status_id = (SELECT s.id
+                         FROM statuses s
+                         WHERE s.title = @completed_status)
+      AND t.updated_at >= @since
+      AND t.deleted_at IS NULL
+    UNION ALL
+    SELECT 'slipped', 'task', t.id, t.title, 2
+    FROM tasks t
+    WHERE (t.assignee_id = @user_id
+               OR t.list_id IN (SELECT list_id
+                                FROM list_members
+                                WHERE user_id = @user_id
+                                  AND role = 'owner'))
+      AND t.status_id <> (SELECT s.id
+                          FROM statuses s
+                          WHERE s.title = @completed_status)
+      AND t.deadline >= @since
+      AND t.deadline < CURRENT_DATE
+      AND t.deleted_at IS NULL
+    UNION ALL
+    SELECT 'stale', 'task', t.id, t.title, 3
+    FROM tasks t
+    WHERE (t.assignee_id = @user_id
+               OR t.list_id IN (SELECT list_id
+                                FROM list_members
+                                WHERE user_id = @user_id
+                                  AND role = 'owner'))
+      AND t.status_id <> (SELECT s.id
+                          FROM statuses s
+                          WHERE s.title = @completed_status)
+      AND t.updated_at < @stale_before
+      AND (t.deferred_until IS NULL OR t.deferred_until <= now())
+      AND t.deleted_at IS NULL
+    UNION ALL
+    SELECT 'no_next_action', 'list', l.id, l.title, 4
+    FROM lists l
+    WHERE l.id IN (SELECT list_id
+                   FROM list_members
+                   WHERE user_id = @user_id
+                     AND role = 'owner')
+      AND l.is_default = FALSE
+      AND l.status = 'active'
+      AND l.deleted_at IS NULL
+      AND NOT EXISTS (SELECT 1
+                      FROM tasks t
+                      WHERE t.list_id = l.id
+                        AND t.status_id <> (SELECT s.id
+                                            FROM statuses s
+                                            WHERE s.title = @completed_status)
+                        AND t.deleted_at IS NULL)
+    UNION ALL
+    SELECT 'inbox', 'task', t.id, t.title, 5
+    FROM tasks t
+        JOIN lists l
+            ON l.id = t.list_id
+    WHERE l.user_id = @user_id
+      AND l.is_default = TRUE
+      AND l.area_id IS NULL
+      AND t.status_id <> (SELECT s.id
+                          FROM statuses s
+                          WHERE s.title = @completed_status)
+      AND t.deleted_at IS NULL
+    ) c
+ORDER BY c.section_order, c.title, c.entity_id;
+
+-- name: CreateReviewItem :exec
+INSERT INTO review_items (id, review_id, section, entity_type, entity_id, title, position)
+VALUES ($1, $2, $3, $4, $5, $6, $7);
+
+-- name: GetReviewItems :many
+SELECT id, review_id, section, entity_type, entity_id, title, position, action, acted_at
+FROM review_items
+WHERE review_id = $1
+ORDER BY position;
+
+-- name: GetReviewItemByID :one
+SELECT i.id, i.review_id, i.section, i.entity_type, i.entity_id, i.title, i.position, i.action, i.acted_at
+FROM review_items i
+    JOIN review_sessions r
+        ON r.id = i.review_id
+WHERE i.id = $1
+  AND i.review_id = $2
+  AND r.user_id = $3;
+
+-- name: UpdateReviewItemAction :execrows
+UPDATE review_items
+SET action = $1, acted_at = $2
+WHERE id = $3
+  AND review_id IN (SELECT id
+                    FROM review_sessions
+                    WHERE user_id = $4
+                      AND completed_at IS NULL);
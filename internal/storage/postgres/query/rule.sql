@@ -0,0 +1,73 @@
+-- name: CreateRule :exec
+INSERT INTO rules (id, title, trigger, conditions, actions, is_active, user_id, updated_at)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8);
+
+-- name: GetRuleByID :one
+SELECT id, title, trigger, conditions, actions, is_active, user_id, updated_at
+FROM rules
+WHERE id = $1
+  AND user_id = $2
+  AND deleted_at IS NULL;
+
+-- name: GetRulesByUserID :many
+SELECT id, title, trigger, conditions, actions, is_active, user_id, updated_at
+FROM rules
+WHERE user_id = $1
+  AND deleted_at IS NULL
+ORDER BY id;
+
+-- name: GetActiveRulesByTrigger :many
+SELECT id, title, trigger, conditions, actions, is_active, user_id, updated_at
+FROM rules
+WHERE user_id = $1
+  AND trigger = $2
+  AND is_active = true
+  AND deleted_at IS NULL
+ORDER BY id;
+
+-- name: UpdateRule :execrows
+UPDATE rules
+SET title = $1,
+    trigger = $2,
+    conditions = $3,
+    actions = $4,
+    is_active = $5,
+    updated_at = $6
+WHERE id = $7
+  AND user_id = $8
+  AND deleted_at IS NULL;
+
+-- name: DeleteRule :execrows
+UPDATE rules
+SET is_active = false,
+    deleted_at = $1
+WHERE id = $2
+  AND user_id = $3
+  AND deleted_at IS NULL;
+
+-- name: GetPendingDeadlineRuleRuns :many
+SELECT r.id AS rule_id, t.id AS task_id, t.user_id, t.deadline::timestamptz AS deadline
+FROM rules r
+    JOIN tasks t
+        ON t.user_id = r.user_id
+WHERE r.trigger = 'deadline_passed'
+  AND r.is_active = true
+  AND r.deleted_at IS NULL
+  AND t.deadline IS NOT NULL
+  AND t.deadline <= $1
+  AND t.deleted_at IS NULL
+  AND t.status_id NOT IN (SELECT id
+                          FROM statuses
+                          WHERE title IN ('Completed', 'Archived'))
+  AND NOT EXISTS (SELECT 1
+                  FROM rule_deadline_runs rdr
+                  WHERE rdr.rule_id = r.id
+                    AND rdr.task_id = t.id
+                    AND rdr.deadline = t.deadline)
+ORDER BY t.deadline
+LIMIT $2;
+
+-- name: CreateRuleDeadlineRun :execrows
+INSERT INTO rule_deadline_runs (rule_id, task_id, deadline, ran_at)
+VALUES ($1, $2, $3, $4)
+ON CONFLICT DO NOTHING;
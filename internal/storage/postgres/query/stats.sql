@@ -0,0 +1,124 @@
+-- name: GetTaskThroughput :many
+WITH user_tasks AS (
+    SELECT t.created_at, t.completed_at
+    FROM tasks t
+    WHERE (t.assignee_id = @user_id
+               OR (t.assignee_id IS NULL AND t.user_id = @user_id))
+      AND (t.deleted_at IS NULL
+               OR t.status_id = (SELECT s.id
+                                 FROM statuses s
+                                 WHERE s.title = @archived_status))
+),
+created AS (
+    SELECT date_trunc(@period::text, u.created_at) AS period_start, count(*) AS created
+    FROM user_tasks u
+    WHERE u.created_at >= @from_date::timestamptz
+      AND u.created_at < @to_date::timestamptz
+    GROUP BY 1
+),
+completed AS (
+    SELECT date_trunc(@period::text, u.completed_at) AS period_start, count(*) AS completed
+    FROM user_tasks u
+    WHERE u.completed_at >= @from_date::timestamptz
+      AND u.completed_at < @to_date::timestamptz
+    GROUP BY 1
+)
+SELECT
+    p.period_start::timestamptz AS period_start,
+    COALESCE(cr.created, 0)::bigint AS created,
+    COALESCE(co.completed, 0)::bigint AS completed
+FROM generate_series(
+         date_trunc(@period::text, @from_date::timestamptz),
+         @to_date::timestamptz - interval '1 second',
+         ('1 ' || @period::text)::interval
+     ) AS p(period_start)
+    LEFT JOIN created cr
+        ON cr.period_start = p.period_start
+    LEFT JOIN completed co
+        ON co.period_start = p.period_start
+ORDER BY p.period_start;
+
+-- name: GetCompletionRatesByList :many
+SELECT
+    l.id,
+    l.title,
+    count(*) AS total,
+    count(t.completed_at) AS completed
+FROM tasks t
+    JOIN lists l
+        ON l.id = t.list_id
+WHERE (t.assignee_id = @user_id
+           OR (t.assignee_id IS NULL AND t.user_id = @user_id))
+  AND (t.deleted_at IS NULL
+           OR t.status_id = (SELECT s.id
+                             FROM statuses s
+                             WHERE s.title = @archived_status))
+  AND t.created_at >= @from_date::timestamptz
+  AND t.created_at < @to_date::timestamptz
+  AND l.deleted_at IS NULL
+GROUP BY l.id, l.title
+ORDER BY total DESC, l.title;
+
+-- name: GetCompletionRatesByTag :many
+SELECT
+    g.id,
+    g.title,
+    count(*) AS total,
+    count(t.completed_at) AS completed
+FROM tasks t
+    JOIN tasks_tags tt
+        ON tt.task_id = t.id
+    JOIN tags g
+        ON g.id = tt.tag_id
+WHERE (t.assignee_id = @user_id
+           OR (t.assignee_id IS NULL AND t.user_id = @user_id))
+  AND (t.deleted_at IS NULL
+           OR t.status_id = (SELECT s.id
+                             FROM statuses s
+                             WHERE s.title = @archived_status))
+  AND t.created_at >= @from_date::timestamptz
+  AND t.created_at < @to_date::timestamptz
+  AND g.deleted_at IS NULL
+GROUP BY g.id, g.title
+ORDER BY total DESC, g.title;
+
+-- name: GetTaskSummaryStats :one
+SELECT
+    count(*) FILTER (WHERE t.created_at >= @from_date::timestamptz
+                       AND t.created_at < @to_date::timestamptz) AS created,
+    count(*) FILTER (WHERE t.completed_at >= @from_date::timestamptz
+                       AND t.completed_at < @to_date::timestamptz) AS completed,
+    COALESCE(avg(EXTRACT(EPOCH FROM t.completed_at - t.created_at))
+                 FILTER (WHERE t.completed_at >= @from_date::timestamptz
+                           AND t.completed_at < @to_date::timestamptz), 0)::float8 AS avg_lead_time_seconds,
+    count(*) FILTER (WHERE t.deadline >= @from_date::timestamptz
+                       AND t.deadline < LEAST(@to_date::timestamptz, CURRENT_DATE::timestamptz)) AS due,
+    count(*) FILTER (WHERE t.deadline >= @from_date::timestamptz
+                       AND t.deadline < LEAST(@to_date::timestamptz, CURRENT_DATE::timestamptz)
+                       AND COALESCE(t.completed_at::date, CURRENT_DATE) > t.deadline::date) AS overdue
+FROM tasks t
+WHERE (t.assignee_id = @user_id
+           OR (t.assignee_id IS NULL AND t.user_id = @user_id))
+  AND (t.deleted_at IS NULL
+           OR t.status_id = (SELECT s.id
+                             FROM statuses s
+                             WHERE s.title = @archived_status));
+
+-- name: GetCompletionStreaks :one
+WITH days AS (
+    SELECT DISTINCT t.completed_at::date AS day
+    FROM tasks t
+    WHERE (t.assignee_id = @user_id
+               OR (t.assignee_id IS NULL AND t.user_id = @user_id))
+      AND t.completed_at IS NOT NULL
+),
+streaks AS (
+    SELECT max(d.day) AS ended_on, count(*) AS length
+    FROM (SELECT day, day - (row_number() OVER (ORDER BY day))::int AS grp
+          FROM days) d
+    GROUP BY d.grp
+)
+SELECT
+    COALESCE(max(length) FILTER (WHERE ended_on >= CURRENT_DATE - 1), 0)::int AS current_streak,
+    COALESCE(max(length), 0)::int AS longest_streak
+FROM streaks;
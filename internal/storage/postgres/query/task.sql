@@ -33,6 +33,10 @@ SELECT
     t.status_id,
     t.list_id,
     t.heading_id,
+    t.assignee_id,
+    t.deferred_until,
+    t.snooze_count,
+    COALESCE(t.evening_date = CURRENT_DATE, FALSE) AS this_evening,
     t.updated_at,
     ttv.tags as tags,
     CASE
@@ -43,7 +47,9 @@ FROM tasks t
     LEFT JOIN task_tags_view ttv
         ON t.id = ttv.task_id
 WHERE t.id = $1
-  AND t.user_id = $2
+  AND t.list_id IN (SELECT list_id
+                    FROM list_members
+                    WHERE user_id = $2)
   AND t.deleted_at IS NULL;
 
 -- name: GetTasksByUserID :many
@@ -67,7 +73,9 @@ SELECT
 FROM tasks t
     LEFT JOIN task_tags_view ttv
         ON t.id = ttv.task_id
-WHERE t.user_id = $1
+WHERE t.list_id IN (SELECT list_id
+                    FROM list_members
+                    WHERE user_id = $1)
   AND t.deleted_at IS NULL
   AND (
       (@after_id::varchar IS NULL AND t.id > @after_id::varchar)
@@ -100,6 +108,7 @@ SELECT
     t.status_id,
     t.list_id,
     t.heading_id,
+    t.assignee_id,
     t.user_id,
     t.updated_at,
     ttv.tags as tags,
@@ -111,8 +120,11 @@ FROM tasks t
     LEFT JOIN task_tags_view ttv
         ON t.id = ttv.task_id
 WHERE t.list_id = $1
-  AND t.user_id = $2
+  AND t.list_id IN (SELECT list_id
+                    FROM list_members
+                    WHERE user_id = $2)
   AND t.deleted_at IS NULL
+  AND (t.deferred_until IS NULL OR t.deferred_until <= now())
 GROUP BY
     t.id,
     t.title,
@@ -123,6 +135,50 @@ GROUP BY
     t.end_time,
     t.status_id,
     t.heading_id,
+    t.assignee_id,
+    overdue,
+    t.updated_at
+ORDER BY t.id;
+
+-- name: GetAllTasksByListID :many
+SELECT
+    t.id,
+    t.title,
+    t.description,
+    t.start_date,
+    t.deadline,
+    t.start_time,
+    t.end_time,
+    t.status_id,
+    t.list_id,
+    t.heading_id,
+    t.assignee_id,
+    t.user_id,
+    t.updated_at,
+    ttv.tags as tags,
+    CASE
+        WHEN t.deadline <= CURRENT_DATE THEN TRUE
+        ELSE FALSE END
+        AS overdue
+FROM tasks t
+    LEFT JOIN task_tags_view ttv
+        ON t.id = ttv.task_id
+WHERE t.list_id = $1
+  AND t.list_id IN (SELECT list_id
+                    FROM list_members
+                    WHERE user_id = $2)
+  AND t.deleted_at IS NULL
+GROUP BY
+    t.id,
+    t.title,
+    t.description,
+    t.start_date,
+    t.deadline,
+    t.start_time,
+    t.end_time,
+    t.status_id,
+    t.heading_id,
+    t.assignee_id,
     overdue,
     t.updated_at
 ORDER BY t.id;
@@ -170,8 +226,11 @@ FROM headings h
             LEFT JOIN task_tags_view ttv
                 ON t.id = ttv.task_id
         WHERE t.list_id = $1
-          AND t.user_id = $2
+          AND t.list_id IN (SELECT list_id
+                            FROM list_members
+                            WHERE user_id = $2)
           AND t.deleted_at IS NULL
+          AND (t.deferred_until IS NULL OR t.deferred_until <= now())
         GROUP BY
             t.id,
             t.title,
@@ -186,13 +245,17 @@ FROM headings h
     ) t
         ON h.id = t.heading_id
 WHERE h.list_id = $1
-  AND h.user_id = $2
+  AND h.list_id IN (SELECT list_id
+                    FROM list_members
+                    WHERE user_id = $2)
 GROUP BY h.id
 ORDER BY h.id;
 
 -- name: GetTasksForToday :many
 SELECT
     l.id AS list_id,
+    l.area_id,
+    a.title AS area_title,
     ARRAY_TO_JSON(
             ARRAY_AGG(
                     JSON_BUILD_OBJECT(
@@ -205,6 +268,8 @@ SELECT
                             'end_time', t.end_time,
                             'list_id', t.list_id,
                             'user_id', t.user_id,
+                            'assignee_id', t.assignee_id,
+                            'this_evening', this_evening,
                             'tags', tags,
                             'overdue', overdue,
                             'updated_at', t.updated_at
@@ -223,6 +288,8 @@ FROM lists l
             t.end_time,
             t.list_id,
             t.user_id,
+            t.assignee_id,
+            COALESCE(t.evening_date = CURRENT_DATE, FALSE) AS this_evening,
             ttv.tags as tags,
             CASE
                 WHEN t.deadline <= CURRENT_DATE THEN TRUE
@@ -232,9 +299,14 @@ FROM lists l
         FROM tasks t
             LEFT JOIN task_tags_view ttv
                 ON t.id = ttv.task_id
-        WHERE t.user_id = $1
-          AND t.start_date = CURRENT_DATE
+        WHERE (t.assignee_id = $1
+                   OR t.list_id IN (SELECT list_id
+                                    FROM list_members
+                                    WHERE user_id = $1
+                                      AND role = 'owner'))
+          AND (t.start_date = CURRENT_DATE OR t.deferred_until::date = CURRENT_DATE)
           AND t.deleted_at IS NULL
+          AND (t.deferred_until IS NULL OR t.deferred_until <= now())
         GROUP BY
             t.id,
             t.title,
@@ -245,11 +317,16 @@ FROM lists l
             t.end_time,
             t.list_id,
             t.user_id,
+            t.assignee_id,
             t.updated_at
         ) t
         ON l.id = t.list_id
-WHERE l.user_id = $1
-GROUP BY l.id
+    LEFT JOIN areas a
+        ON a.id = l.area_id
+WHERE l.id IN (SELECT list_id
+               FROM list_members
+               WHERE user_id = $1)
+GROUP BY l.id, a.id
 ORDER BY l.id;
 
 -- name: GetUpcomingTasks :many
@@ -266,7 +343,9 @@ SELECT
                             'start_time', t.start_time,
                             'end_time', t.end_time,
                             'list_id', t.list_id,
+                            'area_id', t.area_id,
                             'user_id', t.user_id,
+                            'assignee_id', t.assignee_id,
                             'tags', tags,
                             'updated_at', t.updated_at
                     )
@@ -282,13 +361,21 @@ FROM (
         t.start_time,
         t.end_time,
         t.list_id,
+        l.area_id,
         t.user_id,
+        t.assignee_id,
         ttv.tags as tags,
         t.updated_at
     FROM tasks t
+        JOIN lists l
+            ON l.id = t.list_id
         LEFT JOIN task_tags_view ttv
             ON t.id = ttv.task_id
-    WHERE t.user_id = $1
+    WHERE (t.assignee_id = $1
+               OR t.list_id IN (SELECT list_id
+                                FROM list_members
+                                WHERE user_id = $1
+                                  AND role = 'owner'))
       AND (
           (t.start_date >= COALESCE(@after_date::timestamptz, CURRENT_DATE + interval '1 day'))
               AND (t.deleted_at IS NULL)
@@ -303,7 +390,9 @@ FROM (
         t.start_time,
         t.end_time,
         t.list_id,
+        l.area_id,
         t.user_id,
+        t.assignee_id,
         t.updated_at
     ) t
 GROUP BY t.start_date
@@ -352,7 +441,9 @@ FROM lists l
         FROM tasks t
             LEFT JOIN task_tags_view ttv
                 ON t.id = ttv.task_id
-        WHERE t.user_id = $1
+        WHERE t.list_id IN (SELECT list_id
+                            FROM list_members
+                            WHERE user_id = $1)
           AND t.deadline <= CURRENT_DATE
           AND (t.deleted_at IS NULL OR l.id > @after_id::varchar)
         GROUP BY
@@ -367,7 +458,9 @@ FROM lists l
             t.user_id,
             t.updated_at
         ) t ON l.id = t.list_id
-WHERE l.user_id = $1
+WHERE l.id IN (SELECT list_id
+               FROM list_members
+               WHERE user_id = $1)
 GROUP BY l.id
 ORDER BY l.id
 LIMIT $2;
@@ -412,9 +505,12 @@ FROM lists l
         FROM tasks t
             LEFT JOIN task_tags_view ttv
                 ON t.id = ttv.task_id
-        WHERE t.user_id = $1
+        WHERE t.list_id IN (SELECT list_id
+                            FROM list_members
+                            WHERE user_id = $1)
           AND t.start_date IS NULL
           AND t.deadline > CURRENT_DATE
+          AND (t.deferred_until IS NULL OR t.deferred_until <= now())
           AND (t.deleted_at IS NULL OR l.id > @after_id::varchar)
         GROUP BY
             t.id,
@@ -427,7 +523,9 @@ FROM lists l
             t.user_id,
             t.updated_at
         ) t ON l.id = t.list_id
-WHERE l.user_id = $1
+WHERE l.id IN (SELECT list_id
+               FROM list_members
+               WHERE user_id = $1)
 GROUP BY l.id
 ORDER BY l.id
 LIMIT $2;
@@ -468,7 +566,9 @@ FROM (
     FROM tasks t
         LEFT JOIN task_tags_view ttv
             ON t.id = ttv.task_id
-    WHERE t.user_id = $1
+    WHERE t.list_id IN (SELECT list_id
+                        FROM list_members
+                        WHERE user_id = $1)
       AND t.status_id = (
       SELECT id
       FROM statuses
@@ -530,7 +630,9 @@ FROM (
     FROM tasks t
         LEFT JOIN task_tags_view ttv
             ON t.id = ttv.task_id
-    WHERE t.user_id = $1
+    WHERE t.list_id IN (SELECT list_id
+                        FROM list_members
+                        WHERE user_id = $1)
       AND t.status_id = (
         SELECT id
         FROM statuses
@@ -556,13 +658,32 @@ GROUP BY month
 ORDER BY month DESC
 LIMIT $2;
 
+-- name: PatchTask :exec
+UPDATE tasks
+SET title = $1,
+    description = $2,
+    start_date = $3,
+    deadline = $4,
+    start_time = $5,
+    end_time = $6,
+    list_id = $7,
+    heading_id = $8,
+    updated_at = $9
+WHERE id = $10
+  AND list_id IN (SELECT list_id
+                  FROM list_editors_view
+                  WHERE user_id = $11)
+  AND deleted_at IS NULL;
+
 -- name: MoveTaskToAnotherList :exec
 UPDATE tasks
 SET	list_id = $1,
     heading_id = $2,
     updated_at = $3
 WHERE id = $4
-  AND user_id = $5
+  AND list_id IN (SELECT list_id
+                  FROM list_editors_view
+                  WHERE user_id = $5)
   AND deleted_at IS NULL;
 
 -- name: MarkTaskAsCompleted :exec
@@ -570,12 +691,157 @@ UPDATE tasks
 SET	status_id = $1,
     updated_at = $2
 WHERE id = $3
-  AND user_id = $4
+  AND list_id IN (SELECT list_id
+                  FROM list_editors_view
+                  WHERE user_id = $4)
   AND deleted_at IS NULL;
 
 -- name: MarkTaskAsArchived :exec
 UPDATE tasks
 SET status_id = $1, deleted_at = $2
 WHERE id = $3
-  AND user_id = $4
-  AND deleted_at IS NULL;
\ No newline at end of file
+  AND list_id IN (SELECT list_id
+                  FROM list_editors_view
+                  WHERE user_id = $4)
+  AND deleted_at IS NULL;
+-- name: GetAssignedTasks :many
+SELECT
+    t.id,
+    t.title,
+    t.description,
+    t.start_date,
+    t.deadline,
+    t.start_time,
+    t.end_time,
+    t.status_id,
+    t.list_id,
+    t.heading_id,
+    t.updated_at,
+    ttv.tags as tags,
+    CASE
+        WHEN t.deadline <= CURRENT_DATE THEN TRUE
+        ELSE FALSE END
+      AS overdue
+FROM tasks t
+    LEFT JOIN task_tags_view ttv
+        ON t.id = ttv.task_id
+WHERE t.assignee_id = $1
+  AND t.list_id IN (SELECT list_id
+                    FROM list_members
+                    WHERE user_id = $1)
+  AND t.deleted_at IS NULL
+  AND (
+      (@after_id::varchar IS NULL AND t.id > @after_id::varchar)
+          OR (@after_id::varchar IS NOT NULL AND t.id > @after_id::varchar)
+      )
+GROUP BY
+    t.id,
+    t.title,
+    t.description,
+    t.start_date,
+    t.deadline,
+    t.start_time,
+    t.end_time,
+    t.status_id,
+    t.list_id,
+    t.heading_id,
+    t.updated_at
+ORDER BY t.id
+LIMIT $2;
+
+-- name: AssignTask :execrows
+UPDATE tasks
+SET assignee_id = $1,
+    updated_at = $2
+WHERE id = $3
+  AND list_id IN (SELECT list_id
+                  FROM list_editors_view
+                  WHERE user_id = $4)
+  AND deleted_at IS NULL;
+
+-- name: SnoozeTask :execrows
+UPDATE tasks
+SET deferred_until = $1,
+    snooze_count = snooze_count + 1,
+    updated_at = $2
+WHERE id = $3
+  AND list_id IN (SELECT list_id
+                  FROM list_editors_view
+                  WHERE user_id = $4)
+  AND deleted_at IS NULL;
+
+-- name: UnsnoozeTask :execrows
+UPDATE tasks
+SET deferred_until = NULL,
+    updated_at = $1
+WHERE id = $2
+  AND list_id IN (SELECT list_id
+                  FROM list_editors_view
+                  WHERE user_id = $3)
+  AND deleted_at IS NULL;
+
+-- name: GetDailyPlanCandidates :many
+SELECT
+    c.id,
+    c.title,
+    c.description,
+    c.start_date,
+    c.deadline,
+    c.start_time,
+    c.end_time,
+    c.status_id,
+    c.list_id,
+    c.heading_id,
+    c.assignee_id,
+    c.updated_at,
+    c.tags,
+    c.reason
+FROM (
+    SELECT
+        t.id,
+        t.title,
+        t.description,
+        t.start_date,
+        t.deadline,
+        t.start_time,
+        t.end_time,
+        t.status_id,
+        t.list_id,
+        t.heading_id,
+        t.assignee_id,
+        t.updated_at,
+        ttv.tags as tags,
+        CASE
+            WHEN t.deadline <= CURRENT_DATE THEN 'overdue'
+            WHEN t.start_date = CURRENT_DATE - 1 THEN 'unfinished'
+            WHEN t.deadline <= CURRENT_DATE + @days::int THEN 'due_soon'
+            WHEN t.start_date = CURRENT_DATE OR t.deferred_until::date = CURRENT_DATE THEN 'scheduled'
+            END::varchar AS reason
+    FROM tasks t
+        LEFT JOIN task_tags_view ttv
+            ON t.id = ttv.task_id
+    WHERE (t.assignee_id = @user_id
+               OR t.list_id IN (SELECT list_id
+                                FROM list_members
+                                WHERE user_id = @user_id
+                                  AND role = 'owner'))
+      AND t.status_id <> (SELECT s.id
+                          FROM statuses s
+                          WHERE s.title = @status_title)
+      AND (t.deferred_until IS NULL OR t.deferred_until::date <= CURRENT_DATE)
+      AND t.deleted_at IS NULL
+    ) c
+WHERE c.reason IS NOT NULL
+ORDER BY c.reason, c.deadline NULLS LAST, c.id;
+
+-- name: PlanTaskForToday :execrows
+UPDATE tasks
+SET start_date = CURRENT_DATE,
+    evening_date = CASE WHEN @this_evening::bool THEN CURRENT_DATE END,
+    deferred_until = NULL,
+    updated_at = @updated_at
+WHERE id = @id
+  AND list_id IN (SELECT list_id
+                  FROM list_editors_view
+                  WHERE user_id = @user_id)
+  AND deleted_at IS NULL;
@@ -0,0 +1,121 @@
+-- name: LockTaskDependencies :exec
+SELECT pg_advisory_xact_lock(hashtext('task_dependencies'));
+
+-- name: CreateTaskDependency :execrows
+INSERT INTO task_dependencies (task_id, blocked_by_task_id, user_id, created_at)
+VALUES ($1, $2, $3, $4)
+ON CONFLICT DO NOTHING;
+
+-- name: DeleteTaskDependency :execrows
+DELETE FROM task_dependencies
+WHERE task_id = $1
+  AND blocked_by_task_id = $2
+  AND task_id IN (SELECT t.id
+                  FROM tasks t
+                      JOIN list_editors_view e
+                          ON e.list_id = t.list_id
+                  WHERE e.user_id = $3);
+
+-- name: HasTaskDependencyPath :one
+WITH RECURSIVE blockers AS (
+    SELECT td.blocked_by_task_id
+    FROM task_dependencies td
+    WHERE td.task_id = @from_task_id::varchar
+    UNION
+    SELECT td.blocked_by_task_id
+    FROM task_dependencies td
+        JOIN blockers b
+            ON td.task_id = b.blocked_by_task_id
+)
+SELECT EXISTS (
+    SELECT 1
+    FROM blockers
+    WHERE blocked_by_task_id = @to_task_id::varchar
+) AS has_path;
+
+-- name: GetTaskBlockers :many
+SELECT
+    t.id,
+    t.title,
+    t.description,
+    t.start_date,
+    t.deadline,
+    t.start_time,
+    t.end_time,
+    t.status_id,
+    t.list_id,
+    t.heading_id,
+    t.updated_at
+FROM task_dependencies td
+    JOIN tasks t
+        ON t.id = td.blocked_by_task_id
+WHERE td.task_id = $1
+  AND t.list_id IN (SELECT list_id
+                    FROM list_members
+                    WHERE user_id = $2)
+  AND t.deleted_at IS NULL
+ORDER BY t.id;
+
+-- name: GetDependentTaskIDs :many
+SELECT td.task_id
+FROM task_dependencies td
+    JOIN tasks t
+        ON t.id = td.task_id
+WHERE td.blocked_by_task_id = $1
+  AND t.list_id IN (SELECT list_id
+                    FROM list_members
+                    WHERE user_id = $2)
+  AND t.deleted_at IS NULL
+ORDER BY td.task_id;
+
+-- name: GetBlockedTaskIDs :many
+SELECT DISTINCT td.task_id
+FROM task_dependencies td
+    JOIN tasks b
+        ON b.id = td.blocked_by_task_id
+WHERE td.task_id = ANY(@task_ids::varchar[])
+  AND b.list_id IN (SELECT list_id
+                    FROM list_members
+                    WHERE user_id = @user_id::varchar)
+  AND b.deleted_at IS NULL
+  AND b.status_id <> (SELECT id
+                      FROM statuses
+                      WHERE title = @completed_status_title::varchar);
+
+-- name: GetBlockedTasks :many
+SELECT
+    t.id,
+    t.title,
+    t.description,
+    t.start_date,
+    t.deadline,
+    t.start_time,
+    t.end_time,
+    t.status_id,
+    t.list_id,
+    t.heading_id,
+    t.updated_at,
+    ttv.tags as tags,
+    CASE
+        WHEN t.deadline <= CURRENT_DATE THEN TRUE
+        ELSE FALSE END
+        AS overdue
+FROM tasks t
+    LEFT JOIN task_tags_view ttv
+        ON t.id = ttv.task_id
+WHERE t.list_id IN (SELECT list_id
+                    FROM list_members
+                    WHERE user_id = @user_id::varchar)
+  AND t.deleted_at IS NULL
+  AND t.id > @after_id::varchar
+  AND EXISTS (SELECT 1
+              FROM task_dependencies td
+                  JOIN tasks b
+                      ON b.id = td.blocked_by_task_id
+              WHERE td.task_id = t.id
+                AND b.deleted_at IS NULL
+                AND b.status_id <> (SELECT id
+                                    FROM statuses
+                                    WHERE title = @completed_status_title::varchar))
+ORDER BY t.id
+LIMIT @limit_count::int;
@@ -0,0 +1,24 @@
+-- name: CreateTemplate :exec
+INSERT INTO templates (id, title, kind, content, user_id, updated_at)
+VALUES ($1, $2, $3, $4, $5, $6);
+
+-- name: GetTemplateByID :one
+SELECT id, title, kind, content, user_id, updated_at
+FROM templates
+WHERE id = $1
+  AND user_id = $2
+  AND deleted_at IS NULL;
+
+-- name: GetTemplatesByUserID :many
+SELECT id, title, kind, content, user_id, updated_at
+FROM templates
+WHERE user_id = $1
+  AND deleted_at IS NULL
+ORDER BY id;
+
+-- name: DeleteTemplate :execrows
+UPDATE templates
+SET deleted_at = $1
+WHERE id = $2
+  AND user_id = $3
+  AND deleted_at IS NULL;
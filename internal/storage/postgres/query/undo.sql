@@ -0,0 +1,204 @@
+-- name: CreateUndoOperation :exec
+INSERT INTO undo_operations (id, user_id, entity_type, entity_id, action, snapshot, created_at, expires_at)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8);
+
+-- name: GetUndoOperation :one
+SELECT id, user_id, entity_type, entity_id, action, snapshot, created_at, expires_at, undone_at
+FROM undo_operations
+WHERE id = $1
+  AND user_id = $2
+FOR UPDATE;
+
+-- name: MarkUndoOperationAsUndone :execrows
+UPDATE undo_operations
+SET undone_at = $1
+WHERE id = $2
+  AND undone_at IS NULL;
+
+-- name: DeleteExpiredUndoOperations :execrows
+DELETE FROM undo_operations
+WHERE expires_at <= $1;
+
+-- name: GetListSnapshots :one
+SELECT COALESCE(jsonb_agg(l), '[]')::jsonb
+FROM lists l
+WHERE l.id = ANY(@ids::varchar[]);
+
+-- name: GetHeadingSnapshots :one
+SELECT COALESCE(jsonb_agg(h), '[]')::jsonb
+FROM headings h
+WHERE h.id = ANY(@ids::varchar[])
+   OR (h.list_id = ANY(@list_ids::varchar[]) AND h.deleted_at IS NULL);
+
+-- name: GetTaskSnapshots :one
+SELECT COALESCE(jsonb_agg(t), '[]')::jsonb
+FROM tasks t
+WHERE t.id = ANY(@task_ids::varchar[])
+   OR t.heading_id = ANY(@heading_ids::varchar[])
+   OR (t.list_id = ANY(@list_ids::varchar[]) AND t.deleted_at IS NULL);
+
+-- name: GetTaskTagSnapshots :one
+SELECT COALESCE(jsonb_agg(tt), '[]')::jsonb
+FROM tasks_tags tt
+WHERE tt.task_id IN (SELECT t.id
+                     FROM tasks t
+                     WHERE t.id = ANY(@task_ids::varchar[])
+                        OR t.heading_id = ANY(@heading_ids::varchar[])
+                        OR (t.list_id = ANY(@list_ids::varchar[]) AND t.deleted_at IS NULL));
+
+-- name: RestoreLists :exec
+UPDATE lists l
+SET title = s.title,
+    area_id = s.area_id,
+    position = s.position,
+    goal_id = s.goal_id,
+    color = s.color,
+    icon = s.icon,
+    notes = s.notes,
+    start_date = s.start_date,
+    deadline = s.deadline,
+    status = s.status,
+    archived_at = s.archived_at,
+    updated_at = @updated_at,
+    deleted_at = s.deleted_at
+FROM jsonb_populate_recordset(NULL::lists, @lists::jsonb) s
+WHERE l.id = s.id;
+
+-- name: RestoreHeadings :exec
+UPDATE headings h
+SET title = s.title,
+    list_id = s.list_id,
+    updated_at = @updated_at,
+    deleted_at = s.deleted_at
+FROM jsonb_populate_recordset(NULL::headings, @headings::jsonb) s
+WHERE h.id = s.id;
+
+-- name: RestoreTasks :exec
+UPDATE tasks t
+SET title = s.title,
+    description = s.description,
+    start_date = s.start_date,
+    deadline = s.deadline,
+    start_time = s.start_time,
+    end_time = s.end_time,
+    status_id = s.status_id,
+    list_id = s.list_id,
+    heading_id = s.heading_id,
+    goal_id = s.goal_id,
+    assignee_id = s.assignee_id,
+    deferred_until = s.deferred_until,
+    snooze_count = s.snooze_count,
+    evening_date = s.evening_date,
+    updated_at = @updated_at,
+    deleted_at = s.deleted_at
+FROM jsonb_populate_recordset(NULL::tasks, @tasks::jsonb) s
+WHERE t.id = s.id;
+
+-- name: DeleteTaskTagsOfSnapshot :exec
+DELETE FROM tasks_tags
+WHERE task_id IN (SELECT s.id
+                  FROM jsonb_populate_recordset(NULL::tasks, @tasks::jsonb) s);
+
+-- name: RestoreTaskTags :exec
+INSERT INTO tasks_tags (task_id, tag_id)
+SELECT s.task_id, s.tag_id
+FROM jsonb_populate_recordset(NULL::tasks_tags, @task_tags::jsonb) s
+ON CONFLICT DO NOTHING;
+
+-- name: DeleteCreatedList :exec
+UPDATE lists
+SET deleted_at = $1
+WHERE id = $2
+  AND deleted_at IS NULL;
+
+-- name: DeleteCreatedHeading :exec
+UPDATE headings
+SET deleted_at = $1
+WHERE id = $2
+  AND deleted_at IS NULL;
+
+-- name: DeleteCreatedTask :exec
+UPDATE tasks
+SET deleted_at = $1
+WHERE id = $2
+  AND deleted_at IS NULL;
+
+-- name: DeleteCreatedContentTasks :exec
+UPDATE tasks
+SET deleted_at = @deleted_at
+WHERE (list_id = @container_id OR heading_id = @container_id)
+  AND deleted_at IS NULL;
+
+-- name: GetUndoListIDs :many
+SELECT DISTINCT ids.list_id::varchar
+FROM (SELECT s.id AS list_id
+      FROM jsonb_populate_recordset(NULL::lists, @lists::jsonb) s
+      UNION ALL
+      SELECT s.list_id
+      FROM jsonb_populate_recordset(NULL::headings, @headings::jsonb) s
+      UNION ALL
+      SELECT h.list_id
+      FROM headings h
+          JOIN jsonb_populate_recordset(NULL::headings, @headings::jsonb) s
+              ON s.id = h.id
+      UNION ALL
+      SELECT s.list_id
+      FROM jsonb_populate_recordset(NULL::tasks, @tasks::jsonb) s
+      UNION ALL
+      SELECT t.list_id
+      FROM tasks t
+          JOIN jsonb_populate_recordset(NULL::tasks, @tasks::jsonb) s
+              ON s.id = t.id
+      UNION ALL
+      SELECT l.id
+      FROM lists l
+      WHERE l.id = @created_id
+      UNION ALL
+      SELECT h.list_id
+      FROM headings h
+      WHERE h.id = @created_id
+      UNION ALL
+      SELECT t.list_id
+      FROM tasks t
+      WHERE t.id = @created_id) ids
+ORDER BY 1;
+
+-- name: HasUndoConflicts :one
+SELECT EXISTS (
+    SELECT 1
+    FROM undo_operations u
+        JOIN (SELECT l.xmin, l.updated_at, s.updated_at AS snapshot_updated_at
+              FROM lists l
+                  JOIN jsonb_populate_recordset(NULL::lists, @lists::jsonb) s
+                      ON s.id = l.id
+              UNION ALL
+              SELECT h.xmin, h.updated_at, s.updated_at
+              FROM headings h
+                  JOIN jsonb_populate_recordset(NULL::headings, @headings::jsonb) s
+                      ON s.id = h.id
+              UNION ALL
+              SELECT t.xmin, t.updated_at, s.updated_at
+              FROM tasks t
+                  JOIN jsonb_populate_recordset(NULL::tasks, @tasks::jsonb) s
+                      ON s.id = t.id
+              UNION ALL
+              SELECT e.xmin, e.updated_at, NULL
+              FROM (SELECT l.xmin, l.id, l.updated_at FROM lists l
+                    UNION ALL
+                    SELECT h.xmin, h.id, h.updated_at FROM headings h
+                    UNION ALL
+                    SELECT t.xmin, t.id, t.updated_at FROM tasks t) e
+              WHERE e.id = @created_id
+              UNION ALL
+              SELECT h.xmin, h.updated_at, NULL
+              FROM headings h
+              WHERE h.list_id = @created_id
+              UNION ALL
+              SELECT t.xmin, t.updated_at, NULL
+              FROM tasks t
+              WHERE t.list_id = @created_id
+                 OR t.heading_id = @created_id) c
+            ON NOT c.xmin = u.xmin
+    WHERE u.id = @id
+      AND c.updated_at IS DISTINCT FROM c.snapshot_updated_at
+);
@@ -0,0 +1,105 @@
+-- name: CreateWebhook :exec
+INSERT INTO webhooks (id, url, event_types, secret, user_id, updated_at)
+VALUES ($1, $2, $3, $4, $5, $6);
+
+-- name: GetWebhookByID :one
+SELECT id, url, event_types, secret, user_id, is_active, failure_count, disabled_at, updated_at
+FROM webhooks
+WHERE id = $1
+  AND user_id = $2
+  AND deleted_at IS NULL;
+
+-- name: GetWebhooksByUserID :many
+SELECT id, url, event_types, secret, user_id, is_active, failure_count, disabled_at, updated_at
+FROM webhooks
+WHERE user_id = $1
+  AND deleted_at IS NULL
+ORDER BY id;
+
+-- name: GetActiveWebhooksByEventType :many
+SELECT id, url, secret
+FROM webhooks
+WHERE user_id = @user_id
+  AND is_active = true
+  AND deleted_at IS NULL
+  AND (cardinality(event_types) = 0 OR @event_type::varchar = ANY(event_types));
+
+-- name: UpdateWebhook :execrows
+UPDATE webhooks
+SET url = @url,
+    event_types = @event_types,
+    is_active = @is_active::boolean,
+    failure_count = CASE WHEN @is_active::boolean THEN 0 ELSE failure_count END,
+    disabled_at = CASE WHEN @is_active::boolean THEN NULL ELSE COALESCE(disabled_at, @updated_at) END,
+    updated_at = @updated_at
+WHERE id = @id
+  AND user_id = @user_id
+  AND deleted_at IS NULL;
+
+-- name: DeleteWebhook :execrows
+UPDATE webhooks
+SET is_active = false,
+    deleted_at = $1
+WHERE id = $2
+  AND user_id = $3
+  AND deleted_at IS NULL;
+
+-- name: ResetWebhookFailureCount :exec
+UPDATE webhooks
+SET failure_count = 0
+WHERE id = $1
+  AND failure_count > 0;
+
+-- name: IncrementWebhookFailureCount :one
+UPDATE webhooks
+SET failure_count = failure_count + 1,
+    is_active = failure_count + 1 < @max_failures::int,
+    disabled_at = CASE WHEN failure_count + 1 >= @max_failures::int THEN @failed_at ELSE disabled_at END
+WHERE id = @id
+  AND is_active = true
+RETURNING is_active;
+
+-- name: CreateWebhookDelivery :exec
+INSERT INTO webhook_deliveries (id, webhook_id, user_id, event_id, event_type, payload, next_attempt_at, created_at, updated_at)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9);
+
+-- name: ClaimWebhookDeliveries :many
+WITH claimed AS (
+    UPDATE webhook_deliveries
+    SET next_attempt_at = @lease_until
+    WHERE webhook_deliveries.id IN (
+        SELECT d.id
+        FROM webhook_deliveries d
+        WHERE d.status = 'pending'
+          AND d.next_attempt_at <= @now
+        ORDER BY d.next_attempt_at
+        LIMIT @batch_size
+        FOR UPDATE SKIP LOCKED
+    )
+    RETURNING id, webhook_id, user_id, event_id, event_type, payload, attempt, created_at
+)
+SELECT c.id, c.webhook_id, c.user_id, c.event_id, c.event_type, c.payload, c.attempt, c.created_at,
+       w.url, w.secret, (w.is_active AND w.deleted_at IS NULL)::boolean AS webhook_active
+FROM claimed c
+JOIN webhooks w ON w.id = c.webhook_id;
+
+-- name: UpdateWebhookDelivery :exec
+UPDATE webhook_deliveries
+SET status = $1,
+    attempt = $2,
+    response_status = $3,
+    error = $4,
+    next_attempt_at = $5,
+    delivered_at = $6,
+    updated_at = $7
+WHERE id = $8;
+
+-- name: GetWebhookDeliveries :many
+SELECT d.id, d.webhook_id, d.event_id, d.event_type, d.payload, d.status, d.attempt, d.response_status,
+       d.error, d.next_attempt_at, d.delivered_at, d.created_at, d.updated_at
+FROM webhook_deliveries d
+WHERE d.webhook_id = @webhook_id
+  AND d.user_id = @user_id
+  AND (@after_id::varchar = '' OR d.id < @after_id::varchar)
+ORDER BY d.id DESC
+LIMIT @limit_count;
@@ -0,0 +1,223 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/rshelekhov/reframed/internal/lib/constants/le"
+	"github.com/rshelekhov/reframed/internal/model"
+	"github.com/rshelekhov/reframed/internal/storage/postgres/sqlc"
+)
+
+type ReviewStorage struct {
+	*pgxpool.Pool
+	*sqlc.Queries
+}
+
+func NewReviewStorage(pool *pgxpool.Pool) *ReviewStorage {
+	return &ReviewStorage{
+		Pool:    pool,
+		Queries: sqlc.New(newDB(pool)),
+	}
+}
+
+// Transaction runs fn in a transaction, the storages called with the context passed to fn take part in it
+func (s *ReviewStorage) Transaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	return transaction(ctx, s.Pool, fn)
+}
+
+func (s *ReviewStorage) CreateReviewSession(ctx context.Context, review model.ReviewSession) error {
+	const op = "review.storage.CreateReviewSession"
+
+	if err := s.Queries.CreateReviewSession(ctx, sqlc.CreateReviewSessionParams{
+		ID:        review.ID,
+		UserID:    review.UserID,
+		StaleDays: int32(review.StaleDays),
+		StartedAt: review.StartedAt,
+	}); err != nil {
+		return fmt.Errorf("%s: failed to create review session: %w", op, err)
+	}
+	return nil
+}
+
+func (s *ReviewStorage) GetReviewSessionByID(ctx context.Context, reviewID, userID string) (model.ReviewSession, error) {
+	const op = "review.storage.GetReviewSessionByID"
+
+	review, err := s.Queries.GetReviewSessionByID(ctx, sqlc.GetReviewSessionByIDParams{
+		ID:     reviewID,
+		UserID: userID,
+	})
+	if errors.Is(err, pgx.ErrNoRows) {
+		return model.ReviewSession{}, le.ErrReviewNotFound
+	}
+	if err != nil {
+		return model.ReviewSession{}, fmt.Errorf("%s: failed to get review session: %w", op, err)
+	}
+
+	return mapReviewSessionRowToModel(review), nil
+}
+
+func (s *ReviewStorage) GetOpenReviewSession(ctx context.Context, userID string) (model.ReviewSession, error) {
+	const op = "review.storage.GetOpenReviewSession"
+
+	review, err := s.Queries.GetOpenReviewSession(ctx, userID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return model.ReviewSession{}, le.ErrNoReviewInProgress
+	}
+	if err != nil {
+		return model.ReviewSession{}, fmt.Errorf("%s: failed to get open review session: %w", op, err)
+	}
+
+	return mapReviewSessionRowToModel(review), nil
+}
+
+func (s *ReviewStorage) CompleteReviewSession(ctx context.Context, review model.ReviewSession) error {
+	const op = "review.storage.CompleteReviewSession"
+
+	rows, err := s.Queries.CompleteReviewSession(ctx, sqlc.CompleteReviewSessionParams{
+		CompletedAt: pgtype.Timestamptz{
+			Time:  review.CompletedAt,
+			Valid: true,
+		},
+		ID:     review.ID,
+		UserID: review.UserID,
+	})
+	if err != nil {
+		return fmt.Errorf("%s: failed to complete review session: %w", op, err)
+	}
+	if rows == 0 {
+		return le.ErrReviewNotFound
+	}
+	return nil
+}
+
+func (s *ReviewStorage) GetReviewCandidates(ctx context.Context, userID string, since, staleBefore time.Time) ([]model.ReviewItem, error) {
+	const op = "review.storage.GetReviewCandidates"
+
+	items, err := s.Queries.GetReviewCandidates(ctx, sqlc.GetReviewCandidatesParams{
+		UserID:          userID,
+		CompletedStatus: model.StatusCompleted.String(),
+		Since:           since,
+		StaleBefore:     staleBefore,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to get review candidates: %w", op, err)
+	}
+
+	var candidates []model.ReviewItem
+
+	for _, item := range items {
+		candidates = append(candidates, model.ReviewItem{
+			Section:    model.ReviewSection(item.Section),
+			EntityType: model.ReviewEntityType(item.EntityType),
+			EntityID:   item.EntityID,
+			Title:      item.Title,
+		})
+	}
+	return candidates, nil
+}
+
+func (s *ReviewStorage) CreateReviewItem(ctx context.Context, item model.ReviewItem) error {
+	const op = "review.storage.CreateReviewItem"
+
+	if err := s.Queries.CreateReviewItem(ctx, sqlc.CreateReviewItemParams{
+		ID:         item.ID,
+		ReviewID:   item.ReviewID,
+		Section:    item.Section.String(),
+		EntityType: item.EntityType.String(),
+		EntityID:   item.EntityID,
+		Title:      item.Title,
+		Position:   int32(item.Position),
+	}); err != nil {
+		return fmt.Errorf("%s: failed to create review item: %w", op, err)
+	}
+	return nil
+}
+
+func (s *ReviewStorage) GetReviewItems(ctx context.Context, reviewID string) ([]model.ReviewItem, error) {
+	const op = "review.storage.GetReviewItems"
+
+	items, err := s.Queries.GetReviewItems(ctx, reviewID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to get review items: %w", op, err)
+	}
+
+	var reviewItems []model.ReviewItem
+
+	for _, item := range items {
+		reviewItems = append(reviewItems, mapReviewItemRowToModel(item))
+	}
+	return reviewItems, nil
+}
+
+func (s *ReviewStorage) GetReviewItemByID(ctx context.Context, itemID, reviewID, userID string) (model.ReviewItem, error) {
+	const op = "review.storage.GetReviewItemByID"
+
+	item, err := s.Queries.GetReviewItemByID(ctx, sqlc.GetReviewItemByIDParams{
+		ID:       itemID,
+		ReviewID: reviewID,
+		UserID:   userID,
+	})
+	if errors.Is(err, pgx.ErrNoRows) {
+		return model.ReviewItem{}, le.ErrReviewItemNotFound
+	}
+	if err != nil {
+		return model.ReviewItem{}, fmt.Errorf("%s: failed to get review item: %w", op, err)
+	}
+
+	return mapReviewItemRowToModel(item), nil
+}
+
+func (s *ReviewStorage) UpdateReviewItemAction(ctx context.Context, item model.ReviewItem, userID string) error {
+	const op = "review.storage.UpdateReviewItemAction"
+
+	rows, err := s.Queries.UpdateReviewItemAction(ctx, sqlc.UpdateReviewItemActionParams{
+		Action: pgtype.Text{
+			String: item.Action.String(),
+			Valid:  item.Action != "",
+		},
+		ActedAt: pgtype.Timestamptz{
+			Time:  item.ActedAt,
+			Valid: !item.ActedAt.IsZero(),
+		},
+		ID:     item.ID,
+		UserID: userID,
+	})
+	if err != nil {
+		return fmt.Errorf("%s: failed to update review item action: %w", op, err)
+	}
+	if rows == 0 {
+		return le.ErrReviewItemNotFound
+	}
+	return nil
+}
+
+func mapReviewSessionRowToModel(review sqlc.ReviewSession) model.ReviewSession {
+	return model.ReviewSession{
+		ID:          review.ID,
+		UserID:      review.UserID,
+		StaleDays:   int(review.StaleDays),
+		StartedAt:   review.StartedAt,
+		CompletedAt: review.CompletedAt.Time,
+	}
+}
+
+func mapReviewItemRowToModel(item sqlc.ReviewItem) model.ReviewItem {
+	return model.ReviewItem{
+		ID:         item.ID,
+		ReviewID:   item.ReviewID,
+		Section:    model.ReviewSection(item.Section),
+		EntityType: model.ReviewEntityType(item.EntityType),
+		EntityID:   item.EntityID,
+		Title:      item.Title,
+		Position:   int(item.Position),
+		Action:     model.ReviewAction(item.Action.String),
+		ActedAt:    item.ActedAt.Time,
+	}
+}
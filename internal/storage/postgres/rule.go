@@ -0,0 +1,279 @@
+package postgres
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/rshelekhov/reframed/internal/lib/constants/le"
+	"github.com/rshelekhov/reframed/internal/model"
+	"github.com/rshelekhov/reframed/internal/storage/postgres/sqlc"
+)
+
+type RuleStorage struct {
+	*pgxpool.Pool
+	*sqlc.Queries
+}
+
+func NewRuleStorage(pool *pgxpool.Pool) *RuleStorage {
+	return &RuleStorage{
+		Pool:    pool,
+		Queries: sqlc.New(newDB(pool)),
+	}
+}
+
+func (s *RuleStorage) CreateRule(ctx context.Context, rule model.Rule) error {
+	const op = "rule.storage.CreateRule"
+
+	conditions, actions, err := marshalRuleConditionsAndActions(rule)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err = s.Queries.CreateRule(ctx, sqlc.CreateRuleParams{
+		ID:         rule.ID,
+		Title:      rule.Title,
+		Trigger:    rule.Trigger.String(),
+		Conditions: conditions,
+		Actions:    actions,
+		IsActive:   rule.IsActive,
+		UserID:     rule.UserID,
+		UpdatedAt:  rule.UpdatedAt,
+	}); err != nil {
+		return fmt.Errorf("%s: failed to create rule: %w", op, err)
+	}
+	return nil
+}
+
+func (s *RuleStorage) GetRuleByID(ctx context.Context, ruleID, userID string) (model.Rule, error) {
+	const op = "rule.storage.GetRuleByID"
+
+	rule, err := s.Queries.GetRuleByID(ctx, sqlc.GetRuleByIDParams{
+		ID:     ruleID,
+		UserID: userID,
+	})
+	if errors.Is(err, pgx.ErrNoRows) {
+		return model.Rule{}, le.ErrRuleNotFound
+	}
+	if err != nil {
+		return model.Rule{}, fmt.Errorf("%s: failed to get rule: %w", op, err)
+	}
+
+	result, err := mapRuleRowToModel(sqlc.Rule{
+		ID:         rule.ID,
+		Title:      rule.Title,
+		Trigger:    rule.Trigger,
+		Conditions: rule.Conditions,
+		Actions:    rule.Actions,
+		IsActive:   rule.IsActive,
+		UserID:     rule.UserID,
+		UpdatedAt:  rule.UpdatedAt,
+	})
+	if err != nil {
+		return model.Rule{}, fmt.Errorf("%s: %w", op, err)
+	}
+	return result, nil
+}
+
+func (s *RuleStorage) GetRulesByUserID(ctx context.Context, userID string) ([]model.Rule, error) {
+	const op = "rule.storage.GetRulesByUserID"
+
+	items, err := s.Queries.GetRulesByUserID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to get rules: %w", op, err)
+	}
+	if len(items) == 0 {
+		return nil, le.ErrNoRulesFound
+	}
+
+	var rules []model.Rule
+
+	for _, item := range items {
+		rule, err := mapRuleRowToModel(sqlc.Rule{
+			ID:         item.ID,
+			Title:      item.Title,
+			Trigger:    item.Trigger,
+			Conditions: item.Conditions,
+			Actions:    item.Actions,
+			IsActive:   item.IsActive,
+			UserID:     item.UserID,
+			UpdatedAt:  item.UpdatedAt,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+
+		rules = append(rules, rule)
+	}
+	return rules, nil
+}
+
+func (s *RuleStorage) GetActiveRulesByTrigger(ctx context.Context, userID string, trigger model.RuleTrigger) ([]model.Rule, error) {
+	const op = "rule.storage.GetActiveRulesByTrigger"
+
+	items, err := s.Queries.GetActiveRulesByTrigger(ctx, sqlc.GetActiveRulesByTriggerParams{
+		UserID:  userID,
+		Trigger: trigger.String(),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to get active rules: %w", op, err)
+	}
+
+	var rules []model.Rule
+
+	for _, item := range items {
+		rule, err := mapRuleRowToModel(sqlc.Rule{
+			ID:         item.ID,
+			Title:      item.Title,
+			Trigger:    item.Trigger,
+			Conditions: item.Conditions,
+			Actions:    item.Actions,
+			IsActive:   item.IsActive,
+			UserID:     item.UserID,
+			UpdatedAt:  item.UpdatedAt,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+
+		rules = append(rules, rule)
+	}
+	return rules, nil
+}
+
+func marshalRuleConditionsAndActions(rule model.Rule) (conditions, actions []byte, err error) {
+	if rule.Conditions == nil {
+		rule.Conditions = []model.RuleCondition{}
+	}
+
+	conditions, err = json.Marshal(rule.Conditions)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to marshal rule conditions: %w", err)
+	}
+
+	actions, err = json.Marshal(rule.Actions)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to marshal rule actions: %w", err)
+	}
+
+	return conditions, actions, nil
+}
+
+func mapRuleRowToModel(rule sqlc.Rule) (model.Rule, error) {
+	result := model.Rule{
+		ID:        rule.ID,
+		Title:     rule.Title,
+		Trigger:   model.RuleTrigger(rule.Trigger),
+		IsActive:  rule.IsActive,
+		UserID:    rule.UserID,
+		UpdatedAt: rule.UpdatedAt,
+	}
+
+	if err := json.Unmarshal(rule.Conditions, &result.Conditions); err != nil {
+		return model.Rule{}, fmt.Errorf("failed to unmarshal rule conditions: %w", err)
+	}
+	if err := json.Unmarshal(rule.Actions, &result.Actions); err != nil {
+		return model.Rule{}, fmt.Errorf("failed to unmarshal rule actions: %w", err)
+	}
+
+	return result, nil
+}
+
+func (s *RuleStorage) UpdateRule(ctx context.Context, rule model.Rule) error {
+	const op = "rule.storage.UpdateRule"
+
+	conditions, actions, err := marshalRuleConditionsAndActions(rule)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	rows, err := s.Queries.UpdateRule(ctx, sqlc.UpdateRuleParams{
+		Title:      rule.Title,
+		Trigger:    rule.Trigger.String(),
+		Conditions: conditions,
+		Actions:    actions,
+		IsActive:   rule.IsActive,
+		UpdatedAt:  rule.UpdatedAt,
+		ID:         rule.ID,
+		UserID:     rule.UserID,
+	})
+	if err != nil {
+		return fmt.Errorf("%s: failed to update rule: %w", op, err)
+	}
+	if rows == 0 {
+		return le.ErrRuleNotFound
+	}
+	return nil
+}
+
+func (s *RuleStorage) DeleteRule(ctx context.Context, rule model.Rule) error {
+	const op = "rule.storage.DeleteRule"
+
+	rows, err := s.Queries.DeleteRule(ctx, sqlc.DeleteRuleParams{
+		DeletedAt: pgtype.Timestamptz{
+			Time:  rule.DeletedAt,
+			Valid: true,
+		},
+		ID:     rule.ID,
+		UserID: rule.UserID,
+	})
+	if err != nil {
+		return fmt.Errorf("%s: failed to delete rule: %w", op, err)
+	}
+	if rows == 0 {
+		return le.ErrRuleNotFound
+	}
+	return nil
+}
+
+// GetPendingDeadlineRuleRuns returns the pairs of active deadline rules and open tasks
+// whose deadline has passed by now and which the rule hasn't run for yet
+func (s *RuleStorage) GetPendingDeadlineRuleRuns(ctx context.Context, now time.Time, limit int) ([]model.RuleDeadlineRun, error) {
+	const op = "rule.storage.GetPendingDeadlineRuleRuns"
+
+	items, err := s.Queries.GetPendingDeadlineRuleRuns(ctx, sqlc.GetPendingDeadlineRuleRunsParams{
+		Deadline: pgtype.Timestamptz{
+			Time:  now,
+			Valid: true,
+		},
+		Limit: int32(limit),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to get pending deadline rule runs: %w", op, err)
+	}
+
+	var runs []model.RuleDeadlineRun
+
+	for _, item := range items {
+		runs = append(runs, model.RuleDeadlineRun{
+			RuleID:   item.RuleID,
+			TaskID:   item.TaskID,
+			UserID:   item.UserID,
+			Deadline: item.Deadline.Time,
+		})
+	}
+	return runs, nil
+}
+
+// CreateRuleDeadlineRun records that the deadline rule has run for the task.
+// It returns false if the run has already been recorded
+func (s *RuleStorage) CreateRuleDeadlineRun(ctx context.Context, run model.RuleDeadlineRun, ranAt time.Time) (bool, error) {
+	const op = "rule.storage.CreateRuleDeadlineRun"
+
+	rows, err := s.Queries.CreateRuleDeadlineRun(ctx, sqlc.CreateRuleDeadlineRunParams{
+		RuleID:   run.RuleID,
+		TaskID:   run.TaskID,
+		Deadline: run.Deadline,
+		RanAt:    ranAt,
+	})
+	if err != nil {
+		return false, fmt.Errorf("%s: failed to create rule deadline run: %w", op, err)
+	}
+	return rows > 0, nil
+}
@@ -127,7 +127,7 @@ func (q *Queries) GetUserByEmail(ctx context.Context, email string) (GetUserByEm
 }
 
 const getUserByID = `-- name: GetUserByID :one
-SELECT id, email, updated_at
+SELECT id, email, time_zone, updated_at
 FROM users
 WHERE id = $1
   AND deleted_at IS NULL
@@ -136,13 +136,19 @@ WHERE id = $1
 type GetUserByIDRow struct {
 	ID        string    `db:"id"`
 	Email     string    `db:"email"`
+	TimeZone  string    `db:"time_zone"`
 	UpdatedAt time.Time `db:"updated_at"`
 }
 
 func (q *Queries) GetUserByID(ctx context.Context, id string) (GetUserByIDRow, error) {
 	row := q.db.QueryRow(ctx, getUserByID, id)
 	var i GetUserByIDRow
-	err := row.Scan(&i.ID, &i.Email, &i.UpdatedAt)
+	err := row.Scan(
+		&i.ID,
+		&i.Email,
+		&i.TimeZone,
+		&i.UpdatedAt,
+	)
 	return i, err
 }
 
@@ -302,3 +308,25 @@ func (q *Queries) UpdateLatestLoginAt(ctx context.Context, arg UpdateLatestLogin
 	_, err := q.db.Exec(ctx, updateLatestLoginAt, arg.LatestLoginAt, arg.ID)
 	return err
 }
+
+const updateUserTimeZone = `-- name: UpdateUserTimeZone :execrows
+UPDATE users
+SET time_zone = $1,
+    updated_at = $2
+WHERE id = $3
+  AND deleted_at IS NULL
+`
+
+type UpdateUserTimeZoneParams struct {
+	TimeZone  string    `db:"time_zone"`
+	UpdatedAt time.Time `db:"updated_at"`
+	ID        string    `db:"id"`
+}
+
+func (q *Queries) UpdateUserTimeZone(ctx context.Context, arg UpdateUserTimeZoneParams) (int64, error) {
+	result, err := q.db.Exec(ctx, updateUserTimeZone, arg.TimeZone, arg.UpdatedAt, arg.ID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
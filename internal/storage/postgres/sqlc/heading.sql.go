@@ -12,6 +12,39 @@ import (
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
+const archiveTasksByHeadingID = `-- name: ArchiveTasksByHeadingID :exec
+UPDATE tasks
+SET status_id = (SELECT s.id
+                 FROM statuses s
+                 WHERE s.title = $1),
+    updated_at = $2,
+    deleted_at = $3
+WHERE heading_id = $4
+  AND list_id IN (SELECT list_id
+                  FROM list_editors_view
+                  WHERE user_id = $5)
+  AND deleted_at IS NULL
+`
+
+type ArchiveTasksByHeadingIDParams struct {
+	StatusTitle string             `db:"status_title"`
+	UpdatedAt   time.Time          `db:"updated_at"`
+	DeletedAt   pgtype.Timestamptz `db:"deleted_at"`
+	HeadingID   string             `db:"heading_id"`
+	UserID      string             `db:"user_id"`
+}
+
+func (q *Queries) ArchiveTasksByHeadingID(ctx context.Context, arg ArchiveTasksByHeadingIDParams) error {
+	_, err := q.db.Exec(ctx, archiveTasksByHeadingID,
+		arg.StatusTitle,
+		arg.UpdatedAt,
+		arg.DeletedAt,
+		arg.HeadingID,
+		arg.UserID,
+	)
+	return err
+}
+
 const createHeading = `-- name: CreateHeading :exec
 INSERT INTO headings (id, title, list_id, user_id, is_default, updated_at)
 VALUES($1, $2, $3, $4, $5, $6)
@@ -42,7 +75,9 @@ const deleteHeading = `-- name: DeleteHeading :exec
 UPDATE headings
 SET deleted_at = $1
 WHERE id = $2
-  AND user_id = $3
+  AND list_id IN (SELECT list_id
+                  FROM list_editors_view
+                  WHERE user_id = $3)
   AND deleted_at IS NULL
 `
 
@@ -61,7 +96,9 @@ const getDefaultHeadingID = `-- name: GetDefaultHeadingID :one
 SELECT id
 FROM headings
 WHERE list_id = $1
-  AND user_id = $2
+  AND list_id IN (SELECT list_id
+                  FROM list_members
+                  WHERE user_id = $2)
   AND is_default = TRUE
   AND deleted_at IS NULL
 `
@@ -79,10 +116,12 @@ func (q *Queries) GetDefaultHeadingID(ctx context.Context, arg GetDefaultHeading
 }
 
 const getHeadingByID = `-- name: GetHeadingByID :one
-SELECT id, title, list_id, user_id, updated_at
+SELECT id, title, list_id, user_id, is_default, updated_at
 FROM headings
 WHERE id = $1
-  AND user_id = $2
+  AND list_id IN (SELECT list_id
+                  FROM list_members
+                  WHERE user_id = $2)
   AND deleted_at IS NULL
 `
 
@@ -96,6 +135,7 @@ type GetHeadingByIDRow struct {
 	Title     string    `db:"title"`
 	ListID    string    `db:"list_id"`
 	UserID    string    `db:"user_id"`
+	IsDefault bool      `db:"is_default"`
 	UpdatedAt time.Time `db:"updated_at"`
 }
 
@@ -107,6 +147,7 @@ func (q *Queries) GetHeadingByID(ctx context.Context, arg GetHeadingByIDParams)
 		&i.Title,
 		&i.ListID,
 		&i.UserID,
+		&i.IsDefault,
 		&i.UpdatedAt,
 	)
 	return i, err
@@ -116,7 +157,9 @@ const getHeadingsByListID = `-- name: GetHeadingsByListID :many
 SELECT id, title, list_id, user_id, updated_at
 FROM headings
 WHERE list_id = $1
-  AND user_id = $2
+  AND list_id IN (SELECT list_id
+                  FROM list_members
+                  WHERE user_id = $2)
   AND deleted_at IS NULL
 `
 
@@ -159,11 +202,27 @@ func (q *Queries) GetHeadingsByListID(ctx context.Context, arg GetHeadingsByList
 	return items, nil
 }
 
+const headingHasTasks = `-- name: HeadingHasTasks :one
+SELECT EXISTS (SELECT 1
+               FROM tasks
+               WHERE heading_id = $1
+                 AND deleted_at IS NULL)
+`
+
+func (q *Queries) HeadingHasTasks(ctx context.Context, headingID string) (bool, error) {
+	row := q.db.QueryRow(ctx, headingHasTasks, headingID)
+	var exists bool
+	err := row.Scan(&exists)
+	return exists, err
+}
+
 const moveHeadingToAnotherList = `-- name: MoveHeadingToAnotherList :exec
 UPDATE headings
 SET list_id = $1, updated_at = $2
 WHERE id = $3
-  AND user_id = $4
+  AND list_id IN (SELECT list_id
+                  FROM list_editors_view
+                  WHERE user_id = $4)
 `
 
 type MoveHeadingToAnotherListParams struct {
@@ -183,11 +242,42 @@ func (q *Queries) MoveHeadingToAnotherList(ctx context.Context, arg MoveHeadingT
 	return err
 }
 
+const moveTasksToHeading = `-- name: MoveTasksToHeading :exec
+UPDATE tasks
+SET list_id = $1, heading_id = $2, updated_at = $3
+WHERE heading_id = $4
+  AND list_id IN (SELECT list_id
+                  FROM list_editors_view
+                  WHERE user_id = $5)
+  AND deleted_at IS NULL
+`
+
+type MoveTasksToHeadingParams struct {
+	ListID        string    `db:"list_id"`
+	HeadingID     string    `db:"heading_id"`
+	UpdatedAt     time.Time `db:"updated_at"`
+	FromHeadingID string    `db:"from_heading_id"`
+	UserID        string    `db:"user_id"`
+}
+
+func (q *Queries) MoveTasksToHeading(ctx context.Context, arg MoveTasksToHeadingParams) error {
+	_, err := q.db.Exec(ctx, moveTasksToHeading,
+		arg.ListID,
+		arg.HeadingID,
+		arg.UpdatedAt,
+		arg.FromHeadingID,
+		arg.UserID,
+	)
+	return err
+}
+
 const updateHeading = `-- name: UpdateHeading :exec
 UPDATE headings
 SET title = $1, updated_at = $2
 WHERE id = $3
-  AND user_id = $4
+  AND list_id IN (SELECT list_id
+                  FROM list_editors_view
+                  WHERE user_id = $4)
 `
 
 type UpdateHeadingParams struct {
@@ -211,7 +301,9 @@ const updateTasksListID = `-- name: UpdateTasksListID :exec
 UPDATE tasks
 SET list_id = $1, updated_at = $2
 WHERE heading_id = $3
-  AND user_id = $4
+  AND list_id IN (SELECT list_id
+                  FROM list_editors_view
+                  WHERE user_id = $4)
 `
 
 type UpdateTasksListIDParams struct {
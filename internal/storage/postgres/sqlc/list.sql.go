@@ -12,27 +12,131 @@ import (
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
-const createList = `-- name: CreateList :exec
-INSERT INTO lists (id, title, user_id, is_default, updated_at)
-VALUES ($1, $2, $3, $4, $5)
+const archiveTasksByListID = `-- name: ArchiveTasksByListID :exec
+UPDATE tasks
+SET status_id = (SELECT s.id
+                 FROM statuses s
+                 WHERE s.title = $1),
+    updated_at = $2,
+    deleted_at = $3
+WHERE list_id = $4
+  AND list_id IN (SELECT list_id
+                  FROM list_editors_view
+                  WHERE user_id = $5)
+  AND deleted_at IS NULL
+`
+
+type ArchiveTasksByListIDParams struct {
+	StatusTitle string             `db:"status_title"`
+	UpdatedAt   time.Time          `db:"updated_at"`
+	DeletedAt   pgtype.Timestamptz `db:"deleted_at"`
+	ListID      string             `db:"list_id"`
+	UserID      string             `db:"user_id"`
+}
+
+func (q *Queries) ArchiveTasksByListID(ctx context.Context, arg ArchiveTasksByListIDParams) error {
+	_, err := q.db.Exec(ctx, archiveTasksByListID,
+		arg.StatusTitle,
+		arg.UpdatedAt,
+		arg.DeletedAt,
+		arg.ListID,
+		arg.UserID,
+	)
+	return err
+}
+
+const completeTasksByListID = `-- name: CompleteTasksByListID :exec
+UPDATE tasks
+SET status_id = (SELECT s.id
+                 FROM statuses s
+                 WHERE s.title = $1),
+    updated_at = $2
+WHERE list_id = $3
+  AND list_id IN (SELECT list_id
+                  FROM list_editors_view
+                  WHERE user_id = $4)
+  AND status_id <> (SELECT s.id
+                    FROM statuses s
+                    WHERE s.title = $1)
+  AND deleted_at IS NULL
+`
+
+type CompleteTasksByListIDParams struct {
+	StatusTitle string    `db:"status_title"`
+	UpdatedAt   time.Time `db:"updated_at"`
+	ListID      string    `db:"list_id"`
+	UserID      string    `db:"user_id"`
+}
+
+func (q *Queries) CompleteTasksByListID(ctx context.Context, arg CompleteTasksByListIDParams) error {
+	_, err := q.db.Exec(ctx, completeTasksByListID,
+		arg.StatusTitle,
+		arg.UpdatedAt,
+		arg.ListID,
+		arg.UserID,
+	)
+	return err
+}
+
+const createList = `-- name: CreateList :one
+INSERT INTO lists (id, title, user_id, is_default, area_id, position, color, icon, notes, start_date, deadline, updated_at)
+VALUES ($1, $2, $3, $4, $5, (
+    SELECT COALESCE(MAX(position) + 1, 0)
+    FROM lists
+    WHERE user_id = $3
+      AND area_id IS NOT DISTINCT FROM $5
+      AND deleted_at IS NULL
+), $6, $7, $8, $9, $10, $11)
+RETURNING position
 `
 
 type CreateListParams struct {
-	ID        string    `db:"id"`
-	Title     string    `db:"title"`
-	UserID    string    `db:"user_id"`
-	IsDefault bool      `db:"is_default"`
-	UpdatedAt time.Time `db:"updated_at"`
+	ID        string             `db:"id"`
+	Title     string             `db:"title"`
+	UserID    string             `db:"user_id"`
+	IsDefault bool               `db:"is_default"`
+	AreaID    pgtype.Text        `db:"area_id"`
+	Color     string             `db:"color"`
+	Icon      string             `db:"icon"`
+	Notes     string             `db:"notes"`
+	StartDate pgtype.Timestamptz `db:"start_date"`
+	Deadline  pgtype.Timestamptz `db:"deadline"`
+	UpdatedAt time.Time          `db:"updated_at"`
 }
 
-func (q *Queries) CreateList(ctx context.Context, arg CreateListParams) error {
-	_, err := q.db.Exec(ctx, createList,
+func (q *Queries) CreateList(ctx context.Context, arg CreateListParams) (int32, error) {
+	row := q.db.QueryRow(ctx, createList,
 		arg.ID,
 		arg.Title,
 		arg.UserID,
 		arg.IsDefault,
+		arg.AreaID,
+		arg.Color,
+		arg.Icon,
+		arg.Notes,
+		arg.StartDate,
+		arg.Deadline,
 		arg.UpdatedAt,
 	)
+	var position int32
+	err := row.Scan(&position)
+	return position, err
+}
+
+const deleteHeadingsByListID = `-- name: DeleteHeadingsByListID :exec
+UPDATE headings
+SET deleted_at = $1
+WHERE list_id = $2
+  AND deleted_at IS NULL
+`
+
+type DeleteHeadingsByListIDParams struct {
+	DeletedAt pgtype.Timestamptz `db:"deleted_at"`
+	ListID    string             `db:"list_id"`
+}
+
+func (q *Queries) DeleteHeadingsByListID(ctx context.Context, arg DeleteHeadingsByListIDParams) error {
+	_, err := q.db.Exec(ctx, deleteHeadingsByListID, arg.DeletedAt, arg.ListID)
 	return err
 }
 
@@ -40,7 +144,10 @@ const deleteList = `-- name: DeleteList :exec
 UPDATE lists
 SET deleted_at = $1
 WHERE id = $2
-  AND user_id = $3
+  AND id IN (SELECT list_id
+             FROM list_members
+             WHERE user_id = $3
+               AND role = 'owner')
 `
 
 type DeleteListParams struct {
@@ -54,11 +161,146 @@ func (q *Queries) DeleteList(ctx context.Context, arg DeleteListParams) error {
 	return err
 }
 
+const detachListsFromArea = `-- name: DetachListsFromArea :exec
+UPDATE lists
+SET area_id = NULL,
+    is_default = FALSE,
+    title = CASE WHEN is_default THEN $1::varchar ELSE title END,
+    updated_at = $2
+WHERE area_id = $3
+  AND user_id = $4
+  AND deleted_at IS NULL
+`
+
+type DetachListsFromAreaParams struct {
+	AreaTitle string      `db:"area_title"`
+	UpdatedAt time.Time   `db:"updated_at"`
+	AreaID    pgtype.Text `db:"area_id"`
+	UserID    string      `db:"user_id"`
+}
+
+func (q *Queries) DetachListsFromArea(ctx context.Context, arg DetachListsFromAreaParams) error {
+	_, err := q.db.Exec(ctx, detachListsFromArea,
+		arg.AreaTitle,
+		arg.UpdatedAt,
+		arg.AreaID,
+		arg.UserID,
+	)
+	return err
+}
+
+const getArchivedListsByUserID = `-- name: GetArchivedListsByUserID :many
+SELECT
+    l.id,
+    l.title,
+    l.user_id,
+    l.area_id,
+    l.position,
+    l.color,
+    l.icon,
+    l.notes,
+    l.start_date,
+    l.deadline,
+    l.status,
+    l.archived_at,
+    l.updated_at,
+    m.role,
+    COALESCE(p.open_tasks, 0)::int AS open_tasks,
+    COALESCE(p.completed_tasks, 0)::int AS completed_tasks
+FROM lists l
+    JOIN list_members m
+        ON m.list_id = l.id
+    LEFT JOIN list_progress_view p
+        ON p.list_id = l.id
+WHERE m.user_id = $1
+  AND l.deleted_at IS NULL
+  AND l.status <> 'active'
+ORDER BY l.archived_at DESC, l.id
+`
+
+type GetArchivedListsByUserIDRow struct {
+	ID             string             `db:"id"`
+	Title          string             `db:"title"`
+	UserID         string             `db:"user_id"`
+	AreaID         pgtype.Text        `db:"area_id"`
+	Position       int32              `db:"position"`
+	Color          string             `db:"color"`
+	Icon           string             `db:"icon"`
+	Notes          string             `db:"notes"`
+	StartDate      pgtype.Timestamptz `db:"start_date"`
+	Deadline       pgtype.Timestamptz `db:"deadline"`
+	Status         string             `db:"status"`
+	ArchivedAt     pgtype.Timestamptz `db:"archived_at"`
+	UpdatedAt      time.Time          `db:"updated_at"`
+	Role           string             `db:"role"`
+	OpenTasks      int32              `db:"open_tasks"`
+	CompletedTasks int32              `db:"completed_tasks"`
+}
+
+func (q *Queries) GetArchivedListsByUserID(ctx context.Context, userID string) ([]GetArchivedListsByUserIDRow, error) {
+	rows, err := q.db.Query(ctx, getArchivedListsByUserID, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []GetArchivedListsByUserIDRow{}
+	for rows.Next() {
+		var i GetArchivedListsByUserIDRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.Title,
+			&i.UserID,
+			&i.AreaID,
+			&i.Position,
+			&i.Color,
+			&i.Icon,
+			&i.Notes,
+			&i.StartDate,
+			&i.Deadline,
+			&i.Status,
+			&i.ArchivedAt,
+			&i.UpdatedAt,
+			&i.Role,
+			&i.OpenTasks,
+			&i.CompletedTasks,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getAreaDefaultListID = `-- name: GetAreaDefaultListID :one
+SELECT id
+FROM lists
+WHERE area_id = $1
+  AND user_id = $2
+  AND is_default = TRUE
+  AND deleted_at IS NULL
+`
+
+type GetAreaDefaultListIDParams struct {
+	AreaID pgtype.Text `db:"area_id"`
+	UserID string      `db:"user_id"`
+}
+
+func (q *Queries) GetAreaDefaultListID(ctx context.Context, arg GetAreaDefaultListIDParams) (string, error) {
+	row := q.db.QueryRow(ctx, getAreaDefaultListID, arg.AreaID, arg.UserID)
+	var id string
+	err := row.Scan(&id)
+	return id, err
+}
+
 const getDefaultListID = `-- name: GetDefaultListID :one
 SELECT id
 FROM lists
 WHERE user_id = $1
   AND is_default = TRUE
+  AND area_id IS NULL
   AND deleted_at IS NULL
 `
 
@@ -70,11 +312,32 @@ func (q *Queries) GetDefaultListID(ctx context.Context, userID string) (string,
 }
 
 const getListByID = `-- name: GetListByID :one
-SELECT id, title, user_id, updated_at
-FROM lists
-WHERE id = $1
-  AND user_id = $2
-  AND deleted_at IS NULL
+SELECT
+    l.id,
+    l.title,
+    l.user_id,
+    l.is_default,
+    l.area_id,
+    l.position,
+    l.color,
+    l.icon,
+    l.notes,
+    l.start_date,
+    l.deadline,
+    l.status,
+    l.archived_at,
+    l.updated_at,
+    m.role,
+    COALESCE(p.open_tasks, 0)::int AS open_tasks,
+    COALESCE(p.completed_tasks, 0)::int AS completed_tasks
+FROM lists l
+    JOIN list_members m
+        ON m.list_id = l.id
+    LEFT JOIN list_progress_view p
+        ON p.list_id = l.id
+WHERE l.id = $1
+  AND m.user_id = $2
+  AND l.deleted_at IS NULL
 `
 
 type GetListByIDParams struct {
@@ -83,10 +346,23 @@ type GetListByIDParams struct {
 }
 
 type GetListByIDRow struct {
-	ID        string    `db:"id"`
-	Title     string    `db:"title"`
-	UserID    string    `db:"user_id"`
-	UpdatedAt time.Time `db:"updated_at"`
+	ID             string             `db:"id"`
+	Title          string             `db:"title"`
+	UserID         string             `db:"user_id"`
+	IsDefault      bool               `db:"is_default"`
+	AreaID         pgtype.Text        `db:"area_id"`
+	Position       int32              `db:"position"`
+	Color          string             `db:"color"`
+	Icon           string             `db:"icon"`
+	Notes          string             `db:"notes"`
+	StartDate      pgtype.Timestamptz `db:"start_date"`
+	Deadline       pgtype.Timestamptz `db:"deadline"`
+	Status         string             `db:"status"`
+	ArchivedAt     pgtype.Timestamptz `db:"archived_at"`
+	UpdatedAt      time.Time          `db:"updated_at"`
+	Role           string             `db:"role"`
+	OpenTasks      int32              `db:"open_tasks"`
+	CompletedTasks int32              `db:"completed_tasks"`
 }
 
 func (q *Queries) GetListByID(ctx context.Context, arg GetListByIDParams) (GetListByIDRow, error) {
@@ -96,27 +372,80 @@ func (q *Queries) GetListByID(ctx context.Context, arg GetListByIDParams) (GetLi
 		&i.ID,
 		&i.Title,
 		&i.UserID,
+		&i.IsDefault,
+		&i.AreaID,
+		&i.Position,
+		&i.Color,
+		&i.Icon,
+		&i.Notes,
+		&i.StartDate,
+		&i.Deadline,
+		&i.Status,
+		&i.ArchivedAt,
 		&i.UpdatedAt,
+		&i.Role,
+		&i.OpenTasks,
+		&i.CompletedTasks,
 	)
 	return i, err
 }
 
 const getListsByUserID = `-- name: GetListsByUserID :many
-SELECT id, title, updated_at
-FROM lists
-WHERE user_id = $1
-  AND deleted_at IS NULL
-ORDER BY id
+SELECT
+    l.id,
+    l.title,
+    l.user_id,
+    l.area_id,
+    l.position,
+    l.color,
+    l.icon,
+    l.notes,
+    l.start_date,
+    l.deadline,
+    l.status,
+    l.archived_at,
+    l.updated_at,
+    m.role,
+    COALESCE(p.open_tasks, 0)::int AS open_tasks,
+    COALESCE(p.completed_tasks, 0)::int AS completed_tasks
+FROM lists l
+    JOIN list_members m
+        ON m.list_id = l.id
+    LEFT JOIN list_progress_view p
+        ON p.list_id = l.id
+WHERE m.user_id = $1
+  AND l.deleted_at IS NULL
+  AND NOT (l.is_default AND l.area_id IS NOT NULL)
+  AND (l.status = 'active' OR $2::bool)
+ORDER BY l.position, l.id
 `
 
+type GetListsByUserIDParams struct {
+	UserID          string `db:"user_id"`
+	IncludeArchived bool   `db:"include_archived"`
+}
+
 type GetListsByUserIDRow struct {
-	ID        string    `db:"id"`
-	Title     string    `db:"title"`
-	UpdatedAt time.Time `db:"updated_at"`
+	ID             string             `db:"id"`
+	Title          string             `db:"title"`
+	UserID         string             `db:"user_id"`
+	AreaID         pgtype.Text        `db:"area_id"`
+	Position       int32              `db:"position"`
+	Color          string             `db:"color"`
+	Icon           string             `db:"icon"`
+	Notes          string             `db:"notes"`
+	StartDate      pgtype.Timestamptz `db:"start_date"`
+	Deadline       pgtype.Timestamptz `db:"deadline"`
+	Status         string             `db:"status"`
+	ArchivedAt     pgtype.Timestamptz `db:"archived_at"`
+	UpdatedAt      time.Time          `db:"updated_at"`
+	Role           string             `db:"role"`
+	OpenTasks      int32              `db:"open_tasks"`
+	CompletedTasks int32              `db:"completed_tasks"`
 }
 
-func (q *Queries) GetListsByUserID(ctx context.Context, userID string) ([]GetListsByUserIDRow, error) {
-	rows, err := q.db.Query(ctx, getListsByUserID, userID)
+func (q *Queries) GetListsByUserID(ctx context.Context, arg GetListsByUserIDParams) ([]GetListsByUserIDRow, error) {
+	rows, err := q.db.Query(ctx, getListsByUserID, arg.UserID, arg.IncludeArchived)
 	if err != nil {
 		return nil, err
 	}
@@ -124,7 +453,24 @@ func (q *Queries) GetListsByUserID(ctx context.Context, userID string) ([]GetLis
 	items := []GetListsByUserIDRow{}
 	for rows.Next() {
 		var i GetListsByUserIDRow
-		if err := rows.Scan(&i.ID, &i.Title, &i.UpdatedAt); err != nil {
+		if err := rows.Scan(
+			&i.ID,
+			&i.Title,
+			&i.UserID,
+			&i.AreaID,
+			&i.Position,
+			&i.Color,
+			&i.Icon,
+			&i.Notes,
+			&i.StartDate,
+			&i.Deadline,
+			&i.Status,
+			&i.ArchivedAt,
+			&i.UpdatedAt,
+			&i.Role,
+			&i.OpenTasks,
+			&i.CompletedTasks,
+		); err != nil {
 			return nil, err
 		}
 		items = append(items, i)
@@ -135,26 +481,218 @@ func (q *Queries) GetListsByUserID(ctx context.Context, userID string) ([]GetLis
 	return items, nil
 }
 
-const updateList = `-- name: UpdateList :exec
+const listHasContent = `-- name: ListHasContent :one
+SELECT EXISTS (SELECT 1
+               FROM tasks t
+               WHERE t.list_id = $1
+                 AND t.deleted_at IS NULL)
+    OR EXISTS (SELECT 1
+               FROM headings h
+               WHERE h.list_id = $1
+                 AND h.is_default = FALSE
+                 AND h.deleted_at IS NULL)
+`
+
+func (q *Queries) ListHasContent(ctx context.Context, listID string) (bool, error) {
+	row := q.db.QueryRow(ctx, listHasContent, listID)
+	var column_1 bool
+	err := row.Scan(&column_1)
+	return column_1, err
+}
+
+const moveListToArea = `-- name: MoveListToArea :execrows
 UPDATE lists
-SET title = $1,	updated_at = $2
+SET area_id = $1, position = (
+    SELECT COALESCE(MAX(l.position) + 1, 0)
+    FROM lists l
+    WHERE l.user_id = $4
+      AND l.area_id IS NOT DISTINCT FROM $1
+      AND l.deleted_at IS NULL
+), updated_at = $2
 WHERE id = $3
   AND user_id = $4
+  AND is_default = FALSE
+  AND deleted_at IS NULL
+`
+
+type MoveListToAreaParams struct {
+	AreaID    pgtype.Text `db:"area_id"`
+	UpdatedAt time.Time   `db:"updated_at"`
+	ID        string      `db:"id"`
+	UserID    string      `db:"user_id"`
+}
+
+func (q *Queries) MoveListToArea(ctx context.Context, arg MoveListToAreaParams) (int64, error) {
+	result, err := q.db.Exec(ctx, moveListToArea,
+		arg.AreaID,
+		arg.UpdatedAt,
+		arg.ID,
+		arg.UserID,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const moveTasksToList = `-- name: MoveTasksToList :exec
+UPDATE tasks
+SET list_id = $1, heading_id = $2, updated_at = $3
+WHERE list_id = $4
+  AND list_id IN (SELECT list_id
+                  FROM list_editors_view
+                  WHERE user_id = $5)
+  AND deleted_at IS NULL
+`
+
+type MoveTasksToListParams struct {
+	ListID     string    `db:"list_id"`
+	HeadingID  string    `db:"heading_id"`
+	UpdatedAt  time.Time `db:"updated_at"`
+	FromListID string    `db:"from_list_id"`
+	UserID     string    `db:"user_id"`
+}
+
+func (q *Queries) MoveTasksToList(ctx context.Context, arg MoveTasksToListParams) error {
+	_, err := q.db.Exec(ctx, moveTasksToList,
+		arg.ListID,
+		arg.HeadingID,
+		arg.UpdatedAt,
+		arg.FromListID,
+		arg.UserID,
+	)
+	return err
+}
+
+const moveUserTasksToList = `-- name: MoveUserTasksToList :exec
+UPDATE tasks
+SET list_id = $1, heading_id = $2, updated_at = $3
+WHERE list_id = $4
+  AND user_id = $5
+  AND list_id IN (SELECT list_id
+                  FROM list_editors_view
+                  WHERE user_id = $5)
+  AND deleted_at IS NULL
+`
+
+type MoveUserTasksToListParams struct {
+	ListID     string    `db:"list_id"`
+	HeadingID  string    `db:"heading_id"`
+	UpdatedAt  time.Time `db:"updated_at"`
+	FromListID string    `db:"from_list_id"`
+	UserID     string    `db:"user_id"`
+}
+
+func (q *Queries) MoveUserTasksToList(ctx context.Context, arg MoveUserTasksToListParams) error {
+	_, err := q.db.Exec(ctx, moveUserTasksToList,
+		arg.ListID,
+		arg.HeadingID,
+		arg.UpdatedAt,
+		arg.FromListID,
+		arg.UserID,
+	)
+	return err
+}
+
+const updateList = `-- name: UpdateList :exec
+UPDATE lists
+SET title = $1,
+    color = $2,
+    icon = $3,
+    notes = $4,
+    start_date = $5,
+    deadline = $6,
+    updated_at = $7
+WHERE id = $8
+  AND id IN (SELECT list_id
+             FROM list_editors_view
+             WHERE user_id = $9)
 `
 
 type UpdateListParams struct {
-	Title     string    `db:"title"`
-	UpdatedAt time.Time `db:"updated_at"`
-	ID        string    `db:"id"`
-	UserID    string    `db:"user_id"`
+	Title     string             `db:"title"`
+	Color     string             `db:"color"`
+	Icon      string             `db:"icon"`
+	Notes     string             `db:"notes"`
+	StartDate pgtype.Timestamptz `db:"start_date"`
+	Deadline  pgtype.Timestamptz `db:"deadline"`
+	UpdatedAt time.Time          `db:"updated_at"`
+	ID        string             `db:"id"`
+	UserID    string             `db:"user_id"`
 }
 
 func (q *Queries) UpdateList(ctx context.Context, arg UpdateListParams) error {
 	_, err := q.db.Exec(ctx, updateList,
 		arg.Title,
+		arg.Color,
+		arg.Icon,
+		arg.Notes,
+		arg.StartDate,
+		arg.Deadline,
 		arg.UpdatedAt,
 		arg.ID,
 		arg.UserID,
 	)
 	return err
 }
+
+const updateListPosition = `-- name: UpdateListPosition :execrows
+UPDATE lists
+SET position = $1, updated_at = $2
+WHERE id = $3
+  AND user_id = $4
+  AND deleted_at IS NULL
+`
+
+type UpdateListPositionParams struct {
+	Position  int32     `db:"position"`
+	UpdatedAt time.Time `db:"updated_at"`
+	ID        string    `db:"id"`
+	UserID    string    `db:"user_id"`
+}
+
+func (q *Queries) UpdateListPosition(ctx context.Context, arg UpdateListPositionParams) (int64, error) {
+	result, err := q.db.Exec(ctx, updateListPosition,
+		arg.Position,
+		arg.UpdatedAt,
+		arg.ID,
+		arg.UserID,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const updateListStatus = `-- name: UpdateListStatus :execrows
+UPDATE lists
+SET status = $1, archived_at = $2, updated_at = $3
+WHERE id = $4
+  AND id IN (SELECT list_id
+             FROM list_editors_view
+             WHERE user_id = $5)
+  AND is_default = FALSE
+  AND deleted_at IS NULL
+`
+
+type UpdateListStatusParams struct {
+	Status     string             `db:"status"`
+	ArchivedAt pgtype.Timestamptz `db:"archived_at"`
+	UpdatedAt  time.Time          `db:"updated_at"`
+	ID         string             `db:"id"`
+	UserID     string             `db:"user_id"`
+}
+
+func (q *Queries) UpdateListStatus(ctx context.Context, arg UpdateListStatusParams) (int64, error) {
+	result, err := q.db.Exec(ctx, updateListStatus,
+		arg.Status,
+		arg.ArchivedAt,
+		arg.UpdatedAt,
+		arg.ID,
+		arg.UserID,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
@@ -11,25 +11,156 @@ import (
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
-type Heading struct {
+type Area struct {
 	ID        string             `db:"id"`
 	Title     string             `db:"title"`
-	ListID    string             `db:"list_id"`
+	Position  int32              `db:"position"`
 	UserID    string             `db:"user_id"`
-	IsDefault bool               `db:"is_default"`
 	UpdatedAt time.Time          `db:"updated_at"`
 	DeletedAt pgtype.Timestamptz `db:"deleted_at"`
 }
 
-type List struct {
+type Attachment struct {
+	ID          string             `db:"id"`
+	TaskID      string             `db:"task_id"`
+	FileName    string             `db:"file_name"`
+	ContentType string             `db:"content_type"`
+	Size        int64              `db:"size"`
+	BlobKey     string             `db:"blob_key"`
+	UserID      string             `db:"user_id"`
+	CreatedAt   time.Time          `db:"created_at"`
+	DeletedAt   pgtype.Timestamptz `db:"deleted_at"`
+}
+
+type AuditLog struct {
+	ID         string      `db:"id"`
+	EntityType string      `db:"entity_type"`
+	EntityID   string      `db:"entity_id"`
+	Action     string      `db:"action"`
+	Changes    []byte      `db:"changes"`
+	UserID     string      `db:"user_id"`
+	DeviceID   pgtype.Text `db:"device_id"`
+	RequestID  string      `db:"request_id"`
+	CreatedAt  time.Time   `db:"created_at"`
+}
+
+type Goal struct {
+	ID          string             `db:"id"`
+	Title       string             `db:"title"`
+	Description pgtype.Text        `db:"description"`
+	TargetDate  pgtype.Timestamptz `db:"target_date"`
+	UserID      string             `db:"user_id"`
+	UpdatedAt   time.Time          `db:"updated_at"`
+	DeletedAt   pgtype.Timestamptz `db:"deleted_at"`
+}
+
+type GoalProgress struct {
+	ID         int32     `db:"id"`
+	GoalID     string    `db:"goal_id"`
+	Progress   float64   `db:"progress"`
+	RecordedAt time.Time `db:"recorded_at"`
+}
+
+type Habit struct {
+	ID           string             `db:"id"`
+	Title        string             `db:"title"`
+	Notes        string             `db:"notes"`
+	Frequency    string             `db:"frequency"`
+	TimesPerWeek int32              `db:"times_per_week"`
+	UserID       string             `db:"user_id"`
+	UpdatedAt    time.Time          `db:"updated_at"`
+	DeletedAt    pgtype.Timestamptz `db:"deleted_at"`
+}
+
+type HabitCheckIn struct {
+	HabitID   string      `db:"habit_id"`
+	Day       pgtype.Date `db:"day"`
+	CreatedAt time.Time   `db:"created_at"`
+}
+
+type Heading struct {
 	ID        string             `db:"id"`
 	Title     string             `db:"title"`
+	ListID    string             `db:"list_id"`
 	UserID    string             `db:"user_id"`
 	IsDefault bool               `db:"is_default"`
 	UpdatedAt time.Time          `db:"updated_at"`
 	DeletedAt pgtype.Timestamptz `db:"deleted_at"`
 }
 
+type IdempotencyKey struct {
+	Key          string    `db:"key"`
+	UserID       string    `db:"user_id"`
+	Method       string    `db:"method"`
+	Path         string    `db:"path"`
+	RequestHash  string    `db:"request_hash"`
+	StatusCode   int32     `db:"status_code"`
+	ResponseBody []byte    `db:"response_body"`
+	CreatedAt    time.Time `db:"created_at"`
+	ExpiresAt    time.Time `db:"expires_at"`
+}
+
+type KeyResult struct {
+	ID           string             `db:"id"`
+	GoalID       string             `db:"goal_id"`
+	Title        string             `db:"title"`
+	Kind         string             `db:"kind"`
+	StartValue   float64            `db:"start_value"`
+	TargetValue  float64            `db:"target_value"`
+	CurrentValue float64            `db:"current_value"`
+	UserID       string             `db:"user_id"`
+	UpdatedAt    time.Time          `db:"updated_at"`
+	DeletedAt    pgtype.Timestamptz `db:"deleted_at"`
+}
+
+type List struct {
+	ID         string             `db:"id"`
+	Title      string             `db:"title"`
+	UserID     string             `db:"user_id"`
+	IsDefault  bool               `db:"is_default"`
+	UpdatedAt  time.Time          `db:"updated_at"`
+	DeletedAt  pgtype.Timestamptz `db:"deleted_at"`
+	AreaID     pgtype.Text        `db:"area_id"`
+	Position   int32              `db:"position"`
+	GoalID     pgtype.Text        `db:"goal_id"`
+	Color      string             `db:"color"`
+	Icon       string             `db:"icon"`
+	Notes      string             `db:"notes"`
+	StartDate  pgtype.Timestamptz `db:"start_date"`
+	Deadline   pgtype.Timestamptz `db:"deadline"`
+	Status     string             `db:"status"`
+	ArchivedAt pgtype.Timestamptz `db:"archived_at"`
+}
+
+type ListEditorsView struct {
+	ListID string `db:"list_id"`
+	UserID string `db:"user_id"`
+}
+
+type ListInvitation struct {
+	ID        string    `db:"id"`
+	ListID    string    `db:"list_id"`
+	Email     string    `db:"email"`
+	Role      string    `db:"role"`
+	Status    string    `db:"status"`
+	InvitedBy string    `db:"invited_by"`
+	CreatedAt time.Time `db:"created_at"`
+	UpdatedAt time.Time `db:"updated_at"`
+}
+
+type ListMember struct {
+	ListID    string    `db:"list_id"`
+	UserID    string    `db:"user_id"`
+	Role      string    `db:"role"`
+	CreatedAt time.Time `db:"created_at"`
+}
+
+type ListProgressView struct {
+	ListID         string `db:"list_id"`
+	OpenTasks      int32  `db:"open_tasks"`
+	CompletedTasks int32  `db:"completed_tasks"`
+}
+
 type RefreshSession struct {
 	ID           int32     `db:"id"`
 	UserID       string    `db:"user_id"`
@@ -54,6 +185,45 @@ type ReminderSetting struct {
 	Interval string `db:"interval"`
 }
 
+type ReviewItem struct {
+	ID         string             `db:"id"`
+	ReviewID   string             `db:"review_id"`
+	Section    string             `db:"section"`
+	EntityType string             `db:"entity_type"`
+	EntityID   string             `db:"entity_id"`
+	Title      string             `db:"title"`
+	Position   int32              `db:"position"`
+	Action     pgtype.Text        `db:"action"`
+	ActedAt    pgtype.Timestamptz `db:"acted_at"`
+}
+
+type ReviewSession struct {
+	ID          string             `db:"id"`
+	UserID      string             `db:"user_id"`
+	StaleDays   int32              `db:"stale_days"`
+	StartedAt   time.Time          `db:"started_at"`
+	CompletedAt pgtype.Timestamptz `db:"completed_at"`
+}
+
+type Rule struct {
+	ID         string             `db:"id"`
+	Title      string             `db:"title"`
+	Trigger    string             `db:"trigger"`
+	Conditions []byte             `db:"conditions"`
+	Actions    []byte             `db:"actions"`
+	IsActive   bool               `db:"is_active"`
+	UserID     string             `db:"user_id"`
+	UpdatedAt  time.Time          `db:"updated_at"`
+	DeletedAt  pgtype.Timestamptz `db:"deleted_at"`
+}
+
+type RuleDeadlineRun struct {
+	RuleID   string    `db:"rule_id"`
+	TaskID   string    `db:"task_id"`
+	Deadline time.Time `db:"deadline"`
+	RanAt    time.Time `db:"ran_at"`
+}
+
 type Status struct {
 	ID    int32  `db:"id"`
 	Title string `db:"title"`
@@ -68,19 +238,55 @@ type Tag struct {
 }
 
 type Task struct {
-	ID          string             `db:"id"`
-	Title       string             `db:"title"`
-	Description pgtype.Text        `db:"description"`
-	StartDate   pgtype.Timestamptz `db:"start_date"`
-	Deadline    pgtype.Timestamptz `db:"deadline"`
-	StartTime   sql.NullTime       `db:"start_time"`
-	EndTime     sql.NullTime       `db:"end_time"`
-	StatusID    int32              `db:"status_id"`
-	ListID      string             `db:"list_id"`
-	HeadingID   string             `db:"heading_id"`
-	UserID      string             `db:"user_id"`
-	UpdatedAt   time.Time          `db:"updated_at"`
-	DeletedAt   pgtype.Timestamptz `db:"deleted_at"`
+	ID            string             `db:"id"`
+	Title         string             `db:"title"`
+	Description   pgtype.Text        `db:"description"`
+	StartDate     pgtype.Timestamptz `db:"start_date"`
+	Deadline      pgtype.Timestamptz `db:"deadline"`
+	StartTime     sql.NullTime       `db:"start_time"`
+	EndTime       sql.NullTime       `db:"end_time"`
+	StatusID      int32              `db:"status_id"`
+	ListID        string             `db:"list_id"`
+	HeadingID     string             `db:"heading_id"`
+	UserID        string             `db:"user_id"`
+	UpdatedAt     time.Time          `db:"updated_at"`
+	DeletedAt     pgtype.Timestamptz `db:"deleted_at"`
+	GoalID        pgtype.Text        `db:"goal_id"`
+	AssigneeID    pgtype.Text        `db:"assignee_id"`
+	DeferredUntil pgtype.Timestamptz `db:"deferred_until"`
+	SnoozeCount   int32              `db:"snooze_count"`
+	EveningDate   pgtype.Date        `db:"evening_date"`
+	CreatedAt     time.Time          `db:"created_at"`
+	CompletedAt   pgtype.Timestamptz `db:"completed_at"`
+}
+
+type TaskActivity struct {
+	ID        string    `db:"id"`
+	TaskID    string    `db:"task_id"`
+	Kind      string    `db:"kind"`
+	Field     string    `db:"field"`
+	OldValue  string    `db:"old_value"`
+	NewValue  string    `db:"new_value"`
+	UserID    string    `db:"user_id"`
+	CreatedAt time.Time `db:"created_at"`
+}
+
+type TaskComment struct {
+	ID        string             `db:"id"`
+	TaskID    string             `db:"task_id"`
+	Body      string             `db:"body"`
+	Mentions  []string           `db:"mentions"`
+	UserID    string             `db:"user_id"`
+	CreatedAt time.Time          `db:"created_at"`
+	UpdatedAt time.Time          `db:"updated_at"`
+	DeletedAt pgtype.Timestamptz `db:"deleted_at"`
+}
+
+type TaskDependency struct {
+	TaskID          string    `db:"task_id"`
+	BlockedByTaskID string    `db:"blocked_by_task_id"`
+	UserID          string    `db:"user_id"`
+	CreatedAt       time.Time `db:"created_at"`
 }
 
 type TaskTagsView struct {
@@ -93,12 +299,35 @@ type TasksTag struct {
 	TagID  string `db:"tag_id"`
 }
 
+type Template struct {
+	ID        string             `db:"id"`
+	Title     string             `db:"title"`
+	Kind      string             `db:"kind"`
+	Content   []byte             `db:"content"`
+	UserID    string             `db:"user_id"`
+	UpdatedAt time.Time          `db:"updated_at"`
+	DeletedAt pgtype.Timestamptz `db:"deleted_at"`
+}
+
+type UndoOperation struct {
+	ID         string             `db:"id"`
+	UserID     string             `db:"user_id"`
+	EntityType string             `db:"entity_type"`
+	EntityID   string             `db:"entity_id"`
+	Action     string             `db:"action"`
+	Snapshot   []byte             `db:"snapshot"`
+	CreatedAt  time.Time          `db:"created_at"`
+	ExpiresAt  time.Time          `db:"expires_at"`
+	UndoneAt   pgtype.Timestamptz `db:"undone_at"`
+}
+
 type User struct {
 	ID           string             `db:"id"`
 	Email        string             `db:"email"`
 	PasswordHash string             `db:"password_hash"`
 	UpdatedAt    time.Time          `db:"updated_at"`
 	DeletedAt    pgtype.Timestamptz `db:"deleted_at"`
+	TimeZone     string             `db:"time_zone"`
 }
 
 type UserDevice struct {
@@ -110,3 +339,33 @@ type UserDevice struct {
 	LatestLoginAt time.Time          `db:"latest_login_at"`
 	DetachedAt    pgtype.Timestamptz `db:"detached_at"`
 }
+
+type Webhook struct {
+	ID           string             `db:"id"`
+	Url          string             `db:"url"`
+	EventTypes   []string           `db:"event_types"`
+	Secret       string             `db:"secret"`
+	UserID       string             `db:"user_id"`
+	IsActive     bool               `db:"is_active"`
+	FailureCount int32              `db:"failure_count"`
+	DisabledAt   pgtype.Timestamptz `db:"disabled_at"`
+	UpdatedAt    time.Time          `db:"updated_at"`
+	DeletedAt    pgtype.Timestamptz `db:"deleted_at"`
+}
+
+type WebhookDelivery struct {
+	ID             string             `db:"id"`
+	WebhookID      string             `db:"webhook_id"`
+	UserID         string             `db:"user_id"`
+	EventID        string             `db:"event_id"`
+	EventType      string             `db:"event_type"`
+	Payload        []byte             `db:"payload"`
+	Status         string             `db:"status"`
+	Attempt        int32              `db:"attempt"`
+	ResponseStatus int32              `db:"response_status"`
+	Error          string             `db:"error"`
+	NextAttemptAt  time.Time          `db:"next_attempt_at"`
+	DeliveredAt    pgtype.Timestamptz `db:"delivered_at"`
+	CreatedAt      time.Time          `db:"created_at"`
+	UpdatedAt      time.Time          `db:"updated_at"`
+}
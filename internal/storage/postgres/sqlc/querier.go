@@ -6,59 +6,227 @@ package sqlc
 
 import (
 	"context"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
 )
 
 type Querier interface {
 	AddDevice(ctx context.Context, arg AddDeviceParams) error
+	ArchiveTasksByHeadingID(ctx context.Context, arg ArchiveTasksByHeadingIDParams) error
+	ArchiveTasksByListID(ctx context.Context, arg ArchiveTasksByListIDParams) error
+	AssignTask(ctx context.Context, arg AssignTaskParams) (int64, error)
+	ClaimWebhookDeliveries(ctx context.Context, arg ClaimWebhookDeliveriesParams) ([]ClaimWebhookDeliveriesRow, error)
+	CompleteReviewSession(ctx context.Context, arg CompleteReviewSessionParams) (int64, error)
+	CompleteTasksByListID(ctx context.Context, arg CompleteTasksByListIDParams) error
+	CountListOwners(ctx context.Context, listID string) (int64, error)
+	CreateArea(ctx context.Context, arg CreateAreaParams) (int32, error)
+	CreateAttachment(ctx context.Context, arg CreateAttachmentParams) error
+	CreateAuditEntry(ctx context.Context, arg CreateAuditEntryParams) error
+	CreateGoal(ctx context.Context, arg CreateGoalParams) error
+	CreateGoalProgress(ctx context.Context, arg CreateGoalProgressParams) (int64, error)
+	CreateHabit(ctx context.Context, arg CreateHabitParams) error
+	CreateHabitCheckIn(ctx context.Context, arg CreateHabitCheckInParams) error
 	CreateHeading(ctx context.Context, arg CreateHeadingParams) error
-	CreateList(ctx context.Context, arg CreateListParams) error
+	CreateIdempotencyKey(ctx context.Context, arg CreateIdempotencyKeyParams) (int64, error)
+	CreateKeyResult(ctx context.Context, arg CreateKeyResultParams) error
+	CreateList(ctx context.Context, arg CreateListParams) (int32, error)
+	CreateListInvitation(ctx context.Context, arg CreateListInvitationParams) (CreateListInvitationRow, error)
+	CreateListMember(ctx context.Context, arg CreateListMemberParams) error
+	CreateReviewItem(ctx context.Context, arg CreateReviewItemParams) error
+	CreateReviewSession(ctx context.Context, arg CreateReviewSessionParams) error
+	CreateRule(ctx context.Context, arg CreateRuleParams) error
+	CreateRuleDeadlineRun(ctx context.Context, arg CreateRuleDeadlineRunParams) (int64, error)
 	CreateTag(ctx context.Context, arg CreateTagParams) error
 	CreateTask(ctx context.Context, arg CreateTaskParams) error
+	CreateTaskActivity(ctx context.Context, arg CreateTaskActivityParams) error
+	CreateTaskComment(ctx context.Context, arg CreateTaskCommentParams) error
+	CreateTaskDependency(ctx context.Context, arg CreateTaskDependencyParams) (int64, error)
+	CreateTemplate(ctx context.Context, arg CreateTemplateParams) error
+	CreateUndoOperation(ctx context.Context, arg CreateUndoOperationParams) error
+	CreateWebhook(ctx context.Context, arg CreateWebhookParams) error
+	CreateWebhookDelivery(ctx context.Context, arg CreateWebhookDeliveryParams) error
+	DeleteArea(ctx context.Context, arg DeleteAreaParams) (int64, error)
+	DeleteAttachment(ctx context.Context, arg DeleteAttachmentParams) (int64, error)
+	DeleteCreatedContentTasks(ctx context.Context, arg DeleteCreatedContentTasksParams) error
+	DeleteCreatedHeading(ctx context.Context, arg DeleteCreatedHeadingParams) error
+	DeleteCreatedList(ctx context.Context, arg DeleteCreatedListParams) error
+	DeleteCreatedTask(ctx context.Context, arg DeleteCreatedTaskParams) error
+	DeleteExpiredIdempotencyKeys(ctx context.Context, expiresAt time.Time) (int64, error)
+	DeleteExpiredUndoOperations(ctx context.Context, expiresAt time.Time) (int64, error)
+	DeleteGoal(ctx context.Context, arg DeleteGoalParams) (int64, error)
+	DeleteHabit(ctx context.Context, arg DeleteHabitParams) (int64, error)
+	DeleteHabitCheckIn(ctx context.Context, arg DeleteHabitCheckInParams) (int64, error)
 	DeleteHeading(ctx context.Context, arg DeleteHeadingParams) error
+	DeleteHeadingsByListID(ctx context.Context, arg DeleteHeadingsByListIDParams) error
+	DeleteIdempotencyKey(ctx context.Context, arg DeleteIdempotencyKeyParams) error
+	DeleteKeyResult(ctx context.Context, arg DeleteKeyResultParams) (int64, error)
 	DeleteList(ctx context.Context, arg DeleteListParams) error
+	DeleteListMember(ctx context.Context, arg DeleteListMemberParams) (int64, error)
 	DeleteRefreshTokenFromSession(ctx context.Context, refreshToken string) error
+	DeleteRule(ctx context.Context, arg DeleteRuleParams) (int64, error)
 	DeleteSession(ctx context.Context, arg DeleteSessionParams) error
+	DeleteTaskComment(ctx context.Context, arg DeleteTaskCommentParams) (int64, error)
+	DeleteTaskDependency(ctx context.Context, arg DeleteTaskDependencyParams) (int64, error)
+	DeleteTaskTagsOfSnapshot(ctx context.Context, tasks []byte) error
+	DeleteTemplate(ctx context.Context, arg DeleteTemplateParams) (int64, error)
 	DeleteUser(ctx context.Context, arg DeleteUserParams) error
+	DeleteWebhook(ctx context.Context, arg DeleteWebhookParams) (int64, error)
+	DetachListsFromArea(ctx context.Context, arg DetachListsFromAreaParams) error
+	GetActiveRulesByTrigger(ctx context.Context, arg GetActiveRulesByTriggerParams) ([]GetActiveRulesByTriggerRow, error)
+	GetActiveWebhooksByEventType(ctx context.Context, arg GetActiveWebhooksByEventTypeParams) ([]GetActiveWebhooksByEventTypeRow, error)
+	GetAllTaskComments(ctx context.Context, taskID string) ([]GetAllTaskCommentsRow, error)
+	GetAllTasksByListID(ctx context.Context, arg GetAllTasksByListIDParams) ([]GetAllTasksByListIDRow, error)
+	GetArchivedListsByUserID(ctx context.Context, userID string) ([]GetArchivedListsByUserIDRow, error)
 	GetArchivedTasks(ctx context.Context, arg GetArchivedTasksParams) ([]GetArchivedTasksRow, error)
+	GetAreaByID(ctx context.Context, arg GetAreaByIDParams) (GetAreaByIDRow, error)
+	GetAreaDefaultListID(ctx context.Context, arg GetAreaDefaultListIDParams) (string, error)
+	GetAreasByUserID(ctx context.Context, userID string) ([]GetAreasByUserIDRow, error)
+	GetAssignedTasks(ctx context.Context, arg GetAssignedTasksParams) ([]GetAssignedTasksRow, error)
+	GetAttachmentByID(ctx context.Context, arg GetAttachmentByIDParams) (Attachment, error)
+	GetAttachmentsByTaskID(ctx context.Context, taskID string) ([]Attachment, error)
+	GetAttachmentsToPurge(ctx context.Context, arg GetAttachmentsToPurgeParams) ([]Attachment, error)
+	GetAuditEntriesByEntity(ctx context.Context, arg GetAuditEntriesByEntityParams) ([]GetAuditEntriesByEntityRow, error)
+	GetAuditEntriesByUserID(ctx context.Context, arg GetAuditEntriesByUserIDParams) ([]GetAuditEntriesByUserIDRow, error)
+	GetBlockedTaskIDs(ctx context.Context, arg GetBlockedTaskIDsParams) ([]string, error)
+	GetBlockedTasks(ctx context.Context, arg GetBlockedTasksParams) ([]GetBlockedTasksRow, error)
 	GetCompletedTasks(ctx context.Context, arg GetCompletedTasksParams) ([]GetCompletedTasksRow, error)
+	GetCompletionRatesByList(ctx context.Context, arg GetCompletionRatesByListParams) ([]GetCompletionRatesByListRow, error)
+	GetCompletionRatesByTag(ctx context.Context, arg GetCompletionRatesByTagParams) ([]GetCompletionRatesByTagRow, error)
+	GetCompletionStreaks(ctx context.Context, userID string) (GetCompletionStreaksRow, error)
+	GetDailyPlanCandidates(ctx context.Context, arg GetDailyPlanCandidatesParams) ([]GetDailyPlanCandidatesRow, error)
 	GetDefaultHeadingID(ctx context.Context, arg GetDefaultHeadingIDParams) (string, error)
 	GetDefaultListID(ctx context.Context, userID string) (string, error)
+	GetDependentTaskIDs(ctx context.Context, arg GetDependentTaskIDsParams) ([]string, error)
+	GetGoalByID(ctx context.Context, arg GetGoalByIDParams) (GetGoalByIDRow, error)
+	GetGoalListIDs(ctx context.Context, arg GetGoalListIDsParams) ([]string, error)
+	GetGoalProgressHistory(ctx context.Context, goalID string) ([]GetGoalProgressHistoryRow, error)
+	GetGoalTaskCounts(ctx context.Context, arg GetGoalTaskCountsParams) (GetGoalTaskCountsRow, error)
+	GetGoalTaskIDs(ctx context.Context, arg GetGoalTaskIDsParams) ([]string, error)
+	GetGoalsByTaskID(ctx context.Context, id string) ([]GetGoalsByTaskIDRow, error)
+	GetGoalsByUserID(ctx context.Context, userID string) ([]GetGoalsByUserIDRow, error)
+	GetHabitByID(ctx context.Context, arg GetHabitByIDParams) (Habit, error)
+	GetHabitCheckIns(ctx context.Context, userID string) ([]GetHabitCheckInsRow, error)
+	GetHabitCheckInsInRange(ctx context.Context, arg GetHabitCheckInsInRangeParams) ([]pgtype.Date, error)
+	GetHabitsByUserID(ctx context.Context, userID string) ([]Habit, error)
 	GetHeadingByID(ctx context.Context, arg GetHeadingByIDParams) (GetHeadingByIDRow, error)
+	GetHeadingMemberRole(ctx context.Context, arg GetHeadingMemberRoleParams) (string, error)
+	GetHeadingSnapshots(ctx context.Context, arg GetHeadingSnapshotsParams) ([]byte, error)
 	GetHeadingsByListID(ctx context.Context, arg GetHeadingsByListIDParams) ([]GetHeadingsByListIDRow, error)
+	GetIdempotencyKey(ctx context.Context, arg GetIdempotencyKeyParams) (IdempotencyKey, error)
+	GetKeyResultsByGoalID(ctx context.Context, arg GetKeyResultsByGoalIDParams) ([]GetKeyResultsByGoalIDRow, error)
 	GetListByID(ctx context.Context, arg GetListByIDParams) (GetListByIDRow, error)
-	GetListsByUserID(ctx context.Context, userID string) ([]GetListsByUserIDRow, error)
+	GetListInvitationByID(ctx context.Context, id string) (GetListInvitationByIDRow, error)
+	GetListMemberRole(ctx context.Context, arg GetListMemberRoleParams) (string, error)
+	GetListMemberRoleWithDeleted(ctx context.Context, arg GetListMemberRoleWithDeletedParams) (string, error)
+	GetListMembers(ctx context.Context, listID string) ([]GetListMembersRow, error)
+	GetListSnapshots(ctx context.Context, ids []string) ([]byte, error)
+	GetListsByUserID(ctx context.Context, arg GetListsByUserIDParams) ([]GetListsByUserIDRow, error)
+	GetOpenReviewSession(ctx context.Context, userID string) (ReviewSession, error)
 	GetOverdueTasks(ctx context.Context, arg GetOverdueTasksParams) ([]GetOverdueTasksRow, error)
+	GetPendingDeadlineRuleRuns(ctx context.Context, arg GetPendingDeadlineRuleRunsParams) ([]GetPendingDeadlineRuleRunsRow, error)
+	GetPendingListInvitationsByEmail(ctx context.Context, email string) ([]GetPendingListInvitationsByEmailRow, error)
+	GetPendingListInvitationsByListID(ctx context.Context, listID string) ([]GetPendingListInvitationsByListIDRow, error)
+	GetReviewCandidates(ctx context.Context, arg GetReviewCandidatesParams) ([]GetReviewCandidatesRow, error)
+	GetReviewItemByID(ctx context.Context, arg GetReviewItemByIDParams) (ReviewItem, error)
+	GetReviewItems(ctx context.Context, reviewID string) ([]ReviewItem, error)
+	GetReviewSessionByID(ctx context.Context, arg GetReviewSessionByIDParams) (ReviewSession, error)
+	GetRuleByID(ctx context.Context, arg GetRuleByIDParams) (GetRuleByIDRow, error)
+	GetRulesByUserID(ctx context.Context, userID string) ([]GetRulesByUserIDRow, error)
 	GetSessionByRefreshToken(ctx context.Context, refreshToken string) (GetSessionByRefreshTokenRow, error)
 	GetTagIDByTitle(ctx context.Context, arg GetTagIDByTitleParams) (string, error)
 	GetTagsByTaskID(ctx context.Context, taskID string) ([]GetTagsByTaskIDRow, error)
 	GetTagsByUserID(ctx context.Context, userID string) ([]GetTagsByUserIDRow, error)
+	GetTaskActivities(ctx context.Context, taskID string) ([]GetTaskActivitiesRow, error)
+	GetTaskBlockers(ctx context.Context, arg GetTaskBlockersParams) ([]GetTaskBlockersRow, error)
 	GetTaskByID(ctx context.Context, arg GetTaskByIDParams) (GetTaskByIDRow, error)
+	GetTaskCommentByID(ctx context.Context, arg GetTaskCommentByIDParams) (GetTaskCommentByIDRow, error)
+	GetTaskComments(ctx context.Context, arg GetTaskCommentsParams) ([]GetTaskCommentsRow, error)
+	GetTaskMemberIDs(ctx context.Context, taskID string) ([]string, error)
+	GetTaskMemberRole(ctx context.Context, arg GetTaskMemberRoleParams) (string, error)
+	GetTaskSnapshots(ctx context.Context, arg GetTaskSnapshotsParams) ([]byte, error)
 	GetTaskStatusID(ctx context.Context, title string) (int32, error)
+	GetTaskSummaryStats(ctx context.Context, arg GetTaskSummaryStatsParams) (GetTaskSummaryStatsRow, error)
+	GetTaskTagSnapshots(ctx context.Context, arg GetTaskTagSnapshotsParams) ([]byte, error)
+	GetTaskThroughput(ctx context.Context, arg GetTaskThroughputParams) ([]GetTaskThroughputRow, error)
 	GetTasksByListID(ctx context.Context, arg GetTasksByListIDParams) ([]GetTasksByListIDRow, error)
 	GetTasksByUserID(ctx context.Context, arg GetTasksByUserIDParams) ([]GetTasksByUserIDRow, error)
 	GetTasksForSomeday(ctx context.Context, arg GetTasksForSomedayParams) ([]GetTasksForSomedayRow, error)
 	GetTasksForToday(ctx context.Context, userID string) ([]GetTasksForTodayRow, error)
 	GetTasksGroupedByHeadings(ctx context.Context, arg GetTasksGroupedByHeadingsParams) ([]GetTasksGroupedByHeadingsRow, error)
+	GetTemplateByID(ctx context.Context, arg GetTemplateByIDParams) (GetTemplateByIDRow, error)
+	GetTemplatesByUserID(ctx context.Context, userID string) ([]GetTemplatesByUserIDRow, error)
+	GetUndoListIDs(ctx context.Context, arg GetUndoListIDsParams) ([]string, error)
+	GetUndoOperation(ctx context.Context, arg GetUndoOperationParams) (UndoOperation, error)
 	GetUpcomingTasks(ctx context.Context, arg GetUpcomingTasksParams) ([]GetUpcomingTasksRow, error)
+	GetUserAttachmentsSize(ctx context.Context, userID string) (int64, error)
 	GetUserByEmail(ctx context.Context, email string) (GetUserByEmailRow, error)
 	GetUserByID(ctx context.Context, id string) (GetUserByIDRow, error)
 	GetUserData(ctx context.Context, id string) (GetUserDataRow, error)
 	GetUserDeviceID(ctx context.Context, arg GetUserDeviceIDParams) (string, error)
 	GetUserID(ctx context.Context, email string) (string, error)
 	GetUserStatus(ctx context.Context, email string) (string, error)
+	GetWebhookByID(ctx context.Context, arg GetWebhookByIDParams) (GetWebhookByIDRow, error)
+	GetWebhookDeliveries(ctx context.Context, arg GetWebhookDeliveriesParams) ([]GetWebhookDeliveriesRow, error)
+	GetWebhooksByUserID(ctx context.Context, userID string) ([]GetWebhooksByUserIDRow, error)
+	HasTaskDependencyPath(ctx context.Context, arg HasTaskDependencyPathParams) (bool, error)
+	HasUndoConflicts(ctx context.Context, arg HasUndoConflictsParams) (bool, error)
+	HeadingHasTasks(ctx context.Context, headingID string) (bool, error)
+	IncrementWebhookFailureCount(ctx context.Context, arg IncrementWebhookFailureCountParams) (bool, error)
 	InsertUser(ctx context.Context, arg InsertUserParams) error
+	LinkListToGoal(ctx context.Context, arg LinkListToGoalParams) (int64, error)
 	LinkTagToTask(ctx context.Context, arg LinkTagToTaskParams) error
+	LinkTaskToGoal(ctx context.Context, arg LinkTaskToGoalParams) (int64, error)
+	ListHasContent(ctx context.Context, listID string) (bool, error)
+	LockTaskDependencies(ctx context.Context) error
+	LockUserAttachments(ctx context.Context, userID string) error
 	MarkTaskAsArchived(ctx context.Context, arg MarkTaskAsArchivedParams) error
 	MarkTaskAsCompleted(ctx context.Context, arg MarkTaskAsCompletedParams) error
+	MarkUndoOperationAsUndone(ctx context.Context, arg MarkUndoOperationAsUndoneParams) (int64, error)
 	MoveHeadingToAnotherList(ctx context.Context, arg MoveHeadingToAnotherListParams) error
+	MoveListToArea(ctx context.Context, arg MoveListToAreaParams) (int64, error)
 	MoveTaskToAnotherList(ctx context.Context, arg MoveTaskToAnotherListParams) error
+	MoveTasksToHeading(ctx context.Context, arg MoveTasksToHeadingParams) error
+	MoveTasksToList(ctx context.Context, arg MoveTasksToListParams) error
+	MoveUserTasksToList(ctx context.Context, arg MoveUserTasksToListParams) error
+	PatchTask(ctx context.Context, arg PatchTaskParams) error
+	PlanTaskForToday(ctx context.Context, arg PlanTaskForTodayParams) (int64, error)
+	PurgeAttachment(ctx context.Context, id string) error
+	ResetWebhookFailureCount(ctx context.Context, id string) error
+	RestoreHeadings(ctx context.Context, arg RestoreHeadingsParams) error
+	RestoreLists(ctx context.Context, arg RestoreListsParams) error
+	RestoreTaskTags(ctx context.Context, taskTags []byte) error
+	RestoreTasks(ctx context.Context, arg RestoreTasksParams) error
+	SaveIdempotentResponse(ctx context.Context, arg SaveIdempotentResponseParams) error
 	SaveSession(ctx context.Context, arg SaveSessionParams) error
 	SetDeletedUserAtNull(ctx context.Context, email string) error
+	SnoozeTask(ctx context.Context, arg SnoozeTaskParams) (int64, error)
+	UnassignNonMemberTasks(ctx context.Context, listID string) error
+	UnlinkListFromGoal(ctx context.Context, arg UnlinkListFromGoalParams) (int64, error)
+	UnlinkListsFromGoal(ctx context.Context, arg UnlinkListsFromGoalParams) error
 	UnlinkTagFromTask(ctx context.Context, arg UnlinkTagFromTaskParams) error
+	UnlinkTaskFromGoal(ctx context.Context, arg UnlinkTaskFromGoalParams) (int64, error)
+	UnlinkTasksFromGoal(ctx context.Context, arg UnlinkTasksFromGoalParams) error
+	UnsnoozeTask(ctx context.Context, arg UnsnoozeTaskParams) (int64, error)
+	UpdateArea(ctx context.Context, arg UpdateAreaParams) (int64, error)
+	UpdateAreaPosition(ctx context.Context, arg UpdateAreaPositionParams) (int64, error)
+	UpdateGoal(ctx context.Context, arg UpdateGoalParams) (int64, error)
+	UpdateHabit(ctx context.Context, arg UpdateHabitParams) (int64, error)
 	UpdateHeading(ctx context.Context, arg UpdateHeadingParams) error
+	UpdateKeyResult(ctx context.Context, arg UpdateKeyResultParams) (int64, error)
 	UpdateLatestLoginAt(ctx context.Context, arg UpdateLatestLoginAtParams) error
 	UpdateList(ctx context.Context, arg UpdateListParams) error
+	UpdateListInvitationStatus(ctx context.Context, arg UpdateListInvitationStatusParams) (int64, error)
+	UpdateListMemberRole(ctx context.Context, arg UpdateListMemberRoleParams) (int64, error)
+	UpdateListPosition(ctx context.Context, arg UpdateListPositionParams) (int64, error)
+	UpdateListStatus(ctx context.Context, arg UpdateListStatusParams) (int64, error)
+	UpdateReviewItemAction(ctx context.Context, arg UpdateReviewItemActionParams) (int64, error)
+	UpdateRule(ctx context.Context, arg UpdateRuleParams) (int64, error)
+	UpdateTaskComment(ctx context.Context, arg UpdateTaskCommentParams) (int64, error)
 	UpdateTasksListID(ctx context.Context, arg UpdateTasksListIDParams) error
+	UpdateUserTimeZone(ctx context.Context, arg UpdateUserTimeZoneParams) (int64, error)
+	UpdateWebhook(ctx context.Context, arg UpdateWebhookParams) (int64, error)
+	UpdateWebhookDelivery(ctx context.Context, arg UpdateWebhookDeliveryParams) error
 }
 
 var _ Querier = (*Queries)(nil)
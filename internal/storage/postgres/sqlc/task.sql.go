@@ -13,6 +13,37 @@ import (
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
+const assignTask = `-- name: AssignTask :execrows
+UPDATE tasks
+SET assignee_id = $1,
+    updated_at = $2
+WHERE id = $3
+  AND list_id IN (SELECT list_id
+                  FROM list_editors_view
+                  WHERE user_id = $4)
+  AND deleted_at IS NULL
+`
+
+type AssignTaskParams struct {
+	AssigneeID pgtype.Text `db:"assignee_id"`
+	UpdatedAt  time.Time   `db:"updated_at"`
+	ID         string      `db:"id"`
+	UserID     string      `db:"user_id"`
+}
+
+func (q *Queries) AssignTask(ctx context.Context, arg AssignTaskParams) (int64, error) {
+	result, err := q.db.Exec(ctx, assignTask,
+		arg.AssigneeID,
+		arg.UpdatedAt,
+		arg.ID,
+		arg.UserID,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
 const createTask = `-- name: CreateTask :exec
 INSERT INTO tasks (
     id,
@@ -65,6 +96,109 @@ func (q *Queries) CreateTask(ctx context.Context, arg CreateTaskParams) error {
 	return err
 }
 
+const getAllTasksByListID = `-- name: GetAllTasksByListID :many
+SELECT
+    t.id,
+    t.title,
+    t.description,
+    t.start_date,
+    t.deadline,
+    t.start_time,
+    t.end_time,
+    t.status_id,
+    t.list_id,
+    t.heading_id,
+    t.assignee_id,
+    t.user_id,
+    t.updated_at,
+    ttv.tags as tags,
+    CASE
+        WHEN t.deadline <= CURRENT_DATE THEN TRUE
+        ELSE FALSE END
+        AS overdue
+FROM tasks t
+    LEFT JOIN task_tags_view ttv
+        ON t.id = ttv.task_id
+WHERE t.list_id = $1
+  AND t.list_id IN (SELECT list_id
+                    FROM list_members
+                    WHERE user_id = $2)
+  AND t.deleted_at IS NULL
+GROUP BY
+    t.id,
+    t.title,
+    t.description,
+    t.start_date,
+    t.deadline,
+    t.start_time,
+    t.end_time,
+    t.status_id,
+    t.heading_id,
+    t.assignee_id,
+    overdue,
+    t.updated_at
+ORDER BY t.id
+`
+
+type GetAllTasksByListIDParams struct {
+	ListID string `db:"list_id"`
+	UserID string `db:"user_id"`
+}
+
+type GetAllTasksByListIDRow struct {
+	ID          string             `db:"id"`
+	Title       string             `db:"title"`
+	Description pgtype.Text        `db:"description"`
+	StartDate   pgtype.Timestamptz `db:"start_date"`
+	Deadline    pgtype.Timestamptz `db:"deadline"`
+	StartTime   sql.NullTime       `db:"start_time"`
+	EndTime     sql.NullTime       `db:"end_time"`
+	StatusID    int32              `db:"status_id"`
+	ListID      string             `db:"list_id"`
+	HeadingID   string             `db:"heading_id"`
+	AssigneeID  pgtype.Text        `db:"assignee_id"`
+	UserID      string             `db:"user_id"`
+	UpdatedAt   time.Time          `db:"updated_at"`
+	Tags        interface{}        `db:"tags"`
+	Overdue     bool               `db:"overdue"`
+}
+
+func (q *Queries) GetAllTasksByListID(ctx context.Context, arg GetAllTasksByListIDParams) ([]GetAllTasksByListIDRow, error) {
+	rows, err := q.db.Query(ctx, getAllTasksByListID, arg.ListID, arg.UserID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []GetAllTasksByListIDRow{}
+	for rows.Next() {
+		var i GetAllTasksByListIDRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.Title,
+			&i.Description,
+			&i.StartDate,
+			&i.Deadline,
+			&i.StartTime,
+			&i.EndTime,
+			&i.StatusID,
+			&i.ListID,
+			&i.HeadingID,
+			&i.AssigneeID,
+			&i.UserID,
+			&i.UpdatedAt,
+			&i.Tags,
+			&i.Overdue,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const getArchivedTasks = `-- name: GetArchivedTasks :many
 SELECT
     DATE_TRUNC('month', t.updated_at) AS month,
@@ -103,7 +237,9 @@ FROM (
     FROM tasks t
         LEFT JOIN task_tags_view ttv
             ON t.id = ttv.task_id
-    WHERE t.user_id = $1
+    WHERE t.list_id IN (SELECT list_id
+                        FROM list_members
+                        WHERE user_id = $1)
       AND t.status_id = (
         SELECT id
         FROM statuses
@@ -167,6 +303,108 @@ func (q *Queries) GetArchivedTasks(ctx context.Context, arg GetArchivedTasksPara
 	return items, nil
 }
 
+const getAssignedTasks = `-- name: GetAssignedTasks :many
+SELECT
+    t.id,
+    t.title,
+    t.description,
+    t.start_date,
+    t.deadline,
+    t.start_time,
+    t.end_time,
+    t.status_id,
+    t.list_id,
+    t.heading_id,
+    t.updated_at,
+    ttv.tags as tags,
+    CASE
+        WHEN t.deadline <= CURRENT_DATE THEN TRUE
+        ELSE FALSE END
+      AS overdue
+FROM tasks t
+    LEFT JOIN task_tags_view ttv
+        ON t.id = ttv.task_id
+WHERE t.assignee_id = $1
+  AND t.list_id IN (SELECT list_id
+                    FROM list_members
+                    WHERE user_id = $1)
+  AND t.deleted_at IS NULL
+  AND (
+      ($3::varchar IS NULL AND t.id > $3::varchar)
+          OR ($3::varchar IS NOT NULL AND t.id > $3::varchar)
+      )
+GROUP BY
+    t.id,
+    t.title,
+    t.description,
+    t.start_date,
+    t.deadline,
+    t.start_time,
+    t.end_time,
+    t.status_id,
+    t.list_id,
+    t.heading_id,
+    t.updated_at
+ORDER BY t.id
+LIMIT $2
+`
+
+type GetAssignedTasksParams struct {
+	AssigneeID pgtype.Text `db:"assignee_id"`
+	Limit      int32       `db:"limit"`
+	AfterID    string      `db:"after_id"`
+}
+
+type GetAssignedTasksRow struct {
+	ID          string             `db:"id"`
+	Title       string             `db:"title"`
+	Description pgtype.Text        `db:"description"`
+	StartDate   pgtype.Timestamptz `db:"start_date"`
+	Deadline    pgtype.Timestamptz `db:"deadline"`
+	StartTime   sql.NullTime       `db:"start_time"`
+	EndTime     sql.NullTime       `db:"end_time"`
+	StatusID    int32              `db:"status_id"`
+	ListID      string             `db:"list_id"`
+	HeadingID   string             `db:"heading_id"`
+	UpdatedAt   time.Time          `db:"updated_at"`
+	Tags        interface{}        `db:"tags"`
+	Overdue     bool               `db:"overdue"`
+}
+
+func (q *Queries) GetAssignedTasks(ctx context.Context, arg GetAssignedTasksParams) ([]GetAssignedTasksRow, error) {
+	rows, err := q.db.Query(ctx, getAssignedTasks, arg.AssigneeID, arg.Limit, arg.AfterID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []GetAssignedTasksRow{}
+	for rows.Next() {
+		var i GetAssignedTasksRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.Title,
+			&i.Description,
+			&i.StartDate,
+			&i.Deadline,
+			&i.StartTime,
+			&i.EndTime,
+			&i.StatusID,
+			&i.ListID,
+			&i.HeadingID,
+			&i.UpdatedAt,
+			&i.Tags,
+			&i.Overdue,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const getCompletedTasks = `-- name: GetCompletedTasks :many
 SELECT
     DATE_TRUNC('month', t.updated_at) AS month,
@@ -203,7 +441,9 @@ FROM (
     FROM tasks t
         LEFT JOIN task_tags_view ttv
             ON t.id = ttv.task_id
-    WHERE t.user_id = $1
+    WHERE t.list_id IN (SELECT list_id
+                        FROM list_members
+                        WHERE user_id = $1)
       AND t.status_id = (
       SELECT id
       FROM statuses
@@ -265,6 +505,119 @@ func (q *Queries) GetCompletedTasks(ctx context.Context, arg GetCompletedTasksPa
 	return items, nil
 }
 
+const getDailyPlanCandidates = `-- name: GetDailyPlanCandidates :many
+SELECT
+    c.id,
+    c.title,
+    c.description,
+    c.start_date,
+    c.deadline,
+    c.start_time,
+    c.end_time,
+    c.status_id,
+    c.list_id,
+    c.heading_id,
+    c.assignee_id,
+    c.updated_at,
+    c.tags,
+    c.reason
+FROM (
+    SELECT
+        t.id,
+        t.title,
+        t.description,
+        t.start_date,
+        t.deadline,
+        t.start_time,
+        t.end_time,
+        t.status_id,
+        t.list_id,
+        t.heading_id,
+        t.assignee_id,
+        t.updated_at,
+        ttv.tags as tags,
+        CASE
+            WHEN t.deadline <= CURRENT_DATE THEN 'overdue'
+            WHEN t.start_date = CURRENT_DATE - 1 THEN 'unfinished'
+            WHEN t.deadline <= CURRENT_DATE + $1::int THEN 'due_soon'
+            WHEN t.start_date = CURRENT_DATE OR t.deferred_until::date = CURRENT_DATE THEN 'scheduled'
+            END::varchar AS reason
+    FROM tasks t
+        LEFT JOIN task_tags_view ttv
+            ON t.id = ttv.task_id
+    WHERE (t.assignee_id = $2
+               OR t.list_id IN (SELECT list_id
+                                FROM list_members
+                                WHERE user_id = $2
+                                  AND role = 'owner'))
+      AND t.status_id <> (SELECT s.id
+                          FROM statuses s
+                          WHERE s.title = $3)
+      AND (t.deferred_until IS NULL OR t.deferred_until::date <= CURRENT_DATE)
+      AND t.deleted_at IS NULL
+    ) c
+WHERE c.reason IS NOT NULL
+ORDER BY c.reason, c.deadline NULLS LAST, c.id
+`
+
+type GetDailyPlanCandidatesParams struct {
+	Days        int32  `db:"days"`
+	UserID      string `db:"user_id"`
+	StatusTitle string `db:"status_title"`
+}
+
+type GetDailyPlanCandidatesRow struct {
+	ID          string             `db:"id"`
+	Title       string             `db:"title"`
+	Description pgtype.Text        `db:"description"`
+	StartDate   pgtype.Timestamptz `db:"start_date"`
+	Deadline    pgtype.Timestamptz `db:"deadline"`
+	StartTime   sql.NullTime       `db:"start_time"`
+	EndTime     sql.NullTime       `db:"end_time"`
+	StatusID    int32              `db:"status_id"`
+	ListID      string             `db:"list_id"`
+	HeadingID   string             `db:"heading_id"`
+	AssigneeID  pgtype.Text        `db:"assignee_id"`
+	UpdatedAt   time.Time          `db:"updated_at"`
+	Tags        interface{}        `db:"tags"`
+	Reason      string             `db:"reason"`
+}
+
+func (q *Queries) GetDailyPlanCandidates(ctx context.Context, arg GetDailyPlanCandidatesParams) ([]GetDailyPlanCandidatesRow, error) {
+	rows, err := q.db.Query(ctx, getDailyPlanCandidates, arg.Days, arg.UserID, arg.StatusTitle)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []GetDailyPlanCandidatesRow{}
+	for rows.Next() {
+		var i GetDailyPlanCandidatesRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.Title,
+			&i.Description,
+			&i.StartDate,
+			&i.Deadline,
+			&i.StartTime,
+			&i.EndTime,
+			&i.StatusID,
+			&i.ListID,
+			&i.HeadingID,
+			&i.AssigneeID,
+			&i.UpdatedAt,
+			&i.Tags,
+			&i.Reason,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const getOverdueTasks = `-- name: GetOverdueTasks :many
 SELECT
     l.id AS list_id,
@@ -307,7 +660,9 @@ FROM lists l
         FROM tasks t
             LEFT JOIN task_tags_view ttv
                 ON t.id = ttv.task_id
-        WHERE t.user_id = $1
+        WHERE t.list_id IN (SELECT list_id
+                            FROM list_members
+                            WHERE user_id = $1)
           AND t.deadline <= CURRENT_DATE
           AND (t.deleted_at IS NULL OR l.id > $3::varchar)
         GROUP BY
@@ -322,7 +677,9 @@ FROM lists l
             t.user_id,
             t.updated_at
         ) t ON l.id = t.list_id
-WHERE l.user_id = $1
+WHERE l.id IN (SELECT list_id
+               FROM list_members
+               WHERE user_id = $1)
 GROUP BY l.id
 ORDER BY l.id
 LIMIT $2
@@ -371,6 +728,10 @@ SELECT
     t.status_id,
     t.list_id,
     t.heading_id,
+    t.assignee_id,
+    t.deferred_until,
+    t.snooze_count,
+    COALESCE(t.evening_date = CURRENT_DATE, FALSE) AS this_evening,
     t.updated_at,
     ttv.tags as tags,
     CASE
@@ -381,7 +742,9 @@ FROM tasks t
     LEFT JOIN task_tags_view ttv
         ON t.id = ttv.task_id
 WHERE t.id = $1
-  AND t.user_id = $2
+  AND t.list_id IN (SELECT list_id
+                    FROM list_members
+                    WHERE user_id = $2)
   AND t.deleted_at IS NULL
 `
 
@@ -391,19 +754,23 @@ type GetTaskByIDParams struct {
 }
 
 type GetTaskByIDRow struct {
-	ID          string             `db:"id"`
-	Title       string             `db:"title"`
-	Description pgtype.Text        `db:"description"`
-	StartDate   pgtype.Timestamptz `db:"start_date"`
-	Deadline    pgtype.Timestamptz `db:"deadline"`
-	StartTime   sql.NullTime       `db:"start_time"`
-	EndTime     sql.NullTime       `db:"end_time"`
-	StatusID    int32              `db:"status_id"`
-	ListID      string             `db:"list_id"`
-	HeadingID   string             `db:"heading_id"`
-	UpdatedAt   time.Time          `db:"updated_at"`
-	Tags        interface{}        `db:"tags"`
-	Overdue     bool               `db:"overdue"`
+	ID            string             `db:"id"`
+	Title         string             `db:"title"`
+	Description   pgtype.Text        `db:"description"`
+	StartDate     pgtype.Timestamptz `db:"start_date"`
+	Deadline      pgtype.Timestamptz `db:"deadline"`
+	StartTime     sql.NullTime       `db:"start_time"`
+	EndTime       sql.NullTime       `db:"end_time"`
+	StatusID      int32              `db:"status_id"`
+	ListID        string             `db:"list_id"`
+	HeadingID     string             `db:"heading_id"`
+	AssigneeID    pgtype.Text        `db:"assignee_id"`
+	DeferredUntil pgtype.Timestamptz `db:"deferred_until"`
+	SnoozeCount   int32              `db:"snooze_count"`
+	ThisEvening   bool               `db:"this_evening"`
+	UpdatedAt     time.Time          `db:"updated_at"`
+	Tags          interface{}        `db:"tags"`
+	Overdue       bool               `db:"overdue"`
 }
 
 func (q *Queries) GetTaskByID(ctx context.Context, arg GetTaskByIDParams) (GetTaskByIDRow, error) {
@@ -420,6 +787,10 @@ func (q *Queries) GetTaskByID(ctx context.Context, arg GetTaskByIDParams) (GetTa
 		&i.StatusID,
 		&i.ListID,
 		&i.HeadingID,
+		&i.AssigneeID,
+		&i.DeferredUntil,
+		&i.SnoozeCount,
+		&i.ThisEvening,
 		&i.UpdatedAt,
 		&i.Tags,
 		&i.Overdue,
@@ -452,6 +823,7 @@ SELECT
     t.status_id,
     t.list_id,
     t.heading_id,
+    t.assignee_id,
     t.user_id,
     t.updated_at,
     ttv.tags as tags,
@@ -463,8 +835,11 @@ FROM tasks t
     LEFT JOIN task_tags_view ttv
         ON t.id = ttv.task_id
 WHERE t.list_id = $1
-  AND t.user_id = $2
+  AND t.list_id IN (SELECT list_id
+                    FROM list_members
+                    WHERE user_id = $2)
   AND t.deleted_at IS NULL
+  AND (t.deferred_until IS NULL OR t.deferred_until <= now())
 GROUP BY
     t.id,
     t.title,
@@ -475,6 +850,7 @@ GROUP BY
     t.end_time,
     t.status_id,
     t.heading_id,
+    t.assignee_id,
     overdue,
     t.updated_at
 ORDER BY t.id
@@ -496,6 +872,7 @@ type GetTasksByListIDRow struct {
 	StatusID    int32              `db:"status_id"`
 	ListID      string             `db:"list_id"`
 	HeadingID   string             `db:"heading_id"`
+	AssigneeID  pgtype.Text        `db:"assignee_id"`
 	UserID      string             `db:"user_id"`
 	UpdatedAt   time.Time          `db:"updated_at"`
 	Tags        interface{}        `db:"tags"`
@@ -522,6 +899,7 @@ func (q *Queries) GetTasksByListID(ctx context.Context, arg GetTasksByListIDPara
 			&i.StatusID,
 			&i.ListID,
 			&i.HeadingID,
+			&i.AssigneeID,
 			&i.UserID,
 			&i.UpdatedAt,
 			&i.Tags,
@@ -558,7 +936,9 @@ SELECT
 FROM tasks t
     LEFT JOIN task_tags_view ttv
         ON t.id = ttv.task_id
-WHERE t.user_id = $1
+WHERE t.list_id IN (SELECT list_id
+                    FROM list_members
+                    WHERE user_id = $1)
   AND t.deleted_at IS NULL
   AND (
       ($3::varchar IS NULL AND t.id > $3::varchar)
@@ -676,9 +1056,12 @@ FROM lists l
         FROM tasks t
             LEFT JOIN task_tags_view ttv
                 ON t.id = ttv.task_id
-        WHERE t.user_id = $1
+        WHERE t.list_id IN (SELECT list_id
+                            FROM list_members
+                            WHERE user_id = $1)
           AND t.start_date IS NULL
           AND t.deadline > CURRENT_DATE
+          AND (t.deferred_until IS NULL OR t.deferred_until <= now())
           AND (t.deleted_at IS NULL OR l.id > $3::varchar)
         GROUP BY
             t.id,
@@ -691,7 +1074,9 @@ FROM lists l
             t.user_id,
             t.updated_at
         ) t ON l.id = t.list_id
-WHERE l.user_id = $1
+WHERE l.id IN (SELECT list_id
+               FROM list_members
+               WHERE user_id = $1)
 GROUP BY l.id
 ORDER BY l.id
 LIMIT $2
@@ -731,6 +1116,8 @@ func (q *Queries) GetTasksForSomeday(ctx context.Context, arg GetTasksForSomeday
 const getTasksForToday = `-- name: GetTasksForToday :many
 SELECT
     l.id AS list_id,
+    l.area_id,
+    a.title AS area_title,
     ARRAY_TO_JSON(
             ARRAY_AGG(
                     JSON_BUILD_OBJECT(
@@ -743,6 +1130,8 @@ SELECT
                             'end_time', t.end_time,
                             'list_id', t.list_id,
                             'user_id', t.user_id,
+                            'assignee_id', t.assignee_id,
+                            'this_evening', this_evening,
                             'tags', tags,
                             'overdue', overdue,
                             'updated_at', t.updated_at
@@ -761,6 +1150,8 @@ FROM lists l
             t.end_time,
             t.list_id,
             t.user_id,
+            t.assignee_id,
+            COALESCE(t.evening_date = CURRENT_DATE, FALSE) AS this_evening,
             ttv.tags as tags,
             CASE
                 WHEN t.deadline <= CURRENT_DATE THEN TRUE
@@ -770,9 +1161,14 @@ FROM lists l
         FROM tasks t
             LEFT JOIN task_tags_view ttv
                 ON t.id = ttv.task_id
-        WHERE t.user_id = $1
-          AND t.start_date = CURRENT_DATE
+        WHERE (t.assignee_id = $1
+                   OR t.list_id IN (SELECT list_id
+                                    FROM list_members
+                                    WHERE user_id = $1
+                                      AND role = 'owner'))
+          AND (t.start_date = CURRENT_DATE OR t.deferred_until::date = CURRENT_DATE)
           AND t.deleted_at IS NULL
+          AND (t.deferred_until IS NULL OR t.deferred_until <= now())
         GROUP BY
             t.id,
             t.title,
@@ -783,17 +1179,24 @@ FROM lists l
             t.end_time,
             t.list_id,
             t.user_id,
+            t.assignee_id,
             t.updated_at
         ) t
         ON l.id = t.list_id
-WHERE l.user_id = $1
-GROUP BY l.id
+    LEFT JOIN areas a
+        ON a.id = l.area_id
+WHERE l.id IN (SELECT list_id
+               FROM list_members
+               WHERE user_id = $1)
+GROUP BY l.id, a.id
 ORDER BY l.id
 `
 
 type GetTasksForTodayRow struct {
-	ListID string `db:"list_id"`
-	Tasks  []byte `db:"tasks"`
+	ListID    string      `db:"list_id"`
+	AreaID    pgtype.Text `db:"area_id"`
+	AreaTitle pgtype.Text `db:"area_title"`
+	Tasks     []byte      `db:"tasks"`
 }
 
 func (q *Queries) GetTasksForToday(ctx context.Context, userID string) ([]GetTasksForTodayRow, error) {
@@ -805,7 +1208,12 @@ func (q *Queries) GetTasksForToday(ctx context.Context, userID string) ([]GetTas
 	items := []GetTasksForTodayRow{}
 	for rows.Next() {
 		var i GetTasksForTodayRow
-		if err := rows.Scan(&i.ListID, &i.Tasks); err != nil {
+		if err := rows.Scan(
+			&i.ListID,
+			&i.AreaID,
+			&i.AreaTitle,
+			&i.Tasks,
+		); err != nil {
 			return nil, err
 		}
 		items = append(items, i)
@@ -859,8 +1267,11 @@ FROM headings h
             LEFT JOIN task_tags_view ttv
                 ON t.id = ttv.task_id
         WHERE t.list_id = $1
-          AND t.user_id = $2
+          AND t.list_id IN (SELECT list_id
+                            FROM list_members
+                            WHERE user_id = $2)
           AND t.deleted_at IS NULL
+          AND (t.deferred_until IS NULL OR t.deferred_until <= now())
         GROUP BY
             t.id,
             t.title,
@@ -875,7 +1286,9 @@ FROM headings h
     ) t
         ON h.id = t.heading_id
 WHERE h.list_id = $1
-  AND h.user_id = $2
+  AND h.list_id IN (SELECT list_id
+                    FROM list_members
+                    WHERE user_id = $2)
 GROUP BY h.id
 ORDER BY h.id
 `
@@ -924,7 +1337,9 @@ SELECT
                             'start_time', t.start_time,
                             'end_time', t.end_time,
                             'list_id', t.list_id,
+                            'area_id', t.area_id,
                             'user_id', t.user_id,
+                            'assignee_id', t.assignee_id,
                             'tags', tags,
                             'updated_at', t.updated_at
                     )
@@ -940,13 +1355,21 @@ FROM (
         t.start_time,
         t.end_time,
         t.list_id,
+        l.area_id,
         t.user_id,
+        t.assignee_id,
         ttv.tags as tags,
         t.updated_at
     FROM tasks t
+        JOIN lists l
+            ON l.id = t.list_id
         LEFT JOIN task_tags_view ttv
             ON t.id = ttv.task_id
-    WHERE t.user_id = $1
+    WHERE (t.assignee_id = $1
+               OR t.list_id IN (SELECT list_id
+                                FROM list_members
+                                WHERE user_id = $1
+                                  AND role = 'owner'))
       AND (
           (t.start_date >= COALESCE($3::timestamptz, CURRENT_DATE + interval '1 day'))
               AND (t.deleted_at IS NULL)
@@ -961,7 +1384,9 @@ FROM (
         t.start_time,
         t.end_time,
         t.list_id,
+        l.area_id,
         t.user_id,
+        t.assignee_id,
         t.updated_at
     ) t
 GROUP BY t.start_date
@@ -1004,7 +1429,9 @@ const markTaskAsArchived = `-- name: MarkTaskAsArchived :exec
 UPDATE tasks
 SET status_id = $1, deleted_at = $2
 WHERE id = $3
-  AND user_id = $4
+  AND list_id IN (SELECT list_id
+                  FROM list_editors_view
+                  WHERE user_id = $4)
   AND deleted_at IS NULL
 `
 
@@ -1030,7 +1457,9 @@ UPDATE tasks
 SET	status_id = $1,
     updated_at = $2
 WHERE id = $3
-  AND user_id = $4
+  AND list_id IN (SELECT list_id
+                  FROM list_editors_view
+                  WHERE user_id = $4)
   AND deleted_at IS NULL
 `
 
@@ -1057,7 +1486,9 @@ SET	list_id = $1,
     heading_id = $2,
     updated_at = $3
 WHERE id = $4
-  AND user_id = $5
+  AND list_id IN (SELECT list_id
+                  FROM list_editors_view
+                  WHERE user_id = $5)
   AND deleted_at IS NULL
 `
 
@@ -1079,3 +1510,142 @@ func (q *Queries) MoveTaskToAnotherList(ctx context.Context, arg MoveTaskToAnoth
 	)
 	return err
 }
+
+const patchTask = `-- name: PatchTask :exec
+UPDATE tasks
+SET title = $1,
+    description = $2,
+    start_date = $3,
+    deadline = $4,
+    start_time = $5,
+    end_time = $6,
+    list_id = $7,
+    heading_id = $8,
+    updated_at = $9
+WHERE id = $10
+  AND list_id IN (SELECT list_id
+                  FROM list_editors_view
+                  WHERE user_id = $11)
+  AND deleted_at IS NULL
+`
+
+type PatchTaskParams struct {
+	Title       string             `db:"title"`
+	Description pgtype.Text        `db:"description"`
+	StartDate   pgtype.Timestamptz `db:"start_date"`
+	Deadline    pgtype.Timestamptz `db:"deadline"`
+	StartTime   sql.NullTime       `db:"start_time"`
+	EndTime     sql.NullTime       `db:"end_time"`
+	ListID      string             `db:"list_id"`
+	HeadingID   string             `db:"heading_id"`
+	UpdatedAt   time.Time          `db:"updated_at"`
+	ID          string             `db:"id"`
+	UserID      string             `db:"user_id"`
+}
+
+func (q *Queries) PatchTask(ctx context.Context, arg PatchTaskParams) error {
+	_, err := q.db.Exec(ctx, patchTask,
+		arg.Title,
+		arg.Description,
+		arg.StartDate,
+		arg.Deadline,
+		arg.StartTime,
+		arg.EndTime,
+		arg.ListID,
+		arg.HeadingID,
+		arg.UpdatedAt,
+		arg.ID,
+		arg.UserID,
+	)
+	return err
+}
+
+const planTaskForToday = `-- name: PlanTaskForToday :execrows
+UPDATE tasks
+SET start_date = CURRENT_DATE,
+    evening_date = CASE WHEN $1::bool THEN CURRENT_DATE END,
+    deferred_until = NULL,
+    updated_at = $2
+WHERE id = $3
+  AND list_id IN (SELECT list_id
+                  FROM list_editors_view
+                  WHERE user_id = $4)
+  AND deleted_at IS NULL
+`
+
+type PlanTaskForTodayParams struct {
+	ThisEvening bool      `db:"this_evening"`
+	UpdatedAt   time.Time `db:"updated_at"`
+	ID          string    `db:"id"`
+	UserID      string    `db:"user_id"`
+}
+
+func (q *Queries) PlanTaskForToday(ctx context.Context, arg PlanTaskForTodayParams) (int64, error) {
+	result, err := q.db.Exec(ctx, planTaskForToday,
+		arg.ThisEvening,
+		arg.UpdatedAt,
+		arg.ID,
+		arg.UserID,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const snoozeTask = `-- name: SnoozeTask :execrows
+UPDATE tasks
+SET deferred_until = $1,
+    snooze_count = snooze_count + 1,
+    updated_at = $2
+WHERE id = $3
+  AND list_id IN (SELECT list_id
+                  FROM list_editors_view
+                  WHERE user_id = $4)
+  AND deleted_at IS NULL
+`
+
+type SnoozeTaskParams struct {
+	DeferredUntil pgtype.Timestamptz `db:"deferred_until"`
+	UpdatedAt     time.Time          `db:"updated_at"`
+	ID            string             `db:"id"`
+	UserID        string             `db:"user_id"`
+}
+
+func (q *Queries) SnoozeTask(ctx context.Context, arg SnoozeTaskParams) (int64, error) {
+	result, err := q.db.Exec(ctx, snoozeTask,
+		arg.DeferredUntil,
+		arg.UpdatedAt,
+		arg.ID,
+		arg.UserID,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const unsnoozeTask = `-- name: UnsnoozeTask :execrows
+UPDATE tasks
+SET deferred_until = NULL,
+    updated_at = $1
+WHERE id = $2
+  AND list_id IN (SELECT list_id
+                  FROM list_editors_view
+                  WHERE user_id = $3)
+  AND deleted_at IS NULL
+`
+
+type UnsnoozeTaskParams struct {
+	UpdatedAt time.Time `db:"updated_at"`
+	ID        string    `db:"id"`
+	UserID    string    `db:"user_id"`
+}
+
+func (q *Queries) UnsnoozeTask(ctx context.Context, arg UnsnoozeTaskParams) (int64, error) {
+	result, err := q.db.Exec(ctx, unsnoozeTask, arg.UpdatedAt, arg.ID, arg.UserID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
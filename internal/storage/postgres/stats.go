@@ -0,0 +1,137 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/rshelekhov/reframed/internal/model"
+	"github.com/rshelekhov/reframed/internal/storage/postgres/sqlc"
+)
+
+type StatsStorage struct {
+	*pgxpool.Pool
+	*sqlc.Queries
+}
+
+func NewStatsStorage(pool *pgxpool.Pool) *StatsStorage {
+	return &StatsStorage{
+		Pool:    pool,
+		Queries: sqlc.New(newDB(pool)),
+	}
+}
+
+func (s *StatsStorage) GetTaskThroughput(ctx context.Context, data model.StatsRequestData) ([]model.StatsThroughput, error) {
+	const op = "stats.storage.GetTaskThroughput"
+
+	items, err := s.Queries.GetTaskThroughput(ctx, sqlc.GetTaskThroughputParams{
+		UserID:         data.UserID,
+		ArchivedStatus: model.StatusArchived.String(),
+		Period:         data.Period.String(),
+		FromDate:       data.From,
+		ToDate:         data.To,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to get task throughput: %w", op, err)
+	}
+
+	throughput := make([]model.StatsThroughput, 0, len(items))
+
+	for _, item := range items {
+		throughput = append(throughput, model.StatsThroughput{
+			PeriodStart: item.PeriodStart.Time,
+			Created:     item.Created,
+			Completed:   item.Completed,
+		})
+	}
+	return throughput, nil
+}
+
+func (s *StatsStorage) GetCompletionRatesByList(ctx context.Context, data model.StatsRequestData) ([]model.StatsCompletionRate, error) {
+	const op = "stats.storage.GetCompletionRatesByList"
+
+	items, err := s.Queries.GetCompletionRatesByList(ctx, sqlc.GetCompletionRatesByListParams{
+		UserID:         data.UserID,
+		ArchivedStatus: model.StatusArchived.String(),
+		FromDate:       data.From,
+		ToDate:         data.To,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to get completion rates by list: %w", op, err)
+	}
+
+	rates := make([]model.StatsCompletionRate, 0, len(items))
+
+	for _, item := range items {
+		rates = append(rates, model.StatsCompletionRate{
+			ID:        item.ID,
+			Title:     item.Title,
+			Total:     item.Total,
+			Completed: item.Completed,
+		})
+	}
+	return rates, nil
+}
+
+func (s *StatsStorage) GetCompletionRatesByTag(ctx context.Context, data model.StatsRequestData) ([]model.StatsCompletionRate, error) {
+	const op = "stats.storage.GetCompletionRatesByTag"
+
+	items, err := s.Queries.GetCompletionRatesByTag(ctx, sqlc.GetCompletionRatesByTagParams{
+		UserID:         data.UserID,
+		ArchivedStatus: model.StatusArchived.String(),
+		FromDate:       data.From,
+		ToDate:         data.To,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to get completion rates by tag: %w", op, err)
+	}
+
+	rates := make([]model.StatsCompletionRate, 0, len(items))
+
+	for _, item := range items {
+		rates = append(rates, model.StatsCompletionRate{
+			ID:        item.ID,
+			Title:     item.Title,
+			Total:     item.Total,
+			Completed: item.Completed,
+		})
+	}
+	return rates, nil
+}
+
+func (s *StatsStorage) GetTaskSummaryStats(ctx context.Context, data model.StatsRequestData) (model.StatsSummary, error) {
+	const op = "stats.storage.GetTaskSummaryStats"
+
+	summary, err := s.Queries.GetTaskSummaryStats(ctx, sqlc.GetTaskSummaryStatsParams{
+		FromDate:       data.From,
+		ToDate:         data.To,
+		UserID:         data.UserID,
+		ArchivedStatus: model.StatusArchived.String(),
+	})
+	if err != nil {
+		return model.StatsSummary{}, fmt.Errorf("%s: failed to get task summary stats: %w", op, err)
+	}
+
+	return model.StatsSummary{
+		Created:            summary.Created,
+		Completed:          summary.Completed,
+		AvgLeadTimeSeconds: summary.AvgLeadTimeSeconds,
+		Due:                summary.Due,
+		Overdue:            summary.Overdue,
+	}, nil
+}
+
+func (s *StatsStorage) GetCompletionStreaks(ctx context.Context, userID string) (model.StatsStreaks, error) {
+	const op = "stats.storage.GetCompletionStreaks"
+
+	streaks, err := s.Queries.GetCompletionStreaks(ctx, userID)
+	if err != nil {
+		return model.StatsStreaks{}, fmt.Errorf("%s: failed to get completion streaks: %w", op, err)
+	}
+
+	return model.StatsStreaks{
+		Current: int(streaks.CurrentStreak),
+		Longest: int(streaks.LongestStreak),
+	}, nil
+}
@@ -21,7 +21,7 @@ type TagStorage struct {
 func NewTagStorage(pool *pgxpool.Pool) *TagStorage {
 	return &TagStorage{
 		Pool:    pool,
-		Queries: sqlc.New(pool),
+		Queries: sqlc.New(newDB(pool)),
 	}
 }
 
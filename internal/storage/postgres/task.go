@@ -2,6 +2,7 @@ package postgres
 
 import (
 	"context"
+	"database/sql"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -26,27 +27,13 @@ type TaskStorage struct {
 func NewTaskStorage(pool *pgxpool.Pool) port.TaskStorage {
 	return &TaskStorage{
 		Pool:    pool,
-		Queries: sqlc.New(pool),
+		Queries: sqlc.New(newDB(pool)),
 	}
 }
 
-func (s *TaskStorage) Transaction(ctx context.Context, fn func(storage port.TaskStorage) error) error {
-	tx, err := s.Pool.Begin(ctx)
-	if err != nil {
-		return err
-	}
-
-	defer func() {
-		if err != nil {
-			if rbErr := tx.Rollback(ctx); rbErr != nil {
-				err = fmt.Errorf("tx err: %v, rb err: %v", err, rbErr)
-			}
-		} else {
-			err = tx.Commit(ctx)
-		}
-	}()
-
-	return fn(s)
+// Transaction runs fn in a transaction, the storages called with the context passed to fn take part in it
+func (s *TaskStorage) Transaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	return transaction(ctx, s.Pool, fn)
 }
 
 // TODO: make all storage methods with custom struct instead of default types like this
@@ -116,13 +103,16 @@ func (s *TaskStorage) GetTaskByID(ctx context.Context, taskID, userID string) (m
 	}
 
 	taskResp := model.Task{
-		ID:        task.ID,
-		Title:     task.Title,
-		StatusID:  int(task.StatusID),
-		ListID:    task.ListID,
-		HeadingID: task.HeadingID,
-		UpdatedAt: task.UpdatedAt,
-		Overdue:   task.Overdue,
+		ID:          task.ID,
+		Title:       task.Title,
+		StatusID:    int(task.StatusID),
+		ListID:      task.ListID,
+		HeadingID:   task.HeadingID,
+		AssigneeID:  task.AssigneeID.String,
+		SnoozeCount: int(task.SnoozeCount),
+		ThisEvening: task.ThisEvening,
+		UpdatedAt:   task.UpdatedAt,
+		Overdue:     task.Overdue,
 	}
 	if task.Description.Valid {
 		taskResp.Description = task.Description.String
@@ -139,6 +129,9 @@ func (s *TaskStorage) GetTaskByID(ctx context.Context, taskID, userID string) (m
 	if task.EndTime.Valid {
 		taskResp.EndTime = task.EndTime.Time
 	}
+	if task.DeferredUntil.Valid {
+		taskResp.DeferredUntil = task.DeferredUntil.Time
+	}
 
 	if task.Tags != nil {
 		tagsArray, ok := task.Tags.([]interface{})
@@ -187,6 +180,43 @@ func (s *TaskStorage) GetTasksByUserID(ctx context.Context, userID string, pgn m
 	return tasksResp, nil
 }
 
+// GetAssignedTasks returns the tasks assigned to the user in the lists they are a member of
+func (s *TaskStorage) GetAssignedTasks(ctx context.Context, userID string, pgn model.Pagination) ([]model.Task, error) {
+	const op = "task.storage.GetAssignedTasks"
+
+	tasksRaw, err := s.Queries.GetAssignedTasks(ctx, sqlc.GetAssignedTasksParams{
+		AssigneeID: pgtype.Text{
+			String: userID,
+			Valid:  true,
+		},
+		AfterID: pgn.AfterID,
+		Limit:   pgn.Limit,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to get assigned tasks: %w", op, err)
+	}
+
+	var tasks []interface{}
+	for _, task := range tasksRaw {
+		tasks = append(tasks, task)
+	}
+
+	tasksResp, err := transformTasks(tasks)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(tasks) == 0 {
+		return nil, le.ErrNoTasksFound
+	}
+
+	for i := range tasksResp {
+		tasksResp[i].AssigneeID = userID
+	}
+
+	return tasksResp, nil
+}
+
 func (s *TaskStorage) GetTasksByListID(ctx context.Context, listID, userID string) ([]model.Task, error) {
 	const op = "task.storage.GetTasksByListID"
 
@@ -215,6 +245,35 @@ func (s *TaskStorage) GetTasksByListID(ctx context.Context, listID, userID strin
 	return tasksResp, nil
 }
 
+// GetAllTasksByListID returns the tasks of the list including the snoozed ones, for copying the list content
+func (s *TaskStorage) GetAllTasksByListID(ctx context.Context, listID, userID string) ([]model.Task, error) {
+	const op = "task.storage.GetAllTasksByListID"
+
+	tasksRaw, err := s.Queries.GetAllTasksByListID(ctx, sqlc.GetAllTasksByListIDParams{
+		ListID: listID,
+		UserID: userID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to get tasks: %w", op, err)
+	}
+
+	var tasks []interface{}
+	for _, task := range tasksRaw {
+		tasks = append(tasks, task)
+	}
+
+	tasksResp, err := transformTasks(tasks)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(tasks) == 0 {
+		return nil, le.ErrNoTasksFound
+	}
+
+	return tasksResp, nil
+}
+
 func transformTasks(tasks []interface{}) ([]model.Task, error) {
 	var tasksResp []model.Task
 
@@ -236,6 +295,12 @@ func transformTask(task interface{}) (model.Task, error) {
 		return transformGetTasksByUserIDRow(t)
 	case sqlc.GetTasksByListIDRow:
 		return transformGetTasksByListIDRow(t)
+	case sqlc.GetAllTasksByListIDRow:
+		return transformGetTasksByListIDRow(sqlc.GetTasksByListIDRow(t))
+	case sqlc.GetBlockedTasksRow:
+		return transformGetTasksByUserIDRow(sqlc.GetTasksByUserIDRow(t))
+	case sqlc.GetAssignedTasksRow:
+		return transformGetTasksByUserIDRow(sqlc.GetTasksByUserIDRow(t))
 	default:
 		return model.Task{}, errors.New("unsupported task type")
 	}
@@ -282,13 +347,14 @@ func transformGetTasksByUserIDRow(task sqlc.GetTasksByUserIDRow) (model.Task, er
 
 func transformGetTasksByListIDRow(task sqlc.GetTasksByListIDRow) (model.Task, error) {
 	t := model.Task{
-		ID:        task.ID,
-		Title:     task.Title,
-		StatusID:  int(task.StatusID),
-		ListID:    task.ListID,
-		HeadingID: task.HeadingID,
-		UpdatedAt: task.UpdatedAt,
-		Overdue:   task.Overdue,
+		ID:         task.ID,
+		Title:      task.Title,
+		StatusID:   int(task.StatusID),
+		ListID:     task.ListID,
+		HeadingID:  task.HeadingID,
+		AssigneeID: task.AssigneeID.String,
+		UpdatedAt:  task.UpdatedAt,
+		Overdue:    task.Overdue,
 	}
 
 	if task.Description.Valid {
@@ -394,6 +460,8 @@ func (s *TaskStorage) GetTasksForToday(ctx context.Context, userID string) ([]mo
 		}
 
 		taskGroup.ListID = group.ListID
+		taskGroup.AreaID = group.AreaID.String
+		taskGroup.AreaTitle = group.AreaTitle.String
 		taskGroup.Tasks = tasks
 
 		taskGroups = append(taskGroups, taskGroup)
@@ -402,6 +470,72 @@ func (s *TaskStorage) GetTasksForToday(ctx context.Context, userID string) ([]mo
 	return taskGroups, nil
 }
 
+// GetDailyPlanCandidates returns the open tasks proposed for the plan of the day with the reason each task is proposed.
+// The deadlines within the given number of days are proposed as coming soon
+func (s *TaskStorage) GetDailyPlanCandidates(ctx context.Context, userID string, days int) ([]model.DailyPlanCandidate, error) {
+	const op = "task.storage.GetDailyPlanCandidates"
+
+	items, err := s.Queries.GetDailyPlanCandidates(ctx, sqlc.GetDailyPlanCandidatesParams{
+		Days:        int32(days),
+		UserID:      userID,
+		StatusTitle: model.StatusCompleted.String(),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to get daily plan candidates: %w", op, err)
+	}
+	if len(items) == 0 {
+		return nil, le.ErrNoTasksFound
+	}
+
+	candidates := make([]model.DailyPlanCandidate, 0, len(items))
+
+	for _, item := range items {
+		task := model.Task{
+			ID:         item.ID,
+			Title:      item.Title,
+			StatusID:   int(item.StatusID),
+			ListID:     item.ListID,
+			HeadingID:  item.HeadingID,
+			AssigneeID: item.AssigneeID.String,
+			UpdatedAt:  item.UpdatedAt,
+		}
+
+		if item.Description.Valid {
+			task.Description = item.Description.String
+		}
+		if item.StartDate.Valid {
+			task.StartDate = item.StartDate.Time
+		}
+		if item.Deadline.Valid {
+			task.Deadline = item.Deadline.Time
+		}
+		if item.StartTime.Valid {
+			task.StartTime = item.StartTime.Time
+		}
+		if item.EndTime.Valid {
+			task.EndTime = item.EndTime.Time
+		}
+
+		if item.Tags != nil {
+			tags, err := transformTags(item.Tags)
+			if err != nil {
+				return nil, fmt.Errorf("%s: failed to transform tags: %w", op, err)
+			}
+
+			task.Tags = tags
+		}
+
+		task.Overdue = model.PlanReason(item.Reason) == model.PlanReasonOverdue
+
+		candidates = append(candidates, model.DailyPlanCandidate{
+			Task:   task,
+			Reason: model.PlanReason(item.Reason),
+		})
+	}
+
+	return candidates, nil
+}
+
 func (s *TaskStorage) GetUpcomingTasks(ctx context.Context, userID string, pgn model.Pagination) ([]model.TaskGroup, error) {
 	const op = "task.storage.GetUpcomingTasks"
 
@@ -646,12 +780,14 @@ func (s *TaskStorage) UpdateTask(ctx context.Context, task model.Task) error {
 			SELECT heading_id
 			FROM tasks
 			WHERE id = $1
-			  AND user_id = $2`
+			  AND list_id IN (SELECT list_id
+			                  FROM list_members
+			                  WHERE user_id = $2)`
 	)
 
 	var headingID string
 
-	err := s.QueryRow(ctx, queryGetHeadingID, task.ID, task.UserID).Scan(&headingID)
+	err := conn(ctx, s.Pool).QueryRow(ctx, queryGetHeadingID, task.ID, task.UserID).Scan(&headingID)
 	if err != nil {
 		return fmt.Errorf("%s: failed to get heading ID: %w", op, err)
 	}
@@ -682,15 +818,15 @@ func (s *TaskStorage) UpdateTask(ctx context.Context, task model.Task) error {
 		queryParams = append(queryParams, task.HeadingID)
 	}
 
-	// Add condition for the specific user ID
+	// Add condition for the specific task in the lists the user can edit
 	queryUpdate += " WHERE id = $" + strconv.Itoa(len(queryParams)+1)
 	queryParams = append(queryParams, task.ID)
 
-	queryUpdate += " AND user_id = $" + strconv.Itoa(len(queryParams)+1)
+	queryUpdate += " AND list_id IN (SELECT list_id FROM list_editors_view WHERE user_id = $" + strconv.Itoa(len(queryParams)+1) + ")"
 	queryParams = append(queryParams, task.UserID)
 
 	// Execute the update query
-	result, err := s.Exec(ctx, queryUpdate, queryParams...)
+	result, err := conn(ctx, s.Pool).Exec(ctx, queryUpdate, queryParams...)
 	if err != nil {
 		return fmt.Errorf("%s: failed to update task: %w", op, err)
 	}
@@ -702,6 +838,55 @@ func (s *TaskStorage) UpdateTask(ctx context.Context, task model.Task) error {
 	return nil
 }
 
+// PatchTask writes all editable fields of the task, empty values are stored as NULL
+func (s *TaskStorage) PatchTask(ctx context.Context, task model.Task) error {
+	const op = "task.storage.PatchTask"
+
+	taskParams := sqlc.PatchTaskParams{
+		Title:     task.Title,
+		ListID:    task.ListID,
+		HeadingID: task.HeadingID,
+		UpdatedAt: task.UpdatedAt,
+		ID:        task.ID,
+		UserID:    task.UserID,
+	}
+	if task.Description != "" {
+		taskParams.Description = pgtype.Text{
+			String: task.Description,
+			Valid:  true,
+		}
+	}
+	if !task.StartDate.IsZero() {
+		taskParams.StartDate = pgtype.Timestamptz{
+			Time:  task.StartDate,
+			Valid: true,
+		}
+	}
+	if !task.Deadline.IsZero() {
+		taskParams.Deadline = pgtype.Timestamptz{
+			Time:  task.Deadline,
+			Valid: true,
+		}
+	}
+	if !task.StartTime.IsZero() {
+		taskParams.StartTime = sql.NullTime{
+			Time:  task.StartTime,
+			Valid: true,
+		}
+	}
+	if !task.EndTime.IsZero() {
+		taskParams.EndTime = sql.NullTime{
+			Time:  task.EndTime,
+			Valid: true,
+		}
+	}
+
+	if err := s.Queries.PatchTask(ctx, taskParams); err != nil {
+		return fmt.Errorf("%s: failed to patch task: %w", op, err)
+	}
+	return nil
+}
+
 func (s *TaskStorage) UpdateTaskTime(ctx context.Context, task model.Task) error {
 	const op = "task.storage.UpdateTaskTime"
 
@@ -727,15 +912,15 @@ func (s *TaskStorage) UpdateTaskTime(ctx context.Context, task model.Task) error
 	queryUpdate += ", status_id = $" + strconv.Itoa(len(queryParams)+1)
 	queryParams = append(queryParams, statusID)
 
-	// Add condition for the specific user ID
+	// Add condition for the specific task in the lists the user can edit
 	queryUpdate += " WHERE id = $" + strconv.Itoa(len(queryParams)+1)
 	queryParams = append(queryParams, task.ID)
 
-	queryUpdate += " AND user_id = $" + strconv.Itoa(len(queryParams)+1)
+	queryUpdate += " AND list_id IN (SELECT list_id FROM list_editors_view WHERE user_id = $" + strconv.Itoa(len(queryParams)+1) + ")"
 	queryParams = append(queryParams, task.UserID)
 
 	// Execute the update query
-	result, err := s.Exec(ctx, queryUpdate, queryParams...)
+	result, err := conn(ctx, s.Pool).Exec(ctx, queryUpdate, queryParams...)
 	if err != nil {
 		return fmt.Errorf("%s: failed to update task: %w", op, err)
 	}
@@ -762,6 +947,88 @@ func (s *TaskStorage) MoveTaskToAnotherList(ctx context.Context, task model.Task
 	return nil
 }
 
+// AssignTask sets the assignee of the task, an empty assignee ID clears it
+func (s *TaskStorage) AssignTask(ctx context.Context, task model.Task) error {
+	const op = "task.storage.AssignTask"
+
+	rows, err := s.Queries.AssignTask(ctx, sqlc.AssignTaskParams{
+		AssigneeID: pgtype.Text{
+			String: task.AssigneeID,
+			Valid:  task.AssigneeID != "",
+		},
+		UpdatedAt: task.UpdatedAt,
+		ID:        task.ID,
+		UserID:    task.UserID,
+	})
+	if err != nil {
+		return fmt.Errorf("%s: failed to assign task: %w", op, err)
+	}
+	if rows == 0 {
+		return le.ErrTaskNotFound
+	}
+	return nil
+}
+
+// PlanTaskForToday starts the task today, in the evening section if ThisEvening is set.
+// The snooze of the task is cleared
+func (s *TaskStorage) PlanTaskForToday(ctx context.Context, task model.Task) error {
+	const op = "task.storage.PlanTaskForToday"
+
+	rows, err := s.Queries.PlanTaskForToday(ctx, sqlc.PlanTaskForTodayParams{
+		ThisEvening: task.ThisEvening,
+		UpdatedAt:   task.UpdatedAt,
+		ID:          task.ID,
+		UserID:      task.UserID,
+	})
+	if err != nil {
+		return fmt.Errorf("%s: failed to plan task for today: %w", op, err)
+	}
+	if rows == 0 {
+		return le.ErrTaskNotFound
+	}
+	return nil
+}
+
+// SnoozeTask hides the task until its DeferredUntil and counts the snooze
+func (s *TaskStorage) SnoozeTask(ctx context.Context, task model.Task) error {
+	const op = "task.storage.SnoozeTask"
+
+	rows, err := s.Queries.SnoozeTask(ctx, sqlc.SnoozeTaskParams{
+		DeferredUntil: pgtype.Timestamptz{
+			Time:  task.DeferredUntil,
+			Valid: true,
+		},
+		UpdatedAt: task.UpdatedAt,
+		ID:        task.ID,
+		UserID:    task.UserID,
+	})
+	if err != nil {
+		return fmt.Errorf("%s: failed to snooze task: %w", op, err)
+	}
+	if rows == 0 {
+		return le.ErrTaskNotFound
+	}
+	return nil
+}
+
+// UnsnoozeTask brings the snoozed task back to the views, the snooze count is kept
+func (s *TaskStorage) UnsnoozeTask(ctx context.Context, task model.Task) error {
+	const op = "task.storage.UnsnoozeTask"
+
+	rows, err := s.Queries.UnsnoozeTask(ctx, sqlc.UnsnoozeTaskParams{
+		UpdatedAt: task.UpdatedAt,
+		ID:        task.ID,
+		UserID:    task.UserID,
+	})
+	if err != nil {
+		return fmt.Errorf("%s: failed to unsnooze task: %w", op, err)
+	}
+	if rows == 0 {
+		return le.ErrTaskNotFound
+	}
+	return nil
+}
+
 func (s *TaskStorage) MarkAsCompleted(ctx context.Context, task model.Task) error {
 	const op = "task.storage.MarkAsCompleted"
 
@@ -792,3 +1059,166 @@ func (s *TaskStorage) MarkAsArchived(ctx context.Context, task model.Task) error
 	}
 	return nil
 }
+
+// LockTaskDependencies serializes the changes of the task dependencies until the end of the transaction,
+// so concurrent changes can't create a cycle. The lock is shared by all users, because the editors
+// of the shared lists link the tasks of each other and a cycle can go through any number of lists
+func (s *TaskStorage) LockTaskDependencies(ctx context.Context) error {
+	const op = "task.storage.LockTaskDependencies"
+
+	if err := s.Queries.LockTaskDependencies(ctx); err != nil {
+		return fmt.Errorf("%s: failed to lock task dependencies: %w", op, err)
+	}
+	return nil
+}
+
+func (s *TaskStorage) CreateTaskDependency(ctx context.Context, dependency model.TaskDependency) error {
+	const op = "task.storage.CreateTaskDependency"
+
+	rows, err := s.Queries.CreateTaskDependency(ctx, sqlc.CreateTaskDependencyParams{
+		TaskID:          dependency.TaskID,
+		BlockedByTaskID: dependency.BlockedByTaskID,
+		UserID:          dependency.UserID,
+		CreatedAt:       dependency.CreatedAt,
+	})
+	if err != nil {
+		return fmt.Errorf("%s: failed to create task dependency: %w", op, err)
+	}
+	if rows == 0 {
+		return le.ErrTaskDependencyAlreadyExists
+	}
+	return nil
+}
+
+func (s *TaskStorage) DeleteTaskDependency(ctx context.Context, dependency model.TaskDependency) error {
+	const op = "task.storage.DeleteTaskDependency"
+
+	rows, err := s.Queries.DeleteTaskDependency(ctx, sqlc.DeleteTaskDependencyParams{
+		TaskID:          dependency.TaskID,
+		BlockedByTaskID: dependency.BlockedByTaskID,
+		UserID:          dependency.UserID,
+	})
+	if err != nil {
+		return fmt.Errorf("%s: failed to delete task dependency: %w", op, err)
+	}
+	if rows == 0 {
+		return le.ErrTaskDependencyNotFound
+	}
+	return nil
+}
+
+// HasTaskDependencyPath reports whether the task fromTaskID is blocked by the task toTaskID,
+// directly or through the other tasks
+func (s *TaskStorage) HasTaskDependencyPath(ctx context.Context, fromTaskID, toTaskID string) (bool, error) {
+	const op = "task.storage.HasTaskDependencyPath"
+
+	hasPath, err := s.Queries.HasTaskDependencyPath(ctx, sqlc.HasTaskDependencyPathParams{
+		FromTaskID: fromTaskID,
+		ToTaskID:   toTaskID,
+	})
+	if err != nil {
+		return false, fmt.Errorf("%s: failed to check task dependency path: %w", op, err)
+	}
+	return hasPath, nil
+}
+
+func (s *TaskStorage) GetTaskBlockers(ctx context.Context, taskID, userID string) ([]model.Task, error) {
+	const op = "task.storage.GetTaskBlockers"
+
+	items, err := s.Queries.GetTaskBlockers(ctx, sqlc.GetTaskBlockersParams{
+		TaskID: taskID,
+		UserID: userID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to get task blockers: %w", op, err)
+	}
+	if len(items) == 0 {
+		return nil, le.ErrNoTaskDependenciesFound
+	}
+
+	var tasks []model.Task
+
+	for _, item := range items {
+		task := model.Task{
+			ID:        item.ID,
+			Title:     item.Title,
+			StatusID:  int(item.StatusID),
+			ListID:    item.ListID,
+			HeadingID: item.HeadingID,
+			UserID:    userID,
+			UpdatedAt: item.UpdatedAt,
+		}
+
+		if item.Description.Valid {
+			task.Description = item.Description.String
+		}
+		if item.StartDate.Valid {
+			task.StartDate = item.StartDate.Time
+		}
+		if item.Deadline.Valid {
+			task.Deadline = item.Deadline.Time
+		}
+		if item.StartTime.Valid {
+			task.StartTime = item.StartTime.Time
+		}
+		if item.EndTime.Valid {
+			task.EndTime = item.EndTime.Time
+		}
+
+		tasks = append(tasks, task)
+	}
+	return tasks, nil
+}
+
+// GetDependentTaskIDs returns the IDs of the tasks blocked by the task
+func (s *TaskStorage) GetDependentTaskIDs(ctx context.Context, taskID, userID string) ([]string, error) {
+	const op = "task.storage.GetDependentTaskIDs"
+
+	taskIDs, err := s.Queries.GetDependentTaskIDs(ctx, sqlc.GetDependentTaskIDsParams{
+		BlockedByTaskID: taskID,
+		UserID:          userID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to get dependent tasks: %w", op, err)
+	}
+	return taskIDs, nil
+}
+
+// GetBlockedTaskIDs returns the IDs of the given tasks blocked by at least one task which isn't completed
+func (s *TaskStorage) GetBlockedTaskIDs(ctx context.Context, userID string, taskIDs []string) ([]string, error) {
+	const op = "task.storage.GetBlockedTaskIDs"
+
+	blockedIDs, err := s.Queries.GetBlockedTaskIDs(ctx, sqlc.GetBlockedTaskIDsParams{
+		TaskIds:              taskIDs,
+		UserID:               userID,
+		CompletedStatusTitle: model.StatusCompleted.String(),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to get blocked tasks: %w", op, err)
+	}
+	return blockedIDs, nil
+}
+
+func (s *TaskStorage) GetBlockedTasks(ctx context.Context, userID string, pgn model.Pagination) ([]model.Task, error) {
+	const op = "task.storage.GetBlockedTasks"
+
+	tasksRaw, err := s.Queries.GetBlockedTasks(ctx, sqlc.GetBlockedTasksParams{
+		UserID:               userID,
+		AfterID:              pgn.AfterID,
+		CompletedStatusTitle: model.StatusCompleted.String(),
+		LimitCount:           pgn.Limit,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to get blocked tasks: %w", op, err)
+	}
+	if len(tasksRaw) == 0 {
+		return nil, le.ErrNoTasksFound
+	}
+
+	var tasks []interface{}
+	for _, task := range tasksRaw {
+		tasks = append(tasks, task)
+	}
+
+	return transformTasks(tasks)
+}
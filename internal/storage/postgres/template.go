@@ -0,0 +1,149 @@
+package postgres
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/rshelekhov/reframed/internal/lib/constants/le"
+	"github.com/rshelekhov/reframed/internal/model"
+	"github.com/rshelekhov/reframed/internal/storage/postgres/sqlc"
+)
+
+type TemplateStorage struct {
+	*pgxpool.Pool
+	*sqlc.Queries
+}
+
+func NewTemplateStorage(pool *pgxpool.Pool) *TemplateStorage {
+	return &TemplateStorage{
+		Pool:    pool,
+		Queries: sqlc.New(newDB(pool)),
+	}
+}
+
+// Transaction runs fn in a transaction, the storages called with the context passed to fn take part in it
+func (s *TemplateStorage) Transaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	return transaction(ctx, s.Pool, fn)
+}
+
+func (s *TemplateStorage) CreateTemplate(ctx context.Context, template model.Template) error {
+	const op = "template.storage.CreateTemplate"
+
+	content, err := json.Marshal(template.Content)
+	if err != nil {
+		return fmt.Errorf("%s: failed to marshal template content: %w", op, err)
+	}
+
+	if err = s.Queries.CreateTemplate(ctx, sqlc.CreateTemplateParams{
+		ID:        template.ID,
+		Title:     template.Title,
+		Kind:      template.Kind.String(),
+		Content:   content,
+		UserID:    template.UserID,
+		UpdatedAt: template.UpdatedAt,
+	}); err != nil {
+		return fmt.Errorf("%s: failed to create template: %w", op, err)
+	}
+	return nil
+}
+
+func (s *TemplateStorage) GetTemplateByID(ctx context.Context, templateID, userID string) (model.Template, error) {
+	const op = "template.storage.GetTemplateByID"
+
+	template, err := s.Queries.GetTemplateByID(ctx, sqlc.GetTemplateByIDParams{
+		ID:     templateID,
+		UserID: userID,
+	})
+	if errors.Is(err, pgx.ErrNoRows) {
+		return model.Template{}, le.ErrTemplateNotFound
+	}
+	if err != nil {
+		return model.Template{}, fmt.Errorf("%s: failed to get template: %w", op, err)
+	}
+
+	result, err := mapTemplateRowToModel(sqlc.Template{
+		ID:        template.ID,
+		Title:     template.Title,
+		Kind:      template.Kind,
+		Content:   template.Content,
+		UserID:    template.UserID,
+		UpdatedAt: template.UpdatedAt,
+	})
+	if err != nil {
+		return model.Template{}, fmt.Errorf("%s: %w", op, err)
+	}
+	return result, nil
+}
+
+func (s *TemplateStorage) GetTemplatesByUserID(ctx context.Context, userID string) ([]model.Template, error) {
+	const op = "template.storage.GetTemplatesByUserID"
+
+	items, err := s.Queries.GetTemplatesByUserID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to get templates: %w", op, err)
+	}
+	if len(items) == 0 {
+		return nil, le.ErrNoTemplatesFound
+	}
+
+	var templates []model.Template
+
+	for _, item := range items {
+		template, err := mapTemplateRowToModel(sqlc.Template{
+			ID:        item.ID,
+			Title:     item.Title,
+			Kind:      item.Kind,
+			Content:   item.Content,
+			UserID:    item.UserID,
+			UpdatedAt: item.UpdatedAt,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+
+		templates = append(templates, template)
+	}
+	return templates, nil
+}
+
+func mapTemplateRowToModel(template sqlc.Template) (model.Template, error) {
+	result := model.Template{
+		ID:        template.ID,
+		Title:     template.Title,
+		Kind:      model.TemplateKind(template.Kind),
+		UserID:    template.UserID,
+		UpdatedAt: template.UpdatedAt,
+	}
+
+	if err := json.Unmarshal(template.Content, &result.Content); err != nil {
+		return model.Template{}, fmt.Errorf("failed to unmarshal template content: %w", err)
+	}
+
+	return result, nil
+}
+
+func (s *TemplateStorage) DeleteTemplate(ctx context.Context, template model.Template) error {
+	const op = "template.storage.DeleteTemplate"
+
+	rows, err := s.Queries.DeleteTemplate(ctx, sqlc.DeleteTemplateParams{
+		DeletedAt: pgtype.Timestamptz{
+			Time:  template.DeletedAt,
+			Valid: true,
+		},
+		ID:     template.ID,
+		UserID: template.UserID,
+	})
+	if err != nil {
+		return fmt.Errorf("%s: failed to delete template: %w", op, err)
+	}
+	if rows == 0 {
+		return le.ErrTemplateNotFound
+	}
+	return nil
+}
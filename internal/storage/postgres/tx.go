@@ -0,0 +1,82 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/rshelekhov/reframed/internal/lib/aftercommit"
+	"github.com/rshelekhov/reframed/internal/storage/postgres/sqlc"
+)
+
+type txKey struct{}
+
+// transaction runs fn in a database transaction. The transaction is passed to fn in the context,
+// so every storage called with this context takes part in it. Nested calls join the outer transaction.
+// The hooks registered with aftercommit.Do are run once the outermost transaction is committed
+func transaction(ctx context.Context, pool *pgxpool.Pool, fn func(ctx context.Context) error) (err error) {
+	if _, ok := ctx.Value(txKey{}).(pgx.Tx); ok {
+		return fn(ctx)
+	}
+
+	tx, err := pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+
+	defer func() {
+		if p := recover(); p != nil {
+			_ = tx.Rollback(ctx)
+			panic(p)
+		}
+	}()
+
+	txCtx, runHooks := aftercommit.Begin(ctx)
+
+	if err = fn(context.WithValue(txCtx, txKey{}, tx)); err != nil {
+		if rbErr := tx.Rollback(ctx); rbErr != nil {
+			return fmt.Errorf("tx err: %w, rb err: %v", err, rbErr)
+		}
+		return err
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return err
+	}
+
+	runHooks()
+
+	return nil
+}
+
+// conn returns the transaction from the context or the pool, if there is no transaction
+func conn(ctx context.Context, pool *pgxpool.Pool) sqlc.DBTX {
+	if tx, ok := ctx.Value(txKey{}).(pgx.Tx); ok {
+		return tx
+	}
+	return pool
+}
+
+// txAwareDB runs sqlc queries in the transaction from the context, if there is one
+type txAwareDB struct {
+	pool *pgxpool.Pool
+}
+
+func newDB(pool *pgxpool.Pool) sqlc.DBTX {
+	return txAwareDB{pool: pool}
+}
+
+func (db txAwareDB) Exec(ctx context.Context, sql string, args ...interface{}) (pgconn.CommandTag, error) {
+	return conn(ctx, db.pool).Exec(ctx, sql, args...)
+}
+
+func (db txAwareDB) Query(ctx context.Context, sql string, args ...interface{}) (pgx.Rows, error) {
+	return conn(ctx, db.pool).Query(ctx, sql, args...)
+}
+
+func (db txAwareDB) QueryRow(ctx context.Context, sql string, args ...interface{}) pgx.Row {
+	return conn(ctx, db.pool).QueryRow(ctx, sql, args...)
+}
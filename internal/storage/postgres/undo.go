@@ -0,0 +1,323 @@
+package postgres
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/rshelekhov/reframed/internal/lib/constants/le"
+	"github.com/rshelekhov/reframed/internal/model"
+	"github.com/rshelekhov/reframed/internal/storage/postgres/sqlc"
+)
+
+type UndoStorage struct {
+	*pgxpool.Pool
+	*sqlc.Queries
+}
+
+func NewUndoStorage(pool *pgxpool.Pool) *UndoStorage {
+	return &UndoStorage{
+		Pool:    pool,
+		Queries: sqlc.New(newDB(pool)),
+	}
+}
+
+// Transaction runs fn in a transaction, the storages called with the context passed to fn take part in it
+func (s *UndoStorage) Transaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	return transaction(ctx, s.Pool, fn)
+}
+
+// GetUndoSnapshot returns the current rows of the lists, headings and tasks the operation is going to change
+func (s *UndoStorage) GetUndoSnapshot(ctx context.Context, data model.UndoRecordData) (model.UndoSnapshot, error) {
+	const op = "undo.storage.GetUndoSnapshot"
+
+	snapshot := model.UndoSnapshot{
+		Created: data.Created,
+	}
+
+	var (
+		err            error
+		contentListIDs []string
+	)
+
+	if data.WithListContent {
+		contentListIDs = data.ListIDs
+	}
+
+	if len(data.ListIDs) > 0 {
+		snapshot.Lists, err = s.Queries.GetListSnapshots(ctx, data.ListIDs)
+		if err != nil {
+			return model.UndoSnapshot{}, fmt.Errorf("%s: failed to get lists: %w", op, err)
+		}
+	}
+
+	if len(data.HeadingIDs) > 0 || len(contentListIDs) > 0 {
+		snapshot.Headings, err = s.Queries.GetHeadingSnapshots(ctx, sqlc.GetHeadingSnapshotsParams{
+			Ids:     nonNilStrings(data.HeadingIDs),
+			ListIds: nonNilStrings(contentListIDs),
+		})
+		if err != nil {
+			return model.UndoSnapshot{}, fmt.Errorf("%s: failed to get headings: %w", op, err)
+		}
+	}
+
+	var headingIDs []string
+	if data.WithHeadingTasks {
+		headingIDs = data.HeadingIDs
+	}
+
+	if len(data.TaskIDs) > 0 || len(headingIDs) > 0 || len(contentListIDs) > 0 {
+		params := sqlc.GetTaskSnapshotsParams{
+			TaskIds:    nonNilStrings(data.TaskIDs),
+			HeadingIds: nonNilStrings(headingIDs),
+			ListIds:    nonNilStrings(contentListIDs),
+		}
+
+		snapshot.Tasks, err = s.Queries.GetTaskSnapshots(ctx, params)
+		if err != nil {
+			return model.UndoSnapshot{}, fmt.Errorf("%s: failed to get tasks: %w", op, err)
+		}
+
+		snapshot.TaskTags, err = s.Queries.GetTaskTagSnapshots(ctx, sqlc.GetTaskTagSnapshotsParams(params))
+		if err != nil {
+			return model.UndoSnapshot{}, fmt.Errorf("%s: failed to get task tags: %w", op, err)
+		}
+	}
+
+	return snapshot, nil
+}
+
+func (s *UndoStorage) CreateUndoOperation(ctx context.Context, operation model.UndoOperation) error {
+	const op = "undo.storage.CreateUndoOperation"
+
+	snapshot, err := json.Marshal(operation.Snapshot)
+	if err != nil {
+		return fmt.Errorf("%s: failed to marshal snapshot: %w", op, err)
+	}
+
+	if err = s.Queries.CreateUndoOperation(ctx, sqlc.CreateUndoOperationParams{
+		ID:         operation.ID,
+		UserID:     operation.UserID,
+		EntityType: operation.EntityType.String(),
+		EntityID:   operation.EntityID,
+		Action:     operation.Action.String(),
+		Snapshot:   snapshot,
+		CreatedAt:  operation.CreatedAt,
+		ExpiresAt:  operation.ExpiresAt,
+	}); err != nil {
+		return fmt.Errorf("%s: failed to create undo operation: %w", op, err)
+	}
+	return nil
+}
+
+// GetUndoOperation returns the operation made by the user and locks it until the end of the transaction
+func (s *UndoStorage) GetUndoOperation(ctx context.Context, id, userID string) (model.UndoOperation, error) {
+	const op = "undo.storage.GetUndoOperation"
+
+	item, err := s.Queries.GetUndoOperation(ctx, sqlc.GetUndoOperationParams{
+		ID:     id,
+		UserID: userID,
+	})
+	if errors.Is(err, pgx.ErrNoRows) {
+		return model.UndoOperation{}, le.ErrUndoTokenNotFound
+	}
+	if err != nil {
+		return model.UndoOperation{}, fmt.Errorf("%s: failed to get undo operation: %w", op, err)
+	}
+
+	operation := model.UndoOperation{
+		ID:         item.ID,
+		UserID:     item.UserID,
+		EntityType: model.AuditEntityType(item.EntityType),
+		EntityID:   item.EntityID,
+		Action:     model.AuditAction(item.Action),
+		CreatedAt:  item.CreatedAt,
+		ExpiresAt:  item.ExpiresAt,
+		UndoneAt:   item.UndoneAt.Time,
+	}
+
+	if err = json.Unmarshal(item.Snapshot, &operation.Snapshot); err != nil {
+		return model.UndoOperation{}, fmt.Errorf("%s: failed to unmarshal snapshot: %w", op, err)
+	}
+
+	return operation, nil
+}
+
+// RestoreUndoSnapshot reverts the operation: the entity created by the operation is deleted,
+// the changed lists, headings and tasks get back the state saved in the snapshot. The created entity
+// is deleted first, so the rows moved into it by the operation are restored back to their places
+func (s *UndoStorage) RestoreUndoSnapshot(ctx context.Context, operation model.UndoOperation, now time.Time) error {
+	const op = "undo.storage.RestoreUndoSnapshot"
+
+	snapshot := operation.Snapshot
+
+	if snapshot.Created {
+		if err := s.deleteCreatedEntity(ctx, operation.EntityType, operation.EntityID, now); err != nil {
+			return fmt.Errorf("%s: %w", op, err)
+		}
+	}
+
+	if len(snapshot.Lists) > 0 {
+		if err := s.Queries.RestoreLists(ctx, sqlc.RestoreListsParams{
+			UpdatedAt: now,
+			Lists:     snapshot.Lists,
+		}); err != nil {
+			return fmt.Errorf("%s: failed to restore lists: %w", op, err)
+		}
+	}
+
+	if len(snapshot.Headings) > 0 {
+		if err := s.Queries.RestoreHeadings(ctx, sqlc.RestoreHeadingsParams{
+			UpdatedAt: now,
+			Headings:  snapshot.Headings,
+		}); err != nil {
+			return fmt.Errorf("%s: failed to restore headings: %w", op, err)
+		}
+	}
+
+	if len(snapshot.Tasks) > 0 {
+		if err := s.Queries.RestoreTasks(ctx, sqlc.RestoreTasksParams{
+			UpdatedAt: now,
+			Tasks:     snapshot.Tasks,
+		}); err != nil {
+			return fmt.Errorf("%s: failed to restore tasks: %w", op, err)
+		}
+
+		if err := s.Queries.DeleteTaskTagsOfSnapshot(ctx, snapshot.Tasks); err != nil {
+			return fmt.Errorf("%s: failed to delete task tags: %w", op, err)
+		}
+
+		if len(snapshot.TaskTags) > 0 {
+			if err := s.Queries.RestoreTaskTags(ctx, snapshot.TaskTags); err != nil {
+				return fmt.Errorf("%s: failed to restore task tags: %w", op, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// GetUndoListIDs returns the lists the entities of the operation were in before the operation and are in now,
+// the user must be able to edit all of them to revert the operation
+func (s *UndoStorage) GetUndoListIDs(ctx context.Context, operation model.UndoOperation) ([]string, error) {
+	const op = "undo.storage.GetUndoListIDs"
+
+	listIDs, err := s.Queries.GetUndoListIDs(ctx, sqlc.GetUndoListIDsParams{
+		Lists:     snapshotRows(operation.Snapshot.Lists),
+		Headings:  snapshotRows(operation.Snapshot.Headings),
+		Tasks:     snapshotRows(operation.Snapshot.Tasks),
+		CreatedID: createdEntityID(operation),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to get lists of undo operation: %w", op, err)
+	}
+	return listIDs, nil
+}
+
+// HasUndoConflicts reports whether the entities of the operation were changed after it.
+// The undo operation is written in the transaction of the operation, so the rows last written
+// by the same transaction (xmin) were changed by the operation itself. The other rows
+// are only left alone by the later changes if they still have the updated_at of the snapshot.
+// The headings and the tasks in the created list or heading have no snapshot, all of them must be written by the operation
+func (s *UndoStorage) HasUndoConflicts(ctx context.Context, operation model.UndoOperation) (bool, error) {
+	const op = "undo.storage.HasUndoConflicts"
+
+	conflict, err := s.Queries.HasUndoConflicts(ctx, sqlc.HasUndoConflictsParams{
+		Lists:     snapshotRows(operation.Snapshot.Lists),
+		Headings:  snapshotRows(operation.Snapshot.Headings),
+		Tasks:     snapshotRows(operation.Snapshot.Tasks),
+		CreatedID: createdEntityID(operation),
+		ID:        operation.ID,
+	})
+	if err != nil {
+		return false, fmt.Errorf("%s: failed to check undo conflicts: %w", op, err)
+	}
+	return conflict, nil
+}
+
+// snapshotRows returns the empty JSON array for the entities missing in the snapshot
+func snapshotRows(rows json.RawMessage) []byte {
+	if len(rows) == 0 {
+		return []byte("[]")
+	}
+	return rows
+}
+
+func createdEntityID(operation model.UndoOperation) string {
+	if operation.Snapshot.Created {
+		return operation.EntityID
+	}
+	return ""
+}
+
+// deleteCreatedEntity deletes the entity created by the operation. The headings and the tasks of the created
+// list or heading are deleted with it: HasUndoConflicts refuses the undo if any of them wasn't created by the operation
+func (s *UndoStorage) deleteCreatedEntity(ctx context.Context, entityType model.AuditEntityType, id string, now time.Time) error {
+	deletedAt := pgtype.Timestamptz{
+		Time:  now,
+		Valid: true,
+	}
+
+	var err error
+
+	switch entityType {
+	case model.AuditEntityList:
+		err = s.Queries.DeleteCreatedList(ctx, sqlc.DeleteCreatedListParams{DeletedAt: deletedAt, ID: id})
+		if err == nil {
+			err = s.Queries.DeleteHeadingsByListID(ctx, sqlc.DeleteHeadingsByListIDParams{DeletedAt: deletedAt, ListID: id})
+		}
+		if err == nil {
+			err = s.Queries.DeleteCreatedContentTasks(ctx, sqlc.DeleteCreatedContentTasksParams{DeletedAt: deletedAt, ContainerID: id})
+		}
+	case model.AuditEntityHeading:
+		err = s.Queries.DeleteCreatedHeading(ctx, sqlc.DeleteCreatedHeadingParams{DeletedAt: deletedAt, ID: id})
+		if err == nil {
+			err = s.Queries.DeleteCreatedContentTasks(ctx, sqlc.DeleteCreatedContentTasksParams{DeletedAt: deletedAt, ContainerID: id})
+		}
+	case model.AuditEntityTask:
+		err = s.Queries.DeleteCreatedTask(ctx, sqlc.DeleteCreatedTaskParams{DeletedAt: deletedAt, ID: id})
+	default:
+		return fmt.Errorf("unsupported entity type %q", entityType)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to delete created %s: %w", entityType, err)
+	}
+
+	return nil
+}
+
+// MarkUndoOperationAsUndone makes the token used, a token can revert the operation only once
+func (s *UndoStorage) MarkUndoOperationAsUndone(ctx context.Context, id string, undoneAt time.Time) error {
+	const op = "undo.storage.MarkUndoOperationAsUndone"
+
+	affected, err := s.Queries.MarkUndoOperationAsUndone(ctx, sqlc.MarkUndoOperationAsUndoneParams{
+		UndoneAt: pgtype.Timestamptz{
+			Time:  undoneAt,
+			Valid: true,
+		},
+		ID: id,
+	})
+	if err != nil {
+		return fmt.Errorf("%s: failed to mark undo operation as undone: %w", op, err)
+	}
+	if affected == 0 {
+		return le.ErrUndoTokenAlreadyUsed
+	}
+	return nil
+}
+
+func (s *UndoStorage) DeleteExpiredUndoOperations(ctx context.Context, now time.Time) (int64, error) {
+	const op = "undo.storage.DeleteExpiredUndoOperations"
+
+	deleted, err := s.Queries.DeleteExpiredUndoOperations(ctx, now)
+	if err != nil {
+		return 0, fmt.Errorf("%s: failed to delete expired undo operations: %w", op, err)
+	}
+	return deleted, nil
+}
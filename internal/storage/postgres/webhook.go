@@ -0,0 +1,353 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/rshelekhov/reframed/internal/lib/constants/le"
+	"github.com/rshelekhov/reframed/internal/model"
+	"github.com/rshelekhov/reframed/internal/storage/postgres/sqlc"
+)
+
+type WebhookStorage struct {
+	*pgxpool.Pool
+	*sqlc.Queries
+}
+
+func NewWebhookStorage(pool *pgxpool.Pool) *WebhookStorage {
+	return &WebhookStorage{
+		Pool:    pool,
+		Queries: sqlc.New(newDB(pool)),
+	}
+}
+
+func (s *WebhookStorage) CreateWebhook(ctx context.Context, webhook model.Webhook) error {
+	const op = "webhook.storage.CreateWebhook"
+
+	if err := s.Queries.CreateWebhook(ctx, sqlc.CreateWebhookParams{
+		ID:         webhook.ID,
+		Url:        webhook.URL,
+		EventTypes: webhook.EventTypes,
+		Secret:     webhook.Secret,
+		UserID:     webhook.UserID,
+		UpdatedAt:  webhook.UpdatedAt,
+	}); err != nil {
+		return fmt.Errorf("%s: failed to create webhook: %w", op, err)
+	}
+	return nil
+}
+
+func (s *WebhookStorage) GetWebhookByID(ctx context.Context, webhookID, userID string) (model.Webhook, error) {
+	const op = "webhook.storage.GetWebhookByID"
+
+	webhook, err := s.Queries.GetWebhookByID(ctx, sqlc.GetWebhookByIDParams{
+		ID:     webhookID,
+		UserID: userID,
+	})
+	if errors.Is(err, pgx.ErrNoRows) {
+		return model.Webhook{}, le.ErrWebhookNotFound
+	}
+	if err != nil {
+		return model.Webhook{}, fmt.Errorf("%s: failed to get webhook: %w", op, err)
+	}
+
+	return mapWebhookRowToModel(sqlc.Webhook{
+		ID:           webhook.ID,
+		Url:          webhook.Url,
+		EventTypes:   webhook.EventTypes,
+		Secret:       webhook.Secret,
+		UserID:       webhook.UserID,
+		IsActive:     webhook.IsActive,
+		FailureCount: webhook.FailureCount,
+		DisabledAt:   webhook.DisabledAt,
+		UpdatedAt:    webhook.UpdatedAt,
+	}), nil
+}
+
+func (s *WebhookStorage) GetWebhooksByUserID(ctx context.Context, userID string) ([]model.Webhook, error) {
+	const op = "webhook.storage.GetWebhooksByUserID"
+
+	items, err := s.Queries.GetWebhooksByUserID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to get webhooks: %w", op, err)
+	}
+	if len(items) == 0 {
+		return nil, le.ErrNoWebhooksFound
+	}
+
+	var webhooks []model.Webhook
+
+	for _, item := range items {
+		webhooks = append(webhooks, mapWebhookRowToModel(sqlc.Webhook{
+			ID:           item.ID,
+			Url:          item.Url,
+			EventTypes:   item.EventTypes,
+			Secret:       item.Secret,
+			UserID:       item.UserID,
+			IsActive:     item.IsActive,
+			FailureCount: item.FailureCount,
+			DisabledAt:   item.DisabledAt,
+			UpdatedAt:    item.UpdatedAt,
+		}))
+	}
+	return webhooks, nil
+}
+
+func mapWebhookRowToModel(webhook sqlc.Webhook) model.Webhook {
+	result := model.Webhook{
+		ID:           webhook.ID,
+		URL:          webhook.Url,
+		EventTypes:   webhook.EventTypes,
+		Secret:       webhook.Secret,
+		UserID:       webhook.UserID,
+		IsActive:     webhook.IsActive,
+		FailureCount: int(webhook.FailureCount),
+		UpdatedAt:    webhook.UpdatedAt,
+	}
+
+	if webhook.DisabledAt.Valid {
+		result.DisabledAt = webhook.DisabledAt.Time
+	}
+
+	return result
+}
+
+func (s *WebhookStorage) GetActiveWebhooksByEventType(ctx context.Context, userID, eventType string) ([]model.Webhook, error) {
+	const op = "webhook.storage.GetActiveWebhooksByEventType"
+
+	items, err := s.Queries.GetActiveWebhooksByEventType(ctx, sqlc.GetActiveWebhooksByEventTypeParams{
+		UserID:    userID,
+		EventType: eventType,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to get active webhooks: %w", op, err)
+	}
+
+	var webhooks []model.Webhook
+
+	for _, item := range items {
+		webhooks = append(webhooks, model.Webhook{
+			ID:       item.ID,
+			URL:      item.Url,
+			Secret:   item.Secret,
+			UserID:   userID,
+			IsActive: true,
+		})
+	}
+	return webhooks, nil
+}
+
+func (s *WebhookStorage) UpdateWebhook(ctx context.Context, webhook model.Webhook) error {
+	const op = "webhook.storage.UpdateWebhook"
+
+	rows, err := s.Queries.UpdateWebhook(ctx, sqlc.UpdateWebhookParams{
+		Url:        webhook.URL,
+		EventTypes: webhook.EventTypes,
+		IsActive:   webhook.IsActive,
+		UpdatedAt:  webhook.UpdatedAt,
+		ID:         webhook.ID,
+		UserID:     webhook.UserID,
+	})
+	if err != nil {
+		return fmt.Errorf("%s: failed to update webhook: %w", op, err)
+	}
+	if rows == 0 {
+		return le.ErrWebhookNotFound
+	}
+	return nil
+}
+
+func (s *WebhookStorage) DeleteWebhook(ctx context.Context, webhook model.Webhook) error {
+	const op = "webhook.storage.DeleteWebhook"
+
+	rows, err := s.Queries.DeleteWebhook(ctx, sqlc.DeleteWebhookParams{
+		DeletedAt: pgtype.Timestamptz{
+			Time:  webhook.DeletedAt,
+			Valid: true,
+		},
+		ID:     webhook.ID,
+		UserID: webhook.UserID,
+	})
+	if err != nil {
+		return fmt.Errorf("%s: failed to delete webhook: %w", op, err)
+	}
+	if rows == 0 {
+		return le.ErrWebhookNotFound
+	}
+	return nil
+}
+
+func (s *WebhookStorage) ResetWebhookFailureCount(ctx context.Context, webhookID string) error {
+	const op = "webhook.storage.ResetWebhookFailureCount"
+
+	if err := s.Queries.ResetWebhookFailureCount(ctx, webhookID); err != nil {
+		return fmt.Errorf("%s: failed to reset webhook failure count: %w", op, err)
+	}
+	return nil
+}
+
+// IncrementWebhookFailureCount counts the failed delivery of the webhook and disables it,
+// when the count reaches maxFailures. It returns true if the webhook has been disabled
+func (s *WebhookStorage) IncrementWebhookFailureCount(
+	ctx context.Context,
+	webhookID string,
+	maxFailures int,
+	failedAt time.Time,
+) (bool, error) {
+	const op = "webhook.storage.IncrementWebhookFailureCount"
+
+	isActive, err := s.Queries.IncrementWebhookFailureCount(ctx, sqlc.IncrementWebhookFailureCountParams{
+		MaxFailures: int32(maxFailures),
+		FailedAt: pgtype.Timestamptz{
+			Time:  failedAt,
+			Valid: true,
+		},
+		ID: webhookID,
+	})
+	if errors.Is(err, pgx.ErrNoRows) {
+		// The webhook is already disabled or deleted
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("%s: failed to increment webhook failure count: %w", op, err)
+	}
+	return !isActive, nil
+}
+
+func (s *WebhookStorage) CreateWebhookDelivery(ctx context.Context, delivery model.WebhookDelivery) error {
+	const op = "webhook.storage.CreateWebhookDelivery"
+
+	if err := s.Queries.CreateWebhookDelivery(ctx, sqlc.CreateWebhookDeliveryParams{
+		ID:            delivery.ID,
+		WebhookID:     delivery.WebhookID,
+		UserID:        delivery.UserID,
+		EventID:       delivery.EventID,
+		EventType:     delivery.EventType,
+		Payload:       delivery.Payload,
+		NextAttemptAt: delivery.NextAttemptAt,
+		CreatedAt:     delivery.CreatedAt,
+		UpdatedAt:     delivery.UpdatedAt,
+	}); err != nil {
+		return fmt.Errorf("%s: failed to create webhook delivery: %w", op, err)
+	}
+	return nil
+}
+
+// ClaimWebhookDeliveries returns pending deliveries due to be sent and postpones them until leaseUntil,
+// so the other instances of the application don't send them at the same time
+func (s *WebhookStorage) ClaimWebhookDeliveries(
+	ctx context.Context,
+	now, leaseUntil time.Time,
+	limit int,
+) ([]model.WebhookDelivery, error) {
+	const op = "webhook.storage.ClaimWebhookDeliveries"
+
+	items, err := s.Queries.ClaimWebhookDeliveries(ctx, sqlc.ClaimWebhookDeliveriesParams{
+		LeaseUntil: leaseUntil,
+		Now:        now,
+		BatchSize:  int32(limit),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to claim webhook deliveries: %w", op, err)
+	}
+
+	var deliveries []model.WebhookDelivery
+
+	for _, item := range items {
+		deliveries = append(deliveries, model.WebhookDelivery{
+			ID:            item.ID,
+			WebhookID:     item.WebhookID,
+			UserID:        item.UserID,
+			EventID:       item.EventID,
+			EventType:     item.EventType,
+			Payload:       item.Payload,
+			Status:        model.WebhookDeliveryPending,
+			Attempt:       int(item.Attempt),
+			CreatedAt:     item.CreatedAt,
+			URL:           item.Url,
+			Secret:        item.Secret,
+			WebhookActive: item.WebhookActive,
+		})
+	}
+	return deliveries, nil
+}
+
+func (s *WebhookStorage) UpdateWebhookDelivery(ctx context.Context, delivery model.WebhookDelivery) error {
+	const op = "webhook.storage.UpdateWebhookDelivery"
+
+	params := sqlc.UpdateWebhookDeliveryParams{
+		Status:         delivery.Status.String(),
+		Attempt:        int32(delivery.Attempt),
+		ResponseStatus: int32(delivery.ResponseStatus),
+		Error:          delivery.Error,
+		NextAttemptAt:  delivery.NextAttemptAt,
+		UpdatedAt:      delivery.UpdatedAt,
+		ID:             delivery.ID,
+	}
+
+	if !delivery.DeliveredAt.IsZero() {
+		params.DeliveredAt = pgtype.Timestamptz{
+			Time:  delivery.DeliveredAt,
+			Valid: true,
+		}
+	}
+
+	if err := s.Queries.UpdateWebhookDelivery(ctx, params); err != nil {
+		return fmt.Errorf("%s: failed to update webhook delivery: %w", op, err)
+	}
+	return nil
+}
+
+func (s *WebhookStorage) GetWebhookDeliveries(
+	ctx context.Context,
+	webhookID, userID string,
+	pgn model.Pagination,
+) ([]model.WebhookDelivery, error) {
+	const op = "webhook.storage.GetWebhookDeliveries"
+
+	items, err := s.Queries.GetWebhookDeliveries(ctx, sqlc.GetWebhookDeliveriesParams{
+		WebhookID:  webhookID,
+		UserID:     userID,
+		AfterID:    pgn.AfterID,
+		LimitCount: pgn.Limit,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to get webhook deliveries: %w", op, err)
+	}
+	if len(items) == 0 {
+		return nil, le.ErrNoWebhookDeliveriesFound
+	}
+
+	var deliveries []model.WebhookDelivery
+
+	for _, item := range items {
+		delivery := model.WebhookDelivery{
+			ID:             item.ID,
+			WebhookID:      item.WebhookID,
+			UserID:         userID,
+			EventID:        item.EventID,
+			EventType:      item.EventType,
+			Payload:        item.Payload,
+			Status:         model.WebhookDeliveryStatus(item.Status),
+			Attempt:        int(item.Attempt),
+			ResponseStatus: int(item.ResponseStatus),
+			Error:          item.Error,
+			NextAttemptAt:  item.NextAttemptAt,
+			CreatedAt:      item.CreatedAt,
+			UpdatedAt:      item.UpdatedAt,
+		}
+
+		if item.DeliveredAt.Valid {
+			delivery.DeliveredAt = item.DeliveredAt.Time
+		}
+
+		deliveries = append(deliveries, delivery)
+	}
+	return deliveries, nil
+}
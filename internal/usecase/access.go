@@ -0,0 +1,57 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+
+	"github.com/rshelekhov/reframed/internal/lib/constants/le"
+	"github.com/rshelekhov/reframed/internal/model"
+	"github.com/rshelekhov/reframed/internal/port"
+)
+
+// authorizeList checks that the user is a member of the list with at least the required role.
+// The list is reported as not found to the users who aren't its members, so its existence isn't disclosed
+func authorizeList(ctx context.Context, storage port.ListMemberStorage, listID, userID string, required model.ListRole) error {
+	role, err := storage.GetListMemberRole(ctx, listID, userID)
+	if errors.Is(err, le.ErrListMemberNotFound) {
+		return le.ErrListNotFound
+	}
+	if err != nil {
+		return err
+	}
+	return checkRole(role, required)
+}
+
+// authorizeHeading checks the role of the user in the list of the heading
+func authorizeHeading(ctx context.Context, storage port.ListMemberStorage, headingID, userID string, required model.ListRole) error {
+	role, err := storage.GetHeadingMemberRole(ctx, headingID, userID)
+	if errors.Is(err, le.ErrListMemberNotFound) {
+		return le.ErrHeadingNotFound
+	}
+	if err != nil {
+		return err
+	}
+	return checkRole(role, required)
+}
+
+// authorizeTask checks the role of the user in the list of the task
+func authorizeTask(ctx context.Context, storage port.ListMemberStorage, taskID, userID string, required model.ListRole) error {
+	role, err := storage.GetTaskMemberRole(ctx, taskID, userID)
+	if errors.Is(err, le.ErrListMemberNotFound) {
+		return le.ErrTaskNotFound
+	}
+	if err != nil {
+		return err
+	}
+	return checkRole(role, required)
+}
+
+func checkRole(role, required model.ListRole) error {
+	if role.Allows(required) {
+		return nil
+	}
+	if required == model.ListRoleOwner {
+		return le.ErrNoPermissionToManageList
+	}
+	return le.ErrNoPermissionToEditList
+}
@@ -0,0 +1,157 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/segmentio/ksuid"
+
+	"github.com/rshelekhov/reframed/internal/model"
+	"github.com/rshelekhov/reframed/internal/port"
+)
+
+type AreaUsecase struct {
+	areaStorage port.AreaStorage
+	listUsecase port.ListUsecase
+	eventBroker port.EventBroker
+}
+
+func NewAreaUsecase(
+	storage port.AreaStorage,
+	listUsecase port.ListUsecase,
+	eventBroker port.EventBroker,
+) *AreaUsecase {
+	return &AreaUsecase{
+		areaStorage: storage,
+		listUsecase: listUsecase,
+		eventBroker: eventBroker,
+	}
+}
+
+// CreateArea creates the area after the other areas of the user, together with the list for its loose tasks
+func (u *AreaUsecase) CreateArea(ctx context.Context, data *model.AreaRequestData) (model.AreaResponseData, error) {
+	newArea := model.Area{
+		ID:        ksuid.New().String(),
+		Title:     data.Title,
+		UserID:    data.UserID,
+		UpdatedAt: time.Now(),
+	}
+
+	if err := u.areaStorage.Transaction(ctx, func(ctx context.Context) error {
+		position, err := u.areaStorage.CreateArea(ctx, newArea)
+		if err != nil {
+			return err
+		}
+
+		newArea.Position = position
+
+		return u.listUsecase.CreateAreaDefaultList(ctx, newArea.ID, newArea.UserID)
+	}); err != nil {
+		return model.AreaResponseData{}, err
+	}
+
+	areaResp := mapAreaToResponseData(newArea)
+
+	publishEvent(ctx, u.eventBroker, model.EventAreaCreated, areaResp.UserID, areaResp.ID, areaResp)
+
+	return areaResp, nil
+}
+
+func (u *AreaUsecase) GetAreaByID(ctx context.Context, data model.AreaRequestData) (model.AreaResponseData, error) {
+	area, err := u.areaStorage.GetAreaByID(ctx, data.ID, data.UserID)
+	if err != nil {
+		return model.AreaResponseData{}, err
+	}
+
+	return mapAreaToResponseData(area), nil
+}
+
+func (u *AreaUsecase) GetAreasByUserID(ctx context.Context, userID string) ([]model.AreaResponseData, error) {
+	areas, err := u.areaStorage.GetAreasByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	var areasResp []model.AreaResponseData
+
+	for _, area := range areas {
+		areasResp = append(areasResp, mapAreaToResponseData(area))
+	}
+
+	return areasResp, nil
+}
+
+func mapAreaToResponseData(area model.Area) model.AreaResponseData {
+	return model.AreaResponseData{
+		ID:        area.ID,
+		Title:     area.Title,
+		Position:  area.Position,
+		UserID:    area.UserID,
+		UpdatedAt: area.UpdatedAt,
+	}
+}
+
+func (u *AreaUsecase) UpdateArea(ctx context.Context, data *model.AreaRequestData) (model.AreaResponseData, error) {
+	updatedArea := model.Area{
+		ID:        data.ID,
+		Title:     data.Title,
+		UserID:    data.UserID,
+		UpdatedAt: time.Now(),
+	}
+
+	if err := u.areaStorage.UpdateArea(ctx, updatedArea); err != nil {
+		return model.AreaResponseData{}, err
+	}
+
+	areaResp, err := u.GetAreaByID(ctx, *data)
+	if err != nil {
+		return model.AreaResponseData{}, err
+	}
+
+	publishEvent(ctx, u.eventBroker, model.EventAreaUpdated, areaResp.UserID, areaResp.ID, areaResp)
+
+	return areaResp, nil
+}
+
+// ReorderAreas sets the positions of the areas in the order of their IDs
+func (u *AreaUsecase) ReorderAreas(ctx context.Context, data *model.ReorderRequestData) error {
+	return u.areaStorage.Transaction(ctx, func(ctx context.Context) error {
+		for position, areaID := range data.IDs {
+			if err := u.areaStorage.UpdateAreaPosition(ctx, model.Area{
+				ID:        areaID,
+				Position:  position,
+				UserID:    data.UserID,
+				UpdatedAt: time.Now(),
+			}); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// DeleteArea deletes the area. Its lists are kept and taken out of the area,
+// and its loose tasks are kept in a list with the title of the area
+func (u *AreaUsecase) DeleteArea(ctx context.Context, data model.AreaRequestData) error {
+	if err := u.areaStorage.Transaction(ctx, func(ctx context.Context) error {
+		area, err := u.areaStorage.GetAreaByID(ctx, data.ID, data.UserID)
+		if err != nil {
+			return err
+		}
+
+		area.UpdatedAt = time.Now()
+		area.DeletedAt = area.UpdatedAt
+
+		if err = u.listUsecase.DetachListsFromArea(ctx, area); err != nil {
+			return err
+		}
+
+		return u.areaStorage.DeleteArea(ctx, area)
+	}); err != nil {
+		return err
+	}
+
+	publishEvent(ctx, u.eventBroker, model.EventAreaDeleted, data.UserID, data.ID, nil)
+
+	return nil
+}
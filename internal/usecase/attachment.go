@@ -0,0 +1,311 @@
+package usecase
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"mime"
+	"net/http"
+	"path"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/segmentio/ksuid"
+
+	"github.com/rshelekhov/reframed/config"
+	"github.com/rshelekhov/reframed/internal/lib/constants/le"
+	"github.com/rshelekhov/reframed/internal/model"
+	"github.com/rshelekhov/reframed/internal/port"
+)
+
+const (
+	// sniffLength is how much of the file is read to detect its content type, see http.DetectContentType
+	sniffLength = 512
+	// purgeBatchSize is how many attachments are read at once by the purge job
+	purgeBatchSize = 100
+	// maxFileNameLength is the length the names of the uploaded files are cut to, in runes
+	maxFileNameLength = 255
+	defaultFileName   = "file"
+)
+
+type AttachmentUsecase struct {
+	attachmentStorage port.AttachmentStorage
+	memberStorage     port.ListMemberStorage
+	blobStore         port.BlobStore
+	cfg               config.AttachmentConfig
+	allowedTypes      map[string]bool
+}
+
+func NewAttachmentUsecase(
+	attachmentStorage port.AttachmentStorage,
+	memberStorage port.ListMemberStorage,
+	blobStore port.BlobStore,
+	cfg config.AttachmentConfig,
+) *AttachmentUsecase {
+	allowedTypes := make(map[string]bool)
+	for _, contentType := range strings.Split(cfg.AllowedTypes, ",") {
+		if contentType = strings.ToLower(strings.TrimSpace(contentType)); contentType != "" {
+			allowedTypes[contentType] = true
+		}
+	}
+
+	return &AttachmentUsecase{
+		attachmentStorage: attachmentStorage,
+		memberStorage:     memberStorage,
+		blobStore:         blobStore,
+		cfg:               cfg,
+		allowedTypes:      allowedTypes,
+	}
+}
+
+func (u *AttachmentUsecase) MaxUploadSize() int64 {
+	return u.cfg.MaxSize
+}
+
+// UploadAttachment stores the file in the blob store and attaches it to the task, any editor of the list can do it.
+// The file is charged to the quota of the user who uploaded it
+func (u *AttachmentUsecase) UploadAttachment(ctx context.Context, data model.AttachmentUploadData) (model.AttachmentResponseData, error) {
+	if err := authorizeTask(ctx, u.memberStorage, data.TaskID, data.UserID, model.ListRoleEditor); err != nil {
+		return model.AttachmentResponseData{}, err
+	}
+
+	if data.Size > u.cfg.MaxSize {
+		return model.AttachmentResponseData{}, le.ErrAttachmentTooLarge
+	}
+
+	// The quota is checked again when the attachment is created, this check only saves
+	// storing the file which would be refused anyway
+	if err := u.checkQuota(ctx, data.UserID, data.Size); err != nil {
+		return model.AttachmentResponseData{}, err
+	}
+
+	head := make([]byte, sniffLength)
+
+	n, err := io.ReadFull(data.Content, head)
+	if err != nil && !errors.Is(err, io.EOF) && !errors.Is(err, io.ErrUnexpectedEOF) {
+		return model.AttachmentResponseData{}, err
+	}
+	head = head[:n]
+
+	contentType := http.DetectContentType(head)
+	if !u.isAllowedType(contentType) {
+		return model.AttachmentResponseData{}, le.ErrAttachmentTypeNotAllowed
+	}
+
+	attachmentID := ksuid.New().String()
+
+	newAttachment := model.Attachment{
+		ID:          attachmentID,
+		TaskID:      data.TaskID,
+		FileName:    sanitizeFileName(data.FileName),
+		ContentType: contentType,
+		Size:        data.Size,
+		BlobKey:     data.UserID + "/" + attachmentID,
+		UserID:      data.UserID,
+		CreatedAt:   time.Now(),
+	}
+
+	content := io.MultiReader(bytes.NewReader(head), data.Content)
+
+	if err = u.blobStore.Put(ctx, newAttachment.BlobKey, content, newAttachment.Size, newAttachment.ContentType); err != nil {
+		return model.AttachmentResponseData{}, err
+	}
+
+	if err = u.attachmentStorage.Transaction(ctx, func(ctx context.Context) error {
+		if err := u.attachmentStorage.LockUserAttachments(ctx, newAttachment.UserID); err != nil {
+			return err
+		}
+
+		if err := u.checkQuota(ctx, newAttachment.UserID, newAttachment.Size); err != nil {
+			return err
+		}
+
+		return u.attachmentStorage.CreateAttachment(ctx, newAttachment)
+	}); err != nil {
+		// The blob isn't referenced by any attachment, so the purge job would never find it
+		_ = u.blobStore.Delete(ctx, newAttachment.BlobKey)
+		return model.AttachmentResponseData{}, err
+	}
+
+	return u.getAttachmentResponseData(ctx, newAttachment)
+}
+
+// checkQuota checks that the file of the size fits in the quota of the user
+func (u *AttachmentUsecase) checkQuota(ctx context.Context, userID string, size int64) error {
+	used, err := u.attachmentStorage.GetUserAttachmentsSize(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if used+size > u.cfg.UserQuota {
+		return le.ErrAttachmentQuotaExceeded
+	}
+	return nil
+}
+
+func (u *AttachmentUsecase) GetAttachmentByID(ctx context.Context, data model.AttachmentRequestData) (model.AttachmentResponseData, error) {
+	if err := authorizeTask(ctx, u.memberStorage, data.TaskID, data.UserID, model.ListRoleViewer); err != nil {
+		return model.AttachmentResponseData{}, err
+	}
+
+	attachment, err := u.attachmentStorage.GetAttachmentByID(ctx, data.ID, data.TaskID)
+	if err != nil {
+		return model.AttachmentResponseData{}, err
+	}
+
+	return u.getAttachmentResponseData(ctx, attachment)
+}
+
+func (u *AttachmentUsecase) GetAttachmentsByTaskID(ctx context.Context, data model.AttachmentRequestData) ([]model.AttachmentResponseData, error) {
+	if err := authorizeTask(ctx, u.memberStorage, data.TaskID, data.UserID, model.ListRoleViewer); err != nil {
+		return nil, err
+	}
+
+	attachments, err := u.attachmentStorage.GetAttachmentsByTaskID(ctx, data.TaskID)
+	if err != nil {
+		return nil, err
+	}
+	if len(attachments) == 0 {
+		return nil, le.ErrNoAttachmentsFound
+	}
+
+	var attachmentsResp []model.AttachmentResponseData
+
+	for _, attachment := range attachments {
+		attachmentResp, err := u.getAttachmentResponseData(ctx, attachment)
+		if err != nil {
+			return nil, err
+		}
+		attachmentsResp = append(attachmentsResp, attachmentResp)
+	}
+
+	return attachmentsResp, nil
+}
+
+// DeleteAttachment frees the quota at once, the blob is removed later by the purge job
+func (u *AttachmentUsecase) DeleteAttachment(ctx context.Context, data model.AttachmentRequestData) error {
+	if err := authorizeTask(ctx, u.memberStorage, data.TaskID, data.UserID, model.ListRoleEditor); err != nil {
+		return err
+	}
+
+	return u.attachmentStorage.DeleteAttachment(ctx, model.Attachment{
+		ID:        data.ID,
+		TaskID:    data.TaskID,
+		DeletedAt: time.Now(),
+	})
+}
+
+func (u *AttachmentUsecase) GetAttachmentUsage(ctx context.Context, userID string) (model.AttachmentUsageResponseData, error) {
+	used, err := u.attachmentStorage.GetUserAttachmentsSize(ctx, userID)
+	if err != nil {
+		return model.AttachmentUsageResponseData{}, err
+	}
+
+	return model.AttachmentUsageResponseData{
+		Used:      used,
+		Quota:     u.cfg.UserQuota,
+		Remaining: max(u.cfg.UserQuota-used, 0),
+	}, nil
+}
+
+// OpenSignedBlob opens the blob of the download link served by the application, the link is the authorization
+func (u *AttachmentUsecase) OpenSignedBlob(ctx context.Context, data model.SignedBlobRequestData) (io.ReadCloser, error) {
+	if err := u.blobStore.VerifySignedURL(data); err != nil {
+		return nil, err
+	}
+
+	return u.blobStore.Open(ctx, data.Key)
+}
+
+// PurgeAttachments removes the blobs of the deleted attachments and of the attachments of the tasks
+// deleted longer than PurgeAfter ago, then the attachments themselves. It returns the number of purged attachments
+func (u *AttachmentUsecase) PurgeAttachments(ctx context.Context) (int64, error) {
+	purgeBefore := time.Now().Add(-u.cfg.PurgeAfter)
+
+	var purged int64
+
+	for {
+		attachments, err := u.attachmentStorage.GetAttachmentsToPurge(ctx, purgeBefore, purgeBatchSize)
+		if err != nil {
+			return purged, err
+		}
+
+		for _, attachment := range attachments {
+			// The blob goes first, so it's never left behind without the attachment pointing to it
+			if err = u.blobStore.Delete(ctx, attachment.BlobKey); err != nil {
+				return purged, err
+			}
+			if err = u.attachmentStorage.PurgeAttachment(ctx, attachment.ID); err != nil {
+				return purged, err
+			}
+			purged++
+		}
+
+		if len(attachments) < purgeBatchSize {
+			return purged, nil
+		}
+	}
+}
+
+func (u *AttachmentUsecase) getAttachmentResponseData(ctx context.Context, attachment model.Attachment) (model.AttachmentResponseData, error) {
+	expiresAt := time.Now().Add(u.cfg.URLTTL)
+
+	url, err := u.blobStore.SignedURL(ctx, attachment.BlobKey, model.BlobURLOptions{
+		FileName:    attachment.FileName,
+		ContentType: attachment.ContentType,
+		ExpiresAt:   expiresAt,
+	})
+	if err != nil {
+		return model.AttachmentResponseData{}, err
+	}
+
+	return model.AttachmentResponseData{
+		ID:           attachment.ID,
+		TaskID:       attachment.TaskID,
+		FileName:     attachment.FileName,
+		ContentType:  attachment.ContentType,
+		Size:         attachment.Size,
+		URL:          url,
+		URLExpiresAt: expiresAt,
+		UserID:       attachment.UserID,
+		CreatedAt:    attachment.CreatedAt,
+	}, nil
+}
+
+// isAllowedType checks the media type of the sniffed content type without its parameters, like charset.
+// Any type is allowed if the allowed types aren't configured
+func (u *AttachmentUsecase) isAllowedType(contentType string) bool {
+	if len(u.allowedTypes) == 0 {
+		return true
+	}
+
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return false
+	}
+	return u.allowedTypes[mediaType]
+}
+
+// sanitizeFileName keeps only the base name of the uploaded file without the control characters,
+// so the name is safe to put into the headers of the download
+func sanitizeFileName(name string) string {
+	name = path.Base(strings.ReplaceAll(name, `\`, "/"))
+
+	name = strings.TrimSpace(strings.Map(func(r rune) rune {
+		if unicode.IsControl(r) || r == utf8.RuneError {
+			return -1
+		}
+		return r
+	}, name))
+
+	if name == "" || name == "." || name == "/" {
+		return defaultFileName
+	}
+
+	if runes := []rune(name); len(runes) > maxFileNameLength {
+		name = string(runes[:maxFileNameLength])
+	}
+	return name
+}
@@ -0,0 +1,175 @@
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"time"
+
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/segmentio/ksuid"
+
+	"github.com/rshelekhov/reframed/internal/lib/constants/le"
+	"github.com/rshelekhov/reframed/internal/lib/middleware/jwtoken"
+	"github.com/rshelekhov/reframed/internal/model"
+	"github.com/rshelekhov/reframed/internal/port"
+)
+
+type AuditUsecase struct {
+	auditStorage port.AuditStorage
+}
+
+func NewAuditUsecase(auditStorage port.AuditStorage) *AuditUsecase {
+	return &AuditUsecase{
+		auditStorage: auditStorage,
+	}
+}
+
+// zeroTime is how the unset dates look in JSON, they are treated as missing values in the changes
+var zeroTime = time.Time{}.Format(time.RFC3339Nano)
+
+// recordAudit adds the change of the entity made by the user to the audit log.
+// before is nil for the created entities and after is nil for the deleted ones
+func recordAudit(
+	ctx context.Context,
+	audit port.AuditUsecase,
+	action model.AuditAction,
+	entityType model.AuditEntityType,
+	entityID, userID string,
+	before, after any,
+) error {
+	return audit.RecordChange(ctx, model.AuditRecordData{
+		EntityType: entityType,
+		EntityID:   entityID,
+		Action:     action,
+		UserID:     userID,
+		Before:     before,
+		After:      after,
+	})
+}
+
+// RecordChange appends the fields changed between the states of the entity to the audit log,
+// along with the device of the session and the ID of the request which made the change
+func (u *AuditUsecase) RecordChange(ctx context.Context, data model.AuditRecordData) error {
+	changes, err := diffAuditStates(data.Before, data.After)
+	if err != nil {
+		return err
+	}
+
+	// The requests made without a session, like the sign-up, have no device
+	deviceID, _ := jwtoken.GetDeviceID(ctx)
+
+	return u.auditStorage.CreateAuditEntry(ctx, model.AuditEntry{
+		ID:         ksuid.New().String(),
+		EntityType: data.EntityType,
+		EntityID:   data.EntityID,
+		Action:     data.Action,
+		Changes:    changes,
+		UserID:     data.UserID,
+		DeviceID:   deviceID,
+		RequestID:  middleware.GetReqID(ctx),
+		CreatedAt:  time.Now(),
+	})
+}
+
+func (u *AuditUsecase) GetAuditEntriesByEntity(
+	ctx context.Context,
+	data model.AuditRequestData,
+	pgn model.Pagination,
+) ([]model.AuditEntryResponseData, error) {
+	if !data.EntityType.IsValid() {
+		return nil, le.ErrInvalidAuditEntityType
+	}
+
+	entries, err := u.auditStorage.GetAuditEntriesByEntity(ctx, data.EntityType, data.EntityID, data.UserID, pgn)
+	if err != nil {
+		return nil, err
+	}
+
+	return mapAuditEntriesToResponseData(entries), nil
+}
+
+// GetAuditEntriesByUserID returns the changes made by the user
+func (u *AuditUsecase) GetAuditEntriesByUserID(ctx context.Context, userID string, pgn model.Pagination) ([]model.AuditEntryResponseData, error) {
+	entries, err := u.auditStorage.GetAuditEntriesByUserID(ctx, userID, pgn)
+	if err != nil {
+		return nil, err
+	}
+
+	return mapAuditEntriesToResponseData(entries), nil
+}
+
+// diffAuditStates compares the JSON fields of the states and returns the fields which differ
+func diffAuditStates(before, after any) (map[string]model.AuditChange, error) {
+	beforeFields, err := auditFields(before)
+	if err != nil {
+		return nil, err
+	}
+
+	afterFields, err := auditFields(after)
+	if err != nil {
+		return nil, err
+	}
+
+	changes := make(map[string]model.AuditChange)
+
+	for field, oldValue := range beforeFields {
+		if newValue := afterFields[field]; !reflect.DeepEqual(oldValue, newValue) {
+			changes[field] = model.AuditChange{Old: oldValue, New: newValue}
+		}
+	}
+
+	for field, newValue := range afterFields {
+		if _, ok := beforeFields[field]; !ok {
+			changes[field] = model.AuditChange{New: newValue}
+		}
+	}
+
+	return changes, nil
+}
+
+// auditFields returns the non-empty JSON fields of the state, a nil state has no fields
+func auditFields(state any) (map[string]any, error) {
+	if state == nil {
+		return nil, nil
+	}
+
+	data, err := json.Marshal(state)
+	if err != nil {
+		return nil, err
+	}
+
+	var fields map[string]any
+	if err = json.Unmarshal(data, &fields); err != nil {
+		return nil, err
+	}
+
+	for field, value := range fields {
+		if value == nil || value == "" || value == zeroTime {
+			delete(fields, field)
+		}
+	}
+
+	return fields, nil
+}
+
+func mapAuditEntriesToResponseData(entries []model.AuditEntry) []model.AuditEntryResponseData {
+	var entriesResp []model.AuditEntryResponseData
+
+	for _, entry := range entries {
+		entriesResp = append(entriesResp, model.AuditEntryResponseData{
+			ID:         entry.ID,
+			EntityType: entry.EntityType,
+			EntityID:   entry.EntityID,
+			Action:     entry.Action,
+			Changes:    entry.Changes,
+			UserID:     entry.UserID,
+			Email:      entry.Email,
+			DeviceID:   entry.DeviceID,
+			RequestID:  entry.RequestID,
+			CreatedAt:  entry.CreatedAt,
+		})
+	}
+
+	return entriesResp
+}
@@ -20,17 +20,20 @@ type AuthUsecase struct {
 	authStorage    port.AuthStorage
 	listUsecase    port.ListUsecase
 	headingUsecase port.HeadingUsecase
+	auditUsecase   port.AuditUsecase
 }
 
 func NewAuthUsecase(
 	storage port.AuthStorage,
 	listUsecase port.ListUsecase,
 	headingUsecase port.HeadingUsecase,
+	auditUsecase port.AuditUsecase,
 ) *AuthUsecase {
 	return &AuthUsecase{
 		authStorage:    storage,
 		listUsecase:    listUsecase,
 		headingUsecase: headingUsecase,
+		auditUsecase:   auditUsecase,
 	}
 }
 
@@ -53,11 +56,17 @@ func (u *AuthUsecase) CreateUser(ctx context.Context, jwt *jwtoken2.TokenService
 		UpdatedAt:    time.Now(),
 	}
 
-	if err = u.authStorage.Transaction(ctx, func(_ port.AuthStorage) error {
+	if err = u.authStorage.Transaction(ctx, func(ctx context.Context) error {
 		if err = u.authStorage.CreateUser(ctx, user); err != nil {
 			return err
 		}
 
+		if err = recordAudit(
+			ctx, u.auditUsecase, model.AuditActionCreate, model.AuditEntityUser, user.ID, user.ID, nil, mapUserToResponseData(user),
+		); err != nil {
+			return err
+		}
+
 		if err = u.listUsecase.CreateDefaultList(ctx, user.ID); err != nil {
 			return err
 		}
@@ -80,15 +89,16 @@ func (u *AuthUsecase) CreateUserSession(
 	jwtoken2.TokenData,
 	error,
 ) {
-	additionalClaims := map[string]interface{}{
-		jwtoken2.ContextUserID: userID,
-	}
-
 	deviceID, err := u.getDeviceID(ctx, userID, data)
 	if err != nil {
 		return jwtoken2.TokenData{}, err
 	}
 
+	additionalClaims := map[string]interface{}{
+		jwtoken2.ContextUserID:   userID,
+		jwtoken2.ContextDeviceID: deviceID,
+	}
+
 	accessToken, err := jwt.NewAccessToken(additionalClaims)
 	if err != nil {
 		return jwtoken2.TokenData{}, err
@@ -250,13 +260,7 @@ func (u *AuthUsecase) GetUserByID(ctx context.Context, id string) (model.UserRes
 		return model.UserResponseData{}, err
 	}
 
-	userResponse := model.UserResponseData{
-		ID:        user.ID,
-		Email:     user.Email,
-		UpdatedAt: user.UpdatedAt,
-	}
-
-	return userResponse, err
+	return mapUserToResponseData(user), nil
 }
 
 func (u *AuthUsecase) UpdateUser(ctx context.Context, jwt *jwtoken2.TokenService, data *model.UserRequestData, userID string) error {
@@ -300,7 +304,48 @@ func (u *AuthUsecase) UpdateUser(ctx context.Context, jwt *jwtoken2.TokenService
 		}
 	}
 
-	return u.authStorage.UpdateUser(ctx, updatedUser)
+	return u.authStorage.Transaction(ctx, func(ctx context.Context) error {
+		if err = u.authStorage.UpdateUser(ctx, updatedUser); err != nil {
+			return err
+		}
+
+		user, err := u.authStorage.GetUserByID(ctx, userID)
+		if err != nil {
+			return err
+		}
+
+		return recordAudit(
+			ctx, u.auditUsecase, model.AuditActionUpdate, model.AuditEntityUser, userID, userID,
+			mapUserToResponseData(currentUser), mapUserToResponseData(user),
+		)
+	})
+}
+
+// UpdateUserTimeZone sets the time zone the habit check-ins and streaks of the user are counted in
+func (u *AuthUsecase) UpdateUserTimeZone(ctx context.Context, data model.UserTimeZoneRequestData, userID string) (model.UserResponseData, error) {
+	currentUser, err := u.authStorage.GetUserByID(ctx, userID)
+	if err != nil {
+		return model.UserResponseData{}, err
+	}
+
+	updatedUser := currentUser
+	updatedUser.TimeZone = data.TimeZone
+	updatedUser.UpdatedAt = time.Now()
+
+	if err = u.authStorage.Transaction(ctx, func(ctx context.Context) error {
+		if err := u.authStorage.UpdateUserTimeZone(ctx, updatedUser); err != nil {
+			return err
+		}
+
+		return recordAudit(
+			ctx, u.auditUsecase, model.AuditActionUpdate, model.AuditEntityUser, userID, userID,
+			mapUserToResponseData(currentUser), mapUserToResponseData(updatedUser),
+		)
+	}); err != nil {
+		return model.UserResponseData{}, err
+	}
+
+	return mapUserToResponseData(updatedUser), nil
 }
 
 func (u *AuthUsecase) checkPassword(jwt *jwtoken2.TokenService, currentPasswordHash, passwordFromRequest string) error {
@@ -333,11 +378,23 @@ func (u *AuthUsecase) DeleteUser(ctx context.Context, userID string, data model.
 		DeletedAt: time.Now(),
 	}
 
-	err = u.authStorage.DeleteUser(ctx, deletedUser)
+	currentUser, err := u.authStorage.GetUserByID(ctx, userID)
 	if err != nil {
 		return err
 	}
 
+	if err = u.authStorage.Transaction(ctx, func(ctx context.Context) error {
+		if err = u.authStorage.DeleteUser(ctx, deletedUser); err != nil {
+			return err
+		}
+
+		return recordAudit(
+			ctx, u.auditUsecase, model.AuditActionDelete, model.AuditEntityUser, userID, userID, mapUserToResponseData(currentUser), nil,
+		)
+	}); err != nil {
+		return err
+	}
+
 	err = u.authStorage.DeleteSession(ctx, userID, deviceID)
 	if err != nil {
 		return err
@@ -345,3 +402,13 @@ func (u *AuthUsecase) DeleteUser(ctx context.Context, userID string, data model.
 
 	return nil
 }
+
+// mapUserToResponseData returns the public data of the user, without the password hash
+func mapUserToResponseData(user model.User) model.UserResponseData {
+	return model.UserResponseData{
+		ID:        user.ID,
+		Email:     user.Email,
+		TimeZone:  user.TimeZone,
+		UpdatedAt: user.UpdatedAt,
+	}
+}
@@ -0,0 +1,357 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/segmentio/ksuid"
+
+	"github.com/rshelekhov/reframed/internal/lib/constants/le"
+	"github.com/rshelekhov/reframed/internal/lib/logger"
+	"github.com/rshelekhov/reframed/internal/model"
+	"github.com/rshelekhov/reframed/internal/port"
+)
+
+type CommentUsecase struct {
+	commentStorage port.CommentStorage
+	taskStorage    port.TaskStorage
+	memberStorage  port.ListMemberStorage
+	authStorage    port.AuthStorage
+	eventBroker    port.EventBroker
+	log            logger.Interface
+}
+
+func NewCommentUsecase(
+	commentStorage port.CommentStorage,
+	taskStorage port.TaskStorage,
+	memberStorage port.ListMemberStorage,
+	authStorage port.AuthStorage,
+	eventBroker port.EventBroker,
+	log logger.Interface,
+) *CommentUsecase {
+	return &CommentUsecase{
+		commentStorage: commentStorage,
+		taskStorage:    taskStorage,
+		memberStorage:  memberStorage,
+		authStorage:    authStorage,
+		eventBroker:    eventBroker,
+		log:            log,
+	}
+}
+
+// mentionPattern matches the mentions of the users by email in the body of the comment, like @user@example.com
+var mentionPattern = regexp.MustCompile(`(?:^|\s)@([A-Za-z0-9._%+\-]+@[A-Za-z0-9.\-]+\.[A-Za-z]{2,})`)
+
+// CreateComment adds the comment to the task, any member of the list of the task can comment.
+// The members mentioned in the comment are notified
+func (u *CommentUsecase) CreateComment(ctx context.Context, data *model.CommentRequestData) (model.CommentResponseData, error) {
+	if err := authorizeTask(ctx, u.memberStorage, data.TaskID, data.UserID, model.ListRoleViewer); err != nil {
+		return model.CommentResponseData{}, err
+	}
+
+	mentions, err := u.resolveMentions(ctx, data.TaskID, data.Body)
+	if err != nil {
+		return model.CommentResponseData{}, err
+	}
+
+	author, err := u.authStorage.GetUserByID(ctx, data.UserID)
+	if err != nil {
+		return model.CommentResponseData{}, err
+	}
+
+	now := time.Now()
+
+	newComment := model.Comment{
+		ID:        ksuid.New().String(),
+		TaskID:    data.TaskID,
+		Body:      data.Body,
+		Mentions:  mentionEmails(mentions),
+		UserID:    data.UserID,
+		Email:     author.Email,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+
+	if err = u.commentStorage.CreateComment(ctx, newComment); err != nil {
+		return model.CommentResponseData{}, err
+	}
+
+	commentResp := mapCommentToResponseData(newComment)
+
+	u.publishCommentEvent(ctx, model.EventCommentCreated, data.TaskID, data.UserID, commentResp.ID, commentResp)
+	u.notifyMentionedUsers(ctx, commentResp, mentions)
+
+	return commentResp, nil
+}
+
+func (u *CommentUsecase) GetCommentsByTaskID(
+	ctx context.Context,
+	data model.CommentRequestData,
+	pgn model.Pagination,
+) ([]model.CommentResponseData, error) {
+	if err := authorizeTask(ctx, u.memberStorage, data.TaskID, data.UserID, model.ListRoleViewer); err != nil {
+		return nil, err
+	}
+
+	comments, err := u.commentStorage.GetCommentsByTaskID(ctx, data.TaskID, pgn)
+	if err != nil {
+		return nil, err
+	}
+
+	var commentsResp []model.CommentResponseData
+
+	for _, comment := range comments {
+		commentsResp = append(commentsResp, mapCommentToResponseData(comment))
+	}
+
+	return commentsResp, nil
+}
+
+// UpdateComment changes the body of the comment, only its author can do it.
+// Only the users who weren't mentioned in the comment before are notified
+func (u *CommentUsecase) UpdateComment(ctx context.Context, data *model.CommentRequestData) (model.CommentResponseData, error) {
+	if err := authorizeTask(ctx, u.memberStorage, data.TaskID, data.UserID, model.ListRoleViewer); err != nil {
+		return model.CommentResponseData{}, err
+	}
+
+	comment, err := u.commentStorage.GetCommentByID(ctx, data.ID, data.TaskID)
+	if err != nil {
+		return model.CommentResponseData{}, err
+	}
+
+	if comment.UserID != data.UserID {
+		return model.CommentResponseData{}, le.ErrNoPermissionToEditComment
+	}
+
+	mentions, err := u.resolveMentions(ctx, data.TaskID, data.Body)
+	if err != nil {
+		return model.CommentResponseData{}, err
+	}
+
+	previousMentions := make(map[string]bool)
+	for _, email := range comment.Mentions {
+		previousMentions[strings.ToLower(email)] = true
+	}
+
+	var newMentions []model.User
+	for _, user := range mentions {
+		if !previousMentions[strings.ToLower(user.Email)] {
+			newMentions = append(newMentions, user)
+		}
+	}
+
+	comment.Body = data.Body
+	comment.Mentions = mentionEmails(mentions)
+	comment.UpdatedAt = time.Now()
+
+	if err = u.commentStorage.UpdateComment(ctx, comment); err != nil {
+		return model.CommentResponseData{}, err
+	}
+
+	commentResp := mapCommentToResponseData(comment)
+
+	u.publishCommentEvent(ctx, model.EventCommentUpdated, data.TaskID, data.UserID, commentResp.ID, commentResp)
+	u.notifyMentionedUsers(ctx, commentResp, newMentions)
+
+	return commentResp, nil
+}
+
+// DeleteComment deletes the comment, its author and the owners of the list can do it
+func (u *CommentUsecase) DeleteComment(ctx context.Context, data model.CommentRequestData) error {
+	role, err := u.memberStorage.GetTaskMemberRole(ctx, data.TaskID, data.UserID)
+	if errors.Is(err, le.ErrListMemberNotFound) {
+		return le.ErrTaskNotFound
+	}
+	if err != nil {
+		return err
+	}
+
+	comment, err := u.commentStorage.GetCommentByID(ctx, data.ID, data.TaskID)
+	if err != nil {
+		return err
+	}
+
+	if comment.UserID != data.UserID && !role.Allows(model.ListRoleOwner) {
+		return le.ErrNoPermissionToEditComment
+	}
+
+	comment.DeletedAt = time.Now()
+
+	if err = u.commentStorage.DeleteComment(ctx, comment); err != nil {
+		return err
+	}
+
+	u.publishCommentEvent(ctx, model.EventCommentDeleted, data.TaskID, data.UserID, comment.ID, nil)
+
+	return nil
+}
+
+// publishCommentEvent notifies every member of the list of the task about the change of the comment
+func (u *CommentUsecase) publishCommentEvent(ctx context.Context, eventType model.EventType, taskID, userID, commentID string, data any) {
+	if u.eventBroker == nil {
+		return
+	}
+
+	memberIDs, _ := u.memberStorage.GetTaskMemberIDs(ctx, taskID)
+
+	publishMembersEvent(ctx, u.eventBroker, eventType, memberIDs, userID, commentID, data)
+}
+
+// resolveMentions returns the members of the list of the task mentioned in the body.
+// Mentions of unknown users and of users outside the list are left as plain text
+func (u *CommentUsecase) resolveMentions(ctx context.Context, taskID, body string) ([]model.User, error) {
+	var users []model.User
+
+	seen := make(map[string]bool)
+
+	for _, match := range mentionPattern.FindAllStringSubmatch(body, -1) {
+		email := strings.ToLower(match[1])
+		if seen[email] {
+			continue
+		}
+		seen[email] = true
+
+		user, err := u.authStorage.GetUserByEmail(ctx, email)
+		if errors.Is(err, le.ErrUserNotFound) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		_, err = u.memberStorage.GetTaskMemberRole(ctx, taskID, user.ID)
+		if errors.Is(err, le.ErrListMemberNotFound) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		users = append(users, user)
+	}
+
+	return users, nil
+}
+
+// notifyMentionedUsers sends the comment to the mentioned users, the author isn't notified about their own mention.
+// The comment is already saved at this point, so the notification is best effort and its failure is only logged
+func (u *CommentUsecase) notifyMentionedUsers(ctx context.Context, comment model.CommentResponseData, users []model.User) {
+	const op = "comment.usecase.notifyMentionedUsers"
+
+	if len(users) == 0 {
+		return
+	}
+
+	task, err := u.taskStorage.GetTaskByID(ctx, comment.TaskID, comment.UserID)
+	if err != nil {
+		u.log.Error(
+			"failed to notify mentioned users",
+			slog.String("op", op),
+			slog.String("comment_id", comment.ID),
+			logger.Err(err),
+		)
+		return
+	}
+
+	for _, user := range users {
+		if user.ID == comment.UserID {
+			continue
+		}
+
+		publishEvent(ctx, u.eventBroker, model.EventCommentMentioned, user.ID, comment.ID, model.CommentMentionData{
+			Comment: comment,
+			ListID:  task.ListID,
+		})
+	}
+}
+
+// RecordTaskActivity adds the change of the task to its timeline
+func (u *CommentUsecase) RecordTaskActivity(ctx context.Context, activity model.TaskActivity) error {
+	activity.ID = ksuid.New().String()
+
+	if activity.CreatedAt.IsZero() {
+		activity.CreatedAt = time.Now()
+	}
+
+	return u.commentStorage.CreateTaskActivity(ctx, activity)
+}
+
+// GetTaskTimeline returns the comments and the changes of the task in the order they happened
+func (u *CommentUsecase) GetTaskTimeline(ctx context.Context, taskID string) ([]model.TimelineItem, error) {
+	comments, err := u.commentStorage.GetAllCommentsByTaskID(ctx, taskID)
+	if err != nil {
+		return nil, err
+	}
+
+	activities, err := u.commentStorage.GetTaskActivities(ctx, taskID)
+	if err != nil {
+		return nil, err
+	}
+
+	timeline := make([]model.TimelineItem, 0, len(comments)+len(activities))
+
+	for _, comment := range comments {
+		commentResp := mapCommentToResponseData(comment)
+		timeline = append(timeline, model.TimelineItem{
+			Type:      model.TimelineItemComment,
+			CreatedAt: comment.CreatedAt,
+			Comment:   &commentResp,
+		})
+	}
+
+	for _, activity := range activities {
+		activityResp := mapTaskActivityToResponseData(activity)
+		timeline = append(timeline, model.TimelineItem{
+			Type:      model.TimelineItemActivity,
+			CreatedAt: activity.CreatedAt,
+			Activity:  &activityResp,
+		})
+	}
+
+	sort.SliceStable(timeline, func(i, j int) bool {
+		return timeline[i].CreatedAt.Before(timeline[j].CreatedAt)
+	})
+
+	return timeline, nil
+}
+
+func mentionEmails(users []model.User) []string {
+	emails := make([]string, 0, len(users))
+
+	for _, user := range users {
+		emails = append(emails, user.Email)
+	}
+
+	return emails
+}
+
+func mapCommentToResponseData(comment model.Comment) model.CommentResponseData {
+	return model.CommentResponseData{
+		ID:        comment.ID,
+		TaskID:    comment.TaskID,
+		Body:      comment.Body,
+		Mentions:  comment.Mentions,
+		UserID:    comment.UserID,
+		Email:     comment.Email,
+		CreatedAt: comment.CreatedAt,
+		UpdatedAt: comment.UpdatedAt,
+	}
+}
+
+func mapTaskActivityToResponseData(activity model.TaskActivity) model.TaskActivityResponseData {
+	return model.TaskActivityResponseData{
+		ID:        activity.ID,
+		Kind:      activity.Kind,
+		Field:     activity.Field,
+		OldValue:  activity.OldValue,
+		NewValue:  activity.NewValue,
+		UserID:    activity.UserID,
+		Email:     activity.Email,
+		CreatedAt: activity.CreatedAt,
+	}
+}
@@ -0,0 +1,111 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/segmentio/ksuid"
+
+	"github.com/rshelekhov/reframed/internal/lib/constants/le"
+	"github.com/rshelekhov/reframed/internal/model"
+	"github.com/rshelekhov/reframed/internal/port"
+)
+
+// getTasksToDuplicate returns the tasks of the list with the snoozed ones,
+// leaving out the completed ones unless they are asked for
+func getTasksToDuplicate(
+	ctx context.Context,
+	taskStorage port.TaskStorage,
+	listID, userID string,
+	opts model.DuplicateOptions,
+) ([]model.Task, error) {
+	tasks, err := taskStorage.GetAllTasksByListID(ctx, listID, userID)
+	if errors.Is(err, le.ErrNoTasksFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if opts.IncludeCompleted {
+		return tasks, nil
+	}
+
+	statusCompleted, err := taskStorage.GetTaskStatusID(ctx, model.StatusCompleted)
+	if err != nil {
+		return nil, err
+	}
+
+	var openTasks []model.Task
+
+	for _, task := range tasks {
+		if task.StatusID != statusCompleted {
+			openTasks = append(openTasks, task)
+		}
+	}
+
+	return openTasks, nil
+}
+
+// copyTask creates the copy of the task with a new ID in the heading and links the same tags to it.
+// The copy belongs to the user who makes it, whoever created the original task.
+// The rules aren't run for the copy, so it stays the same as the original task
+func copyTask(
+	ctx context.Context,
+	taskStorage port.TaskStorage,
+	tagUsecase port.TagUsecase,
+	auditUsecase port.AuditUsecase,
+	task model.Task,
+	listID, headingID, userID string,
+	opts model.DuplicateOptions,
+) (model.TaskResponseData, error) {
+	newTask := task
+	newTask.ID = ksuid.New().String()
+	newTask.ListID = listID
+	newTask.HeadingID = headingID
+	newTask.UserID = userID
+	newTask.Overdue = false
+	newTask.Blocked = false
+	newTask.UpdatedAt = time.Now()
+
+	newTask.StartDate = shiftDate(newTask.StartDate, opts.ShiftDays)
+	newTask.Deadline = shiftDate(newTask.Deadline, opts.ShiftDays)
+
+	if err := taskStorage.CreateTask(ctx, newTask); err != nil {
+		return model.TaskResponseData{}, err
+	}
+	if err := tagUsecase.LinkTagsToTask(ctx, newTask.ID, newTask.Tags); err != nil {
+		return model.TaskResponseData{}, err
+	}
+
+	taskResp := mapTaskToResponseData(newTask)
+
+	if err := recordAudit(
+		ctx, auditUsecase, model.AuditActionCreate, model.AuditEntityTask, newTask.ID, newTask.UserID, nil, taskResp,
+	); err != nil {
+		return model.TaskResponseData{}, err
+	}
+
+	return taskResp, nil
+}
+
+// publishDuplicatedTasks notifies the members of the lists about the copied tasks
+func publishDuplicatedTasks(
+	ctx context.Context,
+	broker port.EventBroker,
+	memberStorage port.ListMemberStorage,
+	tasks []model.TaskResponseData,
+) {
+	for _, task := range tasks {
+		publishTaskEvent(ctx, broker, memberStorage, model.EventTaskCreated, task.ID, task.UserID, task)
+	}
+}
+
+// shiftDate moves the date by the number of days, the empty date stays empty
+func shiftDate(date time.Time, days int) time.Time {
+	if date.IsZero() {
+		return date
+	}
+	return date.AddDate(0, 0, days)
+}
@@ -0,0 +1,96 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/segmentio/ksuid"
+
+	"github.com/rshelekhov/reframed/internal/lib/aftercommit"
+	"github.com/rshelekhov/reframed/internal/model"
+	"github.com/rshelekhov/reframed/internal/port"
+)
+
+// publishEvent notifies the connected sessions of the user about the change.
+// Events are best effort: the change is already stored, so a failed delivery
+// doesn't fail the request, clients catch up on the next fetch.
+// Inside a transaction the event is published after the commit, so nobody hears about the changes
+// which are rolled back, and the subscribers storing the event don't write in the transaction of the change
+func publishEvent(ctx context.Context, broker port.EventBroker, eventType model.EventType, userID, entityID string, data any) {
+	if broker == nil {
+		return
+	}
+
+	event := model.Event{
+		ID:        ksuid.New().String(),
+		Type:      eventType,
+		UserID:    userID,
+		EntityID:  entityID,
+		Data:      data,
+		CreatedAt: time.Now(),
+	}
+
+	aftercommit.Do(ctx, func(ctx context.Context) {
+		_ = broker.Publish(ctx, event)
+	})
+}
+
+// publishListEvent notifies every member of the shared list about the change in it.
+// The user who made the change is always notified, even if the members can't be read
+func publishListEvent(
+	ctx context.Context,
+	broker port.EventBroker,
+	memberStorage port.ListMemberStorage,
+	eventType model.EventType,
+	listID, userID, entityID string,
+	data any,
+) {
+	if broker == nil {
+		return
+	}
+
+	var memberIDs []string
+
+	if members, err := memberStorage.GetListMembers(ctx, listID); err == nil {
+		for _, member := range members {
+			memberIDs = append(memberIDs, member.UserID)
+		}
+	}
+
+	publishMembersEvent(ctx, broker, eventType, memberIDs, userID, entityID, data)
+}
+
+// publishTaskEvent notifies every member of the list of the task about the change of the task
+func publishTaskEvent(
+	ctx context.Context,
+	broker port.EventBroker,
+	memberStorage port.ListMemberStorage,
+	eventType model.EventType,
+	taskID, userID string,
+	data any,
+) {
+	if broker == nil {
+		return
+	}
+
+	memberIDs, _ := memberStorage.GetTaskMemberIDs(ctx, taskID)
+
+	publishMembersEvent(ctx, broker, eventType, memberIDs, userID, taskID, data)
+}
+
+func publishMembersEvent(
+	ctx context.Context,
+	broker port.EventBroker,
+	eventType model.EventType,
+	memberIDs []string,
+	userID, entityID string,
+	data any,
+) {
+	publishEvent(ctx, broker, eventType, userID, entityID, data)
+
+	for _, memberID := range memberIDs {
+		if memberID != userID {
+			publishEvent(ctx, broker, eventType, memberID, entityID, data)
+		}
+	}
+}
@@ -0,0 +1,379 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/segmentio/ksuid"
+
+	"github.com/rshelekhov/reframed/internal/lib/constants/le"
+	"github.com/rshelekhov/reframed/internal/model"
+	"github.com/rshelekhov/reframed/internal/port"
+)
+
+type GoalUsecase struct {
+	goalStorage port.GoalStorage
+}
+
+func NewGoalUsecase(storage port.GoalStorage) *GoalUsecase {
+	return &GoalUsecase{
+		goalStorage: storage,
+	}
+}
+
+func (u *GoalUsecase) CreateGoal(ctx context.Context, data *model.GoalRequestData) (model.GoalResponseData, error) {
+	newGoal := model.Goal{
+		ID:          ksuid.New().String(),
+		Title:       data.Title,
+		Description: data.Description,
+		TargetDate:  data.TargetDate,
+		UserID:      data.UserID,
+		UpdatedAt:   time.Now(),
+	}
+
+	var goalResp model.GoalResponseData
+
+	if err := u.goalStorage.Transaction(ctx, func(ctx context.Context) error {
+		if err := u.goalStorage.CreateGoal(ctx, newGoal); err != nil {
+			return err
+		}
+
+		var err error
+		goalResp, err = u.refreshGoal(ctx, newGoal.ID, newGoal.UserID)
+		return err
+	}); err != nil {
+		return model.GoalResponseData{}, err
+	}
+
+	return goalResp, nil
+}
+
+func (u *GoalUsecase) GetGoalByID(ctx context.Context, data model.GoalRequestData) (model.GoalResponseData, error) {
+	goal, err := u.goalStorage.GetGoalByID(ctx, data.ID, data.UserID)
+	if err != nil {
+		return model.GoalResponseData{}, err
+	}
+
+	return u.getGoalResponseData(ctx, goal)
+}
+
+func (u *GoalUsecase) GetGoalsByUserID(ctx context.Context, userID string) ([]model.GoalResponseData, error) {
+	goals, err := u.goalStorage.GetGoalsByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	var goalsResp []model.GoalResponseData
+
+	for _, goal := range goals {
+		goalResp, err := u.getGoalResponseData(ctx, goal)
+		if err != nil {
+			return nil, err
+		}
+
+		goalsResp = append(goalsResp, goalResp)
+	}
+
+	return goalsResp, nil
+}
+
+// GetGoalProgressHistory returns the snapshots of the goal progress, from the oldest to the latest.
+// The snapshot is recorded every time the progress changes
+func (u *GoalUsecase) GetGoalProgressHistory(ctx context.Context, data model.GoalRequestData) ([]model.GoalProgress, error) {
+	if _, err := u.goalStorage.GetGoalByID(ctx, data.ID, data.UserID); err != nil {
+		return nil, err
+	}
+
+	history, err := u.goalStorage.GetGoalProgressHistory(ctx, data.ID)
+	if err != nil {
+		return nil, err
+	}
+	if history == nil {
+		history = []model.GoalProgress{}
+	}
+
+	return history, nil
+}
+
+func (u *GoalUsecase) getGoalResponseData(ctx context.Context, goal model.Goal) (model.GoalResponseData, error) {
+	keyResults, err := u.goalStorage.GetKeyResultsByGoalID(ctx, goal.ID, goal.UserID)
+	if err != nil {
+		return model.GoalResponseData{}, err
+	}
+
+	counts, err := u.goalStorage.GetGoalTaskCounts(ctx, goal.ID, goal.UserID)
+	if err != nil {
+		return model.GoalResponseData{}, err
+	}
+
+	listIDs, err := u.goalStorage.GetGoalListIDs(ctx, goal.ID, goal.UserID)
+	if err != nil {
+		return model.GoalResponseData{}, err
+	}
+
+	taskIDs, err := u.goalStorage.GetGoalTaskIDs(ctx, goal.ID, goal.UserID)
+	if err != nil {
+		return model.GoalResponseData{}, err
+	}
+
+	goalResp := model.GoalResponseData{
+		ID:             goal.ID,
+		Title:          goal.Title,
+		Description:    goal.Description,
+		TargetDate:     goal.TargetDate,
+		TasksTotal:     counts.Total,
+		TasksCompleted: counts.Completed,
+		KeyResults:     make([]model.KeyResultResponseData, 0, len(keyResults)),
+		ListIDs:        listIDs,
+		TaskIDs:        taskIDs,
+		UserID:         goal.UserID,
+		UpdatedAt:      goal.UpdatedAt,
+	}
+
+	tasksProgress := progressBetween(0, float64(counts.Total), float64(counts.Completed))
+
+	for _, keyResult := range keyResults {
+		keyResultResp := model.KeyResultResponseData{
+			ID:           keyResult.ID,
+			GoalID:       keyResult.GoalID,
+			Title:        keyResult.Title,
+			Kind:         keyResult.Kind,
+			StartValue:   keyResult.StartValue,
+			TargetValue:  keyResult.TargetValue,
+			CurrentValue: keyResult.CurrentValue,
+			UpdatedAt:    keyResult.UpdatedAt,
+		}
+
+		if keyResult.Kind == model.KeyResultKindTasks {
+			keyResultResp.StartValue = 0
+			keyResultResp.TargetValue = float64(counts.Total)
+			keyResultResp.CurrentValue = float64(counts.Completed)
+			keyResultResp.Progress = tasksProgress
+		} else {
+			keyResultResp.Progress = progressBetween(keyResult.StartValue, keyResult.TargetValue, keyResult.CurrentValue)
+		}
+
+		goalResp.KeyResults = append(goalResp.KeyResults, keyResultResp)
+		goalResp.Progress += keyResultResp.Progress
+	}
+
+	if len(goalResp.KeyResults) > 0 {
+		goalResp.Progress /= float64(len(goalResp.KeyResults))
+	} else {
+		goalResp.Progress = tasksProgress
+	}
+
+	return goalResp, nil
+}
+
+// progressBetween returns the share of the way from the start value to the target value, from 0 to 1
+func progressBetween(start, target, current float64) float64 {
+	if target == start {
+		return 0
+	}
+
+	progress := (current - start) / (target - start)
+
+	switch {
+	case progress < 0:
+		return 0
+	case progress > 1:
+		return 1
+	default:
+		return progress
+	}
+}
+
+// refreshGoal returns the goal with its current progress and records the progress to the history
+func (u *GoalUsecase) refreshGoal(ctx context.Context, goalID, userID string) (model.GoalResponseData, error) {
+	goal, err := u.goalStorage.GetGoalByID(ctx, goalID, userID)
+	if err != nil {
+		return model.GoalResponseData{}, err
+	}
+
+	goalResp, err := u.getGoalResponseData(ctx, goal)
+	if err != nil {
+		return model.GoalResponseData{}, err
+	}
+
+	if err = u.goalStorage.CreateGoalProgress(ctx, goal.ID, model.GoalProgress{
+		Progress:   goalResp.Progress,
+		RecordedAt: time.Now(),
+	}); err != nil {
+		return model.GoalResponseData{}, err
+	}
+
+	return goalResp, nil
+}
+
+func (u *GoalUsecase) UpdateGoal(ctx context.Context, data *model.GoalRequestData) (model.GoalResponseData, error) {
+	updatedGoal := model.Goal{
+		ID:          data.ID,
+		Title:       data.Title,
+		Description: data.Description,
+		TargetDate:  data.TargetDate,
+		UserID:      data.UserID,
+		UpdatedAt:   time.Now(),
+	}
+
+	if err := u.goalStorage.UpdateGoal(ctx, updatedGoal); err != nil {
+		return model.GoalResponseData{}, err
+	}
+
+	return u.GetGoalByID(ctx, *data)
+}
+
+// DeleteGoal deletes the goal and unlinks its lists and tasks
+func (u *GoalUsecase) DeleteGoal(ctx context.Context, data model.GoalRequestData) error {
+	return u.goalStorage.Transaction(ctx, func(ctx context.Context) error {
+		if err := u.goalStorage.UnlinkGoal(ctx, data.ID, data.UserID); err != nil {
+			return err
+		}
+
+		return u.goalStorage.DeleteGoal(ctx, model.Goal{
+			ID:        data.ID,
+			UserID:    data.UserID,
+			DeletedAt: time.Now(),
+		})
+	})
+}
+
+func (u *GoalUsecase) CreateKeyResult(ctx context.Context, data *model.KeyResultRequestData) (model.GoalResponseData, error) {
+	newKeyResult, err := newKeyResultFromRequestData(data)
+	if err != nil {
+		return model.GoalResponseData{}, err
+	}
+
+	newKeyResult.ID = ksuid.New().String()
+
+	return u.changeGoal(ctx, data.GoalID, data.UserID, func(ctx context.Context) error {
+		return u.goalStorage.CreateKeyResult(ctx, newKeyResult)
+	})
+}
+
+func (u *GoalUsecase) UpdateKeyResult(ctx context.Context, data *model.KeyResultRequestData) (model.GoalResponseData, error) {
+	updatedKeyResult, err := newKeyResultFromRequestData(data)
+	if err != nil {
+		return model.GoalResponseData{}, err
+	}
+
+	return u.changeGoal(ctx, data.GoalID, data.UserID, func(ctx context.Context) error {
+		return u.goalStorage.UpdateKeyResult(ctx, updatedKeyResult)
+	})
+}
+
+// newKeyResultFromRequestData validates the key result. The values of the key result
+// measured by tasks are computed from the linked tasks, so they aren't stored
+func newKeyResultFromRequestData(data *model.KeyResultRequestData) (model.KeyResult, error) {
+	keyResult := model.KeyResult{
+		ID:        data.ID,
+		GoalID:    data.GoalID,
+		Title:     data.Title,
+		Kind:      data.Kind,
+		UserID:    data.UserID,
+		UpdatedAt: time.Now(),
+	}
+
+	if data.Kind == model.KeyResultKindManual {
+		if data.TargetValue == data.StartValue {
+			return model.KeyResult{}, le.ErrInvalidKeyResult
+		}
+
+		keyResult.StartValue = data.StartValue
+		keyResult.TargetValue = data.TargetValue
+		keyResult.CurrentValue = data.CurrentValue
+	}
+
+	return keyResult, nil
+}
+
+func (u *GoalUsecase) DeleteKeyResult(ctx context.Context, data model.KeyResultRequestData) (model.GoalResponseData, error) {
+	return u.changeGoal(ctx, data.GoalID, data.UserID, func(ctx context.Context) error {
+		return u.goalStorage.DeleteKeyResult(ctx, model.KeyResult{
+			ID:        data.ID,
+			GoalID:    data.GoalID,
+			UserID:    data.UserID,
+			DeletedAt: time.Now(),
+		})
+	})
+}
+
+func (u *GoalUsecase) LinkListToGoal(ctx context.Context, data model.GoalLinkRequestData) (model.GoalResponseData, error) {
+	return u.changeGoal(ctx, data.GoalID, data.UserID, func(ctx context.Context) error {
+		return u.goalStorage.LinkListToGoal(ctx, data, time.Now())
+	})
+}
+
+func (u *GoalUsecase) UnlinkListFromGoal(ctx context.Context, data model.GoalLinkRequestData) (model.GoalResponseData, error) {
+	return u.changeGoal(ctx, data.GoalID, data.UserID, func(ctx context.Context) error {
+		return u.goalStorage.UnlinkListFromGoal(ctx, data, time.Now())
+	})
+}
+
+// LinkTaskToGoal links the task to the goal. The task keeps counting to the goal of its list, if any
+func (u *GoalUsecase) LinkTaskToGoal(ctx context.Context, data model.GoalLinkRequestData) (model.GoalResponseData, error) {
+	return u.changeGoal(ctx, data.GoalID, data.UserID, func(ctx context.Context) error {
+		return u.goalStorage.LinkTaskToGoal(ctx, data, time.Now())
+	})
+}
+
+func (u *GoalUsecase) UnlinkTaskFromGoal(ctx context.Context, data model.GoalLinkRequestData) (model.GoalResponseData, error) {
+	return u.changeGoal(ctx, data.GoalID, data.UserID, func(ctx context.Context) error {
+		return u.goalStorage.UnlinkTaskFromGoal(ctx, data, time.Now())
+	})
+}
+
+// changeGoal checks the goal exists, runs the change and records the new progress of the goal in one transaction
+func (u *GoalUsecase) changeGoal(
+	ctx context.Context,
+	goalID, userID string,
+	change func(ctx context.Context) error,
+) (model.GoalResponseData, error) {
+	var goalResp model.GoalResponseData
+
+	if err := u.goalStorage.Transaction(ctx, func(ctx context.Context) error {
+		if _, err := u.goalStorage.GetGoalByID(ctx, goalID, userID); err != nil {
+			return err
+		}
+
+		if err := change(ctx); err != nil {
+			return err
+		}
+
+		var err error
+		goalResp, err = u.refreshGoal(ctx, goalID, userID)
+		return err
+	}); err != nil {
+		return model.GoalResponseData{}, err
+	}
+
+	return goalResp, nil
+}
+
+// Publish records the progress of the goals the changed task is linked to,
+// so the progress history follows the tasks being created and completed.
+// The progress is counted for the owner of the goal, since the task may be changed by another member of the list
+func (u *GoalUsecase) Publish(ctx context.Context, event model.Event) error {
+	switch event.Type {
+	case model.EventTaskCreated, model.EventTaskUpdated, model.EventTaskMoved,
+		model.EventTaskCompleted, model.EventTaskArchived:
+	default:
+		return nil
+	}
+
+	goals, err := u.goalStorage.GetGoalsByTaskID(ctx, event.EntityID)
+	if err != nil {
+		return err
+	}
+
+	var errs []error
+
+	for _, goal := range goals {
+		if _, err = u.refreshGoal(ctx, goal.ID, goal.UserID); err != nil && !errors.Is(err, le.ErrGoalNotFound) {
+			errs = append(errs, err)
+		}
+	}
+
+	return errors.Join(errs...)
+}
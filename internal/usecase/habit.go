@@ -0,0 +1,436 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/segmentio/ksuid"
+
+	"github.com/rshelekhov/reframed/internal/lib/constants/le"
+	"github.com/rshelekhov/reframed/internal/model"
+	"github.com/rshelekhov/reframed/internal/port"
+)
+
+const (
+	// defaultHeatmapDays is the range of the heatmap when it isn't given, a year ending today
+	defaultHeatmapDays = 365
+	// maxHeatmapDays limits the range of the heatmap, so it can't be asked for thousands of days
+	maxHeatmapDays = 366
+)
+
+// HabitUsecase counts the days of the check-ins in the time zone of the user. The days are kept
+// as dates at midnight UTC, so they compare the same whatever the time zone of the server is
+type HabitUsecase struct {
+	habitStorage port.HabitStorage
+	authStorage  port.AuthStorage
+}
+
+func NewHabitUsecase(habitStorage port.HabitStorage, authStorage port.AuthStorage) *HabitUsecase {
+	return &HabitUsecase{
+		habitStorage: habitStorage,
+		authStorage:  authStorage,
+	}
+}
+
+func (u *HabitUsecase) CreateHabit(ctx context.Context, data *model.HabitRequestData) (model.HabitResponseData, error) {
+	newHabit := model.Habit{
+		ID:           ksuid.New().String(),
+		Title:        data.Title,
+		Notes:        data.Notes,
+		Frequency:    data.Frequency,
+		TimesPerWeek: habitTimesPerWeek(data.Frequency, data.TimesPerWeek),
+		UserID:       data.UserID,
+		UpdatedAt:    time.Now(),
+	}
+
+	if err := u.habitStorage.CreateHabit(ctx, newHabit); err != nil {
+		return model.HabitResponseData{}, err
+	}
+
+	today, err := u.userToday(ctx, data.UserID)
+	if err != nil {
+		return model.HabitResponseData{}, err
+	}
+
+	return getHabitResponseData(newHabit, nil, today), nil
+}
+
+func (u *HabitUsecase) GetHabitByID(ctx context.Context, data model.HabitRequestData) (model.HabitResponseData, error) {
+	habit, err := u.habitStorage.GetHabitByID(ctx, data.ID, data.UserID)
+	if err != nil {
+		return model.HabitResponseData{}, err
+	}
+
+	return u.refreshHabit(ctx, habit)
+}
+
+func (u *HabitUsecase) GetHabitsByUserID(ctx context.Context, userID string) ([]model.HabitResponseData, error) {
+	habitsResp, err := u.getHabits(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if len(habitsResp) == 0 {
+		return nil, le.ErrNoHabitsFound
+	}
+
+	return habitsResp, nil
+}
+
+// GetHabitsForToday returns the habits to do today: the daily habits and the weekly habits which target
+// of the week isn't reached yet. The habits checked in today are kept, so they can be unchecked
+func (u *HabitUsecase) GetHabitsForToday(ctx context.Context, userID string) ([]model.HabitResponseData, error) {
+	habitsResp, err := u.getHabits(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	todayHabits := make([]model.HabitResponseData, 0, len(habitsResp))
+
+	for _, habit := range habitsResp {
+		if habit.Frequency == model.HabitFrequencyWeekly && habit.WeekCheckIns >= habit.TimesPerWeek && !habit.CheckedInToday {
+			continue
+		}
+
+		todayHabits = append(todayHabits, habit)
+	}
+
+	return todayHabits, nil
+}
+
+func (u *HabitUsecase) UpdateHabit(ctx context.Context, data *model.HabitRequestData) (model.HabitResponseData, error) {
+	updatedHabit := model.Habit{
+		ID:           data.ID,
+		Title:        data.Title,
+		Notes:        data.Notes,
+		Frequency:    data.Frequency,
+		TimesPerWeek: habitTimesPerWeek(data.Frequency, data.TimesPerWeek),
+		UserID:       data.UserID,
+		UpdatedAt:    time.Now(),
+	}
+
+	if err := u.habitStorage.UpdateHabit(ctx, updatedHabit); err != nil {
+		return model.HabitResponseData{}, err
+	}
+
+	return u.refreshHabit(ctx, updatedHabit)
+}
+
+func (u *HabitUsecase) DeleteHabit(ctx context.Context, data model.HabitRequestData) error {
+	return u.habitStorage.DeleteHabit(ctx, model.Habit{
+		ID:        data.ID,
+		UserID:    data.UserID,
+		DeletedAt: time.Now(),
+	})
+}
+
+// CheckInHabit marks the habit as done on the day, checking in the same day again changes nothing
+func (u *HabitUsecase) CheckInHabit(ctx context.Context, data model.HabitCheckInRequestData) (model.HabitResponseData, error) {
+	habit, day, err := u.getHabitCheckInDay(ctx, data)
+	if err != nil {
+		return model.HabitResponseData{}, err
+	}
+
+	if err = u.habitStorage.CreateHabitCheckIn(ctx, model.HabitCheckIn{
+		HabitID: habit.ID,
+		Day:     day,
+	}); err != nil {
+		return model.HabitResponseData{}, err
+	}
+
+	return u.refreshHabit(ctx, habit)
+}
+
+func (u *HabitUsecase) UndoHabitCheckIn(ctx context.Context, data model.HabitCheckInRequestData) (model.HabitResponseData, error) {
+	habit, day, err := u.getHabitCheckInDay(ctx, data)
+	if err != nil {
+		return model.HabitResponseData{}, err
+	}
+
+	if err = u.habitStorage.DeleteHabitCheckIn(ctx, model.HabitCheckIn{
+		HabitID: habit.ID,
+		Day:     day,
+	}); err != nil {
+		return model.HabitResponseData{}, err
+	}
+
+	return u.refreshHabit(ctx, habit)
+}
+
+// GetHabitHeatmap returns every day of the range with the check-in state, by default the last year
+func (u *HabitUsecase) GetHabitHeatmap(ctx context.Context, data model.HabitHeatmapRequestData) (model.HabitHeatmapResponseData, error) {
+	habit, err := u.habitStorage.GetHabitByID(ctx, data.HabitID, data.UserID)
+	if err != nil {
+		return model.HabitHeatmapResponseData{}, err
+	}
+
+	today, err := u.userToday(ctx, data.UserID)
+	if err != nil {
+		return model.HabitHeatmapResponseData{}, err
+	}
+
+	to := today
+	if data.To != "" {
+		if to, err = time.Parse(time.DateOnly, data.To); err != nil {
+			return model.HabitHeatmapResponseData{}, le.ErrInvalidHeatmapRange
+		}
+	}
+
+	from := to.AddDate(0, 0, -defaultHeatmapDays+1)
+	if data.From != "" {
+		if from, err = time.Parse(time.DateOnly, data.From); err != nil {
+			return model.HabitHeatmapResponseData{}, le.ErrInvalidHeatmapRange
+		}
+	}
+
+	if from.After(to) || to.Sub(from) >= maxHeatmapDays*24*time.Hour {
+		return model.HabitHeatmapResponseData{}, le.ErrInvalidHeatmapRange
+	}
+
+	checkIns, err := u.habitStorage.GetHabitCheckInsInRange(ctx, habit.ID, from, to)
+	if err != nil {
+		return model.HabitHeatmapResponseData{}, err
+	}
+
+	checkedIn := make(map[string]bool, len(checkIns))
+	for _, day := range checkIns {
+		checkedIn[day.Format(time.DateOnly)] = true
+	}
+
+	heatmapResp := model.HabitHeatmapResponseData{
+		HabitID: habit.ID,
+		From:    from.Format(time.DateOnly),
+		To:      to.Format(time.DateOnly),
+		Total:   len(checkIns),
+		Days:    []model.HabitHeatmapDay{},
+	}
+
+	for day := from; !day.After(to); day = day.AddDate(0, 0, 1) {
+		date := day.Format(time.DateOnly)
+
+		heatmapResp.Days = append(heatmapResp.Days, model.HabitHeatmapDay{
+			Date:      date,
+			CheckedIn: checkedIn[date],
+		})
+	}
+
+	return heatmapResp, nil
+}
+
+func (u *HabitUsecase) getHabits(ctx context.Context, userID string) ([]model.HabitResponseData, error) {
+	habits, err := u.habitStorage.GetHabitsByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if len(habits) == 0 {
+		return []model.HabitResponseData{}, nil
+	}
+
+	checkIns, err := u.habitStorage.GetHabitCheckIns(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	today, err := u.userToday(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	habitsResp := make([]model.HabitResponseData, 0, len(habits))
+
+	for _, habit := range habits {
+		habitsResp = append(habitsResp, getHabitResponseData(habit, checkIns[habit.ID], today))
+	}
+
+	return habitsResp, nil
+}
+
+func (u *HabitUsecase) refreshHabit(ctx context.Context, habit model.Habit) (model.HabitResponseData, error) {
+	checkIns, err := u.habitStorage.GetHabitCheckIns(ctx, habit.UserID)
+	if err != nil {
+		return model.HabitResponseData{}, err
+	}
+
+	today, err := u.userToday(ctx, habit.UserID)
+	if err != nil {
+		return model.HabitResponseData{}, err
+	}
+
+	return getHabitResponseData(habit, checkIns[habit.ID], today), nil
+}
+
+// getHabitCheckInDay returns the habit and the day of the check-in, today of the user if the date isn't given.
+// The days after today can't be checked in
+func (u *HabitUsecase) getHabitCheckInDay(ctx context.Context, data model.HabitCheckInRequestData) (model.Habit, time.Time, error) {
+	habit, err := u.habitStorage.GetHabitByID(ctx, data.HabitID, data.UserID)
+	if err != nil {
+		return model.Habit{}, time.Time{}, err
+	}
+
+	today, err := u.userToday(ctx, data.UserID)
+	if err != nil {
+		return model.Habit{}, time.Time{}, err
+	}
+
+	if data.Date == "" {
+		return habit, today, nil
+	}
+
+	day, err := time.Parse(time.DateOnly, data.Date)
+	if err != nil || day.After(today) {
+		return model.Habit{}, time.Time{}, le.ErrInvalidHabitCheckInDate
+	}
+
+	return habit, day, nil
+}
+
+// userToday returns the current date in the time zone of the user. The zone is validated when it's set,
+// but the time zone database of the server may lack it, then the date is counted in UTC
+func (u *HabitUsecase) userToday(ctx context.Context, userID string) (time.Time, error) {
+	user, err := u.authStorage.GetUserByID(ctx, userID)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	loc, err := time.LoadLocation(user.TimeZone)
+	if err != nil {
+		loc = time.UTC
+	}
+
+	now := time.Now().In(loc)
+
+	return time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC), nil
+}
+
+// habitTimesPerWeek returns the target of the week, the daily habit is done every day
+// and the weekly habit at least once if the target isn't given
+func habitTimesPerWeek(frequency model.HabitFrequency, timesPerWeek int) int {
+	switch {
+	case frequency == model.HabitFrequencyDaily:
+		return 7
+	case timesPerWeek == 0:
+		return 1
+	default:
+		return timesPerWeek
+	}
+}
+
+// getHabitResponseData counts the streaks of the habit from the check-in days sorted in ascending order
+func getHabitResponseData(habit model.Habit, checkIns []time.Time, today time.Time) model.HabitResponseData {
+	checkedIn := make(map[string]bool, len(checkIns))
+	for _, day := range checkIns {
+		checkedIn[day.Format(time.DateOnly)] = true
+	}
+
+	weekStart := startOfWeek(today)
+	weekCheckIns := 0
+
+	for _, day := range checkIns {
+		if !day.Before(weekStart) && day.Before(weekStart.AddDate(0, 0, 7)) {
+			weekCheckIns++
+		}
+	}
+
+	habitResp := model.HabitResponseData{
+		ID:             habit.ID,
+		Title:          habit.Title,
+		Notes:          habit.Notes,
+		Frequency:      habit.Frequency,
+		Today:          today.Format(time.DateOnly),
+		CheckedInToday: checkedIn[today.Format(time.DateOnly)],
+		WeekCheckIns:   weekCheckIns,
+		UpdatedAt:      habit.UpdatedAt,
+	}
+
+	if habit.Frequency == model.HabitFrequencyWeekly {
+		habitResp.TimesPerWeek = habit.TimesPerWeek
+		habitResp.StreakUnit = model.StreakUnitWeeks
+		habitResp.CurrentStreak, habitResp.LongestStreak = weeklyStreaks(checkIns, habit.TimesPerWeek, today)
+	} else {
+		habitResp.StreakUnit = model.StreakUnitDays
+		habitResp.CurrentStreak, habitResp.LongestStreak = dailyStreaks(checkedIn, checkIns, today)
+	}
+
+	return habitResp
+}
+
+// dailyStreaks counts the days in a row with a check-in. The current streak isn't broken
+// until today is over, so it's counted from yesterday if today isn't checked in yet
+func dailyStreaks(checkedIn map[string]bool, checkIns []time.Time, today time.Time) (current, longest int) {
+	day := today
+	if !checkedIn[day.Format(time.DateOnly)] {
+		day = day.AddDate(0, 0, -1)
+	}
+
+	for checkedIn[day.Format(time.DateOnly)] {
+		current++
+		day = day.AddDate(0, 0, -1)
+	}
+
+	run := 0
+	for i, day := range checkIns {
+		if i > 0 && day.Equal(checkIns[i-1].AddDate(0, 0, 1)) {
+			run++
+		} else {
+			run = 1
+		}
+
+		longest = max(longest, run)
+	}
+
+	return current, longest
+}
+
+// weeklyStreaks counts the weeks in a row which target was reached. The current week
+// isn't over, so it's only counted once the target is reached
+func weeklyStreaks(checkIns []time.Time, timesPerWeek int, today time.Time) (current, longest int) {
+	weekCheckIns := make(map[string]int)
+	var weeks []time.Time
+
+	for _, day := range checkIns {
+		week := startOfWeek(day)
+		key := week.Format(time.DateOnly)
+
+		if weekCheckIns[key] == 0 {
+			weeks = append(weeks, week)
+		}
+		weekCheckIns[key]++
+	}
+
+	reached := func(week time.Time) bool {
+		return weekCheckIns[week.Format(time.DateOnly)] >= timesPerWeek
+	}
+
+	week := startOfWeek(today)
+	if !reached(week) {
+		week = week.AddDate(0, 0, -7)
+	}
+
+	for reached(week) {
+		current++
+		week = week.AddDate(0, 0, -7)
+	}
+
+	run := 0
+	var previous time.Time
+
+	for _, week := range weeks {
+		switch {
+		case !reached(week):
+			run = 0
+		case run > 0 && week.Equal(previous.AddDate(0, 0, 7)):
+			run++
+		default:
+			run = 1
+		}
+
+		previous = week
+		longest = max(longest, run)
+	}
+
+	return current, longest
+}
+
+// startOfWeek returns the Monday of the week of the day
+func startOfWeek(day time.Time) time.Time {
+	return day.AddDate(0, 0, -(int(day.Weekday())+6)%7)
+}
@@ -6,21 +6,46 @@ import (
 
 	"github.com/segmentio/ksuid"
 
+	"github.com/rshelekhov/reframed/internal/lib/constants/le"
 	"github.com/rshelekhov/reframed/internal/model"
 	"github.com/rshelekhov/reframed/internal/port"
 )
 
 type HeadingUsecase struct {
 	headingStorage port.HeadingStorage
+	taskStorage    port.TaskStorage
+	memberStorage  port.ListMemberStorage
+	tagUsecase     port.TagUsecase
+	auditUsecase   port.AuditUsecase
+	undoUsecase    port.UndoUsecase
+	eventBroker    port.EventBroker
 }
 
-func NewHeadingUsecase(storage port.HeadingStorage) *HeadingUsecase {
+func NewHeadingUsecase(
+	storage port.HeadingStorage,
+	taskStorage port.TaskStorage,
+	memberStorage port.ListMemberStorage,
+	tagUsecase port.TagUsecase,
+	auditUsecase port.AuditUsecase,
+	undoUsecase port.UndoUsecase,
+	eventBroker port.EventBroker,
+) *HeadingUsecase {
 	return &HeadingUsecase{
 		headingStorage: storage,
+		taskStorage:    taskStorage,
+		memberStorage:  memberStorage,
+		tagUsecase:     tagUsecase,
+		auditUsecase:   auditUsecase,
+		undoUsecase:    undoUsecase,
+		eventBroker:    eventBroker,
 	}
 }
 
 func (u *HeadingUsecase) CreateHeading(ctx context.Context, data *model.HeadingRequestData) (model.HeadingResponseData, error) {
+	if err := authorizeList(ctx, u.memberStorage, data.ListID, data.UserID, model.ListRoleEditor); err != nil {
+		return model.HeadingResponseData{}, err
+	}
+
 	newHeading := model.Heading{
 		ID:        ksuid.New().String(),
 		Title:     data.Title,
@@ -30,21 +55,49 @@ func (u *HeadingUsecase) CreateHeading(ctx context.Context, data *model.HeadingR
 		UpdatedAt: time.Now(),
 	}
 
-	if err := u.headingStorage.CreateHeading(ctx, newHeading); err != nil {
+	headingResp := mapHeadingToResponseData(newHeading)
+
+	var undoToken string
+
+	if err := u.memberStorage.Transaction(ctx, func(ctx context.Context) error {
+		err := u.headingStorage.CreateHeading(ctx, newHeading)
+		if err != nil {
+			return err
+		}
+
+		undoToken, err = u.undoUsecase.CreateUndoToken(ctx, model.UndoRecordData{
+			EntityType: model.AuditEntityHeading,
+			EntityID:   newHeading.ID,
+			Action:     model.AuditActionCreate,
+			UserID:     newHeading.UserID,
+			Created:    true,
+		})
+		if err != nil {
+			return err
+		}
+
+		return recordAudit(ctx, u.auditUsecase, model.AuditActionCreate, model.AuditEntityHeading, newHeading.ID, newHeading.UserID, nil, headingResp)
+	}); err != nil {
 		return model.HeadingResponseData{}, err
 	}
 
-	return model.HeadingResponseData{
-		ID:        newHeading.ID,
-		Title:     newHeading.Title,
-		ListID:    newHeading.ListID,
-		UserID:    newHeading.UserID,
-		UpdatedAt: newHeading.UpdatedAt,
-	}, nil
+	publishListEvent(ctx, u.eventBroker, u.memberStorage, model.EventHeadingCreated, headingResp.ListID, headingResp.UserID, headingResp.ID, headingResp)
+
+	headingResp.UndoToken = undoToken
+
+	return headingResp, nil
 }
 
 func (u *HeadingUsecase) CreateDefaultHeading(ctx context.Context, heading model.Heading) error {
-	return u.headingStorage.CreateHeading(ctx, heading)
+	return u.memberStorage.Transaction(ctx, func(ctx context.Context) error {
+		if err := u.headingStorage.CreateHeading(ctx, heading); err != nil {
+			return err
+		}
+
+		return recordAudit(
+			ctx, u.auditUsecase, model.AuditActionCreate, model.AuditEntityHeading, heading.ID, heading.UserID, nil, mapHeadingToResponseData(heading),
+		)
+	})
 }
 
 func (u *HeadingUsecase) GetHeadingByID(ctx context.Context, data model.HeadingRequestData) (model.HeadingResponseData, error) {
@@ -92,6 +145,10 @@ func mapHeadingToResponseData(heading model.Heading) model.HeadingResponseData {
 }
 
 func (u *HeadingUsecase) UpdateHeading(ctx context.Context, data *model.HeadingRequestData) (model.HeadingResponseData, error) {
+	if err := authorizeHeading(ctx, u.memberStorage, data.ID, data.UserID, model.ListRoleEditor); err != nil {
+		return model.HeadingResponseData{}, err
+	}
+
 	updatedHeading := model.Heading{
 		ID:        data.ID,
 		Title:     data.Title,
@@ -100,20 +157,74 @@ func (u *HeadingUsecase) UpdateHeading(ctx context.Context, data *model.HeadingR
 		UpdatedAt: time.Now(),
 	}
 
-	if err := u.headingStorage.UpdateHeading(ctx, updatedHeading); err != nil {
+	var undoToken string
+
+	if err := u.memberStorage.Transaction(ctx, func(ctx context.Context) error {
+		currentHeading, err := u.headingStorage.GetHeadingByID(ctx, updatedHeading.ID, updatedHeading.UserID)
+		if err != nil {
+			return err
+		}
+
+		undoToken, err = u.createHeadingUndoToken(ctx, model.AuditActionUpdate, updatedHeading, false)
+		if err != nil {
+			return err
+		}
+
+		if err = u.headingStorage.UpdateHeading(ctx, updatedHeading); err != nil {
+			return err
+		}
+
+		return u.auditHeading(ctx, model.AuditActionUpdate, currentHeading, updatedHeading.UserID)
+	}); err != nil {
 		return model.HeadingResponseData{}, err
 	}
 
-	return model.HeadingResponseData{
-		ID:        updatedHeading.ID,
-		Title:     updatedHeading.Title,
-		ListID:    updatedHeading.ListID,
-		UserID:    updatedHeading.UserID,
-		UpdatedAt: updatedHeading.UpdatedAt,
-	}, nil
+	headingResp := mapHeadingToResponseData(updatedHeading)
+
+	publishListEvent(ctx, u.eventBroker, u.memberStorage, model.EventHeadingUpdated, headingResp.ListID, headingResp.UserID, headingResp.ID, headingResp)
+
+	headingResp.UndoToken = undoToken
+
+	return headingResp, nil
 }
 
+// PatchHeading applies a JSON merge patch to the heading, changing only the fields present in the patch.
+// The list of the heading can't be changed with a patch, use MoveHeadingToAnotherList instead
+func (u *HeadingUsecase) PatchHeading(ctx context.Context, data model.HeadingRequestData, patch []byte) (model.HeadingResponseData, error) {
+	currentHeading, err := u.headingStorage.GetHeadingByID(ctx, data.ID, data.UserID)
+	if err != nil {
+		return model.HeadingResponseData{}, err
+	}
+
+	current := model.HeadingRequestData{
+		ID:     currentHeading.ID,
+		Title:  currentHeading.Title,
+		ListID: currentHeading.ListID,
+		UserID: data.UserID,
+	}
+
+	patched := &model.HeadingRequestData{}
+	if err = applyMergePatch(current, patch, patched); err != nil {
+		return model.HeadingResponseData{}, err
+	}
+
+	patched.ID = data.ID
+	patched.ListID = currentHeading.ListID
+	patched.UserID = data.UserID
+
+	return u.UpdateHeading(ctx, patched)
+}
+
+// MoveHeadingToAnotherList moves the heading with its tasks, the user must be able to edit both lists
 func (u *HeadingUsecase) MoveHeadingToAnotherList(ctx context.Context, data model.HeadingRequestData) (model.HeadingResponseData, error) {
+	if err := authorizeHeading(ctx, u.memberStorage, data.ID, data.UserID, model.ListRoleEditor); err != nil {
+		return model.HeadingResponseData{}, err
+	}
+
+	if err := authorizeList(ctx, u.memberStorage, data.ListID, data.UserID, model.ListRoleEditor); err != nil {
+		return model.HeadingResponseData{}, err
+	}
+
 	updatedHeading := model.Heading{
 		ID:        data.ID,
 		ListID:    data.ListID,
@@ -128,25 +239,258 @@ func (u *HeadingUsecase) MoveHeadingToAnotherList(ctx context.Context, data mode
 		UpdatedAt: time.Now(),
 	}
 
-	if err := u.headingStorage.MoveHeadingToAnotherList(ctx, updatedHeading, updatedTasks); err != nil {
+	var (
+		undoToken    string
+		sourceListID string
+	)
+
+	if err := u.memberStorage.Transaction(ctx, func(ctx context.Context) error {
+		currentHeading, err := u.headingStorage.GetHeadingByID(ctx, updatedHeading.ID, updatedHeading.UserID)
+		if err != nil {
+			return err
+		}
+
+		sourceListID = currentHeading.ListID
+
+		// The tasks of the heading are moved to the list too, so they are restored on undo along with the heading
+		undoToken, err = u.createHeadingUndoToken(ctx, model.AuditActionMove, updatedHeading, true)
+		if err != nil {
+			return err
+		}
+
+		if err = u.headingStorage.MoveHeadingToAnotherList(ctx, updatedHeading, updatedTasks); err != nil {
+			return err
+		}
+		if err = u.memberStorage.UnassignNonMemberTasks(ctx, updatedHeading.ListID); err != nil {
+			return err
+		}
+
+		return u.auditHeading(ctx, model.AuditActionMove, currentHeading, updatedHeading.UserID)
+	}); err != nil {
 		return model.HeadingResponseData{}, err
 	}
 
-	return model.HeadingResponseData{
-		ID:        updatedHeading.ID,
-		Title:     updatedHeading.Title,
-		ListID:    updatedHeading.ListID,
-		UserID:    updatedHeading.UserID,
-		UpdatedAt: updatedHeading.UpdatedAt,
-	}, nil
+	headingResp := mapHeadingToResponseData(updatedHeading)
+
+	// Members of the source list see the heading leave, members of the target list see it arrive
+	publishListEvent(ctx, u.eventBroker, u.memberStorage, model.EventHeadingMoved, headingResp.ListID, headingResp.UserID, headingResp.ID, headingResp)
+	if sourceListID != headingResp.ListID {
+		publishListEvent(ctx, u.eventBroker, u.memberStorage, model.EventHeadingMoved, sourceListID, headingResp.UserID, headingResp.ID, headingResp)
+	}
+
+	headingResp.UndoToken = undoToken
+
+	return headingResp, nil
 }
 
-func (u *HeadingUsecase) DeleteHeading(ctx context.Context, data model.HeadingRequestData) error {
+// DeleteHeading deletes the heading, the default heading of the list can't be deleted.
+// The mode tells whether the tasks of the heading are archived, moved to the default heading or the heading must be empty
+func (u *HeadingUsecase) DeleteHeading(ctx context.Context, data model.HeadingRequestData) (string, error) {
+	if data.DeleteMode == "" {
+		data.DeleteMode = model.DeleteModeArchive
+	}
+
+	if !data.DeleteMode.IsValid() {
+		return "", le.ErrInvalidDeleteMode
+	}
+
+	if err := authorizeHeading(ctx, u.memberStorage, data.ID, data.UserID, model.ListRoleEditor); err != nil {
+		return "", err
+	}
+
 	deletedHeading := model.Heading{
 		ID:        data.ID,
 		UserID:    data.UserID,
 		DeletedAt: time.Now(),
 	}
 
-	return u.headingStorage.DeleteHeading(ctx, deletedHeading)
+	var undoToken string
+
+	if err := u.memberStorage.Transaction(ctx, func(ctx context.Context) error {
+		currentHeading, err := u.headingStorage.GetHeadingByID(ctx, deletedHeading.ID, deletedHeading.UserID)
+		if err != nil {
+			return err
+		}
+
+		if currentHeading.IsDefault {
+			return le.ErrCannotDeleteDefaultHeading
+		}
+
+		deletedHeading.ListID = currentHeading.ListID
+
+		undoToken, err = u.createHeadingUndoToken(ctx, model.AuditActionDelete, deletedHeading, true)
+		if err != nil {
+			return err
+		}
+
+		if err = u.deleteHeadingTasks(ctx, currentHeading, deletedHeading, data.DeleteMode); err != nil {
+			return err
+		}
+
+		if err = u.headingStorage.DeleteHeading(ctx, deletedHeading); err != nil {
+			return err
+		}
+
+		return recordAudit(
+			ctx, u.auditUsecase, model.AuditActionDelete, model.AuditEntityHeading, deletedHeading.ID, deletedHeading.UserID,
+			mapHeadingToResponseData(currentHeading), nil,
+		)
+	}); err != nil {
+		return "", err
+	}
+
+	publishListEvent(ctx, u.eventBroker, u.memberStorage, model.EventHeadingDeleted, deletedHeading.ListID, deletedHeading.UserID, deletedHeading.ID, nil)
+
+	return undoToken, nil
+}
+
+// deleteHeadingTasks handles the tasks of the deleted heading according to the mode
+func (u *HeadingUsecase) deleteHeadingTasks(
+	ctx context.Context,
+	current, deleted model.Heading,
+	mode model.DeleteMode,
+) error {
+	switch mode {
+	case model.DeleteModeArchive:
+		return u.headingStorage.ArchiveTasksByHeadingID(ctx, deleted)
+	case model.DeleteModeMove:
+		defaultHeadingID, err := u.headingStorage.GetDefaultHeadingID(ctx, current.ListID, deleted.UserID)
+		if err != nil {
+			return err
+		}
+
+		return u.headingStorage.MoveTasksToHeading(ctx, deleted.ID, model.Task{
+			ListID:    current.ListID,
+			HeadingID: defaultHeadingID,
+			UserID:    deleted.UserID,
+			UpdatedAt: deleted.DeletedAt,
+		})
+	case model.DeleteModeRestrict:
+		hasTasks, err := u.headingStorage.HeadingHasTasks(ctx, deleted.ID)
+		if err != nil {
+			return err
+		}
+		if hasTasks {
+			return le.ErrHeadingNotEmpty
+		}
+		return nil
+	default:
+		return le.ErrInvalidDeleteMode
+	}
+}
+
+// DuplicateHeading copies the heading with its tasks to the same list.
+// The copy is reverted with one undo token, which deletes the heading along with the copied tasks
+func (u *HeadingUsecase) DuplicateHeading(
+	ctx context.Context,
+	data model.HeadingRequestData,
+	opts model.DuplicateOptions,
+) (model.DuplicateResponseData, error) {
+	if err := authorizeList(ctx, u.memberStorage, data.ListID, data.UserID, model.ListRoleEditor); err != nil {
+		return model.DuplicateResponseData{}, err
+	}
+
+	heading, err := u.GetHeadingByID(ctx, data)
+	if err != nil {
+		return model.DuplicateResponseData{}, err
+	}
+	if heading.ListID != data.ListID {
+		return model.DuplicateResponseData{}, le.ErrHeadingNotFound
+	}
+
+	newHeading := model.Heading{
+		ID:        ksuid.New().String(),
+		Title:     heading.Title,
+		ListID:    heading.ListID,
+		UserID:    data.UserID,
+		IsDefault: false,
+		UpdatedAt: time.Now(),
+	}
+
+	headingResp := mapHeadingToResponseData(newHeading)
+
+	duplicateResp := model.DuplicateResponseData{
+		Heading: &headingResp,
+		Tasks:   []model.TaskResponseData{},
+	}
+
+	if err = u.memberStorage.Transaction(ctx, func(ctx context.Context) error {
+		tasks, err := getTasksToDuplicate(ctx, u.taskStorage, heading.ListID, data.UserID, opts)
+		if err != nil {
+			return err
+		}
+
+		duplicateResp.UndoToken, err = u.undoUsecase.CreateUndoToken(ctx, model.UndoRecordData{
+			EntityType: model.AuditEntityHeading,
+			EntityID:   newHeading.ID,
+			Action:     model.AuditActionCreate,
+			UserID:     data.UserID,
+			Created:    true,
+		})
+		if err != nil {
+			return err
+		}
+
+		if err = u.headingStorage.CreateHeading(ctx, newHeading); err != nil {
+			return err
+		}
+
+		if err = recordAudit(
+			ctx, u.auditUsecase, model.AuditActionCreate, model.AuditEntityHeading, newHeading.ID, newHeading.UserID, nil, headingResp,
+		); err != nil {
+			return err
+		}
+
+		for _, task := range tasks {
+			if task.HeadingID != heading.ID {
+				continue
+			}
+
+			taskResp, err := copyTask(
+				ctx, u.taskStorage, u.tagUsecase, u.auditUsecase, task, newHeading.ListID, newHeading.ID, data.UserID, opts,
+			)
+			if err != nil {
+				return err
+			}
+
+			duplicateResp.Tasks = append(duplicateResp.Tasks, taskResp)
+		}
+		return nil
+	}); err != nil {
+		return model.DuplicateResponseData{}, err
+	}
+
+	publishListEvent(ctx, u.eventBroker, u.memberStorage, model.EventHeadingCreated, headingResp.ListID, headingResp.UserID, headingResp.ID, headingResp)
+	publishDuplicatedTasks(ctx, u.eventBroker, u.memberStorage, duplicateResp.Tasks)
+
+	return duplicateResp, nil
+}
+
+// auditHeading records the change of the heading made by the user, comparing the heading before the change with the stored one
+func (u *HeadingUsecase) auditHeading(ctx context.Context, action model.AuditAction, before model.Heading, userID string) error {
+	after, err := u.headingStorage.GetHeadingByID(ctx, before.ID, userID)
+	if err != nil {
+		return err
+	}
+
+	return recordAudit(
+		ctx, u.auditUsecase, action, model.AuditEntityHeading, before.ID, userID, mapHeadingToResponseData(before), mapHeadingToResponseData(after),
+	)
+}
+
+// createHeadingUndoToken saves the state of the heading before the operation, withTasks adds the tasks of the heading
+func (u *HeadingUsecase) createHeadingUndoToken(
+	ctx context.Context,
+	action model.AuditAction,
+	heading model.Heading,
+	withTasks bool,
+) (string, error) {
+	return u.undoUsecase.CreateUndoToken(ctx, model.UndoRecordData{
+		EntityType:       model.AuditEntityHeading,
+		EntityID:         heading.ID,
+		Action:           action,
+		UserID:           heading.UserID,
+		HeadingIDs:       []string{heading.ID},
+		WithHeadingTasks: withTasks,
+	})
 }
@@ -0,0 +1,76 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/rshelekhov/reframed/internal/lib/constants/le"
+	"github.com/rshelekhov/reframed/internal/model"
+	"github.com/rshelekhov/reframed/internal/port"
+)
+
+const defaultIdempotencyKeyTTL = 24 * time.Hour
+
+type IdempotencyUsecase struct {
+	idempotencyStorage port.IdempotencyStorage
+	keyTTL             time.Duration
+}
+
+func NewIdempotencyUsecase(storage port.IdempotencyStorage, keyTTL time.Duration) *IdempotencyUsecase {
+	if keyTTL <= 0 {
+		keyTTL = defaultIdempotencyKeyTTL
+	}
+
+	return &IdempotencyUsecase{
+		idempotencyStorage: storage,
+		keyTTL:             keyTTL,
+	}
+}
+
+// StartRequest reserves the idempotency key for the request. If the key is already reserved,
+// the stored key is returned with false, so the caller can replay the stored response
+func (u *IdempotencyUsecase) StartRequest(ctx context.Context, data model.IdempotencyKey) (model.IdempotencyKey, bool, error) {
+	now := time.Now()
+
+	data.CreatedAt = now
+	data.ExpiresAt = now.Add(u.keyTTL)
+
+	created, err := u.idempotencyStorage.CreateIdempotencyKey(ctx, data)
+	if err != nil {
+		return model.IdempotencyKey{}, false, err
+	}
+
+	if created {
+		return data, true, nil
+	}
+
+	stored, err := u.idempotencyStorage.GetIdempotencyKey(ctx, data.UserID, data.Key)
+	if err != nil {
+		return model.IdempotencyKey{}, false, err
+	}
+
+	if stored.RequestHash != data.RequestHash {
+		return model.IdempotencyKey{}, false, le.ErrIdempotencyKeyReused
+	}
+
+	if !stored.IsCompleted() {
+		return model.IdempotencyKey{}, false, le.ErrIdempotencyKeyInProgress
+	}
+
+	return stored, false, nil
+}
+
+// SaveResponse stores the response for the reserved idempotency key
+func (u *IdempotencyUsecase) SaveResponse(ctx context.Context, data model.IdempotencyKey) error {
+	return u.idempotencyStorage.SaveIdempotentResponse(ctx, data)
+}
+
+// ReleaseKey removes the reserved idempotency key, so the request can be retried
+func (u *IdempotencyUsecase) ReleaseKey(ctx context.Context, userID, key string) error {
+	return u.idempotencyStorage.DeleteIdempotencyKey(ctx, userID, key)
+}
+
+// DeleteExpiredKeys removes all expired idempotency keys and returns the number of deleted keys
+func (u *IdempotencyUsecase) DeleteExpiredKeys(ctx context.Context) (int64, error) {
+	return u.idempotencyStorage.DeleteExpiredIdempotencyKeys(ctx, time.Now())
+}
@@ -2,58 +2,145 @@ package usecase
 
 import (
 	"context"
+	"errors"
 	"time"
 
 	"github.com/segmentio/ksuid"
 
+	"github.com/rshelekhov/reframed/internal/lib/constants/le"
 	"github.com/rshelekhov/reframed/internal/model"
 	"github.com/rshelekhov/reframed/internal/port"
 )
 
 type ListUsecase struct {
 	listStorage    port.ListStorage
+	headingStorage port.HeadingStorage
+	areaStorage    port.AreaStorage
+	taskStorage    port.TaskStorage
+	memberStorage  port.ListMemberStorage
 	headingUsecase port.HeadingUsecase
+	tagUsecase     port.TagUsecase
+	auditUsecase   port.AuditUsecase
+	undoUsecase    port.UndoUsecase
+	eventBroker    port.EventBroker
 }
 
-func NewListUsecase(listStorage port.ListStorage, headingUsecase port.HeadingUsecase) *ListUsecase {
+func NewListUsecase(
+	listStorage port.ListStorage,
+	headingStorage port.HeadingStorage,
+	areaStorage port.AreaStorage,
+	taskStorage port.TaskStorage,
+	memberStorage port.ListMemberStorage,
+	headingUsecase port.HeadingUsecase,
+	tagUsecase port.TagUsecase,
+	auditUsecase port.AuditUsecase,
+	undoUsecase port.UndoUsecase,
+	eventBroker port.EventBroker,
+) *ListUsecase {
 	return &ListUsecase{
 		listStorage:    listStorage,
+		headingStorage: headingStorage,
+		areaStorage:    areaStorage,
+		taskStorage:    taskStorage,
+		memberStorage:  memberStorage,
 		headingUsecase: headingUsecase,
+		tagUsecase:     tagUsecase,
+		auditUsecase:   auditUsecase,
+		undoUsecase:    undoUsecase,
+		eventBroker:    eventBroker,
 	}
 }
 
 func (u *ListUsecase) CreateList(ctx context.Context, data *model.ListRequestData) (model.ListResponseData, error) {
+	if err := validateListDates(data); err != nil {
+		return model.ListResponseData{}, err
+	}
+
+	if data.AreaID != "" {
+		if _, err := u.areaStorage.GetAreaByID(ctx, data.AreaID, data.UserID); err != nil {
+			return model.ListResponseData{}, err
+		}
+	}
+
 	newList := model.List{
 		ID:        ksuid.New().String(),
 		Title:     data.Title,
 		IsDefault: false,
+		AreaID:    data.AreaID,
+		Color:     data.Color,
+		Icon:      data.Icon,
+		Notes:     data.Notes,
+		StartDate: data.StartDate,
+		Deadline:  data.Deadline,
 		UserID:    data.UserID,
+		Role:      model.ListRoleOwner,
 		UpdatedAt: time.Now(),
 	}
 
-	if err := u.listStorage.CreateList(ctx, newList); err != nil {
+	var undoToken string
+
+	if err := u.listStorage.Transaction(ctx, func(ctx context.Context) error {
+		var err error
+
+		undoToken, err = u.undoUsecase.CreateUndoToken(ctx, model.UndoRecordData{
+			EntityType: model.AuditEntityList,
+			EntityID:   newList.ID,
+			Action:     model.AuditActionCreate,
+			UserID:     newList.UserID,
+			Created:    true,
+		})
+		if err != nil {
+			return err
+		}
+
+		_, err = u.createList(ctx, &newList)
+		return err
+	}); err != nil {
 		return model.ListResponseData{}, err
 	}
 
+	listResp := mapListToResponseData(newList)
+
+	publishEvent(ctx, u.eventBroker, model.EventListCreated, listResp.UserID, listResp.ID, listResp)
+
+	listResp.UndoToken = undoToken
+
+	return listResp, nil
+}
+
+// createList stores the list with its owner and default heading and returns the ID of the default heading.
+// It must be called inside a transaction, creating the undo token and publishing the event is up to the caller
+func (u *ListUsecase) createList(ctx context.Context, newList *model.List) (string, error) {
+	position, err := u.listStorage.CreateList(ctx, *newList)
+	if err != nil {
+		return "", err
+	}
+
+	newList.Position = position
+
+	if err = u.createListOwner(ctx, *newList); err != nil {
+		return "", err
+	}
+	if err = recordAudit(
+		ctx, u.auditUsecase, model.AuditActionCreate, model.AuditEntityList, newList.ID, newList.UserID, nil, mapListToResponseData(*newList),
+	); err != nil {
+		return "", err
+	}
+
 	defaultHeading := model.Heading{
 		ID:        ksuid.New().String(),
 		Title:     model.DefaultHeading.String(),
 		ListID:    newList.ID,
-		UserID:    data.UserID,
+		UserID:    newList.UserID,
 		IsDefault: true,
 		UpdatedAt: time.Now(),
 	}
 
-	if err := u.headingUsecase.CreateDefaultHeading(ctx, defaultHeading); err != nil {
-		return model.ListResponseData{}, err
+	if err = u.headingUsecase.CreateDefaultHeading(ctx, defaultHeading); err != nil {
+		return "", err
 	}
 
-	return model.ListResponseData{
-		ID:        newList.ID,
-		Title:     newList.Title,
-		UserID:    newList.UserID,
-		UpdatedAt: newList.UpdatedAt,
-	}, nil
+	return defaultHeading.ID, nil
 }
 
 func (u *ListUsecase) CreateDefaultList(ctx context.Context, userID string) error {
@@ -65,7 +152,36 @@ func (u *ListUsecase) CreateDefaultList(ctx context.Context, userID string) erro
 		UpdatedAt: time.Now(),
 	}
 
-	if err := u.listStorage.CreateList(ctx, defaultList); err != nil {
+	return u.createDefaultList(ctx, defaultList)
+}
+
+// CreateAreaDefaultList creates the list keeping the loose tasks of the area.
+// It isn't returned with the other lists of the user
+func (u *ListUsecase) CreateAreaDefaultList(ctx context.Context, areaID, userID string) error {
+	defaultList := model.List{
+		ID:        ksuid.New().String(),
+		Title:     model.DefaultAreaList.String(),
+		IsDefault: true,
+		AreaID:    areaID,
+		UserID:    userID,
+		UpdatedAt: time.Now(),
+	}
+
+	return u.createDefaultList(ctx, defaultList)
+}
+
+func (u *ListUsecase) createDefaultList(ctx context.Context, defaultList model.List) error {
+	if _, err := u.listStorage.CreateList(ctx, defaultList); err != nil {
+		return err
+	}
+
+	if err := u.createListOwner(ctx, defaultList); err != nil {
+		return err
+	}
+
+	if err := recordAudit(
+		ctx, u.auditUsecase, model.AuditActionCreate, model.AuditEntityList, defaultList.ID, defaultList.UserID, nil, mapListToResponseData(defaultList),
+	); err != nil {
 		return err
 	}
 
@@ -73,7 +189,7 @@ func (u *ListUsecase) CreateDefaultList(ctx context.Context, userID string) erro
 		ID:        ksuid.New().String(),
 		Title:     model.DefaultHeading.String(),
 		ListID:    defaultList.ID,
-		UserID:    userID,
+		UserID:    defaultList.UserID,
 		IsDefault: true,
 		UpdatedAt: time.Now(),
 	}
@@ -85,22 +201,46 @@ func (u *ListUsecase) CreateDefaultList(ctx context.Context, userID string) erro
 	return nil
 }
 
+// createListOwner makes the creator of the list its owner, the access to the list goes through its members
+func (u *ListUsecase) createListOwner(ctx context.Context, list model.List) error {
+	return u.memberStorage.CreateListMember(ctx, model.ListMember{
+		ListID:    list.ID,
+		UserID:    list.UserID,
+		Role:      model.ListRoleOwner,
+		CreatedAt: list.UpdatedAt,
+	})
+}
+
 func (u *ListUsecase) GetListByID(ctx context.Context, data model.ListRequestData) (model.ListResponseData, error) {
 	list, err := u.listStorage.GetListByID(ctx, data.ID, data.UserID)
 	if err != nil {
 		return model.ListResponseData{}, err
 	}
 
-	return model.ListResponseData{
-		ID:        list.ID,
-		Title:     list.Title,
-		UserID:    list.UserID,
-		UpdatedAt: list.UpdatedAt,
-	}, nil
+	list.UserID = data.UserID
+
+	return mapListToResponseData(list), nil
+}
+
+// GetListsByUserID returns the lists of the user, the completed and archived lists are hidden unless includeArchived is set
+func (u *ListUsecase) GetListsByUserID(ctx context.Context, userID string, includeArchived bool) ([]model.ListResponseData, error) {
+	lists, err := u.listStorage.GetListsByUserID(ctx, userID, includeArchived)
+	if err != nil {
+		return nil, err
+	}
+
+	var listResp []model.ListResponseData
+
+	for _, list := range lists {
+		listResp = append(listResp, mapListToResponseData(list))
+	}
+
+	return listResp, nil
 }
 
-func (u *ListUsecase) GetListsByUserID(ctx context.Context, userID string) ([]model.ListResponseData, error) {
-	lists, err := u.listStorage.GetListsByUserID(ctx, userID)
+// GetArchivedLists returns the completed and archived lists of the user
+func (u *ListUsecase) GetArchivedLists(ctx context.Context, userID string) ([]model.ListResponseData, error) {
+	lists, err := u.listStorage.GetArchivedListsByUserID(ctx, userID)
 	if err != nil {
 		return nil, err
 	}
@@ -114,6 +254,51 @@ func (u *ListUsecase) GetListsByUserID(ctx context.Context, userID string) ([]mo
 	return listResp, nil
 }
 
+// GetListsGroupedByAreas returns the areas of the user with their lists in order,
+// and the lists which aren't in any area
+func (u *ListUsecase) GetListsGroupedByAreas(ctx context.Context, userID string) (model.ListsGroupedByAreas, error) {
+	lists, err := u.listStorage.GetListsByUserID(ctx, userID, false)
+	if err != nil && !errors.Is(err, le.ErrNoListsFound) {
+		return model.ListsGroupedByAreas{}, err
+	}
+
+	areas, err := u.areaStorage.GetAreasByUserID(ctx, userID)
+	if err != nil && !errors.Is(err, le.ErrNoAreasFound) {
+		return model.ListsGroupedByAreas{}, err
+	}
+
+	if len(lists) == 0 && len(areas) == 0 {
+		return model.ListsGroupedByAreas{}, le.ErrNoListsFound
+	}
+
+	listsByArea := make(map[string][]model.ListResponseData)
+
+	grouped := model.ListsGroupedByAreas{
+		Areas: []model.AreaResponseData{},
+		Lists: []model.ListResponseData{},
+	}
+
+	for _, list := range lists {
+		list.UserID = userID
+
+		if list.AreaID == "" {
+			grouped.Lists = append(grouped.Lists, mapListToResponseData(list))
+			continue
+		}
+
+		listsByArea[list.AreaID] = append(listsByArea[list.AreaID], mapListToResponseData(list))
+	}
+
+	for _, area := range areas {
+		areaResp := mapAreaToResponseData(area)
+		areaResp.Lists = listsByArea[area.ID]
+
+		grouped.Areas = append(grouped.Areas, areaResp)
+	}
+
+	return grouped, nil
+}
+
 func (u *ListUsecase) GetDefaultListID(ctx context.Context, userID string) (string, error) {
 	listID, err := u.listStorage.GetDefaultListID(ctx, userID)
 	if err != nil {
@@ -123,41 +308,841 @@ func (u *ListUsecase) GetDefaultListID(ctx context.Context, userID string) (stri
 	return listID, nil
 }
 
+func (u *ListUsecase) GetAreaDefaultListID(ctx context.Context, areaID, userID string) (string, error) {
+	return u.listStorage.GetAreaDefaultListID(ctx, areaID, userID)
+}
+
 func mapListToResponseData(list model.List) model.ListResponseData {
 	return model.ListResponseData{
-		ID:        list.ID,
-		Title:     list.Title,
-		UserID:    list.UserID,
-		UpdatedAt: list.UpdatedAt,
+		ID:         list.ID,
+		Title:      list.Title,
+		AreaID:     list.AreaID,
+		Position:   list.Position,
+		Color:      list.Color,
+		Icon:       list.Icon,
+		Notes:      list.Notes,
+		StartDate:  list.StartDate,
+		Deadline:   list.Deadline,
+		Progress:   newListProgress(list.OpenTasks, list.CompletedTasks),
+		Status:     list.Status,
+		ArchivedAt: list.ArchivedAt,
+		UserID:     list.UserID,
+		Role:       list.Role,
+		UpdatedAt:  list.UpdatedAt,
 	}
 }
 
+func newListProgress(openTasks, completedTasks int) model.ListProgress {
+	progress := model.ListProgress{
+		OpenTasks:      openTasks,
+		CompletedTasks: completedTasks,
+	}
+
+	if total := openTasks + completedTasks; total > 0 {
+		progress.Percent = completedTasks * 100 / total
+	}
+
+	return progress
+}
+
+// validateListDates checks that the deadline of the list isn't before its start date
+func validateListDates(data *model.ListRequestData) error {
+	if !data.StartDate.IsZero() && !data.Deadline.IsZero() && data.Deadline.Before(data.StartDate) {
+		return le.ErrInvalidListDateRange
+	}
+	return nil
+}
+
 func (u *ListUsecase) UpdateList(ctx context.Context, data *model.ListRequestData) (model.ListResponseData, error) {
+	if err := validateListDates(data); err != nil {
+		return model.ListResponseData{}, err
+	}
+
+	if err := authorizeList(ctx, u.memberStorage, data.ID, data.UserID, model.ListRoleEditor); err != nil {
+		return model.ListResponseData{}, err
+	}
+
 	updatedList := model.List{
 		ID:        data.ID,
 		Title:     data.Title,
+		Color:     data.Color,
+		Icon:      data.Icon,
+		Notes:     data.Notes,
+		StartDate: data.StartDate,
+		Deadline:  data.Deadline,
 		UserID:    data.UserID,
 		UpdatedAt: time.Now(),
 	}
 
-	if err := u.listStorage.UpdateList(ctx, updatedList); err != nil {
+	var undoToken string
+
+	if err := u.listStorage.Transaction(ctx, func(ctx context.Context) error {
+		currentList, err := u.listStorage.GetListByID(ctx, updatedList.ID, updatedList.UserID)
+		if err != nil {
+			return err
+		}
+
+		undoToken, err = u.createListUndoToken(ctx, model.AuditActionUpdate, updatedList.UserID, updatedList.ID)
+		if err != nil {
+			return err
+		}
+
+		if err = u.listStorage.UpdateList(ctx, updatedList); err != nil {
+			return err
+		}
+
+		return u.auditList(ctx, model.AuditActionUpdate, currentList, updatedList.UserID)
+	}); err != nil {
 		return model.ListResponseData{}, err
 	}
 
-	return model.ListResponseData{
-		ID:        updatedList.ID,
-		Title:     updatedList.Title,
-		UserID:    updatedList.UserID,
-		UpdatedAt: updatedList.UpdatedAt,
-	}, nil
+	listResp, err := u.GetListByID(ctx, *data)
+	if err != nil {
+		return model.ListResponseData{}, err
+	}
+
+	publishListEvent(ctx, u.eventBroker, u.memberStorage, model.EventListUpdated, listResp.ID, listResp.UserID, listResp.ID, listResp)
+
+	listResp.UndoToken = undoToken
+
+	return listResp, nil
+}
+
+// PatchList applies a JSON merge patch to the list, changing only the fields present in the patch
+func (u *ListUsecase) PatchList(ctx context.Context, data model.ListRequestData, patch []byte) (model.ListResponseData, error) {
+	currentList, err := u.listStorage.GetListByID(ctx, data.ID, data.UserID)
+	if err != nil {
+		return model.ListResponseData{}, err
+	}
+
+	current := model.ListRequestData{
+		ID:        currentList.ID,
+		Title:     currentList.Title,
+		Color:     currentList.Color,
+		Icon:      currentList.Icon,
+		Notes:     currentList.Notes,
+		StartDate: currentList.StartDate,
+		Deadline:  currentList.Deadline,
+		UserID:    data.UserID,
+	}
+
+	patched := &model.ListRequestData{}
+	if err = applyMergePatch(current, patch, patched); err != nil {
+		return model.ListResponseData{}, err
+	}
+
+	patched.ID = data.ID
+	patched.UserID = data.UserID
+
+	return u.UpdateList(ctx, patched)
+}
+
+// MoveListToArea moves the list to the end of the area, or takes it out of its area if the area ID is empty
+func (u *ListUsecase) MoveListToArea(ctx context.Context, data model.ListRequestData) (model.ListResponseData, error) {
+	if data.AreaID != "" {
+		if _, err := u.areaStorage.GetAreaByID(ctx, data.AreaID, data.UserID); err != nil {
+			return model.ListResponseData{}, err
+		}
+	}
+
+	movedList := model.List{
+		ID:        data.ID,
+		AreaID:    data.AreaID,
+		UserID:    data.UserID,
+		UpdatedAt: time.Now(),
+	}
+
+	var undoToken string
+
+	if err := u.listStorage.Transaction(ctx, func(ctx context.Context) error {
+		currentList, err := u.listStorage.GetListByID(ctx, movedList.ID, movedList.UserID)
+		if err != nil {
+			return err
+		}
+
+		undoToken, err = u.createListUndoToken(ctx, model.AuditActionMove, movedList.UserID, movedList.ID)
+		if err != nil {
+			return err
+		}
+
+		if err = u.listStorage.MoveListToArea(ctx, movedList); err != nil {
+			return err
+		}
+
+		return u.auditList(ctx, model.AuditActionMove, currentList, movedList.UserID)
+	}); err != nil {
+		return model.ListResponseData{}, err
+	}
+
+	listResp, err := u.GetListByID(ctx, data)
+	if err != nil {
+		return model.ListResponseData{}, err
+	}
+
+	publishListEvent(ctx, u.eventBroker, u.memberStorage, model.EventListUpdated, listResp.ID, listResp.UserID, listResp.ID, listResp)
+
+	listResp.UndoToken = undoToken
+
+	return listResp, nil
+}
+
+// ReorderLists sets the positions of the lists in the order of their IDs, the returned token reverts all of them
+func (u *ListUsecase) ReorderLists(ctx context.Context, data *model.ReorderRequestData) (string, error) {
+	var undoToken string
+
+	if err := u.listStorage.Transaction(ctx, func(ctx context.Context) error {
+		var err error
+
+		undoToken, err = u.createListUndoToken(ctx, model.AuditActionUpdate, data.UserID, data.IDs...)
+		if err != nil {
+			return err
+		}
+
+		for position, listID := range data.IDs {
+			currentList, err := u.listStorage.GetListByID(ctx, listID, data.UserID)
+			if err != nil {
+				return err
+			}
+
+			if err = u.listStorage.UpdateListPosition(ctx, model.List{
+				ID:        listID,
+				Position:  position,
+				UserID:    data.UserID,
+				UpdatedAt: time.Now(),
+			}); err != nil {
+				return err
+			}
+
+			if currentList.Position == position {
+				continue
+			}
+
+			if err = u.auditList(ctx, model.AuditActionUpdate, currentList, data.UserID); err != nil {
+				return err
+			}
+		}
+		return nil
+	}); err != nil {
+		return "", err
+	}
+
+	return undoToken, nil
+}
+
+// DetachListsFromArea takes the lists out of the area before it's deleted
+func (u *ListUsecase) DetachListsFromArea(ctx context.Context, area model.Area) error {
+	return u.listStorage.DetachListsFromArea(ctx, area)
 }
 
-func (u *ListUsecase) DeleteList(ctx context.Context, data model.ListRequestData) error {
+// DeleteList deletes the list, only the owners can do it. The default lists can't be deleted,
+// the mode tells whether the tasks of the list are archived, moved to Inbox or the list must be empty
+func (u *ListUsecase) DeleteList(ctx context.Context, data model.ListRequestData) (string, error) {
+	if data.DeleteMode == "" {
+		data.DeleteMode = model.DeleteModeArchive
+	}
+
+	if !data.DeleteMode.IsValid() {
+		return "", le.ErrInvalidDeleteMode
+	}
+
+	if err := authorizeList(ctx, u.memberStorage, data.ID, data.UserID, model.ListRoleOwner); err != nil {
+		return "", err
+	}
+
 	deletedList := model.List{
 		ID:        data.ID,
 		UserID:    data.UserID,
 		DeletedAt: time.Now(),
 	}
 
-	return u.listStorage.DeleteList(ctx, deletedList)
+	var undoToken string
+
+	if err := u.listStorage.Transaction(ctx, func(ctx context.Context) error {
+		currentList, err := u.listStorage.GetListByID(ctx, deletedList.ID, deletedList.UserID)
+		if err != nil {
+			return err
+		}
+
+		if currentList.IsDefault {
+			return le.ErrCannotDeleteDefaultList
+		}
+
+		undoToken, err = u.undoUsecase.CreateUndoToken(ctx, model.UndoRecordData{
+			EntityType:      model.AuditEntityList,
+			EntityID:        deletedList.ID,
+			Action:          model.AuditActionDelete,
+			UserID:          deletedList.UserID,
+			ListIDs:         []string{deletedList.ID},
+			WithListContent: true,
+		})
+		if err != nil {
+			return err
+		}
+
+		if err = u.deleteListContent(ctx, deletedList, data.DeleteMode); err != nil {
+			return err
+		}
+
+		if err = u.listStorage.DeleteList(ctx, deletedList); err != nil {
+			return err
+		}
+
+		return recordAudit(
+			ctx, u.auditUsecase, model.AuditActionDelete, model.AuditEntityList, deletedList.ID, deletedList.UserID,
+			mapListToResponseData(currentList), nil,
+		)
+	}); err != nil {
+		return "", err
+	}
+
+	publishListEvent(ctx, u.eventBroker, u.memberStorage, model.EventListDeleted, deletedList.ID, deletedList.UserID, deletedList.ID, nil)
+
+	return undoToken, nil
+}
+
+// CompleteList marks the list as completed and hides it from the lists of the user.
+// If CompleteTasks is set, the open tasks of the list are completed too
+func (u *ListUsecase) CompleteList(ctx context.Context, data model.ListRequestData) (model.ListResponseData, error) {
+	return u.changeListStatus(ctx, data, model.ListStatusCompleted, model.AuditActionComplete)
+}
+
+// ArchiveList puts the list away without completing it, the list is hidden from the lists of the user.
+// If CompleteTasks is set, the open tasks of the list are completed
+func (u *ListUsecase) ArchiveList(ctx context.Context, data model.ListRequestData) (model.ListResponseData, error) {
+	return u.changeListStatus(ctx, data, model.ListStatusArchived, model.AuditActionArchive)
+}
+
+// RestoreList brings the completed or archived list back to the lists of the user, its tasks are left as they are
+func (u *ListUsecase) RestoreList(ctx context.Context, data model.ListRequestData) (model.ListResponseData, error) {
+	data.CompleteTasks = false
+	return u.changeListStatus(ctx, data, model.ListStatusActive, model.AuditActionRestore)
+}
+
+// changeListStatus sets the status of the list, only the owners can do it. The default lists can't change their status
+func (u *ListUsecase) changeListStatus(
+	ctx context.Context, data model.ListRequestData, status model.ListStatus, action model.AuditAction,
+) (model.ListResponseData, error) {
+	if err := authorizeList(ctx, u.memberStorage, data.ID, data.UserID, model.ListRoleOwner); err != nil {
+		return model.ListResponseData{}, err
+	}
+
+	now := time.Now()
+
+	updatedList := model.List{
+		ID:        data.ID,
+		Status:    status,
+		UserID:    data.UserID,
+		UpdatedAt: now,
+	}
+
+	if status != model.ListStatusActive {
+		updatedList.ArchivedAt = now
+	}
+
+	var undoToken string
+
+	if err := u.listStorage.Transaction(ctx, func(ctx context.Context) error {
+		currentList, err := u.listStorage.GetListByID(ctx, updatedList.ID, updatedList.UserID)
+		if err != nil {
+			return err
+		}
+
+		if currentList.IsDefault {
+			return le.ErrCannotArchiveDefaultList
+		}
+
+		if status == model.ListStatusActive && currentList.Status == model.ListStatusActive {
+			return le.ErrListNotArchived
+		}
+
+		undoToken, err = u.undoUsecase.CreateUndoToken(ctx, model.UndoRecordData{
+			EntityType:      model.AuditEntityList,
+			EntityID:        updatedList.ID,
+			Action:          action,
+			UserID:          updatedList.UserID,
+			ListIDs:         []string{updatedList.ID},
+			WithListContent: data.CompleteTasks,
+		})
+		if err != nil {
+			return err
+		}
+
+		if data.CompleteTasks {
+			if err = u.listStorage.CompleteTasksByListID(ctx, updatedList); err != nil {
+				return err
+			}
+		}
+
+		if err = u.listStorage.UpdateListStatus(ctx, updatedList); err != nil {
+			return err
+		}
+
+		return u.auditList(ctx, action, currentList, updatedList.UserID)
+	}); err != nil {
+		return model.ListResponseData{}, err
+	}
+
+	listResp, err := u.GetListByID(ctx, data)
+	if err != nil {
+		return model.ListResponseData{}, err
+	}
+
+	publishListEvent(ctx, u.eventBroker, u.memberStorage, model.EventListUpdated, listResp.ID, listResp.UserID, listResp.ID, listResp)
+
+	listResp.UndoToken = undoToken
+
+	return listResp, nil
+}
+
+// ConvertHeadingToList turns the heading into a new list of the user. The new list is put in the area
+// of the source list when the user owns it, the areas of the other owners aren't shared.
+// The tasks of the heading are moved to the default heading of the new list, and the heading is deleted.
+// The undo token deletes the new list and puts the heading with its tasks back to the source list
+func (u *ListUsecase) ConvertHeadingToList(ctx context.Context, data model.HeadingRequestData) (model.ListResponseData, error) {
+	if err := authorizeHeading(ctx, u.memberStorage, data.ID, data.UserID, model.ListRoleEditor); err != nil {
+		return model.ListResponseData{}, err
+	}
+
+	now := time.Now()
+
+	var (
+		newList        model.List
+		deletedHeading model.Heading
+		undoToken      string
+	)
+
+	if err := u.listStorage.Transaction(ctx, func(ctx context.Context) error {
+		heading, err := u.headingStorage.GetHeadingByID(ctx, data.ID, data.UserID)
+		if err != nil {
+			return err
+		}
+
+		if heading.IsDefault {
+			return le.ErrCannotConvertDefaultHeading
+		}
+
+		sourceList, err := u.listStorage.GetListByID(ctx, heading.ListID, data.UserID)
+		if err != nil {
+			return err
+		}
+
+		var areaID string
+		if sourceList.UserID == data.UserID {
+			areaID = sourceList.AreaID
+		}
+
+		newList = model.List{
+			ID:        ksuid.New().String(),
+			Title:     heading.Title,
+			AreaID:    areaID,
+			UserID:    data.UserID,
+			Role:      model.ListRoleOwner,
+			UpdatedAt: now,
+		}
+
+		undoToken, err = u.undoUsecase.CreateUndoToken(ctx, model.UndoRecordData{
+			EntityType:       model.AuditEntityList,
+			EntityID:         newList.ID,
+			Action:           model.AuditActionCreate,
+			UserID:           data.UserID,
+			HeadingIDs:       []string{heading.ID},
+			WithHeadingTasks: true,
+			Created:          true,
+		})
+		if err != nil {
+			return err
+		}
+
+		newList.Position, err = u.listStorage.CreateList(ctx, newList)
+		if err != nil {
+			return err
+		}
+
+		if err = u.createListOwner(ctx, newList); err != nil {
+			return err
+		}
+
+		defaultHeading := model.Heading{
+			ID:        ksuid.New().String(),
+			Title:     model.DefaultHeading.String(),
+			ListID:    newList.ID,
+			UserID:    data.UserID,
+			IsDefault: true,
+			UpdatedAt: now,
+		}
+
+		if err = u.headingUsecase.CreateDefaultHeading(ctx, defaultHeading); err != nil {
+			return err
+		}
+
+		if err = u.headingStorage.MoveTasksToHeading(ctx, heading.ID, model.Task{
+			ListID:    newList.ID,
+			HeadingID: defaultHeading.ID,
+			UserID:    data.UserID,
+			UpdatedAt: now,
+		}); err != nil {
+			return err
+		}
+
+		// The user is the only member of the new list, the tasks assigned to the others are unassigned
+		if err = u.memberStorage.UnassignNonMemberTasks(ctx, newList.ID); err != nil {
+			return err
+		}
+
+		deletedHeading = model.Heading{
+			ID:        heading.ID,
+			ListID:    heading.ListID,
+			UserID:    data.UserID,
+			DeletedAt: now,
+		}
+
+		if err = u.headingStorage.DeleteHeading(ctx, deletedHeading); err != nil {
+			return err
+		}
+
+		if err = recordAudit(
+			ctx, u.auditUsecase, model.AuditActionCreate, model.AuditEntityList, newList.ID, newList.UserID, nil, mapListToResponseData(newList),
+		); err != nil {
+			return err
+		}
+
+		return recordAudit(
+			ctx, u.auditUsecase, model.AuditActionDelete, model.AuditEntityHeading, heading.ID, data.UserID,
+			mapHeadingToResponseData(heading), nil,
+		)
+	}); err != nil {
+		return model.ListResponseData{}, err
+	}
+
+	listResp := mapListToResponseData(newList)
+
+	publishEvent(ctx, u.eventBroker, model.EventListCreated, listResp.UserID, listResp.ID, listResp)
+	publishListEvent(ctx, u.eventBroker, u.memberStorage, model.EventHeadingDeleted, deletedHeading.ListID, deletedHeading.UserID, deletedHeading.ID, nil)
+
+	listResp.UndoToken = undoToken
+
+	return listResp, nil
+}
+
+// ConvertListToHeading turns the list into a heading of the target list. All the tasks of the list
+// are moved to the new heading, then the list is deleted along with its headings. Only the owners can do it.
+// The undo token deletes the new heading and restores the list with its headings and tasks
+func (u *ListUsecase) ConvertListToHeading(ctx context.Context, data model.ConvertListRequestData) (model.HeadingResponseData, error) {
+	if data.ListID == data.TargetListID {
+		return model.HeadingResponseData{}, le.ErrCannotConvertListToItsHeading
+	}
+
+	if err := authorizeList(ctx, u.memberStorage, data.ListID, data.UserID, model.ListRoleOwner); err != nil {
+		return model.HeadingResponseData{}, err
+	}
+
+	if err := authorizeList(ctx, u.memberStorage, data.TargetListID, data.UserID, model.ListRoleEditor); err != nil {
+		return model.HeadingResponseData{}, err
+	}
+
+	now := time.Now()
+
+	var (
+		newHeading model.Heading
+		undoToken  string
+	)
+
+	if err := u.listStorage.Transaction(ctx, func(ctx context.Context) error {
+		list, err := u.listStorage.GetListByID(ctx, data.ListID, data.UserID)
+		if err != nil {
+			return err
+		}
+
+		if list.IsDefault {
+			return le.ErrCannotConvertDefaultList
+		}
+
+		newHeading = model.Heading{
+			ID:        ksuid.New().String(),
+			Title:     list.Title,
+			ListID:    data.TargetListID,
+			UserID:    data.UserID,
+			IsDefault: false,
+			UpdatedAt: now,
+		}
+
+		undoToken, err = u.undoUsecase.CreateUndoToken(ctx, model.UndoRecordData{
+			EntityType:      model.AuditEntityHeading,
+			EntityID:        newHeading.ID,
+			Action:          model.AuditActionCreate,
+			UserID:          data.UserID,
+			ListIDs:         []string{list.ID},
+			WithListContent: true,
+			Created:         true,
+		})
+		if err != nil {
+			return err
+		}
+
+		if err = u.headingStorage.CreateHeading(ctx, newHeading); err != nil {
+			return err
+		}
+
+		if err = u.listStorage.MoveTasksToList(ctx, list.ID, model.Task{
+			ListID:    newHeading.ListID,
+			HeadingID: newHeading.ID,
+			UserID:    data.UserID,
+			UpdatedAt: now,
+		}); err != nil {
+			return err
+		}
+
+		if err = u.memberStorage.UnassignNonMemberTasks(ctx, newHeading.ListID); err != nil {
+			return err
+		}
+
+		deletedList := model.List{
+			ID:        list.ID,
+			UserID:    data.UserID,
+			DeletedAt: now,
+		}
+
+		if err = u.listStorage.DeleteHeadingsByListID(ctx, deletedList); err != nil {
+			return err
+		}
+
+		if err = u.listStorage.DeleteList(ctx, deletedList); err != nil {
+			return err
+		}
+
+		if err = recordAudit(
+			ctx, u.auditUsecase, model.AuditActionCreate, model.AuditEntityHeading, newHeading.ID, newHeading.UserID,
+			nil, mapHeadingToResponseData(newHeading),
+		); err != nil {
+			return err
+		}
+
+		return recordAudit(
+			ctx, u.auditUsecase, model.AuditActionDelete, model.AuditEntityList, list.ID, data.UserID, mapListToResponseData(list), nil,
+		)
+	}); err != nil {
+		return model.HeadingResponseData{}, err
+	}
+
+	headingResp := mapHeadingToResponseData(newHeading)
+
+	publishListEvent(ctx, u.eventBroker, u.memberStorage, model.EventHeadingCreated, headingResp.ListID, headingResp.UserID, headingResp.ID, headingResp)
+	publishListEvent(ctx, u.eventBroker, u.memberStorage, model.EventListDeleted, data.ListID, data.UserID, data.ListID, nil)
+
+	headingResp.UndoToken = undoToken
+
+	return headingResp, nil
+}
+
+// DuplicateList copies the list with its headings and tasks.
+// The copy is reverted with one undo token, which deletes the list along with its content
+func (u *ListUsecase) DuplicateList(
+	ctx context.Context,
+	data model.ListRequestData,
+	opts model.DuplicateOptions,
+) (model.DuplicateResponseData, error) {
+	list, err := u.GetListByID(ctx, data)
+	if err != nil {
+		return model.DuplicateResponseData{}, err
+	}
+
+	headingData := model.HeadingRequestData{
+		ListID: list.ID,
+		UserID: data.UserID,
+	}
+
+	defaultHeadingID, err := u.headingUsecase.GetDefaultHeadingID(ctx, headingData)
+	if err != nil {
+		return model.DuplicateResponseData{}, err
+	}
+
+	headings, err := u.headingUsecase.GetHeadingsByListID(ctx, headingData)
+	if err != nil && !errors.Is(err, le.ErrNoHeadingsFound) {
+		return model.DuplicateResponseData{}, err
+	}
+
+	newList := model.List{
+		ID:        ksuid.New().String(),
+		Title:     list.Title,
+		IsDefault: false,
+		Color:     list.Color,
+		Icon:      list.Icon,
+		Notes:     list.Notes,
+		StartDate: shiftDate(list.StartDate, opts.ShiftDays),
+		Deadline:  shiftDate(list.Deadline, opts.ShiftDays),
+		UserID:    data.UserID,
+		Role:      model.ListRoleOwner,
+		UpdatedAt: time.Now(),
+	}
+
+	duplicateResp := model.DuplicateResponseData{
+		Tasks: []model.TaskResponseData{},
+	}
+
+	if err = u.listStorage.Transaction(ctx, func(ctx context.Context) error {
+		tasks, err := getTasksToDuplicate(ctx, u.taskStorage, list.ID, data.UserID, opts)
+		if err != nil {
+			return err
+		}
+
+		duplicateResp.UndoToken, err = u.undoUsecase.CreateUndoToken(ctx, model.UndoRecordData{
+			EntityType: model.AuditEntityList,
+			EntityID:   newList.ID,
+			Action:     model.AuditActionCreate,
+			UserID:     data.UserID,
+			Created:    true,
+		})
+		if err != nil {
+			return err
+		}
+
+		newDefaultHeadingID, err := u.createList(ctx, &newList)
+		if err != nil {
+			return err
+		}
+
+		// The new list already has its own default heading, so only the other headings are created
+		newHeadingIDs := map[string]string{
+			defaultHeadingID: newDefaultHeadingID,
+		}
+
+		for _, heading := range headings {
+			if heading.ID == defaultHeadingID {
+				continue
+			}
+
+			newHeading := model.Heading{
+				ID:        ksuid.New().String(),
+				Title:     heading.Title,
+				ListID:    newList.ID,
+				UserID:    data.UserID,
+				IsDefault: false,
+				UpdatedAt: time.Now(),
+			}
+
+			headingResp := mapHeadingToResponseData(newHeading)
+
+			if err = u.headingStorage.CreateHeading(ctx, newHeading); err != nil {
+				return err
+			}
+			if err = recordAudit(
+				ctx, u.auditUsecase, model.AuditActionCreate, model.AuditEntityHeading, newHeading.ID, newHeading.UserID, nil, headingResp,
+			); err != nil {
+				return err
+			}
+
+			newHeadingIDs[heading.ID] = newHeading.ID
+			duplicateResp.Headings = append(duplicateResp.Headings, headingResp)
+		}
+
+		for _, task := range tasks {
+			headingID, ok := newHeadingIDs[task.HeadingID]
+			if !ok {
+				headingID = newDefaultHeadingID
+			}
+
+			taskResp, err := copyTask(ctx, u.taskStorage, u.tagUsecase, u.auditUsecase, task, newList.ID, headingID, data.UserID, opts)
+			if err != nil {
+				return err
+			}
+
+			duplicateResp.Tasks = append(duplicateResp.Tasks, taskResp)
+		}
+		return nil
+	}); err != nil {
+		return model.DuplicateResponseData{}, err
+	}
+
+	listResp := mapListToResponseData(newList)
+	duplicateResp.List = &listResp
+
+	publishEvent(ctx, u.eventBroker, model.EventListCreated, listResp.UserID, listResp.ID, listResp)
+	for _, headingResp := range duplicateResp.Headings {
+		publishListEvent(ctx, u.eventBroker, u.memberStorage, model.EventHeadingCreated, headingResp.ListID, headingResp.UserID, headingResp.ID, headingResp)
+	}
+	publishDuplicatedTasks(ctx, u.eventBroker, u.memberStorage, duplicateResp.Tasks)
+
+	return duplicateResp, nil
+}
+
+// deleteListContent handles the tasks of the deleted list according to the mode and deletes its headings.
+// In the move mode only the tasks created by the owner are moved to their inbox, the tasks of the other
+// members are archived, so they don't end up in the inbox of somebody else
+func (u *ListUsecase) deleteListContent(ctx context.Context, list model.List, mode model.DeleteMode) error {
+	switch mode {
+	case model.DeleteModeArchive:
+		if err := u.listStorage.ArchiveTasksByListID(ctx, list); err != nil {
+			return err
+		}
+	case model.DeleteModeMove:
+		inboxID, err := u.listStorage.GetDefaultListID(ctx, list.UserID)
+		if err != nil {
+			return err
+		}
+
+		headingID, err := u.headingUsecase.GetDefaultHeadingID(ctx, model.HeadingRequestData{
+			ListID: inboxID,
+			UserID: list.UserID,
+		})
+		if err != nil {
+			return err
+		}
+
+		if err = u.listStorage.MoveUserTasksToList(ctx, list.ID, model.Task{
+			ListID:    inboxID,
+			HeadingID: headingID,
+			UserID:    list.UserID,
+			UpdatedAt: list.DeletedAt,
+		}); err != nil {
+			return err
+		}
+
+		if err = u.memberStorage.UnassignNonMemberTasks(ctx, inboxID); err != nil {
+			return err
+		}
+
+		if err = u.listStorage.ArchiveTasksByListID(ctx, list); err != nil {
+			return err
+		}
+	case model.DeleteModeRestrict:
+		hasContent, err := u.listStorage.ListHasContent(ctx, list.ID)
+		if err != nil {
+			return err
+		}
+		if hasContent {
+			return le.ErrListNotEmpty
+		}
+	default:
+		return le.ErrInvalidDeleteMode
+	}
+
+	return u.listStorage.DeleteHeadingsByListID(ctx, list)
+}
+
+// auditList records the change of the list made by the user, comparing the list before the change with the stored one
+func (u *ListUsecase) auditList(ctx context.Context, action model.AuditAction, before model.List, userID string) error {
+	after, err := u.listStorage.GetListByID(ctx, before.ID, userID)
+	if err != nil {
+		return err
+	}
+
+	return recordAudit(
+		ctx, u.auditUsecase, action, model.AuditEntityList, before.ID, userID, mapListToResponseData(before), mapListToResponseData(after),
+	)
+}
+
+// createListUndoToken saves the state of the lists before the operation, the first list is the subject of the operation
+func (u *ListUsecase) createListUndoToken(ctx context.Context, action model.AuditAction, userID string, listIDs ...string) (string, error) {
+	var entityID string
+	if len(listIDs) > 0 {
+		entityID = listIDs[0]
+	}
+
+	return u.undoUsecase.CreateUndoToken(ctx, model.UndoRecordData{
+		EntityType: model.AuditEntityList,
+		EntityID:   entityID,
+		Action:     action,
+		UserID:     userID,
+		ListIDs:    listIDs,
+	})
 }
@@ -0,0 +1,349 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/segmentio/ksuid"
+
+	"github.com/rshelekhov/reframed/internal/lib/constants/le"
+	"github.com/rshelekhov/reframed/internal/model"
+	"github.com/rshelekhov/reframed/internal/port"
+)
+
+type ListMemberUsecase struct {
+	memberStorage port.ListMemberStorage
+	listStorage   port.ListStorage
+	authStorage   port.AuthStorage
+	eventBroker   port.EventBroker
+}
+
+func NewListMemberUsecase(
+	memberStorage port.ListMemberStorage,
+	listStorage port.ListStorage,
+	authStorage port.AuthStorage,
+	eventBroker port.EventBroker,
+) *ListMemberUsecase {
+	return &ListMemberUsecase{
+		memberStorage: memberStorage,
+		listStorage:   listStorage,
+		authStorage:   authStorage,
+		eventBroker:   eventBroker,
+	}
+}
+
+// GetListMembers returns the members of the list, any member can see them
+func (u *ListMemberUsecase) GetListMembers(ctx context.Context, listID, userID string) ([]model.ListMemberResponseData, error) {
+	if err := authorizeList(ctx, u.memberStorage, listID, userID, model.ListRoleViewer); err != nil {
+		return nil, err
+	}
+
+	members, err := u.memberStorage.GetListMembers(ctx, listID)
+	if err != nil {
+		return nil, err
+	}
+
+	var membersResp []model.ListMemberResponseData
+
+	for _, member := range members {
+		membersResp = append(membersResp, mapListMemberToResponseData(member))
+	}
+
+	return membersResp, nil
+}
+
+// UpdateListMemberRole changes the role of the member, only the owners can do it.
+// The last owner of the list can't be demoted
+func (u *ListMemberUsecase) UpdateListMemberRole(ctx context.Context, data *model.ListMemberRequestData) (model.ListMemberResponseData, error) {
+	if err := authorizeList(ctx, u.memberStorage, data.ListID, data.UserID, model.ListRoleOwner); err != nil {
+		return model.ListMemberResponseData{}, err
+	}
+
+	updatedMember := model.ListMember{
+		ListID: data.ListID,
+		UserID: data.MemberID,
+		Role:   data.Role,
+	}
+
+	if err := u.memberStorage.Transaction(ctx, func(ctx context.Context) error {
+		if data.Role != model.ListRoleOwner {
+			if err := u.checkLastOwner(ctx, data.ListID, data.MemberID); err != nil {
+				return err
+			}
+		}
+
+		return u.memberStorage.UpdateListMemberRole(ctx, updatedMember)
+	}); err != nil {
+		return model.ListMemberResponseData{}, err
+	}
+
+	memberResp := mapListMemberToResponseData(updatedMember)
+
+	publishListEvent(ctx, u.eventBroker, u.memberStorage, model.EventListUpdated, data.ListID, data.UserID, data.ListID, memberResp)
+
+	return memberResp, nil
+}
+
+// RemoveListMember removes the member from the list and unassigns their tasks in it.
+// The owners can remove any member, the other members can only leave the list themselves
+func (u *ListMemberUsecase) RemoveListMember(ctx context.Context, data model.ListMemberRequestData) error {
+	required := model.ListRoleOwner
+	if data.MemberID == data.UserID {
+		required = model.ListRoleViewer
+	}
+
+	if err := authorizeList(ctx, u.memberStorage, data.ListID, data.UserID, required); err != nil {
+		return err
+	}
+
+	if err := u.memberStorage.Transaction(ctx, func(ctx context.Context) error {
+		if err := u.checkLastOwner(ctx, data.ListID, data.MemberID); err != nil {
+			return err
+		}
+
+		if err := u.memberStorage.DeleteListMember(ctx, data.ListID, data.MemberID); err != nil {
+			return err
+		}
+
+		return u.memberStorage.UnassignNonMemberTasks(ctx, data.ListID)
+	}); err != nil {
+		return err
+	}
+
+	// The removed member is no longer in the list, so they are notified separately
+	publishListEvent(ctx, u.eventBroker, u.memberStorage, model.EventListUpdated, data.ListID, data.UserID, data.ListID, nil)
+	if data.MemberID != data.UserID {
+		publishEvent(ctx, u.eventBroker, model.EventListUpdated, data.MemberID, data.ListID, nil)
+	}
+
+	return nil
+}
+
+// checkLastOwner returns an error if the member is the only owner of the list
+func (u *ListMemberUsecase) checkLastOwner(ctx context.Context, listID, memberID string) error {
+	role, err := u.memberStorage.GetListMemberRole(ctx, listID, memberID)
+	if err != nil {
+		return err
+	}
+
+	if role != model.ListRoleOwner {
+		return nil
+	}
+
+	owners, err := u.memberStorage.CountListOwners(ctx, listID)
+	if err != nil {
+		return err
+	}
+
+	if owners <= 1 {
+		return le.ErrLastListOwner
+	}
+
+	return nil
+}
+
+// InviteToList sends the invitation to join the list to the email, only the owners can do it.
+// Inviting the same email again updates the role of the pending invitation
+func (u *ListMemberUsecase) InviteToList(ctx context.Context, data *model.ListInvitationRequestData) (model.ListInvitationResponseData, error) {
+	if err := authorizeList(ctx, u.memberStorage, data.ListID, data.UserID, model.ListRoleOwner); err != nil {
+		return model.ListInvitationResponseData{}, err
+	}
+
+	list, err := u.listStorage.GetListByID(ctx, data.ListID, data.UserID)
+	if err != nil {
+		return model.ListInvitationResponseData{}, err
+	}
+
+	if list.IsDefault {
+		return model.ListInvitationResponseData{}, le.ErrCannotShareDefaultList
+	}
+
+	invitee, err := u.authStorage.GetUserByEmail(ctx, data.Email)
+	if err != nil && !errors.Is(err, le.ErrUserNotFound) {
+		return model.ListInvitationResponseData{}, err
+	}
+
+	if err == nil {
+		if invitee.ID == data.UserID {
+			return model.ListInvitationResponseData{}, le.ErrCannotInviteYourself
+		}
+
+		_, err = u.memberStorage.GetListMemberRole(ctx, data.ListID, invitee.ID)
+		if err == nil {
+			return model.ListInvitationResponseData{}, le.ErrAlreadyListMember
+		}
+		if !errors.Is(err, le.ErrListMemberNotFound) {
+			return model.ListInvitationResponseData{}, err
+		}
+	}
+
+	invitation, err := u.memberStorage.CreateListInvitation(ctx, model.ListInvitation{
+		ID:        ksuid.New().String(),
+		ListID:    data.ListID,
+		ListTitle: list.Title,
+		Email:     data.Email,
+		Role:      data.Role,
+		InvitedBy: data.UserID,
+		UpdatedAt: time.Now(),
+	})
+	if err != nil {
+		return model.ListInvitationResponseData{}, err
+	}
+
+	return mapListInvitationToResponseData(invitation), nil
+}
+
+// GetListInvitations returns the pending invitations of the list, only the owners can see them
+func (u *ListMemberUsecase) GetListInvitations(ctx context.Context, listID, userID string) ([]model.ListInvitationResponseData, error) {
+	if err := authorizeList(ctx, u.memberStorage, listID, userID, model.ListRoleOwner); err != nil {
+		return nil, err
+	}
+
+	invitations, err := u.memberStorage.GetPendingListInvitationsByListID(ctx, listID)
+	if err != nil {
+		return nil, err
+	}
+
+	return mapListInvitationsToResponseData(invitations), nil
+}
+
+// GetInvitationsByUserID returns the pending invitations sent to the email of the user
+func (u *ListMemberUsecase) GetInvitationsByUserID(ctx context.Context, userID string) ([]model.ListInvitationResponseData, error) {
+	user, err := u.authStorage.GetUserByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	invitations, err := u.memberStorage.GetPendingListInvitationsByEmail(ctx, user.Email)
+	if err != nil {
+		return nil, err
+	}
+
+	return mapListInvitationsToResponseData(invitations), nil
+}
+
+// AcceptListInvitation makes the user a member of the list with the role of the invitation
+func (u *ListMemberUsecase) AcceptListInvitation(ctx context.Context, data model.ListInvitationRequestData) (model.ListResponseData, error) {
+	invitation, err := u.getOwnInvitation(ctx, data.ID, data.UserID)
+	if err != nil {
+		return model.ListResponseData{}, err
+	}
+
+	var listResp model.ListResponseData
+
+	if err = u.memberStorage.Transaction(ctx, func(ctx context.Context) error {
+		now := time.Now()
+
+		if err := u.memberStorage.UpdateListInvitationStatus(ctx, invitation.ID, model.InvitationStatusAccepted, now); err != nil {
+			return err
+		}
+
+		if err := u.memberStorage.CreateListMember(ctx, model.ListMember{
+			ListID:    invitation.ListID,
+			UserID:    data.UserID,
+			Role:      invitation.Role,
+			CreatedAt: now,
+		}); err != nil {
+			return err
+		}
+
+		list, err := u.listStorage.GetListByID(ctx, invitation.ListID, data.UserID)
+		if err != nil {
+			return err
+		}
+
+		list.UserID = data.UserID
+		listResp = mapListToResponseData(list)
+
+		return nil
+	}); err != nil {
+		return model.ListResponseData{}, err
+	}
+
+	publishEvent(ctx, u.eventBroker, model.EventListCreated, data.UserID, listResp.ID, listResp)
+	publishListEvent(ctx, u.eventBroker, u.memberStorage, model.EventListUpdated, listResp.ID, data.UserID, listResp.ID, nil)
+
+	return listResp, nil
+}
+
+func (u *ListMemberUsecase) DeclineListInvitation(ctx context.Context, data model.ListInvitationRequestData) error {
+	invitation, err := u.getOwnInvitation(ctx, data.ID, data.UserID)
+	if err != nil {
+		return err
+	}
+
+	return u.memberStorage.UpdateListInvitationStatus(ctx, invitation.ID, model.InvitationStatusDeclined, time.Now())
+}
+
+// RevokeListInvitation cancels the pending invitation, only the owners of the list can do it
+func (u *ListMemberUsecase) RevokeListInvitation(ctx context.Context, data model.ListInvitationRequestData) error {
+	if err := authorizeList(ctx, u.memberStorage, data.ListID, data.UserID, model.ListRoleOwner); err != nil {
+		return err
+	}
+
+	invitation, err := u.memberStorage.GetListInvitationByID(ctx, data.ID)
+	if err != nil {
+		return err
+	}
+
+	if invitation.ListID != data.ListID {
+		return le.ErrListInvitationNotFound
+	}
+
+	return u.memberStorage.UpdateListInvitationStatus(ctx, invitation.ID, model.InvitationStatusRevoked, time.Now())
+}
+
+// getOwnInvitation returns the pending invitation sent to the email of the user.
+// The invitations sent to the other emails are reported as not found
+func (u *ListMemberUsecase) getOwnInvitation(ctx context.Context, invitationID, userID string) (model.ListInvitation, error) {
+	invitation, err := u.memberStorage.GetListInvitationByID(ctx, invitationID)
+	if err != nil {
+		return model.ListInvitation{}, err
+	}
+
+	user, err := u.authStorage.GetUserByID(ctx, userID)
+	if err != nil {
+		return model.ListInvitation{}, err
+	}
+
+	if invitation.Email != user.Email || invitation.Status != model.InvitationStatusPending {
+		return model.ListInvitation{}, le.ErrListInvitationNotFound
+	}
+
+	return invitation, nil
+}
+
+func mapListMemberToResponseData(member model.ListMember) model.ListMemberResponseData {
+	return model.ListMemberResponseData{
+		ListID:    member.ListID,
+		UserID:    member.UserID,
+		Email:     member.Email,
+		Role:      member.Role,
+		CreatedAt: member.CreatedAt,
+	}
+}
+
+func mapListInvitationsToResponseData(invitations []model.ListInvitation) []model.ListInvitationResponseData {
+	var invitationsResp []model.ListInvitationResponseData
+
+	for _, invitation := range invitations {
+		invitationsResp = append(invitationsResp, mapListInvitationToResponseData(invitation))
+	}
+
+	return invitationsResp
+}
+
+func mapListInvitationToResponseData(invitation model.ListInvitation) model.ListInvitationResponseData {
+	return model.ListInvitationResponseData{
+		ID:        invitation.ID,
+		ListID:    invitation.ListID,
+		ListTitle: invitation.ListTitle,
+		Email:     invitation.Email,
+		Role:      invitation.Role,
+		Status:    invitation.Status,
+		InvitedBy: invitation.InvitedBy,
+		CreatedAt: invitation.CreatedAt,
+		UpdatedAt: invitation.UpdatedAt,
+	}
+}
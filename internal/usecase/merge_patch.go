@@ -0,0 +1,36 @@
+package usecase
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/go-playground/validator/v10"
+
+	"github.com/rshelekhov/reframed/internal/lib/constants/le"
+	"github.com/rshelekhov/reframed/internal/lib/mergepatch"
+)
+
+// patchValidator validates the patched entities, it caches the parsed structs, so it's shared
+var patchValidator = validator.New()
+
+// applyMergePatch applies the JSON merge patch (RFC 7396) to the current state of the entity,
+// decodes the result into target and validates it
+func applyMergePatch(current any, patch []byte, target any) error {
+	const op = "usecase.applyMergePatch"
+
+	doc, err := json.Marshal(current)
+	if err != nil {
+		return fmt.Errorf("%s: failed to marshal current state: %w", op, err)
+	}
+
+	merged, err := mergepatch.Apply(doc, patch)
+	if err != nil {
+		return le.ErrInvalidMergePatch
+	}
+
+	if err = json.Unmarshal(merged, target); err != nil {
+		return le.ErrInvalidMergePatch
+	}
+
+	return patchValidator.Struct(target)
+}
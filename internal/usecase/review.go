@@ -0,0 +1,270 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/segmentio/ksuid"
+
+	"github.com/rshelekhov/reframed/internal/lib/constants/le"
+	"github.com/rshelekhov/reframed/internal/model"
+	"github.com/rshelekhov/reframed/internal/port"
+)
+
+// reviewPeriod is how far back the review looks for the completed tasks and the slipped deadlines
+const reviewPeriod = 7 * 24 * time.Hour
+
+type ReviewUsecase struct {
+	reviewStorage port.ReviewStorage
+	taskUsecase   port.TaskUsecase
+	listUsecase   port.ListUsecase
+}
+
+func NewReviewUsecase(
+	reviewStorage port.ReviewStorage,
+	taskUsecase port.TaskUsecase,
+	listUsecase port.ListUsecase,
+) *ReviewUsecase {
+	return &ReviewUsecase{
+		reviewStorage: reviewStorage,
+		taskUsecase:   taskUsecase,
+		listUsecase:   listUsecase,
+	}
+}
+
+// StartReview snapshots the items to review, so the session looks the same on every device
+// until it is completed. Only one review can be in progress at a time
+func (u *ReviewUsecase) StartReview(ctx context.Context, data model.ReviewRequestData) (model.ReviewResponseData, error) {
+	_, err := u.reviewStorage.GetOpenReviewSession(ctx, data.UserID)
+	if err == nil {
+		return model.ReviewResponseData{}, le.ErrReviewAlreadyStarted
+	}
+	if !errors.Is(err, le.ErrNoReviewInProgress) {
+		return model.ReviewResponseData{}, err
+	}
+
+	now := time.Now()
+
+	review := model.ReviewSession{
+		ID:        ksuid.New().String(),
+		UserID:    data.UserID,
+		StaleDays: data.StaleDays,
+		StartedAt: now,
+	}
+
+	var items []model.ReviewItem
+
+	if err = u.reviewStorage.Transaction(ctx, func(ctx context.Context) error {
+		if err := u.reviewStorage.CreateReviewSession(ctx, review); err != nil {
+			return err
+		}
+
+		since := now.Add(-reviewPeriod)
+		staleBefore := now.AddDate(0, 0, -review.StaleDays)
+
+		candidates, err := u.reviewStorage.GetReviewCandidates(ctx, review.UserID, since, staleBefore)
+		if err != nil {
+			return err
+		}
+
+		for i, candidate := range candidates {
+			candidate.ID = ksuid.New().String()
+			candidate.ReviewID = review.ID
+			candidate.Position = i
+
+			if err = u.reviewStorage.CreateReviewItem(ctx, candidate); err != nil {
+				return err
+			}
+
+			items = append(items, candidate)
+		}
+
+		return nil
+	}); err != nil {
+		return model.ReviewResponseData{}, err
+	}
+
+	return getReviewResponseData(review, items), nil
+}
+
+func (u *ReviewUsecase) GetCurrentReview(ctx context.Context, userID string) (model.ReviewResponseData, error) {
+	review, err := u.reviewStorage.GetOpenReviewSession(ctx, userID)
+	if err != nil {
+		return model.ReviewResponseData{}, err
+	}
+
+	return u.getReview(ctx, review)
+}
+
+func (u *ReviewUsecase) GetReviewByID(ctx context.Context, data model.ReviewRequestData) (model.ReviewResponseData, error) {
+	review, err := u.reviewStorage.GetReviewSessionByID(ctx, data.ID, data.UserID)
+	if err != nil {
+		return model.ReviewResponseData{}, err
+	}
+
+	return u.getReview(ctx, review)
+}
+
+// ActOnReviewItem applies the action to the task or the list of the item and marks the item as reviewed.
+// The item can be acted on again, e.g. to move the task after it was kept
+func (u *ReviewUsecase) ActOnReviewItem(ctx context.Context, data model.ReviewItemActionRequestData) (model.ReviewItemResponseData, error) {
+	review, err := u.reviewStorage.GetReviewSessionByID(ctx, data.ReviewID, data.UserID)
+	if err != nil {
+		return model.ReviewItemResponseData{}, err
+	}
+
+	if !review.CompletedAt.IsZero() {
+		return model.ReviewItemResponseData{}, le.ErrReviewCompleted
+	}
+
+	item, err := u.reviewStorage.GetReviewItemByID(ctx, data.ItemID, data.ReviewID, data.UserID)
+	if err != nil {
+		return model.ReviewItemResponseData{}, err
+	}
+
+	if !data.Action.IsValidFor(item.EntityType) {
+		return model.ReviewItemResponseData{}, le.ErrInvalidReviewAction
+	}
+
+	if data.Action == model.ReviewActionMove && data.ListID == "" {
+		return model.ReviewItemResponseData{}, le.ErrEmptyQueryListID
+	}
+
+	item.Action = data.Action
+	item.ActedAt = time.Now()
+
+	var undoToken string
+
+	if err = u.reviewStorage.Transaction(ctx, func(ctx context.Context) error {
+		var err error
+
+		undoToken, err = u.applyReviewAction(ctx, item, data)
+		if err != nil {
+			return err
+		}
+
+		return u.reviewStorage.UpdateReviewItemAction(ctx, item, data.UserID)
+	}); err != nil {
+		return model.ReviewItemResponseData{}, err
+	}
+
+	itemResp := getReviewItemResponseData(item)
+	itemResp.UndoToken = undoToken
+
+	return itemResp, nil
+}
+
+func (u *ReviewUsecase) applyReviewAction(ctx context.Context, item model.ReviewItem, data model.ReviewItemActionRequestData) (string, error) {
+	switch {
+	case item.Action == model.ReviewActionKeep:
+		return "", nil
+	case item.Action == model.ReviewActionDefer:
+		taskResp, err := u.taskUsecase.SnoozeTask(ctx, model.TaskSnoozeRequestData{
+			TaskID:        item.EntityID,
+			DeferredUntil: data.DeferredUntil,
+			UserID:        data.UserID,
+		})
+		return taskResp.UndoToken, err
+	case item.Action == model.ReviewActionArchive && item.EntityType == model.ReviewEntityList:
+		listResp, err := u.listUsecase.ArchiveList(ctx, model.ListRequestData{
+			ID:     item.EntityID,
+			UserID: data.UserID,
+		})
+		return listResp.UndoToken, err
+	case item.Action == model.ReviewActionArchive:
+		return u.taskUsecase.ArchiveTask(ctx, model.TaskRequestData{
+			ID:     item.EntityID,
+			UserID: data.UserID,
+		})
+	case item.Action == model.ReviewActionMove:
+		return u.taskUsecase.MoveTaskToAnotherList(ctx, model.TaskRequestData{
+			ID:     item.EntityID,
+			ListID: data.ListID,
+			UserID: data.UserID,
+		})
+	default:
+		return "", le.ErrInvalidReviewAction
+	}
+}
+
+func (u *ReviewUsecase) CompleteReview(ctx context.Context, data model.ReviewRequestData) (model.ReviewResponseData, error) {
+	review, err := u.reviewStorage.GetReviewSessionByID(ctx, data.ID, data.UserID)
+	if err != nil {
+		return model.ReviewResponseData{}, err
+	}
+
+	if !review.CompletedAt.IsZero() {
+		return model.ReviewResponseData{}, le.ErrReviewCompleted
+	}
+
+	review.CompletedAt = time.Now()
+
+	if err = u.reviewStorage.CompleteReviewSession(ctx, review); err != nil {
+		return model.ReviewResponseData{}, err
+	}
+
+	return u.getReview(ctx, review)
+}
+
+func (u *ReviewUsecase) getReview(ctx context.Context, review model.ReviewSession) (model.ReviewResponseData, error) {
+	items, err := u.reviewStorage.GetReviewItems(ctx, review.ID)
+	if err != nil {
+		return model.ReviewResponseData{}, err
+	}
+
+	return getReviewResponseData(review, items), nil
+}
+
+func getReviewResponseData(review model.ReviewSession, items []model.ReviewItem) model.ReviewResponseData {
+	reviewResp := model.ReviewResponseData{
+		ID:          review.ID,
+		StaleDays:   review.StaleDays,
+		StartedAt:   review.StartedAt,
+		CompletedAt: review.CompletedAt,
+		Sections: model.ReviewSections{
+			Completed:    []model.ReviewItemResponseData{},
+			Slipped:      []model.ReviewItemResponseData{},
+			Stale:        []model.ReviewItemResponseData{},
+			NoNextAction: []model.ReviewItemResponseData{},
+			Inbox:        []model.ReviewItemResponseData{},
+		},
+		UserID: review.UserID,
+	}
+
+	for _, item := range items {
+		itemResp := getReviewItemResponseData(item)
+
+		switch item.Section {
+		case model.ReviewSectionCompleted:
+			reviewResp.Sections.Completed = append(reviewResp.Sections.Completed, itemResp)
+		case model.ReviewSectionSlipped:
+			reviewResp.Sections.Slipped = append(reviewResp.Sections.Slipped, itemResp)
+		case model.ReviewSectionStale:
+			reviewResp.Sections.Stale = append(reviewResp.Sections.Stale, itemResp)
+		case model.ReviewSectionNoNextAction:
+			reviewResp.Sections.NoNextAction = append(reviewResp.Sections.NoNextAction, itemResp)
+		case model.ReviewSectionInbox:
+			reviewResp.Sections.Inbox = append(reviewResp.Sections.Inbox, itemResp)
+		}
+
+		reviewResp.Progress.Total++
+		if item.Action != "" {
+			reviewResp.Progress.Reviewed++
+		}
+	}
+
+	return reviewResp
+}
+
+func getReviewItemResponseData(item model.ReviewItem) model.ReviewItemResponseData {
+	return model.ReviewItemResponseData{
+		ID:         item.ID,
+		Section:    item.Section,
+		EntityType: item.EntityType,
+		EntityID:   item.EntityID,
+		Title:      item.Title,
+		Action:     item.Action,
+		ActedAt:    item.ActedAt,
+	}
+}
@@ -0,0 +1,615 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/segmentio/ksuid"
+
+	"github.com/rshelekhov/reframed/internal/lib/constants/le"
+	"github.com/rshelekhov/reframed/internal/model"
+	"github.com/rshelekhov/reframed/internal/port"
+)
+
+const (
+	// maxRuleChainDepth is the number of times the actions of the rules may trigger other rules
+	// for the same change of the task
+	maxRuleChainDepth = 5
+	// ruleDeadlineBatchSize is the number of deadline rule runs processed on each check
+	ruleDeadlineBatchSize = 100
+	ruleDateLayout        = "2006-01-02"
+)
+
+type RuleUsecase struct {
+	ruleStorage    port.RuleStorage
+	taskStorage    port.TaskStorage
+	memberStorage  port.ListMemberStorage
+	headingUsecase port.HeadingUsecase
+	tagUsecase     port.TagUsecase
+	listUsecase    port.ListUsecase
+	auditUsecase   port.AuditUsecase
+	eventBroker    port.EventBroker
+}
+
+func NewRuleUsecase(
+	ruleStorage port.RuleStorage,
+	taskStorage port.TaskStorage,
+	memberStorage port.ListMemberStorage,
+	headingUsecase port.HeadingUsecase,
+	tagUsecase port.TagUsecase,
+	listUsecase port.ListUsecase,
+	auditUsecase port.AuditUsecase,
+	eventBroker port.EventBroker,
+) *RuleUsecase {
+	return &RuleUsecase{
+		ruleStorage:    ruleStorage,
+		taskStorage:    taskStorage,
+		memberStorage:  memberStorage,
+		headingUsecase: headingUsecase,
+		tagUsecase:     tagUsecase,
+		listUsecase:    listUsecase,
+		auditUsecase:   auditUsecase,
+		eventBroker:    eventBroker,
+	}
+}
+
+func (u *RuleUsecase) CreateRule(ctx context.Context, data *model.RuleRequestData) (model.RuleResponseData, error) {
+	if err := validateRule(*data); err != nil {
+		return model.RuleResponseData{}, err
+	}
+
+	newRule := model.Rule{
+		ID:         ksuid.New().String(),
+		Title:      data.Title,
+		Trigger:    data.Trigger,
+		Conditions: data.Conditions,
+		Actions:    data.Actions,
+		IsActive:   true,
+		UserID:     data.UserID,
+		UpdatedAt:  time.Now(),
+	}
+
+	if err := u.ruleStorage.CreateRule(ctx, newRule); err != nil {
+		return model.RuleResponseData{}, err
+	}
+
+	return mapRuleToResponseData(newRule), nil
+}
+
+func (u *RuleUsecase) GetRuleByID(ctx context.Context, data model.RuleRequestData) (model.RuleResponseData, error) {
+	rule, err := u.ruleStorage.GetRuleByID(ctx, data.ID, data.UserID)
+	if err != nil {
+		return model.RuleResponseData{}, err
+	}
+
+	return mapRuleToResponseData(rule), nil
+}
+
+func (u *RuleUsecase) GetRulesByUserID(ctx context.Context, userID string) ([]model.RuleResponseData, error) {
+	rules, err := u.ruleStorage.GetRulesByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	var rulesResp []model.RuleResponseData
+
+	for _, rule := range rules {
+		rulesResp = append(rulesResp, mapRuleToResponseData(rule))
+	}
+
+	return rulesResp, nil
+}
+
+func mapRuleToResponseData(rule model.Rule) model.RuleResponseData {
+	conditions := rule.Conditions
+	if conditions == nil {
+		conditions = []model.RuleCondition{}
+	}
+
+	return model.RuleResponseData{
+		ID:         rule.ID,
+		Title:      rule.Title,
+		Trigger:    rule.Trigger,
+		Conditions: conditions,
+		Actions:    rule.Actions,
+		IsActive:   rule.IsActive,
+		UserID:     rule.UserID,
+		UpdatedAt:  rule.UpdatedAt,
+	}
+}
+
+func (u *RuleUsecase) UpdateRule(ctx context.Context, data *model.RuleRequestData) (model.RuleResponseData, error) {
+	if err := validateRule(*data); err != nil {
+		return model.RuleResponseData{}, err
+	}
+
+	updatedRule := model.Rule{
+		ID:         data.ID,
+		Title:      data.Title,
+		Trigger:    data.Trigger,
+		Conditions: data.Conditions,
+		Actions:    data.Actions,
+		IsActive:   data.IsActive,
+		UserID:     data.UserID,
+		UpdatedAt:  time.Now(),
+	}
+
+	if err := u.ruleStorage.UpdateRule(ctx, updatedRule); err != nil {
+		return model.RuleResponseData{}, err
+	}
+
+	return mapRuleToResponseData(updatedRule), nil
+}
+
+func (u *RuleUsecase) DeleteRule(ctx context.Context, data model.RuleRequestData) error {
+	return u.ruleStorage.DeleteRule(ctx, model.Rule{
+		ID:        data.ID,
+		UserID:    data.UserID,
+		DeletedAt: time.Now(),
+	})
+}
+
+// validateRule checks the combinations of the fields the request validation can't check
+func validateRule(data model.RuleRequestData) error {
+	for _, condition := range data.Conditions {
+		if condition.Field == model.RuleFieldAddedTag && data.Trigger != model.RuleTriggerTagAdded {
+			return le.ErrInvalidRuleCondition
+		}
+
+		isDate := condition.Field == model.RuleFieldStartDate || condition.Field == model.RuleFieldDeadline
+
+		switch condition.Operator {
+		case model.RuleOperatorEquals, model.RuleOperatorNotEquals:
+			if condition.Value == "" {
+				return le.ErrInvalidRuleCondition
+			}
+			if isDate {
+				if _, err := time.Parse(ruleDateLayout, condition.Value); err != nil {
+					return le.ErrInvalidRuleCondition
+				}
+			}
+		case model.RuleOperatorContains:
+			if condition.Value == "" || isDate {
+				return le.ErrInvalidRuleCondition
+			}
+		}
+	}
+
+	for _, action := range data.Actions {
+		if action.Type != model.RuleActionComplete && action.Value == "" {
+			return le.ErrInvalidRuleAction
+		}
+	}
+
+	return nil
+}
+
+// RunRules runs the active rules of the user for the triggers. It must be called with the context
+// of the transaction which changed the task, so the actions of the rules are applied in it.
+// The actions may trigger other rules, but every rule runs only once for the change of the task,
+// so the rules can't loop. It returns true if any rule has changed the task
+func (u *RuleUsecase) RunRules(ctx context.Context, triggers ...model.RuleTriggerData) (bool, error) {
+	fired := make(map[string]bool)
+	applied := false
+
+	for _, data := range triggers {
+		triggerApplied, err := u.runRules(ctx, data, fired, 0)
+		if err != nil {
+			return false, err
+		}
+
+		applied = applied || triggerApplied
+	}
+
+	return applied, nil
+}
+
+func (u *RuleUsecase) runRules(ctx context.Context, data model.RuleTriggerData, fired map[string]bool, depth int) (bool, error) {
+	if depth > maxRuleChainDepth {
+		return false, nil
+	}
+
+	rules, err := u.ruleStorage.GetActiveRulesByTrigger(ctx, data.UserID, data.Trigger)
+	if err != nil {
+		return false, err
+	}
+
+	applied := false
+
+	for _, rule := range rules {
+		ruleApplied, err := u.runRule(ctx, rule, data, fired, depth)
+		if err != nil {
+			return false, err
+		}
+
+		applied = applied || ruleApplied
+	}
+
+	return applied, nil
+}
+
+func (u *RuleUsecase) runRule(
+	ctx context.Context,
+	rule model.Rule,
+	data model.RuleTriggerData,
+	fired map[string]bool,
+	depth int,
+) (bool, error) {
+	if fired[rule.ID] {
+		return false, nil
+	}
+
+	task, err := u.taskStorage.GetTaskByID(ctx, data.TaskID, data.UserID)
+	if errors.Is(err, le.ErrTaskNotFound) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	task.UserID = data.UserID
+
+	matched, err := u.matchConditions(ctx, rule.Conditions, task, data)
+	if err != nil || !matched {
+		return false, err
+	}
+
+	fired[rule.ID] = true
+
+	applied := false
+
+	for _, action := range rule.Actions {
+		before := task
+		before.Tags = append([]string(nil), task.Tags...)
+
+		next, err := u.applyAction(ctx, action, &task)
+		if err != nil {
+			return false, err
+		}
+		if next == nil {
+			continue
+		}
+
+		applied = true
+
+		if err = recordAudit(
+			ctx, u.auditUsecase, ruleActionAuditAction(action.Type), model.AuditEntityTask, task.ID, task.UserID,
+			mapTaskToResponseData(before), mapTaskToResponseData(task),
+		); err != nil {
+			return false, err
+		}
+
+		if _, err = u.runRules(ctx, *next, fired, depth+1); err != nil {
+			return false, err
+		}
+
+		// The triggered rules may have changed the task
+		if task, err = u.taskStorage.GetTaskByID(ctx, data.TaskID, data.UserID); err != nil {
+			return false, err
+		}
+
+		task.UserID = data.UserID
+	}
+
+	return applied, nil
+}
+
+func (u *RuleUsecase) matchConditions(
+	ctx context.Context,
+	conditions []model.RuleCondition,
+	task model.Task,
+	data model.RuleTriggerData,
+) (bool, error) {
+	for _, condition := range conditions {
+		values, err := u.conditionValues(ctx, condition.Field, task, data)
+		if err != nil {
+			return false, err
+		}
+
+		if !matchCondition(condition, values) {
+			return false, nil
+		}
+	}
+
+	return true, nil
+}
+
+// conditionValues returns the values of the task field the condition is compared with
+func (u *RuleUsecase) conditionValues(
+	ctx context.Context,
+	field model.RuleConditionField,
+	task model.Task,
+	data model.RuleTriggerData,
+) ([]string, error) {
+	switch field {
+	case model.RuleFieldTitle:
+		return []string{task.Title}, nil
+	case model.RuleFieldList:
+		list, err := u.listUsecase.GetListByID(ctx, model.ListRequestData{
+			ID:     task.ListID,
+			UserID: task.UserID,
+		})
+		if err != nil {
+			return nil, err
+		}
+		return []string{list.ID, list.Title}, nil
+	case model.RuleFieldHeading:
+		heading, err := u.headingUsecase.GetHeadingByID(ctx, model.HeadingRequestData{
+			ID:     task.HeadingID,
+			UserID: task.UserID,
+		})
+		if err != nil {
+			return nil, err
+		}
+		return []string{heading.ID, heading.Title}, nil
+	case model.RuleFieldTag:
+		return task.Tags, nil
+	case model.RuleFieldAddedTag:
+		return data.AddedTags, nil
+	case model.RuleFieldStartDate:
+		return formatRuleDate(task.StartDate), nil
+	case model.RuleFieldDeadline:
+		return formatRuleDate(task.Deadline), nil
+	default:
+		return nil, le.ErrInvalidRuleCondition
+	}
+}
+
+func formatRuleDate(date time.Time) []string {
+	if date.IsZero() {
+		return nil
+	}
+	return []string{date.Format(ruleDateLayout)}
+}
+
+// matchCondition compares the values of the field with the value of the condition.
+// The field matches, if any of its values matches, strings are compared case-insensitively
+func matchCondition(condition model.RuleCondition, values []string) bool {
+	switch condition.Operator {
+	case model.RuleOperatorEquals:
+		return containsFold(values, condition.Value)
+	case model.RuleOperatorNotEquals:
+		return !containsFold(values, condition.Value)
+	case model.RuleOperatorContains:
+		for _, value := range values {
+			if strings.Contains(strings.ToLower(value), strings.ToLower(condition.Value)) {
+				return true
+			}
+		}
+		return false
+	case model.RuleOperatorIsSet:
+		return hasNonEmpty(values)
+	case model.RuleOperatorIsNotSet:
+		return !hasNonEmpty(values)
+	default:
+		return false
+	}
+}
+
+func containsFold(values []string, target string) bool {
+	for _, value := range values {
+		if strings.EqualFold(value, target) {
+			return true
+		}
+	}
+	return false
+}
+
+func hasNonEmpty(values []string) bool {
+	for _, value := range values {
+		if value != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// applyAction changes the task and returns the trigger caused by the change.
+// It returns nil, if the task hasn't been changed, because it's already in the required state
+// or the target of the action doesn't exist anymore
+func (u *RuleUsecase) applyAction(ctx context.Context, action model.RuleAction, task *model.Task) (*model.RuleTriggerData, error) {
+	next := &model.RuleTriggerData{
+		Trigger: model.RuleTriggerTaskUpdated,
+		TaskID:  task.ID,
+		UserID:  task.UserID,
+	}
+
+	switch action.Type {
+	case model.RuleActionMoveToHeading:
+		headings, err := u.headingUsecase.GetHeadingsByListID(ctx, model.HeadingRequestData{
+			ListID: task.ListID,
+			UserID: task.UserID,
+		})
+		if errors.Is(err, le.ErrNoHeadingsFound) {
+			return nil, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		for _, heading := range headings {
+			if heading.ID != action.Value && !strings.EqualFold(heading.Title, action.Value) {
+				continue
+			}
+			if heading.ID == task.HeadingID {
+				return nil, nil
+			}
+
+			return next, u.moveTask(ctx, task, task.ListID, heading.ID)
+		}
+		return nil, nil
+	case model.RuleActionMoveToList:
+		lists, err := u.listUsecase.GetListsByUserID(ctx, task.UserID, false)
+		if errors.Is(err, le.ErrNoListsFound) {
+			return nil, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		for _, list := range lists {
+			if list.ID != action.Value && !strings.EqualFold(list.Title, action.Value) {
+				continue
+			}
+			if list.ID == task.ListID {
+				return nil, nil
+			}
+
+			headingID, err := u.headingUsecase.GetDefaultHeadingID(ctx, model.HeadingRequestData{
+				ListID: list.ID,
+				UserID: task.UserID,
+			})
+			if err != nil {
+				return nil, err
+			}
+
+			return next, u.moveTask(ctx, task, list.ID, headingID)
+		}
+		return nil, nil
+	case model.RuleActionAddTag:
+		if containsFold(task.Tags, action.Value) {
+			return nil, nil
+		}
+		if err := u.tagUsecase.CreateTagIfNotExists(ctx, model.TagRequestData{
+			Title:  action.Value,
+			UserID: task.UserID,
+		}); err != nil {
+			return nil, err
+		}
+		if err := u.tagUsecase.LinkTagsToTask(ctx, task.ID, []string{action.Value}); err != nil {
+			return nil, err
+		}
+
+		task.Tags = append(task.Tags, action.Value)
+		next.Trigger = model.RuleTriggerTagAdded
+		next.AddedTags = []string{action.Value}
+
+		return next, nil
+	case model.RuleActionRemoveTag:
+		for i, tag := range task.Tags {
+			if !strings.EqualFold(tag, action.Value) {
+				continue
+			}
+			if err := u.tagUsecase.UnlinkTagsFromTask(ctx, task.ID, []string{tag}); err != nil {
+				return nil, err
+			}
+
+			task.Tags = append(task.Tags[:i], task.Tags[i+1:]...)
+
+			return next, nil
+		}
+		return nil, nil
+	case model.RuleActionComplete:
+		statusCompleted, err := u.taskStorage.GetTaskStatusID(ctx, model.StatusCompleted)
+		if err != nil {
+			return nil, err
+		}
+		if task.StatusID == statusCompleted {
+			return nil, nil
+		}
+		if err = u.taskStorage.MarkAsCompleted(ctx, model.Task{
+			ID:        task.ID,
+			StatusID:  statusCompleted,
+			UserID:    task.UserID,
+			UpdatedAt: time.Now(),
+		}); err != nil {
+			return nil, err
+		}
+
+		task.StatusID = statusCompleted
+		next.Trigger = model.RuleTriggerTaskCompleted
+
+		return next, nil
+	default:
+		return nil, le.ErrInvalidRuleAction
+	}
+}
+
+// ruleActionAuditAction returns the audit action for the change made by the rule action
+func ruleActionAuditAction(actionType model.RuleActionType) model.AuditAction {
+	switch actionType {
+	case model.RuleActionMoveToHeading, model.RuleActionMoveToList:
+		return model.AuditActionMove
+	case model.RuleActionComplete:
+		return model.AuditActionComplete
+	default:
+		return model.AuditActionUpdate
+	}
+}
+
+func (u *RuleUsecase) moveTask(ctx context.Context, task *model.Task, listID, headingID string) error {
+	if err := u.taskStorage.MoveTaskToAnotherList(ctx, model.Task{
+		ID:        task.ID,
+		ListID:    listID,
+		HeadingID: headingID,
+		UserID:    task.UserID,
+		UpdatedAt: time.Now(),
+	}); err != nil {
+		return err
+	}
+
+	task.ListID = listID
+	task.HeadingID = headingID
+
+	return nil
+}
+
+// RunDeadlineRules runs the deadline rules for the open tasks whose deadline has passed.
+// Every rule runs once for the deadline of the task, each run in its own transaction
+func (u *RuleUsecase) RunDeadlineRules(ctx context.Context) error {
+	now := time.Now()
+
+	runs, err := u.ruleStorage.GetPendingDeadlineRuleRuns(ctx, now, ruleDeadlineBatchSize)
+	if err != nil {
+		return err
+	}
+
+	var errs []error
+
+	for _, run := range runs {
+		if err = u.runDeadlineRule(ctx, run, now); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+func (u *RuleUsecase) runDeadlineRule(ctx context.Context, run model.RuleDeadlineRun, now time.Time) error {
+	applied := false
+
+	if err := u.taskStorage.Transaction(ctx, func(ctx context.Context) error {
+		created, err := u.ruleStorage.CreateRuleDeadlineRun(ctx, run, now)
+		if err != nil || !created {
+			return err
+		}
+
+		rule, err := u.ruleStorage.GetRuleByID(ctx, run.RuleID, run.UserID)
+		if errors.Is(err, le.ErrRuleNotFound) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		applied, err = u.runRule(ctx, rule, model.RuleTriggerData{
+			Trigger: model.RuleTriggerDeadlinePassed,
+			TaskID:  run.TaskID,
+			UserID:  run.UserID,
+		}, make(map[string]bool), 0)
+		return err
+	}); err != nil {
+		return err
+	}
+
+	if applied {
+		publishTaskEvent(ctx, u.eventBroker, u.memberStorage, model.EventTaskUpdated, run.TaskID, run.UserID, model.TaskResponseData{
+			ID:     run.TaskID,
+			UserID: run.UserID,
+		})
+	}
+
+	return nil
+}
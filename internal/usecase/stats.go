@@ -0,0 +1,139 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/rshelekhov/reframed/internal/model"
+	"github.com/rshelekhov/reframed/internal/port"
+)
+
+// StatsUsecase computes the statistics in the database and keeps them in memory for cacheTTL,
+// so the dashboards polling the endpoint don't run the aggregations on every request
+type StatsUsecase struct {
+	statsStorage port.StatsStorage
+	cacheTTL     time.Duration
+
+	mu    sync.Mutex
+	cache map[string]model.StatsResponseData
+}
+
+func NewStatsUsecase(storage port.StatsStorage, cacheTTL time.Duration) *StatsUsecase {
+	return &StatsUsecase{
+		statsStorage: storage,
+		cacheTTL:     cacheTTL,
+		cache:        make(map[string]model.StatsResponseData),
+	}
+}
+
+func (u *StatsUsecase) GetStats(ctx context.Context, data model.StatsRequestData) (model.StatsResponseData, error) {
+	now := time.Now()
+
+	if statsResp, ok := u.getCachedStats(data, now); ok {
+		return statsResp, nil
+	}
+
+	throughput, err := u.statsStorage.GetTaskThroughput(ctx, data)
+	if err != nil {
+		return model.StatsResponseData{}, err
+	}
+
+	byList, err := u.statsStorage.GetCompletionRatesByList(ctx, data)
+	if err != nil {
+		return model.StatsResponseData{}, err
+	}
+
+	byTag, err := u.statsStorage.GetCompletionRatesByTag(ctx, data)
+	if err != nil {
+		return model.StatsResponseData{}, err
+	}
+
+	summary, err := u.statsStorage.GetTaskSummaryStats(ctx, data)
+	if err != nil {
+		return model.StatsResponseData{}, err
+	}
+
+	streaks, err := u.statsStorage.GetCompletionStreaks(ctx, data.UserID)
+	if err != nil {
+		return model.StatsResponseData{}, err
+	}
+
+	statsResp := model.StatsResponseData{
+		Period:           data.Period,
+		From:             data.From,
+		To:               data.To,
+		Created:          summary.Created,
+		Completed:        summary.Completed,
+		Throughput:       throughput,
+		ByList:           withCompletionRates(byList),
+		ByTag:            withCompletionRates(byTag),
+		AvgLeadTimeHours: roundRate(summary.AvgLeadTimeSeconds / time.Hour.Seconds()),
+		Due:              summary.Due,
+		Overdue:          summary.Overdue,
+		OverdueRate:      ratio(summary.Overdue, summary.Due),
+		Streaks:          streaks,
+		GeneratedAt:      now,
+		ExpiresAt:        now.Add(u.cacheTTL),
+	}
+
+	u.cacheStats(data, statsResp, now)
+
+	return statsResp, nil
+}
+
+func (u *StatsUsecase) getCachedStats(data model.StatsRequestData, now time.Time) (model.StatsResponseData, bool) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	statsResp, ok := u.cache[statsCacheKey(data)]
+	if !ok || !now.Before(statsResp.ExpiresAt) {
+		return model.StatsResponseData{}, false
+	}
+
+	return statsResp, true
+}
+
+// cacheStats stores the statistics and drops the expired ones, so the cache doesn't grow
+// with the users who stopped asking for their statistics
+func (u *StatsUsecase) cacheStats(data model.StatsRequestData, statsResp model.StatsResponseData, now time.Time) {
+	if u.cacheTTL <= 0 {
+		return
+	}
+
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	for key, cached := range u.cache {
+		if !now.Before(cached.ExpiresAt) {
+			delete(u.cache, key)
+		}
+	}
+
+	u.cache[statsCacheKey(data)] = statsResp
+}
+
+// statsCacheKey compares the time range by instant, time.Time values of the same instant aren't always equal
+func statsCacheKey(data model.StatsRequestData) string {
+	return fmt.Sprintf("%s:%s:%d:%d", data.UserID, data.Period, data.From.Unix(), data.To.Unix())
+}
+
+func withCompletionRates(rates []model.StatsCompletionRate) []model.StatsCompletionRate {
+	for i := range rates {
+		rates[i].Rate = ratio(rates[i].Completed, rates[i].Total)
+	}
+	return rates
+}
+
+func ratio(part, total int64) float64 {
+	if total == 0 {
+		return 0
+	}
+	return roundRate(float64(part) / float64(total))
+}
+
+func roundRate(value float64) float64 {
+	return math.Round(value*100) / 100
+}
@@ -13,12 +13,14 @@ import (
 )
 
 type TagUsecase struct {
-	tagStorage port.TagStorage
+	tagStorage   port.TagStorage
+	auditUsecase port.AuditUsecase
 }
 
-func NewTagUsecase(storage port.TagStorage) *TagUsecase {
+func NewTagUsecase(storage port.TagStorage, auditUsecase port.AuditUsecase) *TagUsecase {
 	return &TagUsecase{
-		tagStorage: storage,
+		tagStorage:   storage,
+		auditUsecase: auditUsecase,
 	}
 }
 
@@ -32,7 +34,13 @@ func (u *TagUsecase) CreateTagIfNotExists(ctx context.Context, data model.TagReq
 			UpdatedAt: time.Now(),
 		}
 
-		return u.tagStorage.CreateTag(ctx, newTag)
+		if err = u.tagStorage.CreateTag(ctx, newTag); err != nil {
+			return err
+		}
+
+		return recordAudit(
+			ctx, u.auditUsecase, model.AuditActionCreate, model.AuditEntityTag, newTag.ID, newTag.UserID, nil, mapTagToTagResponseData(newTag),
+		)
 	}
 	if err != nil {
 		return err
@@ -2,6 +2,7 @@ package usecase
 
 import (
 	"context"
+	"errors"
 	"time"
 
 	"github.com/segmentio/ksuid"
@@ -13,26 +14,56 @@ import (
 
 type TaskUsecase struct {
 	taskStorage    port.TaskStorage
+	memberStorage  port.ListMemberStorage
 	headingUsecase port.HeadingUsecase
 	tagUsecase     port.TagUsecase
 	listUsecase    port.ListUsecase
+	ruleUsecase    port.RuleUsecase
+	commentUsecase port.CommentUsecase
+	habitUsecase   port.HabitUsecase
+	auditUsecase   port.AuditUsecase
+	undoUsecase    port.UndoUsecase
+	eventBroker    port.EventBroker
 }
 
 func NewTaskUsecase(
 	storage port.TaskStorage,
+	memberStorage port.ListMemberStorage,
 	headingUsecase port.HeadingUsecase,
 	tagUsecase port.TagUsecase,
 	listUsecase port.ListUsecase,
+	ruleUsecase port.RuleUsecase,
+	commentUsecase port.CommentUsecase,
+	habitUsecase port.HabitUsecase,
+	auditUsecase port.AuditUsecase,
+	undoUsecase port.UndoUsecase,
+	eventBroker port.EventBroker,
 ) *TaskUsecase {
 	return &TaskUsecase{
 		taskStorage:    storage,
+		memberStorage:  memberStorage,
 		headingUsecase: headingUsecase,
 		tagUsecase:     tagUsecase,
 		listUsecase:    listUsecase,
+		ruleUsecase:    ruleUsecase,
+		commentUsecase: commentUsecase,
+		habitUsecase:   habitUsecase,
+		auditUsecase:   auditUsecase,
+		undoUsecase:    undoUsecase,
+		eventBroker:    eventBroker,
 	}
 }
 
 func (u *TaskUsecase) CreateTask(ctx context.Context, data *model.TaskRequestData) (model.TaskResponseData, error) {
+	if data.ListID == "" && data.AreaID != "" {
+		areaListID, err := u.listUsecase.GetAreaDefaultListID(ctx, data.AreaID, data.UserID)
+		if err != nil {
+			return model.TaskResponseData{}, err
+		}
+
+		data.ListID = areaListID
+	}
+
 	if data.ListID == "" {
 		defaultListID, err := u.listUsecase.GetDefaultListID(ctx, data.UserID)
 		if err != nil {
@@ -42,6 +73,10 @@ func (u *TaskUsecase) CreateTask(ctx context.Context, data *model.TaskRequestDat
 		data.ListID = defaultListID
 	}
 
+	if err := authorizeList(ctx, u.memberStorage, data.ListID, data.UserID, model.ListRoleEditor); err != nil {
+		return model.TaskResponseData{}, err
+	}
+
 	if data.HeadingID == "" {
 		defaultHeadingID, err := u.headingUsecase.GetDefaultHeadingID(ctx, model.HeadingRequestData{
 			ListID: data.ListID,
@@ -73,10 +108,16 @@ func (u *TaskUsecase) CreateTask(ctx context.Context, data *model.TaskRequestDat
 		ListID:      data.ListID,
 		HeadingID:   data.HeadingID,
 		UserID:      data.UserID,
+		Tags:        data.Tags,
 		UpdatedAt:   time.Now(),
 	}
 
-	if err = u.taskStorage.Transaction(ctx, func(_ port.TaskStorage) error {
+	var (
+		rulesApplied bool
+		undoToken    string
+	)
+
+	if err = u.taskStorage.Transaction(ctx, func(ctx context.Context) error {
 		for _, tag := range newTask.Tags {
 			if err = u.tagUsecase.CreateTagIfNotExists(ctx, model.TagRequestData{
 				Title:  tag,
@@ -88,15 +129,41 @@ func (u *TaskUsecase) CreateTask(ctx context.Context, data *model.TaskRequestDat
 		if err = u.taskStorage.CreateTask(ctx, newTask); err != nil {
 			return err
 		}
+		undoToken, err = u.undoUsecase.CreateUndoToken(ctx, model.UndoRecordData{
+			EntityType: model.AuditEntityTask,
+			EntityID:   newTask.ID,
+			Action:     model.AuditActionCreate,
+			UserID:     newTask.UserID,
+			Created:    true,
+		})
+		if err != nil {
+			return err
+		}
+		if err = u.recordActivity(ctx, newTask.ID, newTask.UserID, model.TaskActivityCreated, "", "", ""); err != nil {
+			return err
+		}
 		if err = u.tagUsecase.LinkTagsToTask(ctx, newTask.ID, newTask.Tags); err != nil {
 			return err
 		}
-		return nil
+		if err = recordAudit(
+			ctx, u.auditUsecase, model.AuditActionCreate, model.AuditEntityTask, newTask.ID, newTask.UserID, nil, mapTaskToResponseData(newTask),
+		); err != nil {
+			return err
+		}
+
+		rulesApplied, err = u.runRules(ctx, model.RuleTriggerTaskCreated, newTask, newTask.Tags)
+		if err != nil {
+			return err
+		}
+		if rulesApplied {
+			newTask, err = u.getTaskChangedByRules(ctx, newTask)
+		}
+		return err
 	}); err != nil {
 		return model.TaskResponseData{}, err
 	}
 
-	return model.TaskResponseData{
+	taskResp := model.TaskResponseData{
 		ID:          newTask.ID,
 		Title:       newTask.Title,
 		Description: newTask.Description,
@@ -108,8 +175,15 @@ func (u *TaskUsecase) CreateTask(ctx context.Context, data *model.TaskRequestDat
 		ListID:      newTask.ListID,
 		HeadingID:   newTask.HeadingID,
 		UserID:      newTask.UserID,
+		Tags:        newTask.Tags,
 		UpdatedAt:   newTask.UpdatedAt,
-	}, nil
+	}
+
+	publishTaskEvent(ctx, u.eventBroker, u.memberStorage, model.EventTaskCreated, taskResp.ID, taskResp.UserID, taskResp)
+
+	taskResp.UndoToken = undoToken
+
+	return taskResp, nil
 }
 
 func (u *TaskUsecase) GetTaskByID(ctx context.Context, data model.TaskRequestData) (model.TaskResponseData, error) {
@@ -118,19 +192,18 @@ func (u *TaskUsecase) GetTaskByID(ctx context.Context, data model.TaskRequestDat
 		return model.TaskResponseData{}, err
 	}
 
-	return model.TaskResponseData{
-		ID:        task.ID,
-		Title:     task.Title,
-		StartDate: task.StartDate,
-		Deadline:  task.Deadline,
-		StartTime: task.StartTime,
-		EndTime:   task.EndTime,
-		StatusID:  task.StatusID,
-		ListID:    task.ListID,
-		HeadingID: task.HeadingID,
-		UserID:    task.UserID,
-		UpdatedAt: task.UpdatedAt,
-	}, nil
+	taskResp := []model.TaskResponseData{mapTaskToResponseData(task)}
+
+	if err = u.markBlockedTasks(ctx, data.UserID, taskResp); err != nil {
+		return model.TaskResponseData{}, err
+	}
+
+	taskResp[0].Timeline, err = u.commentUsecase.GetTaskTimeline(ctx, task.ID)
+	if err != nil {
+		return model.TaskResponseData{}, err
+	}
+
+	return taskResp[0], nil
 }
 
 func (u *TaskUsecase) GetTasksByUserID(ctx context.Context, userID string, pgn model.Pagination) ([]model.TaskResponseData, error) {
@@ -144,11 +217,16 @@ func (u *TaskUsecase) GetTasksByUserID(ctx context.Context, userID string, pgn m
 		tasksResp = append(tasksResp, mapTaskToResponseData(task))
 	}
 
+	if err = u.markBlockedTasks(ctx, userID, tasksResp); err != nil {
+		return nil, err
+	}
+
 	return tasksResp, nil
 }
 
-func (u *TaskUsecase) GetTasksByListID(ctx context.Context, data model.TaskRequestData) ([]model.TaskResponseData, error) {
-	tasks, err := u.taskStorage.GetTasksByListID(ctx, data.ListID, data.UserID)
+// GetAssignedTasks returns the tasks assigned to the user, including the ones from the lists shared with them
+func (u *TaskUsecase) GetAssignedTasks(ctx context.Context, userID string, pgn model.Pagination) ([]model.TaskResponseData, error) {
+	tasks, err := u.taskStorage.GetAssignedTasks(ctx, userID, pgn)
 	if err != nil {
 		return nil, err
 	}
@@ -158,261 +236,1590 @@ func (u *TaskUsecase) GetTasksByListID(ctx context.Context, data model.TaskReque
 		tasksResp = append(tasksResp, mapTaskToResponseData(task))
 	}
 
-	return tasksResp, nil
-}
-
-func mapTaskToResponseData(task model.Task) model.TaskResponseData {
-	return model.TaskResponseData{
-		ID:        task.ID,
-		Title:     task.Title,
-		StartDate: task.StartDate,
-		Deadline:  task.Deadline,
-		StartTime: task.StartTime,
-		EndTime:   task.EndTime,
-		StatusID:  task.StatusID,
-		ListID:    task.ListID,
-		HeadingID: task.HeadingID,
-		UserID:    task.UserID,
-		UpdatedAt: task.UpdatedAt,
+	if err = u.markBlockedTasks(ctx, userID, tasksResp); err != nil {
+		return nil, err
 	}
+
+	return tasksResp, nil
 }
 
-func (u *TaskUsecase) GetTasksGroupedByHeadings(ctx context.Context, data model.TaskRequestData) ([]model.TaskGroup, error) {
-	taskGroups, err := u.taskStorage.GetTasksGroupedByHeadings(ctx, data.ListID, data.UserID)
+func (u *TaskUsecase) GetTasksByListID(ctx context.Context, data model.TaskRequestData) ([]model.TaskResponseData, error) {
+	tasks, err := u.taskStorage.GetTasksByListID(ctx, data.ListID, data.UserID)
 	if err != nil {
 		return nil, err
 	}
 
-	return taskGroups, nil
-}
+	var tasksResp []model.TaskResponseData
+	for _, task := range tasks {
+		tasksResp = append(tasksResp, mapTaskToResponseData(task))
+	}
 
-func (u *TaskUsecase) GetTasksForToday(ctx context.Context, userID string) ([]model.TaskGroup, error) {
-	taskGroups, err := u.taskStorage.GetTasksForToday(ctx, userID)
-	if err != nil {
+	if err = u.markBlockedTasks(ctx, data.UserID, tasksResp); err != nil {
 		return nil, err
 	}
 
-	return taskGroups, nil
+	return tasksResp, nil
 }
 
-func (u *TaskUsecase) GetUpcomingTasks(ctx context.Context, userID string, pgn model.Pagination) ([]model.TaskGroup, error) {
-	taskGroups, err := u.taskStorage.GetUpcomingTasks(ctx, userID, pgn)
+// GetAllTasksByListID returns the tasks of the list including the snoozed ones, which are hidden from the list view
+func (u *TaskUsecase) GetAllTasksByListID(ctx context.Context, data model.TaskRequestData) ([]model.TaskResponseData, error) {
+	tasks, err := u.taskStorage.GetAllTasksByListID(ctx, data.ListID, data.UserID)
 	if err != nil {
 		return nil, err
 	}
 
-	return taskGroups, nil
-}
+	var tasksResp []model.TaskResponseData
+	for _, task := range tasks {
+		tasksResp = append(tasksResp, mapTaskToResponseData(task))
+	}
 
-func (u *TaskUsecase) GetOverdueTasks(ctx context.Context, userID string, pgn model.Pagination) ([]model.TaskGroup, error) {
-	taskGroups, err := u.taskStorage.GetOverdueTasks(ctx, userID, pgn)
-	if err != nil {
+	if err = u.markBlockedTasks(ctx, data.UserID, tasksResp); err != nil {
 		return nil, err
 	}
 
-	return taskGroups, nil
+	return tasksResp, nil
 }
 
-func (u *TaskUsecase) GetTasksForSomeday(ctx context.Context, userID string, pgn model.Pagination) ([]model.TaskGroup, error) {
-	taskGroups, err := u.taskStorage.GetTasksForSomeday(ctx, userID, pgn)
+// GetTasksByAreaID returns the loose tasks of the area, which aren't in any of its lists
+func (u *TaskUsecase) GetTasksByAreaID(ctx context.Context, data model.TaskRequestData) ([]model.TaskResponseData, error) {
+	areaListID, err := u.listUsecase.GetAreaDefaultListID(ctx, data.AreaID, data.UserID)
 	if err != nil {
 		return nil, err
 	}
 
-	return taskGroups, nil
-}
+	data.ListID = areaListID
 
-func (u *TaskUsecase) GetCompletedTasks(ctx context.Context, userID string, pgn model.Pagination) ([]model.TaskGroup, error) {
-	taskGroups, err := u.taskStorage.GetCompletedTasks(ctx, userID, pgn)
+	tasksResp, err := u.GetTasksByListID(ctx, data)
 	if err != nil {
 		return nil, err
 	}
 
-	return taskGroups, nil
+	for i := range tasksResp {
+		tasksResp[i].AreaID = data.AreaID
+	}
+
+	return tasksResp, nil
 }
 
-func (u *TaskUsecase) GetArchivedTasks(ctx context.Context, userID string, pgn model.Pagination) ([]model.TaskGroup, error) {
-	taskGroups, err := u.taskStorage.GetArchivedTasks(ctx, userID, pgn)
+func mapTaskToResponseData(task model.Task) model.TaskResponseData {
+	return model.TaskResponseData{
+		ID:            task.ID,
+		Title:         task.Title,
+		Description:   task.Description,
+		StartDate:     task.StartDate,
+		Deadline:      task.Deadline,
+		StartTime:     task.StartTime,
+		EndTime:       task.EndTime,
+		StatusID:      task.StatusID,
+		ListID:        task.ListID,
+		HeadingID:     task.HeadingID,
+		AssigneeID:    task.AssigneeID,
+		UserID:        task.UserID,
+		DeferredUntil: task.DeferredUntil,
+		SnoozeCount:   task.SnoozeCount,
+		ThisEvening:   task.ThisEvening,
+		Tags:          task.Tags,
+		Overdue:       task.Overdue,
+		Blocked:       task.Blocked,
+		UpdatedAt:     task.UpdatedAt,
+	}
+}
+
+func (u *TaskUsecase) GetTasksGroupedByHeadings(ctx context.Context, data model.TaskRequestData) ([]model.TaskGroup, error) {
+	taskGroups, err := u.taskStorage.GetTasksGroupedByHeadings(ctx, data.ListID, data.UserID)
 	if err != nil {
 		return nil, err
 	}
 
-	return taskGroups, nil
+	return u.markBlockedTaskGroups(ctx, data.UserID, taskGroups, false)
 }
 
-func (u *TaskUsecase) UpdateTask(ctx context.Context, data *model.TaskRequestData) (model.TaskResponseData, error) {
-	updatedTask := model.Task{
-		ID:        data.ID,
-		Title:     data.Title,
-		StartDate: data.StartDate,
-		Deadline:  data.Deadline,
-		StartTime: data.StartTime,
-		EndTime:   data.EndTime,
-		ListID:    data.ListID,
-		HeadingID: data.HeadingID,
-		UserID:    data.UserID,
-		UpdatedAt: time.Now(),
+// GetTasksForToday returns the tasks for today grouped by list, the tasks planned for the evening are in their own section.
+// The habits to do today are returned alongside the tasks, so Today isn't empty while there are habits to do
+func (u *TaskUsecase) GetTasksForToday(ctx context.Context, userID string, hideBlocked bool) (model.TodayTasksResponseData, error) {
+	taskGroups, err := u.taskStorage.GetTasksForToday(ctx, userID)
+	if err != nil && !errors.Is(err, le.ErrNoTasksFound) {
+		return model.TodayTasksResponseData{}, err
 	}
 
-	if err := u.taskStorage.Transaction(ctx, func(_ port.TaskStorage) error {
-		currentTags, err := u.tagUsecase.GetTagsByTaskID(ctx, updatedTask.ID)
-		if err != nil {
-			return err
+	// No groups are left when all the tasks are blocked and hidden, the habits are still returned then
+	if len(taskGroups) > 0 {
+		taskGroups, err = u.markBlockedTaskGroups(ctx, userID, taskGroups, hideBlocked)
+		if err != nil && !errors.Is(err, le.ErrNoTasksFound) {
+			return model.TodayTasksResponseData{}, err
 		}
+	}
 
-		tagsToAdd, tagsToRemove := findTagsToAddAndRemove(currentTags, updatedTask.Tags)
+	habits, err := u.habitUsecase.GetHabitsForToday(ctx, userID)
+	if err != nil {
+		return model.TodayTasksResponseData{}, err
+	}
 
-		for _, tag := range updatedTask.Tags {
-			if err = u.tagUsecase.CreateTagIfNotExists(ctx, model.TagRequestData{
-				Title:  tag,
-				UserID: updatedTask.UserID,
-			}); err != nil {
-				return err
+	if len(taskGroups) == 0 && len(habits) == 0 {
+		return model.TodayTasksResponseData{}, le.ErrNoTasksFound
+	}
+
+	today := splitEveningTaskGroups(taskGroups)
+	today.Habits = habits
+
+	return today, nil
+}
+
+// splitEveningTaskGroups moves the tasks planned for the evening to the evening section, keeping their groups
+func splitEveningTaskGroups(taskGroups []model.TaskGroup) model.TodayTasksResponseData {
+	today := model.TodayTasksResponseData{
+		Today:       []model.TaskGroup{},
+		ThisEvening: []model.TaskGroup{},
+	}
+
+	for _, group := range taskGroups {
+		dayTasks := make([]model.TaskResponseData, 0, len(group.Tasks))
+		eveningTasks := make([]model.TaskResponseData, 0)
+
+		for _, task := range group.Tasks {
+			if task.ThisEvening {
+				eveningTasks = append(eveningTasks, task)
+				continue
 			}
-		}
 
-		if err = u.taskStorage.UpdateTask(ctx, updatedTask); err != nil {
-			return err
+			dayTasks = append(dayTasks, task)
 		}
-		if err = u.tagUsecase.UnlinkTagsFromTask(ctx, updatedTask.ID, tagsToRemove); err != nil {
-			return err
+
+		if len(eveningTasks) > 0 {
+			eveningGroup := group
+			eveningGroup.Tasks = eveningTasks
+			today.ThisEvening = append(today.ThisEvening, eveningGroup)
 		}
-		if err = u.tagUsecase.LinkTagsToTask(ctx, updatedTask.ID, tagsToAdd); err != nil {
-			return err
+
+		if len(dayTasks) > 0 || len(eveningTasks) == 0 {
+			group.Tasks = dayTasks
+			today.Today = append(today.Today, group)
 		}
-		return nil
-	}); err != nil {
-		return model.TaskResponseData{}, err
 	}
 
-	return model.TaskResponseData{
-		ID:        updatedTask.ID,
-		Title:     updatedTask.Title,
-		StartDate: updatedTask.StartDate,
-		Deadline:  updatedTask.Deadline,
-		StartTime: updatedTask.StartTime,
-		EndTime:   updatedTask.EndTime,
-		StatusID:  updatedTask.StatusID,
-		ListID:    updatedTask.ListID,
-		HeadingID: updatedTask.HeadingID,
-		UserID:    updatedTask.UserID,
-		Tags:      updatedTask.Tags,
-		UpdatedAt: updatedTask.UpdatedAt,
-	}, nil
+	return today
 }
 
-func findTagsToAddAndRemove(currentTags []model.TagResponseData, updatedTags []string) (tagsToAdd, tagsToRemove []string) {
-	tagMap := make(map[string]bool)
+// GetDailyPlanCandidates proposes the tasks for the plan of the day: the overdue tasks, the tasks left from yesterday,
+// the tasks which deadline comes within the given number of days and the tasks scheduled for today
+func (u *TaskUsecase) GetDailyPlanCandidates(ctx context.Context, userID string, days int) (model.DailyPlanCandidatesResponseData, error) {
+	candidates, err := u.taskStorage.GetDailyPlanCandidates(ctx, userID, days)
+	if err != nil {
+		return model.DailyPlanCandidatesResponseData{}, err
+	}
 
-	for _, tag := range currentTags {
-		tagMap[tag.Title] = true
+	tasksResp := make([]model.TaskResponseData, 0, len(candidates))
+	for _, candidate := range candidates {
+		tasksResp = append(tasksResp, mapTaskToResponseData(candidate.Task))
 	}
 
-	for _, tag := range updatedTags {
-		if _, ok := tagMap[tag]; ok {
-			delete(tagMap, tag)
-		} else {
-			tagsToAdd = append(tagsToAdd, tag)
-		}
+	if err = u.markBlockedTasks(ctx, userID, tasksResp); err != nil {
+		return model.DailyPlanCandidatesResponseData{}, err
 	}
 
-	for tag := range tagMap {
-		tagsToRemove = append(tagsToRemove, tag)
+	planResp := model.DailyPlanCandidatesResponseData{
+		Overdue:    []model.TaskResponseData{},
+		Unfinished: []model.TaskResponseData{},
+		DueSoon:    []model.TaskResponseData{},
+		Scheduled:  []model.TaskResponseData{},
 	}
 
-	return tagsToAdd, tagsToRemove
+	for i, candidate := range candidates {
+		switch candidate.Reason {
+		case model.PlanReasonOverdue:
+			planResp.Overdue = append(planResp.Overdue, tasksResp[i])
+		case model.PlanReasonUnfinished:
+			planResp.Unfinished = append(planResp.Unfinished, tasksResp[i])
+		case model.PlanReasonDueSoon:
+			planResp.DueSoon = append(planResp.DueSoon, tasksResp[i])
+		case model.PlanReasonScheduled:
+			planResp.Scheduled = append(planResp.Scheduled, tasksResp[i])
+		}
+	}
+
+	return planResp, nil
 }
 
-func (u *TaskUsecase) UpdateTaskTime(ctx context.Context, data *model.TaskRequestTimeData) (model.TaskResponseTimeData, error) {
-	var statusID int
+// CommitDailyPlan starts the chosen tasks today in one go, the tasks of the evening section are planned for the evening.
+// The returned token reverts the whole plan
+func (u *TaskUsecase) CommitDailyPlan(ctx context.Context, data model.DailyPlanRequestData) (model.TodayTasksResponseData, error) {
+	evening := make(map[string]bool, len(data.ThisEvening))
+	for _, taskID := range data.ThisEvening {
+		evening[taskID] = true
+	}
 
-	switch {
-	case !data.StartTime.IsZero() && !data.EndTime.IsZero():
-		taskStatusID, err := u.taskStorage.GetTaskStatusID(ctx, model.StatusPlanned)
-		if err != nil {
-			return model.TaskResponseTimeData{}, err
+	var taskIDs []string
+
+	planned := make(map[string]bool)
+	for _, section := range [][]string{data.Today, data.ThisEvening} {
+		for _, taskID := range section {
+			if planned[taskID] {
+				continue
+			}
+
+			planned[taskID] = true
+			taskIDs = append(taskIDs, taskID)
 		}
-		statusID = taskStatusID
-	case data.StartTime.IsZero() && data.EndTime.IsZero():
-		taskStatusID, err := u.taskStorage.GetTaskStatusID(ctx, model.StatusNotStarted)
+	}
+
+	if len(taskIDs) == 0 {
+		return model.TodayTasksResponseData{}, le.ErrEmptyDailyPlan
+	}
+
+	for _, taskID := range taskIDs {
+		if err := authorizeTask(ctx, u.memberStorage, taskID, data.UserID, model.ListRoleEditor); err != nil {
+			return model.TodayTasksResponseData{}, err
+		}
+	}
+
+	now := time.Now()
+
+	var (
+		plannedTasks []model.Task
+		undoToken    string
+	)
+
+	if err := u.taskStorage.Transaction(ctx, func(ctx context.Context) error {
+		var err error
+
+		undoToken, err = u.undoUsecase.CreateUndoToken(ctx, model.UndoRecordData{
+			EntityType: model.AuditEntityTask,
+			EntityID:   taskIDs[0],
+			Action:     model.AuditActionUpdate,
+			UserID:     data.UserID,
+			TaskIDs:    taskIDs,
+		})
 		if err != nil {
-			return model.TaskResponseTimeData{}, err
+			return err
 		}
-		statusID = taskStatusID
-	default:
-		return model.TaskResponseTimeData{}, le.ErrInvalidTaskTimeRange
+
+		for _, taskID := range taskIDs {
+			plannedTask, err := u.planTaskForToday(ctx, model.Task{
+				ID:          taskID,
+				ThisEvening: evening[taskID],
+				UserID:      data.UserID,
+				UpdatedAt:   now,
+			})
+			if err != nil {
+				return err
+			}
+
+			plannedTasks = append(plannedTasks, plannedTask)
+		}
+		return nil
+	}); err != nil {
+		return model.TodayTasksResponseData{}, err
 	}
 
-	updatedTaskTime := model.Task{
-		ID:        data.ID,
-		StartTime: data.StartTime,
-		EndTime:   data.EndTime,
-		StatusID:  statusID,
-		UserID:    data.UserID,
-		UpdatedAt: time.Now(),
+	for _, task := range plannedTasks {
+		taskResp := mapTaskToResponseData(task)
+		publishTaskEvent(ctx, u.eventBroker, u.memberStorage, model.EventTaskUpdated, taskResp.ID, data.UserID, taskResp)
 	}
 
-	if err := u.taskStorage.UpdateTaskTime(ctx, updatedTaskTime); err != nil {
-		return model.TaskResponseTimeData{}, err
+	todayResp, err := u.GetTasksForToday(ctx, data.UserID, false)
+	if err != nil {
+		return model.TodayTasksResponseData{}, err
 	}
 
-	return model.TaskResponseTimeData{
-		ID:        updatedTaskTime.ID,
-		StartTime: updatedTaskTime.StartTime,
-		EndTime:   updatedTaskTime.EndTime,
-		UserID:    updatedTaskTime.UserID,
-		UpdatedAt: updatedTaskTime.UpdatedAt,
-	}, nil
+	todayResp.UndoToken = undoToken
+
+	return todayResp, nil
 }
 
-func (u *TaskUsecase) MoveTaskToAnotherList(ctx context.Context, data model.TaskRequestData) error {
-	defaultHeadingID, err := u.headingUsecase.GetDefaultHeadingID(ctx, model.HeadingRequestData{
-		ListID: data.ListID,
-		UserID: data.UserID,
-	})
-	if err != nil {
-		return err
+// PlanTaskForEvening starts the task today and moves it to the evening section, or back to the day if thisEvening isn't set
+func (u *TaskUsecase) PlanTaskForEvening(ctx context.Context, data model.TaskRequestData, thisEvening bool) (model.TaskResponseData, error) {
+	if err := authorizeTask(ctx, u.memberStorage, data.ID, data.UserID, model.ListRoleEditor); err != nil {
+		return model.TaskResponseData{}, err
 	}
 
-	data.HeadingID = defaultHeadingID
+	var (
+		plannedTask model.Task
+		undoToken   string
+	)
 
-	return u.taskStorage.MoveTaskToAnotherList(ctx, model.Task{
-		ID:        data.ID,
-		ListID:    data.ListID,
-		HeadingID: data.HeadingID,
-		UserID:    data.UserID,
-		UpdatedAt: time.Now(),
-	})
-}
+	if err := u.taskStorage.Transaction(ctx, func(ctx context.Context) error {
+		var err error
 
-func (u *TaskUsecase) CompleteTask(ctx context.Context, data model.TaskRequestData) error {
-	statusCompleted, err := u.taskStorage.GetTaskStatusID(ctx, model.StatusCompleted)
-	if err != nil {
+		undoToken, err = u.createTaskUndoToken(ctx, model.AuditActionUpdate, data.ID, data.UserID)
+		if err != nil {
+			return err
+		}
+
+		plannedTask, err = u.planTaskForToday(ctx, model.Task{
+			ID:          data.ID,
+			ThisEvening: thisEvening,
+			UserID:      data.UserID,
+			UpdatedAt:   time.Now(),
+		})
 		return err
+	}); err != nil {
+		return model.TaskResponseData{}, err
 	}
 
-	data.StatusID = statusCompleted
+	taskResp := mapTaskToResponseData(plannedTask)
 
-	return u.taskStorage.MarkAsCompleted(ctx, model.Task{
-		ID:        data.ID,
-		StatusID:  data.StatusID,
-		UserID:    data.UserID,
-		DeletedAt: time.Now(),
-	})
+	publishTaskEvent(ctx, u.eventBroker, u.memberStorage, model.EventTaskUpdated, taskResp.ID, data.UserID, taskResp)
+
+	taskResp.UndoToken = undoToken
+
+	return taskResp, nil
 }
 
-func (u *TaskUsecase) ArchiveTask(ctx context.Context, data model.TaskRequestData) error {
-	statusArchived, err := u.taskStorage.GetTaskStatusID(ctx, model.StatusArchived)
+// planTaskForToday starts the task today and adds the changed dates to its timeline and the change to the audit log.
+// It returns the planned task
+func (u *TaskUsecase) planTaskForToday(ctx context.Context, task model.Task) (model.Task, error) {
+	currentTask, err := u.taskStorage.GetTaskByID(ctx, task.ID, task.UserID)
 	if err != nil {
-		return err
+		return model.Task{}, err
 	}
 
-	data.StatusID = statusArchived
+	if err = u.taskStorage.PlanTaskForToday(ctx, task); err != nil {
+		return model.Task{}, err
+	}
 
-	return u.taskStorage.MarkAsArchived(ctx, model.Task{
-		ID:        data.ID,
-		StatusID:  data.StatusID,
-		UserID:    data.UserID,
-		UpdatedAt: time.Now(),
-	})
+	plannedTask, err := u.taskStorage.GetTaskByID(ctx, task.ID, task.UserID)
+	if err != nil {
+		return model.Task{}, err
+	}
+
+	if err = u.recordTaskChanges(ctx, currentTask, plannedTask, task.UserID); err != nil {
+		return model.Task{}, err
+	}
+
+	if err = recordAudit(
+		ctx, u.auditUsecase, model.AuditActionUpdate, model.AuditEntityTask, task.ID, task.UserID,
+		mapTaskToResponseData(currentTask), mapTaskToResponseData(plannedTask),
+	); err != nil {
+		return model.Task{}, err
+	}
+
+	return plannedTask, nil
+}
+
+func (u *TaskUsecase) GetUpcomingTasks(
+	ctx context.Context,
+	userID string,
+	pgn model.Pagination,
+	hideBlocked bool,
+) ([]model.TaskGroup, error) {
+	taskGroups, err := u.taskStorage.GetUpcomingTasks(ctx, userID, pgn)
+	if err != nil {
+		return nil, err
+	}
+
+	return u.markBlockedTaskGroups(ctx, userID, taskGroups, hideBlocked)
+}
+
+func (u *TaskUsecase) GetOverdueTasks(ctx context.Context, userID string, pgn model.Pagination) ([]model.TaskGroup, error) {
+	taskGroups, err := u.taskStorage.GetOverdueTasks(ctx, userID, pgn)
+	if err != nil {
+		return nil, err
+	}
+
+	return u.markBlockedTaskGroups(ctx, userID, taskGroups, false)
+}
+
+func (u *TaskUsecase) GetTasksForSomeday(ctx context.Context, userID string, pgn model.Pagination) ([]model.TaskGroup, error) {
+	taskGroups, err := u.taskStorage.GetTasksForSomeday(ctx, userID, pgn)
+	if err != nil {
+		return nil, err
+	}
+
+	return u.markBlockedTaskGroups(ctx, userID, taskGroups, false)
+}
+
+func (u *TaskUsecase) GetCompletedTasks(ctx context.Context, userID string, pgn model.Pagination) ([]model.TaskGroup, error) {
+	taskGroups, err := u.taskStorage.GetCompletedTasks(ctx, userID, pgn)
+	if err != nil {
+		return nil, err
+	}
+
+	return taskGroups, nil
+}
+
+func (u *TaskUsecase) GetArchivedTasks(ctx context.Context, userID string, pgn model.Pagination) ([]model.TaskGroup, error) {
+	taskGroups, err := u.taskStorage.GetArchivedTasks(ctx, userID, pgn)
+	if err != nil {
+		return nil, err
+	}
+
+	return taskGroups, nil
+}
+
+func (u *TaskUsecase) GetBlockedTasks(ctx context.Context, userID string, pgn model.Pagination) ([]model.TaskResponseData, error) {
+	tasks, err := u.taskStorage.GetBlockedTasks(ctx, userID, pgn)
+	if err != nil {
+		return nil, err
+	}
+
+	var tasksResp []model.TaskResponseData
+	for _, task := range tasks {
+		task.UserID = userID
+		task.Blocked = true
+		tasksResp = append(tasksResp, mapTaskToResponseData(task))
+	}
+
+	return tasksResp, nil
+}
+
+// markBlockedTasks sets the blocked flag of the tasks blocked by at least one task which isn't completed
+func (u *TaskUsecase) markBlockedTasks(ctx context.Context, userID string, tasks []model.TaskResponseData) error {
+	taskIDs := make([]string, 0, len(tasks))
+	for _, task := range tasks {
+		taskIDs = append(taskIDs, task.ID)
+	}
+
+	blocked, err := u.getBlockedTaskIDs(ctx, userID, taskIDs)
+	if err != nil {
+		return err
+	}
+
+	for i := range tasks {
+		tasks[i].Blocked = blocked[tasks[i].ID]
+	}
+
+	return nil
+}
+
+// markBlockedTaskGroups sets the blocked flag of the tasks in the groups. If hideBlocked is set,
+// the blocked tasks are left out along with the groups which have no other tasks
+func (u *TaskUsecase) markBlockedTaskGroups(
+	ctx context.Context,
+	userID string,
+	taskGroups []model.TaskGroup,
+	hideBlocked bool,
+) ([]model.TaskGroup, error) {
+	var taskIDs []string
+	for _, group := range taskGroups {
+		for _, task := range group.Tasks {
+			taskIDs = append(taskIDs, task.ID)
+		}
+	}
+
+	blocked, err := u.getBlockedTaskIDs(ctx, userID, taskIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	groups := make([]model.TaskGroup, 0, len(taskGroups))
+
+	for _, group := range taskGroups {
+		tasks := make([]model.TaskResponseData, 0, len(group.Tasks))
+
+		for _, task := range group.Tasks {
+			task.Blocked = blocked[task.ID]
+			if hideBlocked && task.Blocked {
+				continue
+			}
+
+			tasks = append(tasks, task)
+		}
+
+		if len(tasks) == 0 && len(group.Tasks) > 0 {
+			continue
+		}
+
+		group.Tasks = tasks
+		groups = append(groups, group)
+	}
+
+	if len(groups) == 0 {
+		return nil, le.ErrNoTasksFound
+	}
+
+	return groups, nil
+}
+
+func (u *TaskUsecase) getBlockedTaskIDs(ctx context.Context, userID string, taskIDs []string) (map[string]bool, error) {
+	blocked := make(map[string]bool)
+
+	if len(taskIDs) == 0 {
+		return blocked, nil
+	}
+
+	blockedIDs, err := u.taskStorage.GetBlockedTaskIDs(ctx, userID, taskIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, taskID := range blockedIDs {
+		blocked[taskID] = true
+	}
+
+	return blocked, nil
+}
+
+func (u *TaskUsecase) UpdateTask(ctx context.Context, data *model.TaskRequestData) (model.TaskResponseData, error) {
+	if err := authorizeTask(ctx, u.memberStorage, data.ID, data.UserID, model.ListRoleEditor); err != nil {
+		return model.TaskResponseData{}, err
+	}
+
+	if data.ListID != "" {
+		if err := authorizeList(ctx, u.memberStorage, data.ListID, data.UserID, model.ListRoleEditor); err != nil {
+			return model.TaskResponseData{}, err
+		}
+	}
+
+	updatedTask := model.Task{
+		ID:          data.ID,
+		Title:       data.Title,
+		Description: data.Description,
+		StartDate:   data.StartDate,
+		Deadline:    data.Deadline,
+		StartTime:   data.StartTime,
+		EndTime:     data.EndTime,
+		ListID:      data.ListID,
+		HeadingID:   data.HeadingID,
+		UserID:      data.UserID,
+		Tags:        data.Tags,
+		UpdatedAt:   time.Now(),
+	}
+
+	var undoToken string
+
+	if err := u.taskStorage.Transaction(ctx, func(ctx context.Context) error {
+		currentTask, err := u.taskStorage.GetTaskByID(ctx, updatedTask.ID, updatedTask.UserID)
+		if err != nil {
+			return err
+		}
+
+		undoToken, err = u.createTaskUndoToken(ctx, model.AuditActionUpdate, updatedTask.ID, updatedTask.UserID)
+		if err != nil {
+			return err
+		}
+
+		currentTags, err := u.tagUsecase.GetTagsByTaskID(ctx, updatedTask.ID)
+		if err != nil {
+			return err
+		}
+
+		tagsToAdd, tagsToRemove := findTagsToAddAndRemove(currentTags, updatedTask.Tags)
+
+		for _, tag := range updatedTask.Tags {
+			if err = u.tagUsecase.CreateTagIfNotExists(ctx, model.TagRequestData{
+				Title:  tag,
+				UserID: updatedTask.UserID,
+			}); err != nil {
+				return err
+			}
+		}
+
+		if err = u.taskStorage.UpdateTask(ctx, updatedTask); err != nil {
+			return err
+		}
+
+		// Only the dates set in the request are changed
+		changedTask := currentTask
+		if !updatedTask.StartDate.IsZero() {
+			changedTask.StartDate = updatedTask.StartDate
+		}
+		if !updatedTask.Deadline.IsZero() {
+			changedTask.Deadline = updatedTask.Deadline
+		}
+		if err = u.recordTaskChanges(ctx, currentTask, changedTask, updatedTask.UserID); err != nil {
+			return err
+		}
+		if updatedTask.ListID != "" {
+			if err = u.memberStorage.UnassignNonMemberTasks(ctx, updatedTask.ListID); err != nil {
+				return err
+			}
+		}
+		if err = u.tagUsecase.UnlinkTagsFromTask(ctx, updatedTask.ID, tagsToRemove); err != nil {
+			return err
+		}
+		if err = u.tagUsecase.LinkTagsToTask(ctx, updatedTask.ID, tagsToAdd); err != nil {
+			return err
+		}
+		if err = u.auditTask(ctx, model.AuditActionUpdate, currentTask, updatedTask.UserID); err != nil {
+			return err
+		}
+
+		rulesApplied, err := u.runRules(ctx, model.RuleTriggerTaskUpdated, updatedTask, tagsToAdd)
+		if err != nil {
+			return err
+		}
+		if rulesApplied {
+			updatedTask, err = u.getTaskChangedByRules(ctx, updatedTask)
+		}
+		return err
+	}); err != nil {
+		return model.TaskResponseData{}, err
+	}
+
+	taskResp := model.TaskResponseData{
+		ID:          updatedTask.ID,
+		Title:       updatedTask.Title,
+		Description: updatedTask.Description,
+		StartDate:   updatedTask.StartDate,
+		Deadline:    updatedTask.Deadline,
+		StartTime:   updatedTask.StartTime,
+		EndTime:     updatedTask.EndTime,
+		StatusID:    updatedTask.StatusID,
+		ListID:      updatedTask.ListID,
+		HeadingID:   updatedTask.HeadingID,
+		UserID:      updatedTask.UserID,
+		Tags:        updatedTask.Tags,
+		UpdatedAt:   updatedTask.UpdatedAt,
+	}
+
+	publishTaskEvent(ctx, u.eventBroker, u.memberStorage, model.EventTaskUpdated, taskResp.ID, taskResp.UserID, taskResp)
+
+	taskResp.UndoToken = undoToken
+
+	return taskResp, nil
+}
+
+// PatchTask applies a JSON merge patch to the task, changing only the fields present in the patch.
+// Fields set to null are cleared.
+func (u *TaskUsecase) PatchTask(ctx context.Context, data model.TaskRequestData, patch []byte) (model.TaskResponseData, error) {
+	if err := authorizeTask(ctx, u.memberStorage, data.ID, data.UserID, model.ListRoleEditor); err != nil {
+		return model.TaskResponseData{}, err
+	}
+
+	var (
+		patchedTask model.Task
+		undoToken   string
+	)
+
+	if err := u.taskStorage.Transaction(ctx, func(ctx context.Context) error {
+		currentTask, err := u.taskStorage.GetTaskByID(ctx, data.ID, data.UserID)
+		if err != nil {
+			return err
+		}
+
+		undoToken, err = u.createTaskUndoToken(ctx, model.AuditActionUpdate, data.ID, data.UserID)
+		if err != nil {
+			return err
+		}
+
+		current := model.TaskRequestData{
+			ID:          currentTask.ID,
+			Title:       currentTask.Title,
+			Description: currentTask.Description,
+			StartDate:   currentTask.StartDate,
+			Deadline:    currentTask.Deadline,
+			StartTime:   currentTask.StartTime,
+			EndTime:     currentTask.EndTime,
+			StatusID:    currentTask.StatusID,
+			ListID:      currentTask.ListID,
+			HeadingID:   currentTask.HeadingID,
+			UserID:      data.UserID,
+			Tags:        currentTask.Tags,
+		}
+
+		patched := model.TaskRequestData{}
+		if err = applyMergePatch(current, patch, &patched); err != nil {
+			return err
+		}
+
+		// The owner of the task can't be changed with a patch
+		patched.UserID = data.UserID
+
+		if patched.StartTime.IsZero() != patched.EndTime.IsZero() {
+			return le.ErrInvalidTaskTimeRange
+		}
+
+		if patched.ListID == "" {
+			patched.ListID = currentTask.ListID
+		}
+
+		if patched.ListID != currentTask.ListID {
+			if err = authorizeList(ctx, u.memberStorage, patched.ListID, data.UserID, model.ListRoleEditor); err != nil {
+				return err
+			}
+		}
+
+		headingID, err := u.resolvePatchedHeadingID(ctx, currentTask, patched)
+		if err != nil {
+			return err
+		}
+
+		patchedTask = model.Task{
+			ID:          currentTask.ID,
+			Title:       patched.Title,
+			Description: patched.Description,
+			StartDate:   patched.StartDate,
+			Deadline:    patched.Deadline,
+			StartTime:   patched.StartTime,
+			EndTime:     patched.EndTime,
+			StatusID:    currentTask.StatusID,
+			ListID:      patched.ListID,
+			HeadingID:   headingID,
+			UserID:      patched.UserID,
+			Tags:        patched.Tags,
+			UpdatedAt:   time.Now(),
+		}
+
+		tagsToAdd, tagsToRemove := findTagsToAddAndRemove(mapTagTitlesToResponseData(currentTask.Tags), patchedTask.Tags)
+
+		for _, tag := range tagsToAdd {
+			if err = u.tagUsecase.CreateTagIfNotExists(ctx, model.TagRequestData{
+				Title:  tag,
+				UserID: patchedTask.UserID,
+			}); err != nil {
+				return err
+			}
+		}
+
+		if err = u.taskStorage.PatchTask(ctx, patchedTask); err != nil {
+			return err
+		}
+		if err = u.recordTaskChanges(ctx, currentTask, patchedTask, patchedTask.UserID); err != nil {
+			return err
+		}
+		if patchedTask.ListID != currentTask.ListID {
+			if err = u.memberStorage.UnassignNonMemberTasks(ctx, patchedTask.ListID); err != nil {
+				return err
+			}
+		}
+		if err = u.tagUsecase.UnlinkTagsFromTask(ctx, patchedTask.ID, tagsToRemove); err != nil {
+			return err
+		}
+		if err = u.tagUsecase.LinkTagsToTask(ctx, patchedTask.ID, tagsToAdd); err != nil {
+			return err
+		}
+		if err = u.auditTask(ctx, model.AuditActionUpdate, currentTask, patchedTask.UserID); err != nil {
+			return err
+		}
+
+		rulesApplied, err := u.runRules(ctx, model.RuleTriggerTaskUpdated, patchedTask, tagsToAdd)
+		if err != nil {
+			return err
+		}
+		if rulesApplied {
+			patchedTask, err = u.getTaskChangedByRules(ctx, patchedTask)
+		}
+		return err
+	}); err != nil {
+		return model.TaskResponseData{}, err
+	}
+
+	taskResp := model.TaskResponseData{
+		ID:          patchedTask.ID,
+		Title:       patchedTask.Title,
+		Description: patchedTask.Description,
+		StartDate:   patchedTask.StartDate,
+		Deadline:    patchedTask.Deadline,
+		StartTime:   patchedTask.StartTime,
+		EndTime:     patchedTask.EndTime,
+		StatusID:    patchedTask.StatusID,
+		ListID:      patchedTask.ListID,
+		HeadingID:   patchedTask.HeadingID,
+		UserID:      patchedTask.UserID,
+		Tags:        patchedTask.Tags,
+		UpdatedAt:   patchedTask.UpdatedAt,
+	}
+
+	publishTaskEvent(ctx, u.eventBroker, u.memberStorage, model.EventTaskUpdated, taskResp.ID, taskResp.UserID, taskResp)
+
+	taskResp.UndoToken = undoToken
+
+	return taskResp, nil
+}
+
+// resolvePatchedHeadingID returns the heading for the patched task. If the task was moved
+// to another list without choosing a heading, the default heading of that list is used
+func (u *TaskUsecase) resolvePatchedHeadingID(ctx context.Context, current model.Task, patched model.TaskRequestData) (string, error) {
+	if patched.HeadingID == "" || (patched.ListID != current.ListID && patched.HeadingID == current.HeadingID) {
+		return u.headingUsecase.GetDefaultHeadingID(ctx, model.HeadingRequestData{
+			ListID: patched.ListID,
+			UserID: patched.UserID,
+		})
+	}
+
+	if patched.HeadingID == current.HeadingID {
+		return current.HeadingID, nil
+	}
+
+	heading, err := u.headingUsecase.GetHeadingByID(ctx, model.HeadingRequestData{
+		ID:     patched.HeadingID,
+		UserID: patched.UserID,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	if heading.ListID != patched.ListID {
+		return "", le.ErrHeadingNotFound
+	}
+
+	return heading.ID, nil
+}
+
+func mapTagTitlesToResponseData(titles []string) []model.TagResponseData {
+	tags := make([]model.TagResponseData, 0, len(titles))
+
+	for _, title := range titles {
+		tags = append(tags, model.TagResponseData{Title: title})
+	}
+
+	return tags
+}
+
+func findTagsToAddAndRemove(currentTags []model.TagResponseData, updatedTags []string) (tagsToAdd, tagsToRemove []string) {
+	tagMap := make(map[string]bool)
+
+	for _, tag := range currentTags {
+		tagMap[tag.Title] = true
+	}
+
+	for _, tag := range updatedTags {
+		if _, ok := tagMap[tag]; ok {
+			delete(tagMap, tag)
+		} else {
+			tagsToAdd = append(tagsToAdd, tag)
+		}
+	}
+
+	for tag := range tagMap {
+		tagsToRemove = append(tagsToRemove, tag)
+	}
+
+	return tagsToAdd, tagsToRemove
+}
+
+// runRules runs the rules of the user for the change of the task and for the tags added to it
+func (u *TaskUsecase) runRules(ctx context.Context, trigger model.RuleTrigger, task model.Task, addedTags []string) (bool, error) {
+	triggers := []model.RuleTriggerData{{
+		Trigger: trigger,
+		TaskID:  task.ID,
+		UserID:  task.UserID,
+	}}
+
+	if len(addedTags) > 0 {
+		triggers = append(triggers, model.RuleTriggerData{
+			Trigger:   model.RuleTriggerTagAdded,
+			TaskID:    task.ID,
+			UserID:    task.UserID,
+			AddedTags: addedTags,
+		})
+	}
+
+	return u.ruleUsecase.RunRules(ctx, triggers...)
+}
+
+// getTaskChangedByRules returns the current state of the task after the rules have changed it
+func (u *TaskUsecase) getTaskChangedByRules(ctx context.Context, task model.Task) (model.Task, error) {
+	changedTask, err := u.taskStorage.GetTaskByID(ctx, task.ID, task.UserID)
+	if err != nil {
+		return model.Task{}, err
+	}
+
+	changedTask.UserID = task.UserID
+
+	return changedTask, nil
+}
+
+func (u *TaskUsecase) UpdateTaskTime(ctx context.Context, data *model.TaskRequestTimeData) (model.TaskResponseTimeData, error) {
+	if err := authorizeTask(ctx, u.memberStorage, data.ID, data.UserID, model.ListRoleEditor); err != nil {
+		return model.TaskResponseTimeData{}, err
+	}
+
+	var (
+		statusID   int
+		statusName model.StatusName
+	)
+
+	switch {
+	case !data.StartTime.IsZero() && !data.EndTime.IsZero():
+		taskStatusID, err := u.taskStorage.GetTaskStatusID(ctx, model.StatusPlanned)
+		if err != nil {
+			return model.TaskResponseTimeData{}, err
+		}
+		statusID = taskStatusID
+		statusName = model.StatusPlanned
+	case data.StartTime.IsZero() && data.EndTime.IsZero():
+		taskStatusID, err := u.taskStorage.GetTaskStatusID(ctx, model.StatusNotStarted)
+		if err != nil {
+			return model.TaskResponseTimeData{}, err
+		}
+		statusID = taskStatusID
+		statusName = model.StatusNotStarted
+	default:
+		return model.TaskResponseTimeData{}, le.ErrInvalidTaskTimeRange
+	}
+
+	updatedTaskTime := model.Task{
+		ID:        data.ID,
+		StartTime: data.StartTime,
+		EndTime:   data.EndTime,
+		StatusID:  statusID,
+		UserID:    data.UserID,
+		UpdatedAt: time.Now(),
+	}
+
+	var undoToken string
+
+	if err := u.taskStorage.Transaction(ctx, func(ctx context.Context) error {
+		currentTask, err := u.taskStorage.GetTaskByID(ctx, updatedTaskTime.ID, updatedTaskTime.UserID)
+		if err != nil {
+			return err
+		}
+
+		undoToken, err = u.createTaskUndoToken(ctx, model.AuditActionUpdate, updatedTaskTime.ID, updatedTaskTime.UserID)
+		if err != nil {
+			return err
+		}
+
+		if err = u.taskStorage.UpdateTaskTime(ctx, updatedTaskTime); err != nil {
+			return err
+		}
+
+		changedTask := currentTask
+		changedTask.StartTime = updatedTaskTime.StartTime
+		changedTask.EndTime = updatedTaskTime.EndTime
+
+		if err = u.recordTaskChanges(ctx, currentTask, changedTask, updatedTaskTime.UserID); err != nil {
+			return err
+		}
+		if currentTask.StatusID != updatedTaskTime.StatusID {
+			if err = u.recordActivity(
+				ctx, updatedTaskTime.ID, updatedTaskTime.UserID, model.TaskActivityStatusChanged, "status", "", statusName.String(),
+			); err != nil {
+				return err
+			}
+		}
+		if err = u.auditTask(ctx, model.AuditActionUpdate, currentTask, updatedTaskTime.UserID); err != nil {
+			return err
+		}
+
+		_, err = u.runRules(ctx, model.RuleTriggerTaskUpdated, updatedTaskTime, nil)
+		return err
+	}); err != nil {
+		return model.TaskResponseTimeData{}, err
+	}
+
+	taskTimeResp := model.TaskResponseTimeData{
+		ID:        updatedTaskTime.ID,
+		StartTime: updatedTaskTime.StartTime,
+		EndTime:   updatedTaskTime.EndTime,
+		UserID:    updatedTaskTime.UserID,
+		UpdatedAt: updatedTaskTime.UpdatedAt,
+	}
+
+	publishTaskEvent(ctx, u.eventBroker, u.memberStorage, model.EventTaskUpdated, taskTimeResp.ID, taskTimeResp.UserID, taskTimeResp)
+
+	taskTimeResp.UndoToken = undoToken
+
+	return taskTimeResp, nil
+}
+
+// MoveTaskToAnotherList moves the task to the default heading of the list, the user must be able to edit both lists
+func (u *TaskUsecase) MoveTaskToAnotherList(ctx context.Context, data model.TaskRequestData) (string, error) {
+	if err := authorizeTask(ctx, u.memberStorage, data.ID, data.UserID, model.ListRoleEditor); err != nil {
+		return "", err
+	}
+
+	if err := authorizeList(ctx, u.memberStorage, data.ListID, data.UserID, model.ListRoleEditor); err != nil {
+		return "", err
+	}
+
+	defaultHeadingID, err := u.headingUsecase.GetDefaultHeadingID(ctx, model.HeadingRequestData{
+		ListID: data.ListID,
+		UserID: data.UserID,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	data.HeadingID = defaultHeadingID
+
+	movedTask := model.Task{
+		ID:        data.ID,
+		ListID:    data.ListID,
+		HeadingID: data.HeadingID,
+		UserID:    data.UserID,
+		UpdatedAt: time.Now(),
+	}
+
+	var undoToken string
+
+	if err = u.taskStorage.Transaction(ctx, func(ctx context.Context) error {
+		currentTask, err := u.taskStorage.GetTaskByID(ctx, movedTask.ID, movedTask.UserID)
+		if err != nil {
+			return err
+		}
+
+		undoToken, err = u.createTaskUndoToken(ctx, model.AuditActionMove, movedTask.ID, movedTask.UserID)
+		if err != nil {
+			return err
+		}
+
+		if err = u.taskStorage.MoveTaskToAnotherList(ctx, movedTask); err != nil {
+			return err
+		}
+		if err = u.memberStorage.UnassignNonMemberTasks(ctx, movedTask.ListID); err != nil {
+			return err
+		}
+		if currentTask.ListID != movedTask.ListID {
+			if err = u.recordActivity(
+				ctx, movedTask.ID, movedTask.UserID, model.TaskActivityMoved, "list_id", currentTask.ListID, movedTask.ListID,
+			); err != nil {
+				return err
+			}
+		}
+		if err = u.auditTask(ctx, model.AuditActionMove, currentTask, movedTask.UserID); err != nil {
+			return err
+		}
+
+		_, err = u.runRules(ctx, model.RuleTriggerTaskUpdated, movedTask, nil)
+		return err
+	}); err != nil {
+		return "", err
+	}
+
+	publishTaskEvent(ctx, u.eventBroker, u.memberStorage, model.EventTaskMoved, movedTask.ID, movedTask.UserID, model.TaskResponseData{
+		ID:        movedTask.ID,
+		ListID:    movedTask.ListID,
+		HeadingID: movedTask.HeadingID,
+		UserID:    movedTask.UserID,
+		UpdatedAt: movedTask.UpdatedAt,
+	})
+
+	return undoToken, nil
+}
+
+func (u *TaskUsecase) CompleteTask(ctx context.Context, data model.TaskRequestData) (string, error) {
+	if err := authorizeTask(ctx, u.memberStorage, data.ID, data.UserID, model.ListRoleEditor); err != nil {
+		return "", err
+	}
+
+	statusCompleted, err := u.taskStorage.GetTaskStatusID(ctx, model.StatusCompleted)
+	if err != nil {
+		return "", err
+	}
+
+	data.StatusID = statusCompleted
+
+	completedTask := model.Task{
+		ID:        data.ID,
+		StatusID:  data.StatusID,
+		UserID:    data.UserID,
+		UpdatedAt: time.Now(),
+	}
+
+	var (
+		unblockedTaskIDs []string
+		undoToken        string
+	)
+
+	if err = u.taskStorage.Transaction(ctx, func(ctx context.Context) error {
+		dependentTaskIDs, err := u.taskStorage.GetDependentTaskIDs(ctx, completedTask.ID, completedTask.UserID)
+		if err != nil {
+			return err
+		}
+
+		blockedBefore, err := u.getBlockedTaskIDs(ctx, completedTask.UserID, dependentTaskIDs)
+		if err != nil {
+			return err
+		}
+
+		currentTask, err := u.taskStorage.GetTaskByID(ctx, completedTask.ID, completedTask.UserID)
+		if err != nil {
+			return err
+		}
+
+		undoToken, err = u.createTaskUndoToken(ctx, model.AuditActionComplete, completedTask.ID, completedTask.UserID)
+		if err != nil {
+			return err
+		}
+
+		if err = u.taskStorage.MarkAsCompleted(ctx, completedTask); err != nil {
+			return err
+		}
+		if err = u.recordActivity(
+			ctx, completedTask.ID, completedTask.UserID, model.TaskActivityStatusChanged, "status", "", model.StatusCompleted.String(),
+		); err != nil {
+			return err
+		}
+		if err = u.auditTask(ctx, model.AuditActionComplete, currentTask, completedTask.UserID); err != nil {
+			return err
+		}
+
+		if _, err = u.runRules(ctx, model.RuleTriggerTaskCompleted, completedTask, nil); err != nil {
+			return err
+		}
+
+		blockedAfter, err := u.getBlockedTaskIDs(ctx, completedTask.UserID, dependentTaskIDs)
+		if err != nil {
+			return err
+		}
+
+		for _, taskID := range dependentTaskIDs {
+			if blockedBefore[taskID] && !blockedAfter[taskID] {
+				unblockedTaskIDs = append(unblockedTaskIDs, taskID)
+			}
+		}
+		return nil
+	}); err != nil {
+		return "", err
+	}
+
+	publishTaskEvent(ctx, u.eventBroker, u.memberStorage, model.EventTaskCompleted, data.ID, data.UserID, nil)
+
+	for _, taskID := range unblockedTaskIDs {
+		publishTaskEvent(ctx, u.eventBroker, u.memberStorage, model.EventTaskUnblocked, taskID, data.UserID, model.TaskUnblockedData{
+			TaskID:            taskID,
+			UnblockedByTaskID: data.ID,
+		})
+	}
+
+	return undoToken, nil
+}
+
+func (u *TaskUsecase) ArchiveTask(ctx context.Context, data model.TaskRequestData) (string, error) {
+	if err := authorizeTask(ctx, u.memberStorage, data.ID, data.UserID, model.ListRoleEditor); err != nil {
+		return "", err
+	}
+
+	statusArchived, err := u.taskStorage.GetTaskStatusID(ctx, model.StatusArchived)
+	if err != nil {
+		return "", err
+	}
+
+	data.StatusID = statusArchived
+
+	var undoToken string
+
+	if err = u.taskStorage.Transaction(ctx, func(ctx context.Context) error {
+		currentTask, err := u.taskStorage.GetTaskByID(ctx, data.ID, data.UserID)
+		if err != nil {
+			return err
+		}
+
+		undoToken, err = u.createTaskUndoToken(ctx, model.AuditActionArchive, data.ID, data.UserID)
+		if err != nil {
+			return err
+		}
+
+		archivedTask := currentTask
+		archivedTask.StatusID = data.StatusID
+		archivedTask.UpdatedAt = time.Now()
+
+		if err = u.taskStorage.MarkAsArchived(ctx, model.Task{
+			ID:        data.ID,
+			StatusID:  data.StatusID,
+			UserID:    data.UserID,
+			UpdatedAt: archivedTask.UpdatedAt,
+		}); err != nil {
+			return err
+		}
+		if err = u.recordActivity(
+			ctx, data.ID, data.UserID, model.TaskActivityStatusChanged, "status", "", model.StatusArchived.String(),
+		); err != nil {
+			return err
+		}
+
+		// The archived task can't be read back, so its new state is known only here
+		return recordAudit(
+			ctx, u.auditUsecase, model.AuditActionArchive, model.AuditEntityTask, data.ID, data.UserID,
+			mapTaskToResponseData(currentTask), mapTaskToResponseData(archivedTask),
+		)
+	}); err != nil {
+		return "", err
+	}
+
+	publishTaskEvent(ctx, u.eventBroker, u.memberStorage, model.EventTaskArchived, data.ID, data.UserID, nil)
+
+	return undoToken, nil
+}
+
+// AssignTask assigns the task to a member of its list and notifies the assignee
+func (u *TaskUsecase) AssignTask(ctx context.Context, data model.TaskAssigneeRequestData) (model.TaskResponseData, error) {
+	if err := authorizeTask(ctx, u.memberStorage, data.TaskID, data.UserID, model.ListRoleEditor); err != nil {
+		return model.TaskResponseData{}, err
+	}
+
+	task, err := u.taskStorage.GetTaskByID(ctx, data.TaskID, data.UserID)
+	if err != nil {
+		return model.TaskResponseData{}, err
+	}
+
+	_, err = u.memberStorage.GetListMemberRole(ctx, task.ListID, data.AssigneeID)
+	if errors.Is(err, le.ErrListMemberNotFound) {
+		return model.TaskResponseData{}, le.ErrAssigneeNotListMember
+	}
+	if err != nil {
+		return model.TaskResponseData{}, err
+	}
+
+	currentTask := task
+
+	task.AssigneeID = data.AssigneeID
+	task.UserID = data.UserID
+	task.UpdatedAt = time.Now()
+
+	undoToken, err := u.assignTask(ctx, currentTask, task)
+	if err != nil {
+		return model.TaskResponseData{}, err
+	}
+
+	taskResp := mapTaskToResponseData(task)
+
+	publishTaskEvent(ctx, u.eventBroker, u.memberStorage, model.EventTaskUpdated, taskResp.ID, data.UserID, taskResp)
+
+	if data.AssigneeID != data.UserID {
+		publishEvent(ctx, u.eventBroker, model.EventTaskAssigned, data.AssigneeID, taskResp.ID, model.TaskAssignedData{
+			Task:       taskResp,
+			AssignedBy: data.UserID,
+		})
+	}
+
+	taskResp.UndoToken = undoToken
+
+	return taskResp, nil
+}
+
+func (u *TaskUsecase) UnassignTask(ctx context.Context, data model.TaskRequestData) (model.TaskResponseData, error) {
+	if err := authorizeTask(ctx, u.memberStorage, data.ID, data.UserID, model.ListRoleEditor); err != nil {
+		return model.TaskResponseData{}, err
+	}
+
+	task, err := u.taskStorage.GetTaskByID(ctx, data.ID, data.UserID)
+	if err != nil {
+		return model.TaskResponseData{}, err
+	}
+
+	currentTask := task
+
+	task.AssigneeID = ""
+	task.UserID = data.UserID
+	task.UpdatedAt = time.Now()
+
+	undoToken, err := u.assignTask(ctx, currentTask, task)
+	if err != nil {
+		return model.TaskResponseData{}, err
+	}
+
+	taskResp := mapTaskToResponseData(task)
+
+	publishTaskEvent(ctx, u.eventBroker, u.memberStorage, model.EventTaskUpdated, taskResp.ID, data.UserID, taskResp)
+
+	taskResp.UndoToken = undoToken
+
+	return taskResp, nil
+}
+
+// SnoozeTask hides the task from Today, Someday and the list views until the given time.
+// The start date of the task is kept, the task reappears by itself when the time comes
+func (u *TaskUsecase) SnoozeTask(ctx context.Context, data model.TaskSnoozeRequestData) (model.TaskResponseData, error) {
+	now := time.Now()
+
+	if !data.DeferredUntil.After(now) {
+		return model.TaskResponseData{}, le.ErrInvalidSnoozeTime
+	}
+
+	if err := authorizeTask(ctx, u.memberStorage, data.TaskID, data.UserID, model.ListRoleEditor); err != nil {
+		return model.TaskResponseData{}, err
+	}
+
+	snoozedTask := model.Task{
+		ID:            data.TaskID,
+		DeferredUntil: data.DeferredUntil,
+		UserID:        data.UserID,
+		UpdatedAt:     now,
+	}
+
+	return u.changeTaskSnooze(ctx, snoozedTask, u.taskStorage.SnoozeTask)
+}
+
+// UnsnoozeTask brings the snoozed task back to the views before its time comes
+func (u *TaskUsecase) UnsnoozeTask(ctx context.Context, data model.TaskRequestData) (model.TaskResponseData, error) {
+	if err := authorizeTask(ctx, u.memberStorage, data.ID, data.UserID, model.ListRoleEditor); err != nil {
+		return model.TaskResponseData{}, err
+	}
+
+	unsnoozedTask := model.Task{
+		ID:        data.ID,
+		UserID:    data.UserID,
+		UpdatedAt: time.Now(),
+	}
+
+	return u.changeTaskSnooze(ctx, unsnoozedTask, u.taskStorage.UnsnoozeTask)
+}
+
+// changeTaskSnooze stores the snooze of the task with the given function and adds the change to its timeline and to the audit log
+func (u *TaskUsecase) changeTaskSnooze(
+	ctx context.Context, task model.Task, store func(ctx context.Context, task model.Task) error,
+) (model.TaskResponseData, error) {
+	var (
+		updatedTask model.Task
+		undoToken   string
+	)
+
+	if err := u.taskStorage.Transaction(ctx, func(ctx context.Context) error {
+		currentTask, err := u.taskStorage.GetTaskByID(ctx, task.ID, task.UserID)
+		if err != nil {
+			return err
+		}
+
+		undoToken, err = u.createTaskUndoToken(ctx, model.AuditActionUpdate, task.ID, task.UserID)
+		if err != nil {
+			return err
+		}
+
+		if err = store(ctx, task); err != nil {
+			return err
+		}
+
+		if err = u.recordActivity(
+			ctx, task.ID, task.UserID, model.TaskActivitySnoozed, "deferred_until",
+			formatActivityTime(currentTask.DeferredUntil), formatActivityTime(task.DeferredUntil),
+		); err != nil {
+			return err
+		}
+
+		if err = u.auditTask(ctx, model.AuditActionUpdate, currentTask, task.UserID); err != nil {
+			return err
+		}
+
+		updatedTask, err = u.taskStorage.GetTaskByID(ctx, task.ID, task.UserID)
+		return err
+	}); err != nil {
+		return model.TaskResponseData{}, err
+	}
+
+	taskResp := mapTaskToResponseData(updatedTask)
+
+	publishTaskEvent(ctx, u.eventBroker, u.memberStorage, model.EventTaskUpdated, taskResp.ID, task.UserID, taskResp)
+
+	taskResp.UndoToken = undoToken
+
+	return taskResp, nil
+}
+
+// assignTask stores the assignee of the task and adds the change to its timeline and to the audit log.
+// It returns the token to revert the change
+func (u *TaskUsecase) assignTask(ctx context.Context, current, task model.Task) (string, error) {
+	var undoToken string
+
+	if err := u.taskStorage.Transaction(ctx, func(ctx context.Context) error {
+		var err error
+
+		undoToken, err = u.createTaskUndoToken(ctx, model.AuditActionUpdate, task.ID, task.UserID)
+		if err != nil {
+			return err
+		}
+
+		if err = u.taskStorage.AssignTask(ctx, task); err != nil {
+			return err
+		}
+
+		if task.AssigneeID == current.AssigneeID {
+			return nil
+		}
+
+		if err = u.recordActivity(
+			ctx, task.ID, task.UserID, model.TaskActivityAssigned, "assignee_id", current.AssigneeID, task.AssigneeID,
+		); err != nil {
+			return err
+		}
+
+		return u.auditTask(ctx, model.AuditActionUpdate, current, task.UserID)
+	}); err != nil {
+		return "", err
+	}
+
+	return undoToken, nil
+}
+
+// createTaskUndoToken saves the state of the task before the operation, so the operation can be reverted
+func (u *TaskUsecase) createTaskUndoToken(ctx context.Context, action model.AuditAction, taskID, userID string) (string, error) {
+	return u.undoUsecase.CreateUndoToken(ctx, model.UndoRecordData{
+		EntityType: model.AuditEntityTask,
+		EntityID:   taskID,
+		Action:     action,
+		UserID:     userID,
+		TaskIDs:    []string{taskID},
+	})
+}
+
+// auditTask records the change of the task made by the user, comparing the task before the change with the stored one
+func (u *TaskUsecase) auditTask(ctx context.Context, action model.AuditAction, before model.Task, userID string) error {
+	after, err := u.taskStorage.GetTaskByID(ctx, before.ID, userID)
+	if err != nil {
+		return err
+	}
+
+	return recordAudit(
+		ctx, u.auditUsecase, action, model.AuditEntityTask, before.ID, userID, mapTaskToResponseData(before), mapTaskToResponseData(after),
+	)
+}
+
+// recordActivity adds the change of the task made by the user to its timeline
+func (u *TaskUsecase) recordActivity(
+	ctx context.Context,
+	taskID, userID string,
+	kind model.TaskActivityKind,
+	field, oldValue, newValue string,
+) error {
+	return u.commentUsecase.RecordTaskActivity(ctx, model.TaskActivity{
+		TaskID:   taskID,
+		Kind:     kind,
+		Field:    field,
+		OldValue: oldValue,
+		NewValue: newValue,
+		UserID:   userID,
+	})
+}
+
+// recordTaskChanges adds the move and the changed dates of the task to its timeline
+func (u *TaskUsecase) recordTaskChanges(ctx context.Context, before, after model.Task, userID string) error {
+	if after.ListID != "" && after.ListID != before.ListID {
+		if err := u.recordActivity(ctx, after.ID, userID, model.TaskActivityMoved, "list_id", before.ListID, after.ListID); err != nil {
+			return err
+		}
+	}
+
+	dates := []struct {
+		field    string
+		oldValue time.Time
+		newValue time.Time
+	}{
+		{"start_date", before.StartDate, after.StartDate},
+		{"deadline", before.Deadline, after.Deadline},
+		{"start_time", before.StartTime, after.StartTime},
+		{"end_time", before.EndTime, after.EndTime},
+	}
+
+	for _, date := range dates {
+		if date.oldValue.Equal(date.newValue) {
+			continue
+		}
+
+		if err := u.recordActivity(
+			ctx, after.ID, userID, model.TaskActivityDateChanged, date.field, formatActivityTime(date.oldValue), formatActivityTime(date.newValue),
+		); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// formatActivityTime returns the time in RFC 3339, a cleared date is stored as an empty value
+func formatActivityTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.Format(time.RFC3339)
+}
+
+// AddTaskDependency makes the task blocked by another task of the user.
+// The dependency is rejected if the other task is already blocked by the task, directly or through other tasks
+func (u *TaskUsecase) AddTaskDependency(
+	ctx context.Context,
+	data model.TaskDependencyRequestData,
+) (model.TaskDependencyResponseData, error) {
+	if data.TaskID == data.BlockedByTaskID {
+		return model.TaskDependencyResponseData{}, le.ErrTaskCannotBlockItself
+	}
+
+	if err := authorizeTask(ctx, u.memberStorage, data.TaskID, data.UserID, model.ListRoleEditor); err != nil {
+		return model.TaskDependencyResponseData{}, err
+	}
+
+	dependency := model.TaskDependency{
+		TaskID:          data.TaskID,
+		BlockedByTaskID: data.BlockedByTaskID,
+		UserID:          data.UserID,
+		CreatedAt:       time.Now(),
+	}
+
+	if err := u.taskStorage.Transaction(ctx, func(ctx context.Context) error {
+		if err := u.taskStorage.LockTaskDependencies(ctx); err != nil {
+			return err
+		}
+
+		for _, taskID := range []string{dependency.TaskID, dependency.BlockedByTaskID} {
+			if _, err := u.taskStorage.GetTaskByID(ctx, taskID, dependency.UserID); err != nil {
+				return err
+			}
+		}
+
+		hasCycle, err := u.taskStorage.HasTaskDependencyPath(ctx, dependency.BlockedByTaskID, dependency.TaskID)
+		if err != nil {
+			return err
+		}
+		if hasCycle {
+			return le.ErrTaskDependencyCycle
+		}
+
+		return u.taskStorage.CreateTaskDependency(ctx, dependency)
+	}); err != nil {
+		return model.TaskDependencyResponseData{}, err
+	}
+
+	return model.TaskDependencyResponseData{
+		TaskID:          dependency.TaskID,
+		BlockedByTaskID: dependency.BlockedByTaskID,
+		UserID:          dependency.UserID,
+		CreatedAt:       dependency.CreatedAt,
+	}, nil
+}
+
+// GetTaskBlockers returns the tasks the task is blocked by, including the completed ones
+func (u *TaskUsecase) GetTaskBlockers(ctx context.Context, data model.TaskRequestData) ([]model.TaskResponseData, error) {
+	if _, err := u.taskStorage.GetTaskByID(ctx, data.ID, data.UserID); err != nil {
+		return nil, err
+	}
+
+	blockers, err := u.taskStorage.GetTaskBlockers(ctx, data.ID, data.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	var blockersResp []model.TaskResponseData
+	for _, blocker := range blockers {
+		blockersResp = append(blockersResp, mapTaskToResponseData(blocker))
+	}
+
+	if err = u.markBlockedTasks(ctx, data.UserID, blockersResp); err != nil {
+		return nil, err
+	}
+
+	return blockersResp, nil
+}
+
+func (u *TaskUsecase) RemoveTaskDependency(ctx context.Context, data model.TaskDependencyRequestData) error {
+	if err := authorizeTask(ctx, u.memberStorage, data.TaskID, data.UserID, model.ListRoleEditor); err != nil {
+		return err
+	}
+
+	return u.taskStorage.DeleteTaskDependency(ctx, model.TaskDependency{
+		TaskID:          data.TaskID,
+		BlockedByTaskID: data.BlockedByTaskID,
+		UserID:          data.UserID,
+	})
+}
+
+// DuplicateTask copies the task with its tags to the same heading
+func (u *TaskUsecase) DuplicateTask(
+	ctx context.Context,
+	data model.TaskRequestData,
+	opts model.DuplicateOptions,
+) (model.TaskResponseData, error) {
+	if err := authorizeTask(ctx, u.memberStorage, data.ID, data.UserID, model.ListRoleEditor); err != nil {
+		return model.TaskResponseData{}, err
+	}
+
+	task, err := u.taskStorage.GetTaskByID(ctx, data.ID, data.UserID)
+	if err != nil {
+		return model.TaskResponseData{}, err
+	}
+
+	var taskResp model.TaskResponseData
+
+	if err = u.taskStorage.Transaction(ctx, func(ctx context.Context) error {
+		taskResp, err = copyTask(
+			ctx, u.taskStorage, u.tagUsecase, u.auditUsecase, task, task.ListID, task.HeadingID, data.UserID, opts,
+		)
+		return err
+	}); err != nil {
+		return model.TaskResponseData{}, err
+	}
+
+	publishTaskEvent(ctx, u.eventBroker, u.memberStorage, model.EventTaskCreated, taskResp.ID, taskResp.UserID, taskResp)
+
+	return taskResp, nil
 }
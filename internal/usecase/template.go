@@ -0,0 +1,391 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/segmentio/ksuid"
+
+	"github.com/rshelekhov/reframed/internal/lib/constants/le"
+	"github.com/rshelekhov/reframed/internal/model"
+	"github.com/rshelekhov/reframed/internal/port"
+)
+
+type TemplateUsecase struct {
+	templateStorage port.TemplateStorage
+	listUsecase     port.ListUsecase
+	headingUsecase  port.HeadingUsecase
+	taskUsecase     port.TaskUsecase
+}
+
+func NewTemplateUsecase(
+	storage port.TemplateStorage,
+	listUsecase port.ListUsecase,
+	headingUsecase port.HeadingUsecase,
+	taskUsecase port.TaskUsecase,
+) *TemplateUsecase {
+	return &TemplateUsecase{
+		templateStorage: storage,
+		listUsecase:     listUsecase,
+		headingUsecase:  headingUsecase,
+		taskUsecase:     taskUsecase,
+	}
+}
+
+// CreateTemplate saves the list with its headings and tasks, or the single task, as the template
+func (u *TemplateUsecase) CreateTemplate(ctx context.Context, data *model.TemplateRequestData) (model.TemplateResponseData, error) {
+	if (data.ListID == "") == (data.TaskID == "") {
+		return model.TemplateResponseData{}, le.ErrInvalidTemplateSource
+	}
+
+	newTemplate := model.Template{
+		ID:        ksuid.New().String(),
+		Title:     data.Title,
+		UserID:    data.UserID,
+		UpdatedAt: time.Now(),
+	}
+
+	var err error
+
+	if data.ListID != "" {
+		newTemplate.Kind = model.TemplateKindList
+		newTemplate.Content, err = u.getListTemplateContent(ctx, *data)
+	} else {
+		newTemplate.Kind = model.TemplateKindTask
+		newTemplate.Content, err = u.getTaskTemplateContent(ctx, *data)
+	}
+	if err != nil {
+		return model.TemplateResponseData{}, err
+	}
+
+	if err = u.templateStorage.CreateTemplate(ctx, newTemplate); err != nil {
+		return model.TemplateResponseData{}, err
+	}
+
+	return mapTemplateToResponseData(newTemplate), nil
+}
+
+func (u *TemplateUsecase) getListTemplateContent(ctx context.Context, data model.TemplateRequestData) (model.TemplateContent, error) {
+	list, err := u.listUsecase.GetListByID(ctx, model.ListRequestData{
+		ID:     data.ListID,
+		UserID: data.UserID,
+	})
+	if err != nil {
+		return model.TemplateContent{}, err
+	}
+
+	headingData := model.HeadingRequestData{
+		ListID: list.ID,
+		UserID: data.UserID,
+	}
+
+	defaultHeadingID, err := u.headingUsecase.GetDefaultHeadingID(ctx, headingData)
+	if err != nil {
+		return model.TemplateContent{}, err
+	}
+
+	headings, err := u.headingUsecase.GetHeadingsByListID(ctx, headingData)
+	if err != nil && !errors.Is(err, le.ErrNoHeadingsFound) {
+		return model.TemplateContent{}, err
+	}
+
+	// The snoozed tasks are a part of the list too, the template keeps them
+	tasks, err := u.taskUsecase.GetAllTasksByListID(ctx, model.TaskRequestData{
+		ListID: list.ID,
+		UserID: data.UserID,
+	})
+	if err != nil && !errors.Is(err, le.ErrNoTasksFound) {
+		return model.TemplateContent{}, err
+	}
+
+	anchor := templateAnchorDate(data.AnchorDate, tasks)
+
+	tasksByHeading := make(map[string][]model.TemplateTask)
+	for _, task := range tasks {
+		tasksByHeading[task.HeadingID] = append(tasksByHeading[task.HeadingID], mapTaskToTemplateTask(task, anchor))
+	}
+
+	content := model.TemplateContent{
+		ListTitle: list.Title,
+		Headings:  make([]model.TemplateHeading, 0, len(headings)),
+	}
+
+	for _, heading := range headings {
+		templateTasks := tasksByHeading[heading.ID]
+		if templateTasks == nil {
+			templateTasks = []model.TemplateTask{}
+		}
+
+		content.Headings = append(content.Headings, model.TemplateHeading{
+			Title:     heading.Title,
+			IsDefault: heading.ID == defaultHeadingID,
+			Tasks:     templateTasks,
+		})
+	}
+
+	return content, nil
+}
+
+func (u *TemplateUsecase) getTaskTemplateContent(ctx context.Context, data model.TemplateRequestData) (model.TemplateContent, error) {
+	task, err := u.taskUsecase.GetTaskByID(ctx, model.TaskRequestData{
+		ID:     data.TaskID,
+		UserID: data.UserID,
+	})
+	if err != nil {
+		return model.TemplateContent{}, err
+	}
+
+	anchor := templateAnchorDate(data.AnchorDate, []model.TaskResponseData{task})
+
+	return model.TemplateContent{
+		Headings: []model.TemplateHeading{{
+			IsDefault: true,
+			Tasks:     []model.TemplateTask{mapTaskToTemplateTask(task, anchor)},
+		}},
+	}, nil
+}
+
+// templateAnchorDate returns the date the dates of the template are counted from.
+// If it isn't set, the earliest date of the tasks is used, so the template starts at the anchor date
+// it is instantiated with
+func templateAnchorDate(anchor time.Time, tasks []model.TaskResponseData) time.Time {
+	if !anchor.IsZero() {
+		return anchor
+	}
+
+	for _, task := range tasks {
+		for _, date := range []time.Time{task.StartDate, task.Deadline} {
+			if !date.IsZero() && (anchor.IsZero() || date.Before(anchor)) {
+				anchor = date
+			}
+		}
+	}
+
+	if anchor.IsZero() {
+		return time.Now()
+	}
+
+	return anchor
+}
+
+func mapTaskToTemplateTask(task model.TaskResponseData, anchor time.Time) model.TemplateTask {
+	return model.TemplateTask{
+		Title:           task.Title,
+		Description:     task.Description,
+		StartDateOffset: daysFromAnchor(anchor, task.StartDate),
+		DeadlineOffset:  daysFromAnchor(anchor, task.Deadline),
+		StartTime:       task.StartTime,
+		EndTime:         task.EndTime,
+		Tags:            task.Tags,
+	}
+}
+
+// daysFromAnchor returns the number of calendar days from the anchor to the date, or nil if the date isn't set
+func daysFromAnchor(anchor, date time.Time) *int {
+	if date.IsZero() {
+		return nil
+	}
+
+	days := int(truncateToDay(date).Sub(truncateToDay(anchor)).Hours() / 24)
+
+	return &days
+}
+
+func shiftFromAnchor(anchor time.Time, days *int) time.Time {
+	if days == nil {
+		return time.Time{}
+	}
+
+	return truncateToDay(anchor).AddDate(0, 0, *days)
+}
+
+func truncateToDay(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+}
+
+func (u *TemplateUsecase) GetTemplateByID(ctx context.Context, data model.TemplateRequestData) (model.TemplateResponseData, error) {
+	template, err := u.templateStorage.GetTemplateByID(ctx, data.ID, data.UserID)
+	if err != nil {
+		return model.TemplateResponseData{}, err
+	}
+
+	return mapTemplateToResponseData(template), nil
+}
+
+func (u *TemplateUsecase) GetTemplatesByUserID(ctx context.Context, userID string) ([]model.TemplateResponseData, error) {
+	templates, err := u.templateStorage.GetTemplatesByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	var templatesResp []model.TemplateResponseData
+
+	for _, template := range templates {
+		templatesResp = append(templatesResp, mapTemplateToResponseData(template))
+	}
+
+	return templatesResp, nil
+}
+
+func mapTemplateToResponseData(template model.Template) model.TemplateResponseData {
+	return model.TemplateResponseData{
+		ID:        template.ID,
+		Title:     template.Title,
+		Kind:      template.Kind,
+		ListTitle: template.Content.ListTitle,
+		Headings:  template.Content.Headings,
+		UserID:    template.UserID,
+		UpdatedAt: template.UpdatedAt,
+	}
+}
+
+func (u *TemplateUsecase) DeleteTemplate(ctx context.Context, data model.TemplateRequestData) error {
+	return u.templateStorage.DeleteTemplate(ctx, model.Template{
+		ID:        data.ID,
+		UserID:    data.UserID,
+		DeletedAt: time.Now(),
+	})
+}
+
+// InstantiateTemplate creates the list, the headings and the tasks of the template in one transaction,
+// so either all of them are created or none. The usecases called here join the transaction,
+// and their events are only published once it's committed
+func (u *TemplateUsecase) InstantiateTemplate(
+	ctx context.Context,
+	data *model.TemplateInstanceRequestData,
+) (model.TemplateInstanceResponseData, error) {
+	template, err := u.templateStorage.GetTemplateByID(ctx, data.ID, data.UserID)
+	if err != nil {
+		return model.TemplateInstanceResponseData{}, err
+	}
+
+	var instanceResp model.TemplateInstanceResponseData
+
+	if err = u.templateStorage.Transaction(ctx, func(ctx context.Context) error {
+		if template.Kind == model.TemplateKindTask {
+			instanceResp, err = u.instantiateTasks(ctx, template, *data)
+		} else {
+			instanceResp, err = u.instantiateList(ctx, template, *data)
+		}
+		return err
+	}); err != nil {
+		return model.TemplateInstanceResponseData{}, err
+	}
+
+	return instanceResp, nil
+}
+
+func (u *TemplateUsecase) instantiateList(
+	ctx context.Context,
+	template model.Template,
+	data model.TemplateInstanceRequestData,
+) (model.TemplateInstanceResponseData, error) {
+	title := data.Title
+	if title == "" {
+		title = template.Content.ListTitle
+	}
+	if title == "" {
+		title = template.Title
+	}
+
+	list, err := u.listUsecase.CreateList(ctx, &model.ListRequestData{
+		Title:  title,
+		UserID: data.UserID,
+	})
+	if err != nil {
+		return model.TemplateInstanceResponseData{}, err
+	}
+
+	defaultHeadingID, err := u.headingUsecase.GetDefaultHeadingID(ctx, model.HeadingRequestData{
+		ListID: list.ID,
+		UserID: data.UserID,
+	})
+	if err != nil {
+		return model.TemplateInstanceResponseData{}, err
+	}
+
+	instanceResp := model.TemplateInstanceResponseData{
+		List:  &list,
+		Tasks: []model.TaskResponseData{},
+	}
+
+	for _, templateHeading := range template.Content.Headings {
+		headingID := defaultHeadingID
+
+		if !templateHeading.IsDefault {
+			heading, err := u.headingUsecase.CreateHeading(ctx, &model.HeadingRequestData{
+				Title:  templateHeading.Title,
+				ListID: list.ID,
+				UserID: data.UserID,
+			})
+			if err != nil {
+				return model.TemplateInstanceResponseData{}, err
+			}
+
+			headingID = heading.ID
+			instanceResp.Headings = append(instanceResp.Headings, heading)
+		}
+
+		tasks, err := u.createTemplateTasks(ctx, templateHeading.Tasks, data, list.ID, headingID)
+		if err != nil {
+			return model.TemplateInstanceResponseData{}, err
+		}
+
+		instanceResp.Tasks = append(instanceResp.Tasks, tasks...)
+	}
+
+	return instanceResp, nil
+}
+
+func (u *TemplateUsecase) instantiateTasks(
+	ctx context.Context,
+	template model.Template,
+	data model.TemplateInstanceRequestData,
+) (model.TemplateInstanceResponseData, error) {
+	instanceResp := model.TemplateInstanceResponseData{
+		Tasks: []model.TaskResponseData{},
+	}
+
+	for _, templateHeading := range template.Content.Headings {
+		tasks, err := u.createTemplateTasks(ctx, templateHeading.Tasks, data, data.ListID, data.HeadingID)
+		if err != nil {
+			return model.TemplateInstanceResponseData{}, err
+		}
+
+		instanceResp.Tasks = append(instanceResp.Tasks, tasks...)
+	}
+
+	return instanceResp, nil
+}
+
+func (u *TemplateUsecase) createTemplateTasks(
+	ctx context.Context,
+	templateTasks []model.TemplateTask,
+	data model.TemplateInstanceRequestData,
+	listID, headingID string,
+) ([]model.TaskResponseData, error) {
+	var tasksResp []model.TaskResponseData
+
+	for _, templateTask := range templateTasks {
+		task, err := u.taskUsecase.CreateTask(ctx, &model.TaskRequestData{
+			Title:       templateTask.Title,
+			Description: templateTask.Description,
+			StartDate:   shiftFromAnchor(data.AnchorDate, templateTask.StartDateOffset),
+			Deadline:    shiftFromAnchor(data.AnchorDate, templateTask.DeadlineOffset),
+			StartTime:   templateTask.StartTime,
+			EndTime:     templateTask.EndTime,
+			ListID:      listID,
+			HeadingID:   headingID,
+			UserID:      data.UserID,
+			Tags:        templateTask.Tags,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		tasksResp = append(tasksResp, task)
+	}
+
+	return tasksResp, nil
+}
@@ -0,0 +1,188 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/segmentio/ksuid"
+
+	"github.com/rshelekhov/reframed/internal/lib/constants/le"
+	"github.com/rshelekhov/reframed/internal/model"
+	"github.com/rshelekhov/reframed/internal/port"
+)
+
+const defaultUndoTokenTTL = 10 * time.Minute
+
+type UndoUsecase struct {
+	undoStorage   port.UndoStorage
+	memberStorage port.ListMemberStorage
+	tokenTTL      time.Duration
+	eventBroker   port.EventBroker
+}
+
+func NewUndoUsecase(
+	storage port.UndoStorage,
+	memberStorage port.ListMemberStorage,
+	tokenTTL time.Duration,
+	eventBroker port.EventBroker,
+) *UndoUsecase {
+	if tokenTTL <= 0 {
+		tokenTTL = defaultUndoTokenTTL
+	}
+
+	return &UndoUsecase{
+		undoStorage:   storage,
+		memberStorage: memberStorage,
+		tokenTTL:      tokenTTL,
+		eventBroker:   eventBroker,
+	}
+}
+
+// CreateUndoToken saves the state of the entities the operation is going to change and returns the token to revert it.
+// It must be called in the transaction of the operation before the changes are made
+func (u *UndoUsecase) CreateUndoToken(ctx context.Context, data model.UndoRecordData) (string, error) {
+	snapshot, err := u.undoStorage.GetUndoSnapshot(ctx, data)
+	if err != nil {
+		return "", err
+	}
+
+	now := time.Now()
+
+	operation := model.UndoOperation{
+		ID:         ksuid.New().String(),
+		UserID:     data.UserID,
+		EntityType: data.EntityType,
+		EntityID:   data.EntityID,
+		Action:     data.Action,
+		Snapshot:   snapshot,
+		CreatedAt:  now,
+		ExpiresAt:  now.Add(u.tokenTTL),
+	}
+
+	if err = u.undoStorage.CreateUndoOperation(ctx, operation); err != nil {
+		return "", err
+	}
+
+	return operation.ID, nil
+}
+
+// Undo reverts the operation of the token along with the changes of the child entities made by it.
+// The token can be used once, by the user who made the operation, until it expires.
+// The user must still be able to edit the lists the operation touched, and the operation
+// isn't reverted over the changes made after it
+func (u *UndoUsecase) Undo(ctx context.Context, data model.UndoRequestData) (model.UndoResponseData, error) {
+	var (
+		undoResp model.UndoResponseData
+		listIDs  []string
+	)
+
+	if err := u.undoStorage.Transaction(ctx, func(ctx context.Context) error {
+		operation, err := u.undoStorage.GetUndoOperation(ctx, data.Token, data.UserID)
+		if err != nil {
+			return err
+		}
+
+		if !operation.UndoneAt.IsZero() {
+			return le.ErrUndoTokenAlreadyUsed
+		}
+
+		now := time.Now()
+
+		if !operation.ExpiresAt.After(now) {
+			return le.ErrUndoTokenExpired
+		}
+
+		listIDs, err = u.undoStorage.GetUndoListIDs(ctx, operation)
+		if err != nil {
+			return err
+		}
+
+		if err = u.authorizeUndo(ctx, listIDs, data.UserID); err != nil {
+			return err
+		}
+
+		conflict, err := u.undoStorage.HasUndoConflicts(ctx, operation)
+		if err != nil {
+			return err
+		}
+		if conflict {
+			return le.ErrUndoConflict
+		}
+
+		if err = u.undoStorage.RestoreUndoSnapshot(ctx, operation, now); err != nil {
+			return err
+		}
+
+		if err = u.undoStorage.MarkUndoOperationAsUndone(ctx, operation.ID, now); err != nil {
+			return err
+		}
+
+		undoResp = model.UndoResponseData{
+			Token:      operation.ID,
+			EntityType: operation.EntityType,
+			EntityID:   operation.EntityID,
+			Action:     operation.Action,
+			UndoneAt:   now,
+		}
+		return nil
+	}); err != nil {
+		return model.UndoResponseData{}, err
+	}
+
+	u.publishUndoEvent(ctx, listIDs, data.UserID, undoResp)
+
+	return undoResp, nil
+}
+
+// authorizeUndo checks that the user can edit every list the operation touched.
+// The lists deleted by the operation are checked too, since the undo restores them
+func (u *UndoUsecase) authorizeUndo(ctx context.Context, listIDs []string, userID string) error {
+	for _, listID := range listIDs {
+		role, err := u.memberStorage.GetListMemberRoleWithDeleted(ctx, listID, userID)
+		if errors.Is(err, le.ErrListMemberNotFound) {
+			return le.ErrListNotFound
+		}
+		if err != nil {
+			return err
+		}
+
+		if err = checkRole(role, model.ListRoleEditor); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// publishUndoEvent notifies the members of the lists touched by the operation, so they reload the reverted entities
+func (u *UndoUsecase) publishUndoEvent(ctx context.Context, listIDs []string, userID string, undoResp model.UndoResponseData) {
+	if u.eventBroker == nil {
+		return
+	}
+
+	seen := make(map[string]bool)
+
+	var memberIDs []string
+
+	for _, listID := range listIDs {
+		members, err := u.memberStorage.GetListMembers(ctx, listID)
+		if err != nil {
+			continue
+		}
+
+		for _, member := range members {
+			if !seen[member.UserID] {
+				seen[member.UserID] = true
+				memberIDs = append(memberIDs, member.UserID)
+			}
+		}
+	}
+
+	publishMembersEvent(ctx, u.eventBroker, model.EventOperationUndone, memberIDs, userID, undoResp.EntityID, undoResp)
+}
+
+// DeleteExpiredUndoOperations removes the operations which can't be undone anymore and returns their number
+func (u *UndoUsecase) DeleteExpiredUndoOperations(ctx context.Context) (int64, error) {
+	return u.undoStorage.DeleteExpiredUndoOperations(ctx, time.Now())
+}
@@ -0,0 +1,487 @@
+package usecase
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/segmentio/ksuid"
+
+	"github.com/rshelekhov/reframed/config"
+	"github.com/rshelekhov/reframed/internal/lib/constants/le"
+	"github.com/rshelekhov/reframed/internal/lib/hmacsign"
+	"github.com/rshelekhov/reframed/internal/lib/safehttp"
+	"github.com/rshelekhov/reframed/internal/model"
+	"github.com/rshelekhov/reframed/internal/port"
+)
+
+const (
+	WebhookIDHeader        = "X-Webhook-ID"
+	WebhookDeliveryHeader  = "X-Webhook-Delivery"
+	WebhookEventHeader     = "X-Webhook-Event"
+	WebhookTimestampHeader = "X-Webhook-Timestamp"
+	WebhookSignatureHeader = "X-Webhook-Signature"
+)
+
+const (
+	// webhookDeliveryBatchSize is the number of deliveries sent on each run of the dispatcher
+	webhookDeliveryBatchSize = 50
+	// webhookDeliveryLease is the time the claimed deliveries are hidden from the other dispatchers
+	webhookDeliveryLease = 5 * time.Minute
+	// webhookResponseBodyLimit is the length of the response body stored in the delivery log
+	webhookResponseBodyLimit = 1024
+	webhookSecretLength      = 32
+)
+
+type WebhookUsecase struct {
+	webhookStorage port.WebhookStorage
+	client         *http.Client
+	cfg            config.WebhookConfig
+}
+
+// NewWebhookUsecase creates the webhook usecase. Deliveries are sent with the given client,
+// so it can be pointed to a local HTTP server. Without a client, the client that only
+// connects to public addresses is used
+func NewWebhookUsecase(storage port.WebhookStorage, client *http.Client, cfg config.WebhookConfig) *WebhookUsecase {
+	if client == nil {
+		client = safehttp.NewClient(cfg.Timeout)
+	}
+
+	return &WebhookUsecase{
+		webhookStorage: storage,
+		client:         client,
+		cfg:            cfg,
+	}
+}
+
+func (u *WebhookUsecase) CreateWebhook(ctx context.Context, data *model.WebhookRequestData) (model.WebhookResponseData, error) {
+	if err := validateWebhookURL(data.URL); err != nil {
+		return model.WebhookResponseData{}, err
+	}
+
+	if err := validateWebhookEventTypes(data.EventTypes); err != nil {
+		return model.WebhookResponseData{}, err
+	}
+
+	secret := data.Secret
+	if secret == "" {
+		generated, err := generateWebhookSecret()
+		if err != nil {
+			return model.WebhookResponseData{}, err
+		}
+
+		secret = generated
+	}
+
+	newWebhook := model.Webhook{
+		ID:         ksuid.New().String(),
+		URL:        data.URL,
+		EventTypes: normalizeWebhookEventTypes(data.EventTypes),
+		Secret:     secret,
+		UserID:     data.UserID,
+		IsActive:   true,
+		UpdatedAt:  time.Now(),
+	}
+
+	if err := u.webhookStorage.CreateWebhook(ctx, newWebhook); err != nil {
+		return model.WebhookResponseData{}, err
+	}
+
+	webhookResp := mapWebhookToResponseData(newWebhook)
+
+	// The secret is only returned once, when the webhook is created
+	webhookResp.Secret = newWebhook.Secret
+
+	return webhookResp, nil
+}
+
+func (u *WebhookUsecase) GetWebhookByID(ctx context.Context, data model.WebhookRequestData) (model.WebhookResponseData, error) {
+	webhook, err := u.webhookStorage.GetWebhookByID(ctx, data.ID, data.UserID)
+	if err != nil {
+		return model.WebhookResponseData{}, err
+	}
+
+	return mapWebhookToResponseData(webhook), nil
+}
+
+func (u *WebhookUsecase) GetWebhooksByUserID(ctx context.Context, userID string) ([]model.WebhookResponseData, error) {
+	webhooks, err := u.webhookStorage.GetWebhooksByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	var webhooksResp []model.WebhookResponseData
+
+	for _, webhook := range webhooks {
+		webhooksResp = append(webhooksResp, mapWebhookToResponseData(webhook))
+	}
+
+	return webhooksResp, nil
+}
+
+func mapWebhookToResponseData(webhook model.Webhook) model.WebhookResponseData {
+	return model.WebhookResponseData{
+		ID:           webhook.ID,
+		URL:          webhook.URL,
+		EventTypes:   webhook.EventTypes,
+		IsActive:     webhook.IsActive,
+		FailureCount: webhook.FailureCount,
+		DisabledAt:   webhook.DisabledAt,
+		UserID:       webhook.UserID,
+		UpdatedAt:    webhook.UpdatedAt,
+	}
+}
+
+// UpdateWebhook changes the URL and the event types of the webhook.
+// Enabling the disabled webhook resets its failure count
+func (u *WebhookUsecase) UpdateWebhook(ctx context.Context, data *model.WebhookRequestData) (model.WebhookResponseData, error) {
+	if err := validateWebhookURL(data.URL); err != nil {
+		return model.WebhookResponseData{}, err
+	}
+
+	if err := validateWebhookEventTypes(data.EventTypes); err != nil {
+		return model.WebhookResponseData{}, err
+	}
+
+	updatedWebhook := model.Webhook{
+		ID:         data.ID,
+		URL:        data.URL,
+		EventTypes: normalizeWebhookEventTypes(data.EventTypes),
+		UserID:     data.UserID,
+		IsActive:   data.IsActive,
+		UpdatedAt:  time.Now(),
+	}
+
+	if err := u.webhookStorage.UpdateWebhook(ctx, updatedWebhook); err != nil {
+		return model.WebhookResponseData{}, err
+	}
+
+	return u.GetWebhookByID(ctx, *data)
+}
+
+func (u *WebhookUsecase) DeleteWebhook(ctx context.Context, data model.WebhookRequestData) error {
+	return u.webhookStorage.DeleteWebhook(ctx, model.Webhook{
+		ID:        data.ID,
+		UserID:    data.UserID,
+		DeletedAt: time.Now(),
+	})
+}
+
+func (u *WebhookUsecase) GetWebhookDeliveries(
+	ctx context.Context,
+	data model.WebhookRequestData,
+	pgn model.Pagination,
+) ([]model.WebhookDeliveryResponseData, error) {
+	if _, err := u.webhookStorage.GetWebhookByID(ctx, data.ID, data.UserID); err != nil {
+		return nil, err
+	}
+
+	deliveries, err := u.webhookStorage.GetWebhookDeliveries(ctx, data.ID, data.UserID, pgn)
+	if err != nil {
+		return nil, err
+	}
+
+	var deliveriesResp []model.WebhookDeliveryResponseData
+
+	for _, delivery := range deliveries {
+		deliveriesResp = append(deliveriesResp, mapWebhookDeliveryToResponseData(delivery))
+	}
+
+	return deliveriesResp, nil
+}
+
+func mapWebhookDeliveryToResponseData(delivery model.WebhookDelivery) model.WebhookDeliveryResponseData {
+	return model.WebhookDeliveryResponseData{
+		ID:             delivery.ID,
+		WebhookID:      delivery.WebhookID,
+		EventID:        delivery.EventID,
+		EventType:      delivery.EventType,
+		Payload:        delivery.Payload,
+		Status:         delivery.Status,
+		Attempt:        delivery.Attempt,
+		ResponseStatus: delivery.ResponseStatus,
+		Error:          delivery.Error,
+		NextAttemptAt:  delivery.NextAttemptAt,
+		DeliveredAt:    delivery.DeliveredAt,
+		CreatedAt:      delivery.CreatedAt,
+		UpdatedAt:      delivery.UpdatedAt,
+	}
+}
+
+// SendTestEvent sends the test event to the webhook right away, even if the webhook is disabled.
+// The test delivery is not retried and doesn't count towards the failures of the webhook
+func (u *WebhookUsecase) SendTestEvent(ctx context.Context, data model.WebhookRequestData) (model.WebhookDeliveryResponseData, error) {
+	webhook, err := u.webhookStorage.GetWebhookByID(ctx, data.ID, data.UserID)
+	if err != nil {
+		return model.WebhookDeliveryResponseData{}, err
+	}
+
+	event := model.Event{
+		ID:        ksuid.New().String(),
+		Type:      model.EventWebhookTest,
+		UserID:    webhook.UserID,
+		EntityID:  webhook.ID,
+		Data:      mapWebhookToResponseData(webhook),
+		CreatedAt: time.Now(),
+	}
+
+	delivery, err := u.createDelivery(ctx, webhook, event)
+	if err != nil {
+		return model.WebhookDeliveryResponseData{}, err
+	}
+
+	delivery.URL = webhook.URL
+	delivery.Secret = webhook.Secret
+
+	u.attemptDelivery(ctx, &delivery)
+
+	if delivery.Status != model.WebhookDeliverySucceeded {
+		delivery.Status = model.WebhookDeliveryFailed
+	}
+
+	if err = u.webhookStorage.UpdateWebhookDelivery(ctx, delivery); err != nil {
+		return model.WebhookDeliveryResponseData{}, err
+	}
+
+	return mapWebhookDeliveryToResponseData(delivery), nil
+}
+
+// Publish queues the delivery of the event to every active webhook of the user subscribed to it
+func (u *WebhookUsecase) Publish(ctx context.Context, event model.Event) error {
+	webhooks, err := u.webhookStorage.GetActiveWebhooksByEventType(ctx, event.UserID, event.Type.String())
+	if err != nil {
+		return err
+	}
+
+	for _, webhook := range webhooks {
+		if _, err = u.createDelivery(ctx, webhook, event); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (u *WebhookUsecase) createDelivery(ctx context.Context, webhook model.Webhook, event model.Event) (model.WebhookDelivery, error) {
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return model.WebhookDelivery{}, fmt.Errorf("failed to marshal webhook payload: %w", err)
+	}
+
+	now := time.Now()
+
+	delivery := model.WebhookDelivery{
+		ID:            ksuid.New().String(),
+		WebhookID:     webhook.ID,
+		UserID:        webhook.UserID,
+		EventID:       event.ID,
+		EventType:     event.Type.String(),
+		Payload:       payload,
+		Status:        model.WebhookDeliveryPending,
+		NextAttemptAt: now,
+		CreatedAt:     now,
+		UpdatedAt:     now,
+	}
+
+	if err = u.webhookStorage.CreateWebhookDelivery(ctx, delivery); err != nil {
+		return model.WebhookDelivery{}, err
+	}
+
+	return delivery, nil
+}
+
+// DeliverPendingWebhooks sends the queued deliveries. Failed deliveries are retried with exponential
+// backoff until the attempts are exhausted. The webhook is disabled after too many failures in a row
+func (u *WebhookUsecase) DeliverPendingWebhooks(ctx context.Context) error {
+	now := time.Now()
+
+	deliveries, err := u.webhookStorage.ClaimWebhookDeliveries(ctx, now, now.Add(webhookDeliveryLease), webhookDeliveryBatchSize)
+	if err != nil {
+		return err
+	}
+
+	var errs []error
+
+	for _, delivery := range deliveries {
+		if err = u.deliver(ctx, delivery); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+func (u *WebhookUsecase) deliver(ctx context.Context, delivery model.WebhookDelivery) error {
+	if !delivery.WebhookActive {
+		delivery.Status = model.WebhookDeliveryFailed
+		delivery.Error = le.ErrWebhookDisabled.Error()
+		delivery.NextAttemptAt = time.Now()
+		delivery.UpdatedAt = delivery.NextAttemptAt
+
+		return u.webhookStorage.UpdateWebhookDelivery(ctx, delivery)
+	}
+
+	now := u.attemptDelivery(ctx, &delivery)
+
+	if delivery.Status == model.WebhookDeliverySucceeded {
+		if err := u.webhookStorage.ResetWebhookFailureCount(ctx, delivery.WebhookID); err != nil {
+			return err
+		}
+
+		return u.webhookStorage.UpdateWebhookDelivery(ctx, delivery)
+	}
+
+	if delivery.Attempt >= u.cfg.MaxAttempts {
+		delivery.Status = model.WebhookDeliveryFailed
+	} else {
+		delivery.NextAttemptAt = now.Add(u.retryDelay(delivery.Attempt))
+	}
+
+	if _, err := u.webhookStorage.IncrementWebhookFailureCount(ctx, delivery.WebhookID, u.cfg.MaxFailures, now); err != nil {
+		return err
+	}
+
+	return u.webhookStorage.UpdateWebhookDelivery(ctx, delivery)
+}
+
+// attemptDelivery sends the signed payload to the webhook and records the result in the delivery.
+// It returns the time of the attempt
+func (u *WebhookUsecase) attemptDelivery(ctx context.Context, delivery *model.WebhookDelivery) time.Time {
+	delivery.Attempt++
+
+	statusCode, err := u.send(ctx, *delivery)
+
+	now := time.Now()
+
+	delivery.ResponseStatus = statusCode
+	delivery.NextAttemptAt = now
+	delivery.UpdatedAt = now
+
+	switch {
+	case err != nil:
+		delivery.Error = err.Error()
+	case statusCode < http.StatusOK || statusCode >= http.StatusMultipleChoices:
+		delivery.Error = fmt.Sprintf("unexpected response status: %d", statusCode)
+	default:
+		delivery.Status = model.WebhookDeliverySucceeded
+		delivery.Error = ""
+		delivery.DeliveredAt = now
+	}
+
+	return now
+}
+
+func (u *WebhookUsecase) send(ctx context.Context, delivery model.WebhookDelivery) (int, error) {
+	// The webhooks created before the URL was validated may still point to other schemes
+	if err := validateWebhookURL(delivery.URL); err != nil {
+		return 0, err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, u.cfg.Timeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, delivery.URL, bytes.NewReader(delivery.Payload))
+	if err != nil {
+		return 0, err
+	}
+
+	timestamp := time.Now().Unix()
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "Reframed-Webhooks")
+	req.Header.Set(WebhookIDHeader, delivery.WebhookID)
+	req.Header.Set(WebhookDeliveryHeader, delivery.ID)
+	req.Header.Set(WebhookEventHeader, delivery.EventType)
+	req.Header.Set(WebhookTimestampHeader, strconv.FormatInt(timestamp, 10))
+	req.Header.Set(WebhookSignatureHeader, hmacsign.Sign(delivery.Secret, timestamp, delivery.Payload))
+
+	resp, err := u.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+
+	// Drain a part of the body, so the connection can be reused
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, webhookResponseBodyLimit))
+
+	return resp.StatusCode, nil
+}
+
+// retryDelay returns the exponential backoff for the attempt: base, 2*base, 4*base, ... up to the max delay
+func (u *WebhookUsecase) retryDelay(attempt int) time.Duration {
+	delay := u.cfg.RetryBaseDelay
+
+	for i := 1; i < attempt; i++ {
+		delay *= 2
+		if delay >= u.cfg.MaxRetryDelay {
+			return u.cfg.MaxRetryDelay
+		}
+	}
+
+	return delay
+}
+
+// validateWebhookURL only allows absolute HTTP(S) URLs. The address the URL resolves to
+// is checked by the client when the delivery is sent
+func validateWebhookURL(rawURL string) error {
+	webhookURL, err := url.Parse(rawURL)
+	if err != nil {
+		return le.ErrInvalidWebhookURL
+	}
+
+	if webhookURL.Scheme != "http" && webhookURL.Scheme != "https" {
+		return le.ErrInvalidWebhookURL
+	}
+
+	if webhookURL.Hostname() == "" || webhookURL.User != nil {
+		return le.ErrInvalidWebhookURL
+	}
+
+	return nil
+}
+
+func validateWebhookEventTypes(eventTypes []string) error {
+	for _, eventType := range eventTypes {
+		if !model.EventType(eventType).IsValid() {
+			return le.ErrInvalidWebhookEventType
+		}
+	}
+
+	return nil
+}
+
+// normalizeWebhookEventTypes removes duplicates, an empty list subscribes the webhook to all events
+func normalizeWebhookEventTypes(eventTypes []string) []string {
+	seen := make(map[string]bool)
+	result := make([]string, 0, len(eventTypes))
+
+	for _, eventType := range eventTypes {
+		if seen[eventType] {
+			continue
+		}
+
+		seen[eventType] = true
+		result = append(result, eventType)
+	}
+
+	return result
+}
+
+func generateWebhookSecret() (string, error) {
+	b := make([]byte, webhookSecretLength)
+
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("failed to generate webhook secret: %w", err)
+	}
+
+	return "whsec_" + hex.EncodeToString(b), nil
+}
@@ -0,0 +1,17 @@
+CREATE TABLE IF NOT EXISTS idempotency_keys
+(
+    key           character varying NOT NULL,
+    user_id       character varying NOT NULL,
+    method        character varying NOT NULL,
+    path          character varying NOT NULL,
+    request_hash  character varying NOT NULL,
+    status_code   int NOT NULL DEFAULT 0,
+    response_body bytea,
+    created_at    timestamp WITH TIME ZONE NOT NULL DEFAULT now(),
+    expires_at    timestamp WITH TIME ZONE NOT NULL,
+    CONSTRAINT idempotency_keys_pkey PRIMARY KEY (user_id, key)
+);
+
+CREATE INDEX IF NOT EXISTS idx_idempotency_key_expires_at ON idempotency_keys(expires_at);
+
+ALTER TABLE idempotency_keys ADD FOREIGN KEY (user_id) REFERENCES users(id);
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS webhook_deliveries CASCADE;
+DROP TABLE IF EXISTS webhooks CASCADE;
\ No newline at end of file
@@ -0,0 +1,40 @@
+CREATE TABLE IF NOT EXISTS webhooks
+(
+    id            character varying PRIMARY KEY,
+    url           character varying NOT NULL,
+    event_types   character varying[] NOT NULL DEFAULT '{}',
+    secret        character varying NOT NULL,
+    user_id       character varying NOT NULL,
+    is_active     boolean NOT NULL DEFAULT true,
+    failure_count int NOT NULL DEFAULT 0,
+    disabled_at   timestamp WITH TIME ZONE DEFAULT NULL,
+    updated_at    timestamp WITH TIME ZONE NOT NULL DEFAULT now(),
+    deleted_at    timestamp WITH TIME ZONE DEFAULT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_webhook_user_id ON webhooks(user_id);
+
+CREATE TABLE IF NOT EXISTS webhook_deliveries
+(
+    id              character varying PRIMARY KEY,
+    webhook_id      character varying NOT NULL,
+    user_id         character varying NOT NULL,
+    event_id        character varying NOT NULL,
+    event_type      character varying NOT NULL,
+    payload         jsonb NOT NULL,
+    status          character varying NOT NULL DEFAULT 'pending',
+    attempt         int NOT NULL DEFAULT 0,
+    response_status int NOT NULL DEFAULT 0,
+    error           character varying NOT NULL DEFAULT '',
+    next_attempt_at timestamp WITH TIME ZONE NOT NULL DEFAULT now(),
+    delivered_at    timestamp WITH TIME ZONE DEFAULT NULL,
+    created_at      timestamp WITH TIME ZONE NOT NULL DEFAULT now(),
+    updated_at      timestamp WITH TIME ZONE NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS idx_webhook_delivery_webhook_id ON webhook_deliveries(webhook_id);
+CREATE INDEX IF NOT EXISTS idx_pending_webhook_deliveries ON webhook_deliveries(next_attempt_at) WHERE status = 'pending';
+
+ALTER TABLE webhooks ADD FOREIGN KEY (user_id) REFERENCES users(id);
+ALTER TABLE webhook_deliveries ADD FOREIGN KEY (webhook_id) REFERENCES webhooks(id);
+ALTER TABLE webhook_deliveries ADD FOREIGN KEY (user_id) REFERENCES users(id);
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS rule_deadline_runs CASCADE;
+DROP TABLE IF EXISTS rules CASCADE;
\ No newline at end of file
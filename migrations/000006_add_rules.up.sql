@@ -0,0 +1,28 @@
+CREATE TABLE IF NOT EXISTS rules
+(
+    id         character varying PRIMARY KEY,
+    title      character varying NOT NULL,
+    trigger    character varying NOT NULL,
+    conditions jsonb NOT NULL DEFAULT '[]',
+    actions    jsonb NOT NULL DEFAULT '[]',
+    is_active  boolean NOT NULL DEFAULT true,
+    user_id    character varying NOT NULL,
+    updated_at timestamp WITH TIME ZONE NOT NULL DEFAULT now(),
+    deleted_at timestamp WITH TIME ZONE DEFAULT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_rule_user_id_trigger ON rules(user_id, trigger);
+
+-- Deadline rules run once for each deadline of the task
+CREATE TABLE IF NOT EXISTS rule_deadline_runs
+(
+    rule_id  character varying NOT NULL,
+    task_id  character varying NOT NULL,
+    deadline timestamp WITH TIME ZONE NOT NULL,
+    ran_at   timestamp WITH TIME ZONE NOT NULL DEFAULT now(),
+    CONSTRAINT rule_deadline_runs_pkey PRIMARY KEY (rule_id, task_id, deadline)
+);
+
+ALTER TABLE rules ADD FOREIGN KEY (user_id) REFERENCES users(id);
+ALTER TABLE rule_deadline_runs ADD FOREIGN KEY (rule_id) REFERENCES rules(id);
+ALTER TABLE rule_deadline_runs ADD FOREIGN KEY (task_id) REFERENCES tasks(id);
@@ -0,0 +1 @@
+DROP TABLE IF EXISTS task_dependencies CASCADE;
\ No newline at end of file
@@ -0,0 +1,16 @@
+-- The task can't be started until all the tasks blocking it are completed
+CREATE TABLE IF NOT EXISTS task_dependencies
+(
+    task_id            character varying NOT NULL,
+    blocked_by_task_id character varying NOT NULL,
+    user_id            character varying NOT NULL,
+    created_at         timestamp WITH TIME ZONE NOT NULL DEFAULT now(),
+    CONSTRAINT task_dependencies_pkey PRIMARY KEY (task_id, blocked_by_task_id),
+    CONSTRAINT task_dependencies_not_self CHECK (task_id <> blocked_by_task_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_task_dependency_blocked_by_task_id ON task_dependencies(blocked_by_task_id);
+
+ALTER TABLE task_dependencies ADD FOREIGN KEY (task_id) REFERENCES tasks(id);
+ALTER TABLE task_dependencies ADD FOREIGN KEY (blocked_by_task_id) REFERENCES tasks(id);
+ALTER TABLE task_dependencies ADD FOREIGN KEY (user_id) REFERENCES users(id);
@@ -0,0 +1 @@
+DROP TABLE IF EXISTS templates CASCADE;
\ No newline at end of file
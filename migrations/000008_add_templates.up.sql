@@ -0,0 +1,14 @@
+CREATE TABLE IF NOT EXISTS templates
+(
+    id         character varying PRIMARY KEY,
+    title      character varying NOT NULL,
+    kind       character varying NOT NULL,
+    content    jsonb NOT NULL,
+    user_id    character varying NOT NULL,
+    updated_at timestamp WITH TIME ZONE NOT NULL DEFAULT now(),
+    deleted_at timestamp WITH TIME ZONE DEFAULT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_template_user_id ON templates(user_id);
+
+ALTER TABLE templates ADD FOREIGN KEY (user_id) REFERENCES users(id);
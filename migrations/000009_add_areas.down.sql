@@ -0,0 +1,4 @@
+ALTER TABLE lists DROP COLUMN IF EXISTS position;
+ALTER TABLE lists DROP COLUMN IF EXISTS area_id;
+
+DROP TABLE IF EXISTS areas CASCADE;
\ No newline at end of file
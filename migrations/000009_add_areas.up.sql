@@ -0,0 +1,23 @@
+-- Areas group the lists of the user. The loose tasks of the area are kept
+-- in the default list of the area, the same way as the loose tasks of the list
+-- are kept in its default heading
+CREATE TABLE IF NOT EXISTS areas
+(
+    id         character varying PRIMARY KEY,
+    title      character varying NOT NULL,
+    position   int NOT NULL DEFAULT 0,
+    user_id    character varying NOT NULL,
+    updated_at timestamp WITH TIME ZONE NOT NULL DEFAULT now(),
+    deleted_at timestamp WITH TIME ZONE DEFAULT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_area_user_id ON areas(user_id);
+
+ALTER TABLE areas ADD FOREIGN KEY (user_id) REFERENCES users(id);
+
+ALTER TABLE lists ADD COLUMN IF NOT EXISTS area_id character varying DEFAULT NULL;
+ALTER TABLE lists ADD COLUMN IF NOT EXISTS position int NOT NULL DEFAULT 0;
+
+CREATE INDEX IF NOT EXISTS idx_list_area_id ON lists(area_id);
+
+ALTER TABLE lists ADD FOREIGN KEY (area_id) REFERENCES areas(id);
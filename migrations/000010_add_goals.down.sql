@@ -0,0 +1,6 @@
+ALTER TABLE tasks DROP COLUMN IF EXISTS goal_id;
+ALTER TABLE lists DROP COLUMN IF EXISTS goal_id;
+
+DROP TABLE IF EXISTS goal_progress CASCADE;
+DROP TABLE IF EXISTS key_results CASCADE;
+DROP TABLE IF EXISTS goals CASCADE;
@@ -0,0 +1,56 @@
+-- Goals are reached through their key results. The key result is measured either
+-- by the tasks linked to the goal, directly or through their lists, or by the value
+-- the user updates manually
+CREATE TABLE IF NOT EXISTS goals
+(
+    id          character varying PRIMARY KEY,
+    title       character varying NOT NULL,
+    description character varying,
+    target_date timestamp WITH TIME ZONE,
+    user_id     character varying NOT NULL,
+    updated_at  timestamp WITH TIME ZONE NOT NULL DEFAULT now(),
+    deleted_at  timestamp WITH TIME ZONE DEFAULT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_goal_user_id ON goals(user_id);
+
+CREATE TABLE IF NOT EXISTS key_results
+(
+    id            character varying PRIMARY KEY,
+    goal_id       character varying NOT NULL,
+    title         character varying NOT NULL,
+    kind          character varying NOT NULL,
+    start_value   double precision NOT NULL DEFAULT 0,
+    target_value  double precision NOT NULL DEFAULT 0,
+    current_value double precision NOT NULL DEFAULT 0,
+    user_id       character varying NOT NULL,
+    updated_at    timestamp WITH TIME ZONE NOT NULL DEFAULT now(),
+    deleted_at    timestamp WITH TIME ZONE DEFAULT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_key_result_goal_id ON key_results(goal_id);
+
+-- The snapshot of the goal progress is recorded every time it changes
+CREATE TABLE IF NOT EXISTS goal_progress
+(
+    id          SERIAL PRIMARY KEY,
+    goal_id     character varying NOT NULL,
+    progress    double precision NOT NULL,
+    recorded_at timestamp WITH TIME ZONE NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS idx_goal_progress_goal_id ON goal_progress(goal_id, recorded_at);
+
+ALTER TABLE goals ADD FOREIGN KEY (user_id) REFERENCES users(id);
+ALTER TABLE key_results ADD FOREIGN KEY (goal_id) REFERENCES goals(id);
+ALTER TABLE key_results ADD FOREIGN KEY (user_id) REFERENCES users(id);
+ALTER TABLE goal_progress ADD FOREIGN KEY (goal_id) REFERENCES goals(id) ON DELETE CASCADE;
+
+ALTER TABLE lists ADD COLUMN IF NOT EXISTS goal_id character varying DEFAULT NULL;
+ALTER TABLE tasks ADD COLUMN IF NOT EXISTS goal_id character varying DEFAULT NULL;
+
+CREATE INDEX IF NOT EXISTS idx_list_goal_id ON lists(goal_id);
+CREATE INDEX IF NOT EXISTS idx_task_goal_id ON tasks(goal_id);
+
+ALTER TABLE lists ADD FOREIGN KEY (goal_id) REFERENCES goals(id);
+ALTER TABLE tasks ADD FOREIGN KEY (goal_id) REFERENCES goals(id);
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS list_invitations CASCADE;
+DROP VIEW IF EXISTS list_editors_view CASCADE;
+DROP TABLE IF EXISTS list_members CASCADE;
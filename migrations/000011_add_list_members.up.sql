@@ -0,0 +1,46 @@
+-- The members of the list access it with their role: viewers read the list,
+-- editors also change its headings and tasks, owners also share and delete it.
+-- The creator of the list is its first owner
+CREATE TABLE IF NOT EXISTS list_members
+(
+    list_id    character varying NOT NULL,
+    user_id    character varying NOT NULL,
+    role       character varying NOT NULL,
+    created_at timestamp WITH TIME ZONE NOT NULL DEFAULT now(),
+    CONSTRAINT list_members_pkey PRIMARY KEY (list_id, user_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_list_member_user_id ON list_members(user_id);
+
+ALTER TABLE list_members ADD FOREIGN KEY (list_id) REFERENCES lists(id);
+ALTER TABLE list_members ADD FOREIGN KEY (user_id) REFERENCES users(id);
+
+INSERT INTO list_members (list_id, user_id, role, created_at)
+SELECT id, user_id, 'owner', updated_at
+FROM lists
+ON CONFLICT DO NOTHING;
+
+-- The members who can change the list
+CREATE VIEW list_editors_view AS
+SELECT list_id, user_id
+FROM list_members
+WHERE role IN ('owner', 'editor');
+
+-- The invitation is sent to the email, so the user can be invited before signing up
+CREATE TABLE IF NOT EXISTS list_invitations
+(
+    id         character varying PRIMARY KEY,
+    list_id    character varying NOT NULL,
+    email      character varying NOT NULL,
+    role       character varying NOT NULL,
+    status     character varying NOT NULL DEFAULT 'pending',
+    invited_by character varying NOT NULL,
+    created_at timestamp WITH TIME ZONE NOT NULL DEFAULT now(),
+    updated_at timestamp WITH TIME ZONE NOT NULL DEFAULT now()
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_pending_list_invitation ON list_invitations(list_id, email) WHERE status = 'pending';
+CREATE INDEX IF NOT EXISTS idx_list_invitation_email ON list_invitations(email);
+
+ALTER TABLE list_invitations ADD FOREIGN KEY (list_id) REFERENCES lists(id);
+ALTER TABLE list_invitations ADD FOREIGN KEY (invited_by) REFERENCES users(id);
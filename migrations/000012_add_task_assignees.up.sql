@@ -0,0 +1,5 @@
+ALTER TABLE tasks ADD COLUMN IF NOT EXISTS assignee_id character varying DEFAULT NULL;
+
+CREATE INDEX IF NOT EXISTS idx_task_assignee_id ON tasks(assignee_id);
+
+ALTER TABLE tasks ADD FOREIGN KEY (assignee_id) REFERENCES users(id);
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS task_activities CASCADE;
+DROP TABLE IF EXISTS task_comments CASCADE;
@@ -0,0 +1,32 @@
+CREATE TABLE IF NOT EXISTS task_comments
+(
+    id         character varying PRIMARY KEY,
+    task_id    character varying NOT NULL,
+    body       text NOT NULL,
+    mentions   character varying[] NOT NULL DEFAULT '{}',
+    user_id    character varying NOT NULL,
+    created_at timestamp WITH TIME ZONE NOT NULL DEFAULT now(),
+    updated_at timestamp WITH TIME ZONE NOT NULL DEFAULT now(),
+    deleted_at timestamp WITH TIME ZONE DEFAULT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_task_comment_task_id ON task_comments(task_id, id);
+
+CREATE TABLE IF NOT EXISTS task_activities
+(
+    id         character varying PRIMARY KEY,
+    task_id    character varying NOT NULL,
+    kind       character varying NOT NULL,
+    field      character varying NOT NULL DEFAULT '',
+    old_value  character varying NOT NULL DEFAULT '',
+    new_value  character varying NOT NULL DEFAULT '',
+    user_id    character varying NOT NULL,
+    created_at timestamp WITH TIME ZONE NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS idx_task_activity_task_id ON task_activities(task_id, created_at);
+
+ALTER TABLE task_comments ADD FOREIGN KEY (task_id) REFERENCES tasks(id);
+ALTER TABLE task_comments ADD FOREIGN KEY (user_id) REFERENCES users(id);
+ALTER TABLE task_activities ADD FOREIGN KEY (task_id) REFERENCES tasks(id);
+ALTER TABLE task_activities ADD FOREIGN KEY (user_id) REFERENCES users(id);
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS audit_log CASCADE;
+DROP FUNCTION IF EXISTS reject_audit_log_change();
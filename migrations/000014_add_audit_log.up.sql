@@ -0,0 +1,29 @@
+CREATE TABLE IF NOT EXISTS audit_log
+(
+    id          character varying PRIMARY KEY,
+    entity_type character varying NOT NULL,
+    entity_id   character varying NOT NULL,
+    action      character varying NOT NULL,
+    changes     jsonb NOT NULL DEFAULT '{}',
+    user_id     character varying NOT NULL,
+    device_id   character varying DEFAULT NULL,
+    request_id  character varying NOT NULL DEFAULT '',
+    created_at  timestamp WITH TIME ZONE NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS idx_audit_log_entity ON audit_log(entity_type, entity_id, id);
+CREATE INDEX IF NOT EXISTS idx_audit_log_user_id ON audit_log(user_id, id);
+
+ALTER TABLE audit_log ADD FOREIGN KEY (user_id) REFERENCES users(id);
+ALTER TABLE audit_log ADD FOREIGN KEY (device_id) REFERENCES user_devices(id);
+
+-- The audit log is append-only, its entries can't be changed or removed
+CREATE OR REPLACE FUNCTION reject_audit_log_change() RETURNS trigger AS $$
+BEGIN
+    RAISE EXCEPTION 'audit log is append-only';
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER audit_log_append_only
+    BEFORE UPDATE OR DELETE ON audit_log
+    FOR EACH ROW EXECUTE FUNCTION reject_audit_log_change();
@@ -0,0 +1 @@
+DROP TABLE IF EXISTS undo_operations CASCADE;
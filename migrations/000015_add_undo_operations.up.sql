@@ -0,0 +1,16 @@
+CREATE TABLE IF NOT EXISTS undo_operations
+(
+    id          character varying PRIMARY KEY,
+    user_id     character varying NOT NULL,
+    entity_type character varying NOT NULL,
+    entity_id   character varying NOT NULL,
+    action      character varying NOT NULL,
+    snapshot    jsonb NOT NULL,
+    created_at  timestamp WITH TIME ZONE NOT NULL DEFAULT now(),
+    expires_at  timestamp WITH TIME ZONE NOT NULL,
+    undone_at   timestamp WITH TIME ZONE DEFAULT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_undo_operation_expires_at ON undo_operations(expires_at);
+
+ALTER TABLE undo_operations ADD FOREIGN KEY (user_id) REFERENCES users(id);
@@ -0,0 +1,7 @@
+DROP VIEW IF EXISTS list_progress_view CASCADE;
+
+ALTER TABLE lists DROP COLUMN IF EXISTS deadline;
+ALTER TABLE lists DROP COLUMN IF EXISTS start_date;
+ALTER TABLE lists DROP COLUMN IF EXISTS notes;
+ALTER TABLE lists DROP COLUMN IF EXISTS icon;
+ALTER TABLE lists DROP COLUMN IF EXISTS color;
@@ -0,0 +1,15 @@
+ALTER TABLE lists ADD COLUMN IF NOT EXISTS color character varying NOT NULL DEFAULT '';
+ALTER TABLE lists ADD COLUMN IF NOT EXISTS icon character varying NOT NULL DEFAULT '';
+ALTER TABLE lists ADD COLUMN IF NOT EXISTS notes text NOT NULL DEFAULT '';
+ALTER TABLE lists ADD COLUMN IF NOT EXISTS start_date timestamp WITH TIME ZONE DEFAULT NULL;
+ALTER TABLE lists ADD COLUMN IF NOT EXISTS deadline timestamp WITH TIME ZONE DEFAULT NULL;
+
+-- Progress of the lists: the archived and deleted tasks aren't counted
+CREATE VIEW list_progress_view AS
+SELECT t.list_id,
+       COUNT(*) FILTER (WHERE s.title <> 'Completed')::int AS open_tasks,
+       COUNT(*) FILTER (WHERE s.title = 'Completed')::int AS completed_tasks
+FROM tasks t
+         JOIN statuses s ON t.status_id = s.id
+WHERE t.deleted_at IS NULL
+GROUP BY t.list_id;
@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS idx_list_status;
+
+ALTER TABLE lists DROP COLUMN IF EXISTS archived_at;
+ALTER TABLE lists DROP COLUMN IF EXISTS status;
@@ -0,0 +1,5 @@
+-- The completed and archived lists are hidden from the sidebar until they are restored
+ALTER TABLE lists ADD COLUMN IF NOT EXISTS status character varying NOT NULL DEFAULT 'active';
+ALTER TABLE lists ADD COLUMN IF NOT EXISTS archived_at timestamp WITH TIME ZONE DEFAULT NULL;
+
+CREATE INDEX IF NOT EXISTS idx_list_status ON lists(status);
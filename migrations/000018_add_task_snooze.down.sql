@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS idx_task_deferred_until;
+
+ALTER TABLE tasks DROP COLUMN IF EXISTS snooze_count;
+ALTER TABLE tasks DROP COLUMN IF EXISTS deferred_until;
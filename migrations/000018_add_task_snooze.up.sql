@@ -0,0 +1,6 @@
+-- The snoozed tasks are hidden from Today, Someday and the list views until deferred_until,
+-- the start date of the task is kept as it is
+ALTER TABLE tasks ADD COLUMN IF NOT EXISTS deferred_until timestamp WITH TIME ZONE DEFAULT NULL;
+ALTER TABLE tasks ADD COLUMN IF NOT EXISTS snooze_count integer NOT NULL DEFAULT 0;
+
+CREATE INDEX IF NOT EXISTS idx_task_deferred_until ON tasks(deferred_until) WHERE deferred_until IS NOT NULL;
@@ -0,0 +1,2 @@
+-- The task is shown in the This Evening section of Today only on the day it was planned for the evening
+ALTER TABLE tasks ADD COLUMN IF NOT EXISTS evening_date date DEFAULT NULL;
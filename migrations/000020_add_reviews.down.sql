@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS review_items;
+DROP TABLE IF EXISTS review_sessions;
@@ -0,0 +1,31 @@
+-- The weekly review keeps the items to walk through when it starts, so the progress is shared by the devices of the user
+CREATE TABLE IF NOT EXISTS review_sessions
+(
+    id           character varying PRIMARY KEY,
+    user_id      character varying NOT NULL,
+    stale_days   integer NOT NULL,
+    started_at   timestamp WITH TIME ZONE NOT NULL DEFAULT now(),
+    completed_at timestamp WITH TIME ZONE DEFAULT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_review_session_user_id ON review_sessions(user_id);
+
+ALTER TABLE review_sessions ADD FOREIGN KEY (user_id) REFERENCES users(id);
+
+CREATE TABLE IF NOT EXISTS review_items
+(
+    id          character varying PRIMARY KEY,
+    review_id   character varying NOT NULL,
+    section     character varying NOT NULL,
+    entity_type character varying NOT NULL,
+    entity_id   character varying NOT NULL,
+    title       character varying NOT NULL,
+    position    integer NOT NULL,
+    action      character varying DEFAULT NULL,
+    acted_at    timestamp WITH TIME ZONE DEFAULT NULL,
+    UNIQUE (review_id, section, entity_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_review_item_review_id ON review_items(review_id);
+
+ALTER TABLE review_items ADD FOREIGN KEY (review_id) REFERENCES review_sessions(id) ON DELETE CASCADE;
@@ -0,0 +1,8 @@
+DROP TRIGGER IF EXISTS task_completed_at ON tasks;
+DROP FUNCTION IF EXISTS set_task_completed_at();
+
+DROP INDEX IF EXISTS idx_task_completed_at;
+DROP INDEX IF EXISTS idx_task_created_at;
+
+ALTER TABLE tasks DROP COLUMN IF EXISTS completed_at;
+ALTER TABLE tasks DROP COLUMN IF EXISTS created_at;
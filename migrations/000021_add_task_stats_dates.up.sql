@@ -0,0 +1,39 @@
+-- The existing tasks don't know when they were created or completed, updated_at is the best guess
+ALTER TABLE tasks ADD COLUMN IF NOT EXISTS created_at timestamp WITH TIME ZONE NOT NULL DEFAULT now();
+ALTER TABLE tasks ADD COLUMN IF NOT EXISTS completed_at timestamp WITH TIME ZONE DEFAULT NULL;
+
+UPDATE tasks SET created_at = updated_at;
+UPDATE tasks
+SET completed_at = updated_at
+WHERE status_id = (SELECT id
+                   FROM statuses
+                   WHERE title = 'Completed');
+
+CREATE INDEX IF NOT EXISTS idx_task_created_at ON tasks(user_id, created_at);
+CREATE INDEX IF NOT EXISTS idx_task_completed_at ON tasks(user_id, completed_at) WHERE completed_at IS NOT NULL;
+
+-- The status of the task is changed by many queries, so completed_at is kept in sync by the trigger.
+-- The completed task keeps completed_at when it is archived and loses it when it is reopened
+CREATE OR REPLACE FUNCTION set_task_completed_at() RETURNS trigger AS $$
+DECLARE
+    completed_id integer;
+    archived_id  integer;
+BEGIN
+    SELECT id INTO completed_id FROM statuses WHERE title = 'Completed';
+    SELECT id INTO archived_id FROM statuses WHERE title = 'Archived';
+
+    IF NEW.status_id = completed_id THEN
+        IF TG_OP = 'INSERT' OR OLD.status_id IS DISTINCT FROM completed_id THEN
+            NEW.completed_at = now();
+        END IF;
+    ELSIF NEW.status_id IS DISTINCT FROM archived_id THEN
+        NEW.completed_at = NULL;
+    END IF;
+
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER task_completed_at
+    BEFORE INSERT OR UPDATE OF status_id ON tasks
+    FOR EACH ROW EXECUTE FUNCTION set_task_completed_at();
@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS habit_check_ins CASCADE;
+DROP TABLE IF EXISTS habits CASCADE;
+
+ALTER TABLE users DROP COLUMN IF EXISTS time_zone;
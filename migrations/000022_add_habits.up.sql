@@ -0,0 +1,29 @@
+-- The days of the habit check-ins and streaks are counted in the time zone of the user
+ALTER TABLE users ADD COLUMN IF NOT EXISTS time_zone character varying NOT NULL DEFAULT 'UTC';
+
+CREATE TABLE IF NOT EXISTS habits
+(
+    id             character varying PRIMARY KEY,
+    title          character varying NOT NULL,
+    notes          text NOT NULL DEFAULT '',
+    frequency      character varying NOT NULL,
+    times_per_week integer NOT NULL DEFAULT 7,
+    user_id        character varying NOT NULL,
+    updated_at     timestamp WITH TIME ZONE NOT NULL DEFAULT now(),
+    deleted_at     timestamp WITH TIME ZONE DEFAULT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_habit_user_id ON habits(user_id);
+
+ALTER TABLE habits ADD FOREIGN KEY (user_id) REFERENCES users(id);
+
+-- A habit is checked in at most once a day, day is the local date of the user
+CREATE TABLE IF NOT EXISTS habit_check_ins
+(
+    habit_id   character varying NOT NULL,
+    day        date NOT NULL,
+    created_at timestamp WITH TIME ZONE NOT NULL DEFAULT now(),
+    CONSTRAINT habit_check_ins_pkey PRIMARY KEY (habit_id, day)
+);
+
+ALTER TABLE habit_check_ins ADD FOREIGN KEY (habit_id) REFERENCES habits(id) ON DELETE CASCADE;
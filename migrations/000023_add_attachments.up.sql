@@ -0,0 +1,21 @@
+-- The files themselves are kept in the blob store, blob_key is the key of the file there.
+-- The deleted attachments and the attachments of the tasks deleted long ago are purged
+-- along with their blobs by a background job
+CREATE TABLE IF NOT EXISTS attachments
+(
+    id           character varying PRIMARY KEY,
+    task_id      character varying NOT NULL,
+    file_name    character varying NOT NULL,
+    content_type character varying NOT NULL,
+    size         bigint NOT NULL,
+    blob_key     character varying NOT NULL UNIQUE,
+    user_id      character varying NOT NULL,
+    created_at   timestamp WITH TIME ZONE NOT NULL DEFAULT now(),
+    deleted_at   timestamp WITH TIME ZONE DEFAULT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_attachment_task_id ON attachments(task_id);
+CREATE INDEX IF NOT EXISTS idx_attachment_user_id ON attachments(user_id);
+
+ALTER TABLE attachments ADD FOREIGN KEY (task_id) REFERENCES tasks(id);
+ALTER TABLE attachments ADD FOREIGN KEY (user_id) REFERENCES users(id);